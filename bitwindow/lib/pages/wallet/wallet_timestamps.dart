@@ -182,6 +182,8 @@ class TimestampsTable extends StatelessWidget {
     switch (status) {
       case 'pending':
         return 'Pending';
+      case 'sending':
+        return 'Sending';
       case 'confirming':
         return 'Confirming';
       case 'confirmed':
@@ -197,6 +199,7 @@ class TimestampsTable extends StatelessWidget {
     final theme = context.sailTheme.colors;
     switch (status) {
       case 'pending':
+      case 'sending':
         return theme.orange;
       case 'confirming':
         return theme.primary;
@@ -110,7 +110,7 @@ class TimestampProvider extends ChangeNotifier {
   void startPolling({Duration interval = const Duration(seconds: 10)}) {
     stopPolling();
     _pollTimer = Timer.periodic(interval, (_) async {
-      if (timestamps.any((t) => t.status == 'pending' || t.status == 'confirming')) {
+      if (timestamps.any((t) => t.status == 'pending' || t.status == 'sending' || t.status == 'confirming')) {
         await fetch();
       } else {
         stopPolling();
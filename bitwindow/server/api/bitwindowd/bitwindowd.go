@@ -4,7 +4,10 @@ import (
 	"cmp"
 	"context"
 	"database/sql"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
 	"slices"
 	"time"
 
@@ -17,16 +20,21 @@ import (
 	validatorpb "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/cusf/mainchain/v1"
 	validatorrpc "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/cusf/mainchain/v1/mainchainv1connect"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/addressbook"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/addresshistory"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blocks"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blockstats"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/deniability"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/transactions"
 	service "github.com/LayerTwo-Labs/sidesail/bitwindow/server/service"
 	corepb "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha"
 	corerpc "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha/bitcoindv1alphaconnect"
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/rs/zerolog"
 	"github.com/samber/lo"
 	"github.com/sourcegraph/conc/pool"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -41,6 +49,8 @@ func New(
 	wallet *service.Service[validatorrpc.WalletServiceClient],
 	bitcoind *service.Service[corerpc.BitcoinServiceClient],
 	walletEngine *engines.WalletEngine,
+	bitcoinEngine *engines.Parser,
+	chainParams *chaincfg.Params,
 	config config.Config,
 ) *Server {
 	s := &Server{
@@ -51,6 +61,9 @@ func New(
 		bitcoind:     bitcoind,
 		walletEngine: walletEngine,
 
+		bitcoinEngine: bitcoinEngine,
+		chainParams:   chainParams,
+
 		config: config,
 	}
 	return s
@@ -64,6 +77,9 @@ type Server struct {
 	bitcoind     *service.Service[corerpc.BitcoinServiceClient]
 	walletEngine *engines.WalletEngine
 
+	bitcoinEngine *engines.Parser
+	chainParams   *chaincfg.Params
+
 	config config.Config
 }
 
@@ -381,6 +397,84 @@ func (s *Server) GetSyncInfo(ctx context.Context, req *connect.Request[emptypb.E
 	}), nil
 }
 
+// WatchSyncInfo implements bitwindowdv1connect.BitwindowdServiceHandler.
+func (s *Server) WatchSyncInfo(ctx context.Context, req *connect.Request[emptypb.Empty], stream *connect.ServerStream[pb.WatchSyncInfoResponse]) error {
+	for status := range s.bitcoinEngine.WatchSyncStatus(ctx) {
+		msg, err := s.syncStatusToProto(ctx, status)
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Msg("could not convert sync status")
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+func (s *Server) syncStatusToProto(ctx context.Context, status engines.SyncStatus) (*pb.WatchSyncInfoResponse, error) {
+	info := &pb.GetSyncInfoResponse{
+		TipBlockProcessedAt: &timestamppb.Timestamp{},
+		HeaderHeight:        int64(status.HeaderHeight),
+	}
+
+	processedTip, err := blocks.GetProcessedTip(ctx, s.db)
+	if err != nil {
+		return nil, fmt.Errorf("get processed tip: %w", err)
+	}
+
+	if processedTip != nil {
+		info.TipBlockHeight = int64(processedTip.Height)
+		info.TipBlockTime = processedTip.ProcessedAt.Unix()
+		info.TipBlockHash = processedTip.Hash.String()
+		info.TipBlockProcessedAt = timestamppb.New(processedTip.ProcessedAt)
+		if status.BlockHeight > 0 {
+			info.SyncProgress = min(1, float64(processedTip.Height)/float64(status.BlockHeight))
+		}
+	}
+
+	msg := &pb.WatchSyncInfoResponse{
+		State:           syncStateToProto(status.State),
+		Info:            info,
+		BlocksPerSecond: status.BlocksPerSecond,
+		UpdateTime:      timestamppb.New(status.UpdatedAt),
+	}
+
+	if status.ETA > 0 {
+		msg.Eta = durationpb.New(status.ETA)
+	}
+
+	if status.Reorg != nil {
+		msg.Reorg = &pb.WatchSyncInfoResponse_Reorg{
+			FromHeight: int64(status.Reorg.FromHeight),
+			ForkHeight: int64(status.Reorg.ForkHeight),
+		}
+	}
+
+	if status.Err != nil {
+		msg.Error = lo.ToPtr(status.Err.Error())
+	}
+
+	return msg, nil
+}
+
+func syncStateToProto(state engines.SyncState) pb.WatchSyncInfoResponse_State {
+	switch state {
+	case engines.SyncStateSyncing:
+		return pb.WatchSyncInfoResponse_STATE_SYNCING
+	case engines.SyncStateSynced:
+		return pb.WatchSyncInfoResponse_STATE_SYNCED
+	case engines.SyncStateWaitingForIBD:
+		return pb.WatchSyncInfoResponse_STATE_WAITING_FOR_IBD
+	case engines.SyncStateCoreUnavailable:
+		return pb.WatchSyncInfoResponse_STATE_CORE_UNAVAILABLE
+	default:
+		return pb.WatchSyncInfoResponse_STATE_UNSPECIFIED
+	}
+}
+
 // SetTransactionNote implements bitwindowdv1connect.BitwindowdServiceHandler.
 func (s *Server) SetTransactionNote(ctx context.Context, req *connect.Request[pb.SetTransactionNoteRequest]) (*connect.Response[emptypb.Empty], error) {
 	if err := transactions.SetNote(ctx, s.db, req.Msg.Txid, req.Msg.Note); err != nil {
@@ -411,11 +505,26 @@ func (s *Server) GetFireplaceStats(ctx context.Context, req *connect.Request[emp
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get coinnews count: %w", err))
 	}
 
-	return connect.NewResponse(&pb.GetFireplaceStatsResponse{
+	// Fees and sizes come from the block stats index
+	summary, err := blockstats.Summarize(ctx, s.db, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to summarize block stats: %w", err))
+	}
+
+	res := &pb.GetFireplaceStatsResponse{
 		TransactionCount_24H: txCount24h,
 		CoinnewsCount_7D:     coinnewsCount7d,
 		BlockCount_24H:       blockCount24h,
-	}), nil
+		TotalFeeSats_24H:     int64(summary.TotalFee),
+		Vsize_24H:            summary.VSize,
+		OpReturnBytes_24H:    summary.OPReturnBytes,
+	}
+	if summary.TxCount > 0 {
+		res.SegwitShare_24H = float64(summary.SegwitTxCount) / float64(summary.TxCount)
+		res.TaprootShare_24H = float64(summary.TaprootTxCount) / float64(summary.TxCount)
+	}
+
+	return connect.NewResponse(res), nil
 }
 
 // getBlockCount24h returns the number of blocks in the last 24 hours
@@ -466,6 +575,8 @@ func (s *Server) getCoinnewsCount7d(ctx context.Context) (int64, error) {
 		FROM op_returns o
 		-- created in the last 7 days
 		WHERE o.created_at >= ?
+		-- skip entries that were evicted or replaced in the mempool
+		AND o.status = 'active'
 		AND LENGTH(o.op_return_data) >= 16
 		-- filter out all all topic creation operations
 		-- 6e6577 is hex for "new"
@@ -850,13 +961,27 @@ func (s *Server) GetNetworkStats(ctx context.Context, req *connect.Request[empty
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("get blockchain info: %w", err))
 	}
 
-	// Calculate average block time from last 144 blocks
-	avgBlockTime, err := s.calculateAverageBlockTime(ctx, bitcoind, int64(blockchainInfo.Msg.Blocks))
+	// Prefer the block stats index, and only fall back to Bitcoin Core
+	// if it doesn't have enough blocks yet
+	indexed, err := s.indexedNetworkStats(ctx)
 	if err != nil {
-		zerolog.Ctx(ctx).Warn().Err(err).Msg("calculate average block time")
-		avgBlockTime = 600.0 // Default to 10 minutes
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("get indexed network stats")
+	}
+
+	// Calculate average block time from last 144 blocks
+	avgBlockTime := indexed.avgBlockTime
+	if indexed.avgBlockTime == 0 {
+		avgBlockTime, err = s.calculateAverageBlockTime(ctx, bitcoind, int64(blockchainInfo.Msg.Blocks))
+		if err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Msg("calculate average block time")
+			avgBlockTime = 600.0 // Default to 10 minutes
+		}
 	}
 
+	// The expected number of hashes needed to find a block, over the
+	// time it takes to find one
+	networkHashrate := indexed.difficulty * math.Pow(2, 32) / avgBlockTime
+
 	// Get peer info
 	peerInfo, err := bitcoind.GetPeerInfo(ctx, connect.NewRequest(&corepb.GetPeerInfoRequest{}))
 	if err != nil {
@@ -877,8 +1002,8 @@ func (s *Server) GetNetworkStats(ctx context.Context, req *connect.Request[empty
 	// TODO: Add getnetworkinfo, getnettotals, getmininginfo RPCs to btc-buf
 	// For now, use available data
 	return connect.NewResponse(&pb.GetNetworkStatsResponse{
-		NetworkHashrate:     0,                                      // TODO: Get from getmininginfo
-		Difficulty:          0,                                      // TODO: Get difficulty from block header
+		NetworkHashrate:     networkHashrate,
+		Difficulty:          indexed.difficulty,
 		PeerCount:           int32(len(peerInfo.Msg.Peers)),
 		TotalBytesReceived:  0,                                      // TODO: Get from getnettotals
 		TotalBytesSent:      0,                                      // TODO: Get from getnettotals
@@ -893,6 +1018,36 @@ func (s *Server) GetNetworkStats(ctx context.Context, req *connect.Request[empty
 	}), nil
 }
 
+type indexedNetworkStats struct {
+	difficulty float64
+	// Zero if less than two blocks are indexed
+	avgBlockTime float64
+}
+
+// indexedNetworkStats returns the current difficulty and the average block
+// time over the last 144 blocks, from the block stats index.
+func (s *Server) indexedNetworkStats(ctx context.Context) (indexedNetworkStats, error) {
+	tip, err := blockstats.GetTip(ctx, s.db)
+	if err != nil || tip == nil {
+		return indexedNetworkStats{}, err
+	}
+
+	lookback := min(uint32(144), tip.Height)
+	first, err := blockstats.List(ctx, s.db, tip.Height-lookback, tip.Height-lookback)
+	if err != nil {
+		return indexedNetworkStats{}, err
+	}
+
+	result := indexedNetworkStats{difficulty: tip.Difficulty()}
+	if len(first) == 1 && lookback > 0 {
+		if timeDiff := tip.BlockTime.Sub(first[0].BlockTime); timeDiff > 0 {
+			result.avgBlockTime = timeDiff.Seconds() / float64(lookback)
+		}
+	}
+
+	return result, nil
+}
+
 func (s *Server) calculateAverageBlockTime(ctx context.Context, bitcoind corerpc.BitcoinServiceClient, currentHeight int64) (float64, error) {
 	// Get blocks from last 144 blocks or from genesis if we don't have that many
 	lookback := min(int64(144), currentHeight)
@@ -944,3 +1099,200 @@ func (s *Server) getBlockAtHeight(ctx context.Context, bitcoind corerpc.BitcoinS
 
 	return block.Msg.Time.Seconds, nil
 }
+
+// ListAddressHistory implements bitwindowdv1connect.BitwindowdServiceHandler.
+func (s *Server) ListAddressHistory(ctx context.Context, req *connect.Request[pb.ListAddressHistoryRequest]) (*connect.Response[pb.ListAddressHistoryResponse], error) {
+	indexedHeight, err := s.addressIndexHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := s.parseScript(req.Msg.Address, req.Msg.ScriptPubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := 50
+	if req.Msg.PageSize > 0 {
+		pageSize = int(req.Msg.PageSize)
+	}
+
+	// Fetch one extra, to know if there are more
+	entries, err := addresshistory.ListHistory(ctx, s.db, script, pageSize+1, int(req.Msg.Offset))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("could not list address history")
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	hasMore := len(entries) > pageSize
+	if hasMore {
+		entries = entries[:pageSize]
+	}
+
+	return connect.NewResponse(&pb.ListAddressHistoryResponse{
+		Entries: lo.Map(entries, func(entry addresshistory.Entry, _ int) *pb.AddressHistoryEntry {
+			entryType := pb.AddressHistoryEntry_TYPE_FUNDING
+			if entry.Type == addresshistory.EntryTypeSpending {
+				entryType = pb.AddressHistoryEntry_TYPE_SPENDING
+			}
+
+			return &pb.AddressHistoryEntry{
+				Type:       entryType,
+				Txid:       entry.TxID,
+				Height:     entry.Height,
+				ValueSats:  int64(entry.Value),
+				OutputTxid: entry.OutputTxID,
+				OutputVout: entry.OutputVout,
+				InputIndex: entry.InputIndex,
+			}
+		}),
+		HasMore:       hasMore,
+		IndexedHeight: indexedHeight,
+	}), nil
+}
+
+// GetAddressBalance implements bitwindowdv1connect.BitwindowdServiceHandler.
+func (s *Server) GetAddressBalance(ctx context.Context, req *connect.Request[pb.GetAddressBalanceRequest]) (*connect.Response[pb.GetAddressBalanceResponse], error) {
+	indexedHeight, err := s.addressIndexHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := s.parseScript(req.Msg.Address, req.Msg.ScriptPubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := addresshistory.GetBalance(ctx, s.db, script)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("could not get address balance")
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&pb.GetAddressBalanceResponse{
+		ConfirmedSats:    int64(balance.Confirmed()),
+		FundedTxoCount:   balance.FundedCount,
+		FundedTxoSumSats: int64(balance.FundedSum),
+		SpentTxoCount:    balance.SpentCount,
+		SpentTxoSumSats:  int64(balance.SpentSum),
+		IndexedHeight:    indexedHeight,
+	}), nil
+}
+
+// addressIndexHeight returns the last block included in the address index.
+func (s *Server) addressIndexHeight(ctx context.Context) (uint32, error) {
+	if !s.config.IndexAddresses {
+		return 0, connect.NewError(connect.CodeFailedPrecondition,
+			errors.New("address index is not enabled, start bitwindowd with --index.addresses"))
+	}
+
+	cursor, err := blocks.GetProcessorCursor(ctx, s.db, engines.AddressIndexName)
+	if err != nil {
+		return 0, connect.NewError(connect.CodeInternal, err)
+	}
+	if cursor == nil {
+		return 0, nil
+	}
+
+	return cursor.Height, nil
+}
+
+// parseScript returns the script of the given address, or the given
+// hex-encoded script if no address is set.
+func (s *Server) parseScript(address, scriptPubKey string) ([]byte, error) {
+	switch {
+	case address != "":
+		decoded, err := btcutil.DecodeAddress(address, s.chainParams)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid address: %w", err))
+		}
+
+		script, err := txscript.PayToAddrScript(decoded)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid address: %w", err))
+		}
+		return script, nil
+
+	case scriptPubKey != "":
+		script, err := hex.DecodeString(scriptPubKey)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid script_pubkey: %w", err))
+		}
+		return script, nil
+
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("address or script_pubkey must be set"))
+	}
+}
+
+// Maximum number of blocks returned by GetBlockStats
+const maxBlockStatsRange = 10_000
+
+// GetBlockStats implements bitwindowdv1connect.BitwindowdServiceHandler.
+func (s *Server) GetBlockStats(ctx context.Context, req *connect.Request[pb.GetBlockStatsRequest]) (*connect.Response[pb.GetBlockStatsResponse], error) {
+	cursor, err := blocks.GetProcessorCursor(ctx, s.db, engines.BlockStatsName)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	var indexedHeight uint32
+	if cursor != nil {
+		indexedHeight = cursor.Height
+	}
+
+	start, end := req.Msg.StartHeight, req.Msg.EndHeight
+	if end == 0 {
+		end = indexedHeight
+	}
+	if end < start {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("end height %d is below start height %d", end, start))
+	}
+	end = min(end, start+maxBlockStatsRange-1)
+
+	stats, err := blockstats.List(ctx, s.db, start, end)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("could not list block stats")
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&pb.GetBlockStatsResponse{
+		Stats:         lo.Map(stats, func(stats blockstats.Stats, _ int) *pb.BlockStats { return blockStatsToProto(stats) }),
+		IndexedHeight: indexedHeight,
+	}), nil
+}
+
+func blockStatsToProto(stats blockstats.Stats) *pb.BlockStats {
+	res := &pb.BlockStats{
+		Height:         stats.Height,
+		Hash:           stats.Hash.String(),
+		BlockTime:      timestamppb.New(stats.BlockTime),
+		Difficulty:     stats.Difficulty(),
+		TxCount:        stats.TxCount,
+		Weight:         stats.Weight,
+		Vsize:          stats.VSize,
+		TotalFeeSats:   int64(stats.TotalFee),
+		SegwitTxCount:  stats.SegwitTxCount,
+		TaprootTxCount: stats.TaprootTxCount,
+		OpReturnCount:  stats.OPReturnCount,
+		OpReturnBytes:  stats.OPReturnBytes,
+	}
+
+	// Shares are of all non-coinbase transactions
+	if stats.TxCount > 1 {
+		res.SegwitShare = float64(stats.SegwitTxCount) / float64(stats.TxCount-1)
+		res.TaprootShare = float64(stats.TaprootTxCount) / float64(stats.TxCount-1)
+	}
+
+	if p := stats.FeeratePercentiles; p != nil {
+		res.FeeratePercentiles = &pb.BlockStats_FeeratePercentiles{
+			P10: p[0],
+			P25: p[1],
+			P50: p[2],
+			P75: p[3],
+			P90: p[4],
+		}
+	}
+
+	return res
+}
@@ -1,24 +1,32 @@
 package api_bitwindowd_test
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
+	"encoding/hex"
 	"testing"
 	"time"
 
 	"connectrpc.com/connect"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/config"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/engines"
 	v1 "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/bitwindowd/v1"
 	v1connect "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/bitwindowd/v1/bitwindowdv1connect"
 	commonv1 "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/cusf/common/v1"
 	mainchainv1 "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/cusf/mainchain/v1"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/addresshistory"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blocks"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blockstats"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/deniability"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/opreturns"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests/apitests"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests/mocks"
 	corepb "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -957,6 +965,182 @@ func TestService_GetSyncInfo(t *testing.T) {
 	})
 }
 
+func TestService_WatchSyncInfo(t *testing.T) {
+	t.Parallel()
+
+	database := database.Test(t)
+
+	require.NoError(t, blocks.MarkBlocksProcessed(context.Background(), database, []blocks.ProcessedBlock{
+		{Height: 1, Hash: chainhash.Hash{1}, BlockTime: time.Now()},
+	}))
+
+	cli := v1connect.NewBitwindowdServiceClient(apitests.API(t, database))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := cli.WatchSyncInfo(ctx, connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	defer stream.Close()
+
+	// The current state is sent right away, even though the parser
+	// isn't running
+	require.True(t, stream.Receive(), stream.Err())
+	msg := stream.Msg()
+	assert.Equal(t, v1.WatchSyncInfoResponse_STATE_UNSPECIFIED, msg.State)
+	assert.Equal(t, int64(1), msg.Info.TipBlockHeight)
+	assert.Equal(t, chainhash.Hash{1}.String(), msg.Info.TipBlockHash)
+	assert.Nil(t, msg.Eta)
+	assert.Nil(t, msg.Reorg)
+}
+
+func TestService_AddressHistory(t *testing.T) {
+	t.Parallel()
+
+	// Signet P2WPKH address, and the output it pays to
+	const address = "tb1qqyqszqgpqyqszqgpqyqszqgpqyqszqgpw0yxjz"
+	script := append([]byte{txscript.OP_0, txscript.OP_DATA_20}, bytes.Repeat([]byte{0x01}, 20)...)
+
+	t.Run("index disabled", func(t *testing.T) {
+		t.Parallel()
+
+		database := database.Test(t)
+		cli := v1connect.NewBitwindowdServiceClient(apitests.API(t, database))
+
+		_, err := cli.GetAddressBalance(context.Background(), connect.NewRequest(&v1.GetAddressBalanceRequest{
+			Address: address,
+		}))
+		require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		t.Parallel()
+
+		database := database.Test(t)
+		cli := v1connect.NewBitwindowdServiceClient(apitests.API(t, database,
+			apitests.WithConfig(config.Config{IndexAddresses: true}),
+		))
+
+		_, err := cli.ListAddressHistory(context.Background(), connect.NewRequest(&v1.ListAddressHistoryRequest{
+			Address: "bc1qnotanaddress",
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = cli.ListAddressHistory(context.Background(), connect.NewRequest(&v1.ListAddressHistoryRequest{}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("history and balance", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		database := database.Test(t)
+
+		funding := wire.NewMsgTx(2)
+		funding.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+		funding.AddTxOut(wire.NewTxOut(10_000, script))
+		funding.AddTxOut(wire.NewTxOut(20_000, script))
+		require.NoError(t, addresshistory.IndexBlock(ctx, database, 1, &wire.MsgBlock{
+			Transactions: []*wire.MsgTx{wire.NewMsgTx(2), funding},
+		}))
+
+		fundingHash := funding.TxHash()
+		spending := wire.NewMsgTx(2)
+		spending.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&fundingHash, 1), nil, nil))
+		require.NoError(t, addresshistory.IndexBlock(ctx, database, 2, &wire.MsgBlock{
+			Transactions: []*wire.MsgTx{wire.NewMsgTx(2), spending},
+		}))
+		require.NoError(t, blocks.SetProcessorCursor(ctx, database, engines.AddressIndexName, 2, chainhash.Hash{2}))
+
+		cli := v1connect.NewBitwindowdServiceClient(apitests.API(t, database,
+			apitests.WithConfig(config.Config{IndexAddresses: true}),
+		))
+
+		balance, err := cli.GetAddressBalance(ctx, connect.NewRequest(&v1.GetAddressBalanceRequest{
+			Address: address,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, int64(10_000), balance.Msg.ConfirmedSats)
+		assert.Equal(t, int64(2), balance.Msg.FundedTxoCount)
+		assert.Equal(t, int64(1), balance.Msg.SpentTxoCount)
+		assert.Equal(t, uint32(2), balance.Msg.IndexedHeight)
+
+		history, err := cli.ListAddressHistory(ctx, connect.NewRequest(&v1.ListAddressHistoryRequest{
+			ScriptPubkey: hex.EncodeToString(script),
+			PageSize:     2,
+		}))
+		require.NoError(t, err)
+		require.Len(t, history.Msg.Entries, 2)
+		assert.True(t, history.Msg.HasMore)
+
+		assert.Equal(t, v1.AddressHistoryEntry_TYPE_SPENDING, history.Msg.Entries[0].Type)
+		assert.Equal(t, spending.TxID(), history.Msg.Entries[0].Txid)
+		assert.Equal(t, uint32(1), history.Msg.Entries[0].OutputVout)
+		assert.Equal(t, int64(20_000), history.Msg.Entries[0].ValueSats)
+
+		history, err = cli.ListAddressHistory(ctx, connect.NewRequest(&v1.ListAddressHistoryRequest{
+			Address: address,
+			Offset:  2,
+		}))
+		require.NoError(t, err)
+		require.Len(t, history.Msg.Entries, 1)
+		assert.False(t, history.Msg.HasMore)
+		assert.Equal(t, v1.AddressHistoryEntry_TYPE_FUNDING, history.Msg.Entries[0].Type)
+	})
+}
+
+func TestService_GetBlockStats(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	database := database.Test(t)
+
+	for height := uint32(1); height <= 3; height++ {
+		stats := blockstats.Stats{
+			Height:         height,
+			Hash:           chainhash.Hash{byte(height)},
+			BlockTime:      time.Unix(1_700_000_000, 0),
+			TxCount:        5,
+			SegwitTxCount:  2,
+			TaprootTxCount: 1,
+			TotalFee:       10_000,
+		}
+		if height == 3 {
+			stats.FeeratePercentiles = &[5]float64{1, 2, 3, 4, 5}
+		}
+		require.NoError(t, blockstats.Save(ctx, database, stats))
+	}
+	require.NoError(t, blocks.SetProcessorCursor(ctx, database, engines.BlockStatsName, 3, chainhash.Hash{3}))
+
+	cli := v1connect.NewBitwindowdServiceClient(apitests.API(t, database))
+
+	t.Run("range", func(t *testing.T) {
+		res, err := cli.GetBlockStats(ctx, connect.NewRequest(&v1.GetBlockStatsRequest{
+			StartHeight: 2,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, uint32(3), res.Msg.IndexedHeight)
+		require.Len(t, res.Msg.Stats, 2)
+
+		assert.Equal(t, uint32(2), res.Msg.Stats[0].Height)
+		assert.Equal(t, int64(10_000), res.Msg.Stats[0].TotalFeeSats)
+		assert.InDelta(t, 0.5, res.Msg.Stats[0].SegwitShare, 1e-9)
+		assert.InDelta(t, 0.25, res.Msg.Stats[0].TaprootShare, 1e-9)
+		assert.Nil(t, res.Msg.Stats[0].FeeratePercentiles)
+
+		require.NotNil(t, res.Msg.Stats[1].FeeratePercentiles)
+		assert.Equal(t, 3.0, res.Msg.Stats[1].FeeratePercentiles.P50)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := cli.GetBlockStats(ctx, connect.NewRequest(&v1.GetBlockStatsRequest{
+			StartHeight: 3,
+			EndHeight:   2,
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
+
 func TestService_SetTransactionNote(t *testing.T) {
 	t.Parallel()
 
@@ -1140,5 +1324,26 @@ func TestService_GetFireplaceStats(t *testing.T) {
 	assert.EqualValues(t, 1, resp.Msg.TransactionCount_24H)
 	assert.EqualValues(t, 1, resp.Msg.CoinnewsCount_7D)
 	assert.EqualValues(t, 1, resp.Msg.BlockCount_24H)
+	assert.Empty(t, resp.Msg.TotalFeeSats_24H, "nothing in the block stats index yet")
+
+	for _, stats := range []blockstats.Stats{
+		{
+			Height: 100, Hash: newHash(t), BlockTime: time.Now(),
+			TxCount: 5, VSize: 1_000, TotalFee: 2_500,
+			SegwitTxCount: 3, TaprootTxCount: 1, OPReturnBytes: 40,
+		},
+		// old block, doesn't count
+		{Height: 99, Hash: newHash(t), BlockTime: time.Now().AddDate(0, 0, -2), TxCount: 2, TotalFee: 1_000},
+	} {
+		require.NoError(t, blockstats.Save(ctx, database, stats))
+	}
+
+	resp, err = cli.GetFireplaceStats(ctx, connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
 
+	assert.EqualValues(t, 2_500, resp.Msg.TotalFeeSats_24H)
+	assert.EqualValues(t, 1_000, resp.Msg.Vsize_24H)
+	assert.InDelta(t, 0.75, resp.Msg.SegwitShare_24H, 1e-9)
+	assert.InDelta(t, 0.25, resp.Msg.TaprootShare_24H, 1e-9)
+	assert.EqualValues(t, 40, resp.Msg.OpReturnBytes_24H)
 }
@@ -0,0 +1,654 @@
+// Package api_esplora serves the subset of the Esplora REST API
+// (https://github.com/Blockstream/esplora/blob/master/API.md) that our
+// scripts and third-party tools use, backed by our own index and Bitcoin
+// Core. Saves running electrs next to every node.
+package api_esplora
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/config"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/addresshistory"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blocks"
+	service "github.com/LayerTwo-Labs/sidesail/bitwindow/server/service"
+	corepb "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha"
+	corerpc "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha/bitcoindv1alphaconnect"
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+)
+
+const (
+	// Esplora returns confirmed address transactions in pages of 25
+	addressTxsPageSize = 25
+
+	// Estimating fees takes one RPC call per target, don't do that on
+	// every request
+	feeEstimatesTTL = 30 * time.Second
+)
+
+// Confirmation targets Esplora returns fee estimates for
+var feeEstimateTargets = append(lo.RangeFrom[int64](1, 25), 144, 504, 1008)
+
+func New(
+	database *sql.DB,
+	bitcoind *service.Service[corerpc.BitcoinServiceClient],
+	chainParams *chaincfg.Params,
+	config config.Config,
+) *Server {
+	return &Server{
+		database:    database,
+		bitcoind:    bitcoind,
+		chainParams: chainParams,
+		config:      config,
+	}
+}
+
+type Server struct {
+	database    *sql.DB
+	bitcoind    *service.Service[corerpc.BitcoinServiceClient]
+	chainParams *chaincfg.Params
+	config      config.Config
+
+	feeEstimatesMu sync.Mutex
+	feeEstimates   map[string]float64
+	feeEstimatesAt time.Time
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /blocks/tip/height", s.handle(s.tipHeight))
+	mux.Handle("GET /blocks/tip/hash", s.handle(s.tipHash))
+	mux.Handle("GET /block/{hash}", s.handle(s.block))
+	mux.Handle("GET /tx/{txid}", s.handle(s.tx))
+	mux.Handle("GET /address/{address}/txs", s.handle(s.addressTxs))
+	mux.Handle("GET /address/{address}/txs/chain/{last_seen_txid}", s.handle(s.addressTxs))
+	mux.Handle("GET /fee-estimates", s.handle(s.feeEstimatesHandler))
+	return mux
+}
+
+// Serve listens on the given address until the context is cancelled.
+func (s *Server) Serve(ctx context.Context, address string) error {
+	log := zerolog.Ctx(ctx)
+
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("listen on %q: %w", address, err)
+	}
+
+	server := &http.Server{
+		Handler: s.Handler(),
+		// Makes the logger available to handlers
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second*3)
+		defer cancel()
+
+		if err := server.Shutdown(ctx); err != nil {
+			log.Err(err).Msg("esplora: could not gracefully stop HTTP server")
+		}
+	}()
+
+	log.Info().Msgf("esplora: listening on %s", address)
+
+	if err := server.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// httpError is returned from handlers to respond with something other than
+// a 500. Esplora errors are plain text.
+type httpError struct {
+	status  int
+	message string
+}
+
+func (e *httpError) Error() string {
+	return e.message
+}
+
+func newHTTPError(status int, format string, args ...any) error {
+	return &httpError{status: status, message: fmt.Sprintf(format, args...)}
+}
+
+func (s *Server) handle(handler func(w http.ResponseWriter, r *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		log := zerolog.Ctx(r.Context())
+
+		err := handler(w, r)
+		if err == nil {
+			log.Trace().Msgf("esplora: %s %s in %s", r.Method, r.URL.Path, time.Since(start))
+			return
+		}
+
+		if httpErr, ok := lo.ErrorsAs[*httpError](err); ok {
+			log.Debug().Msgf("esplora: %s %s: %d %s", r.Method, r.URL.Path, httpErr.status, httpErr.message)
+			http.Error(w, httpErr.message, httpErr.status)
+			return
+		}
+
+		log.Error().Err(err).Msgf("esplora: %s %s", r.Method, r.URL.Path)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) error {
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(v)
+}
+
+func writeText(w http.ResponseWriter, text string) error {
+	w.Header().Set("Content-Type", "text/plain")
+	_, err := fmt.Fprint(w, text)
+	return err
+}
+
+func (s *Server) tipHeight(w http.ResponseWriter, r *http.Request) error {
+	info, err := s.blockchainInfo(r.Context())
+	if err != nil {
+		return err
+	}
+
+	return writeText(w, strconv.FormatUint(uint64(info.Blocks), 10))
+}
+
+func (s *Server) tipHash(w http.ResponseWriter, r *http.Request) error {
+	info, err := s.blockchainInfo(r.Context())
+	if err != nil {
+		return err
+	}
+
+	return writeText(w, info.BestBlockHash)
+}
+
+func (s *Server) blockchainInfo(ctx context.Context) (*corepb.GetBlockchainInfoResponse, error) {
+	core, err := s.bitcoind.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := core.GetBlockchainInfo(ctx, connect.NewRequest(&corepb.GetBlockchainInfoRequest{}))
+	if err != nil {
+		return nil, fmt.Errorf("bitcoind: get blockchain info: %w", err)
+	}
+
+	return res.Msg, nil
+}
+
+// Block is an Esplora block.
+type Block struct {
+	ID                string  `json:"id"`
+	Height            uint32  `json:"height"`
+	Version           int32   `json:"version"`
+	Timestamp         int64   `json:"timestamp"`
+	TxCount           int     `json:"tx_count"`
+	Size              int32   `json:"size"`
+	Weight            int32   `json:"weight"`
+	MerkleRoot        string  `json:"merkle_root"`
+	PreviousBlockHash *string `json:"previousblockhash"`
+	Nonce             uint32  `json:"nonce"`
+	Bits              uint32  `json:"bits"`
+	Difficulty        float64 `json:"difficulty"`
+}
+
+func (s *Server) block(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	hash, err := chainhash.NewHashFromStr(r.PathValue("hash"))
+	if err != nil {
+		return newHTTPError(http.StatusBadRequest, "Invalid hex string")
+	}
+
+	core, err := s.bitcoind.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := core.GetBlock(ctx, connect.NewRequest(&corepb.GetBlockRequest{
+		Hash:      hash.String(),
+		Verbosity: corepb.GetBlockRequest_VERBOSITY_BLOCK_INFO,
+	}))
+	if connect.CodeOf(err) == connect.CodeNotFound {
+		return newHTTPError(http.StatusNotFound, "Block not found")
+	} else if err != nil {
+		return fmt.Errorf("bitcoind: get block %s: %w", hash, err)
+	}
+
+	bits, err := strconv.ParseUint(res.Msg.Bits, 16, 32)
+	if err != nil {
+		return fmt.Errorf("parse bits %q: %w", res.Msg.Bits, err)
+	}
+
+	return writeJSON(w, Block{
+		ID:                res.Msg.Hash,
+		Height:            res.Msg.Height,
+		Version:           res.Msg.Version,
+		Timestamp:         res.Msg.Time.AsTime().Unix(),
+		TxCount:           len(res.Msg.Txids),
+		Size:              res.Msg.Size,
+		Weight:            res.Msg.Weight,
+		MerkleRoot:        res.Msg.MerkleRoot,
+		PreviousBlockHash: lo.EmptyableToPtr(res.Msg.PreviousBlockHash),
+		Nonce:             res.Msg.Nonce,
+		Bits:              uint32(bits),
+		Difficulty:        res.Msg.Difficulty,
+	})
+}
+
+// Tx is an Esplora transaction.
+type Tx struct {
+	TxID     string `json:"txid"`
+	Version  int32  `json:"version"`
+	Locktime uint32 `json:"locktime"`
+	Vin      []Vin  `json:"vin"`
+	Vout     []Vout `json:"vout"`
+	Size     int    `json:"size"`
+	Weight   int64  `json:"weight"`
+	// Left out if we can't find all prevouts
+	Fee    *int64   `json:"fee,omitempty"`
+	Status TxStatus `json:"status"`
+}
+
+type Vin struct {
+	TxID string `json:"txid"`
+	Vout uint32 `json:"vout"`
+	// Null for coinbases, and prevouts we can't find
+	Prevout    *Vout    `json:"prevout"`
+	ScriptSig  string   `json:"scriptsig"`
+	Witness    []string `json:"witness,omitempty"`
+	IsCoinbase bool     `json:"is_coinbase"`
+	Sequence   uint32   `json:"sequence"`
+}
+
+type Vout struct {
+	ScriptPubKey        string `json:"scriptpubkey"`
+	ScriptPubKeyType    string `json:"scriptpubkey_type"`
+	ScriptPubKeyAddress string `json:"scriptpubkey_address,omitempty"`
+	Value               int64  `json:"value"`
+}
+
+type TxStatus struct {
+	Confirmed   bool    `json:"confirmed"`
+	BlockHeight *uint32 `json:"block_height,omitempty"`
+	BlockHash   string  `json:"block_hash,omitempty"`
+	BlockTime   int64   `json:"block_time,omitempty"`
+}
+
+func (s *Server) tx(w http.ResponseWriter, r *http.Request) error {
+	txid, err := chainhash.NewHashFromStr(r.PathValue("txid"))
+	if err != nil {
+		return newHTTPError(http.StatusBadRequest, "Invalid hex string")
+	}
+
+	tx, err := s.getTx(r.Context(), txid.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, tx)
+}
+
+func (s *Server) addressTxs(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	if !s.config.IndexAddresses {
+		return newHTTPError(http.StatusNotImplemented, "address index is not enabled, start bitwindowd with --index.addresses")
+	}
+
+	address, err := btcutil.DecodeAddress(r.PathValue("address"), s.chainParams)
+	if err != nil || !address.IsForNet(s.chainParams) {
+		return newHTTPError(http.StatusBadRequest, "Invalid Bitcoin address")
+	}
+	script, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		return newHTTPError(http.StatusBadRequest, "Invalid Bitcoin address")
+	}
+
+	lastSeen := r.PathValue("last_seen_txid")
+	if lastSeen != "" {
+		if _, err := chainhash.NewHashFromStr(lastSeen); err != nil {
+			return newHTTPError(http.StatusBadRequest, "Invalid hex string")
+		}
+	}
+
+	// Only confirmed transactions are indexed, so unlike Esplora we never
+	// include mempool transactions on the first page.
+	refs, err := addresshistory.ListTxs(ctx, s.database, script, lastSeen, addressTxsPageSize)
+	if err != nil {
+		return err
+	}
+
+	txs := make([]*Tx, 0, len(refs))
+	for _, ref := range refs {
+		tx, err := s.getTx(ctx, ref.TxID, &ref.Height)
+		if err != nil {
+			return err
+		}
+		txs = append(txs, tx)
+	}
+
+	return writeJSON(w, txs)
+}
+
+// getTx fetches a transaction from Bitcoin Core. If we know which block
+// it's in, Core finds it without -txindex.
+func (s *Server) getTx(ctx context.Context, txid string, height *uint32) (*Tx, error) {
+	if height == nil && s.config.IndexAddresses {
+		var err error
+		height, err = addresshistory.GetTxHeight(ctx, s.database, txid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var blockHash string
+	if height != nil {
+		block, err := blocks.GetProcessedBlock(ctx, s.database, *height)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		} else if err == nil {
+			blockHash = block.Hash.String()
+		}
+	}
+
+	core, err := s.bitcoind.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := core.GetRawTransaction(ctx, connect.NewRequest(&corepb.GetRawTransactionRequest{
+		Txid: txid,
+		// Includes the fee, if Core has the prevouts
+		Verbosity: corepb.GetRawTransactionRequest_VERBOSITY_TX_PREVOUT_INFO,
+		Blockhash: blockHash,
+	}))
+	if connect.CodeOf(err) == connect.CodeNotFound {
+		return nil, newHTTPError(http.StatusNotFound, "Transaction not found")
+	} else if err != nil {
+		return nil, fmt.Errorf("bitcoind: get raw transaction %s: %w", txid, err)
+	}
+
+	msgTx, err := decodeTx(res.Msg.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("decode transaction %s: %w", txid, err)
+	}
+
+	prevouts, err := s.getPrevouts(ctx, msgTx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := s.txToEsplora(msgTx, prevouts)
+	if tx.Fee == nil && res.Msg.Fee != 0 {
+		fee, err := btcutil.NewAmount(res.Msg.Fee)
+		if err != nil {
+			return nil, err
+		}
+		tx.Fee = lo.ToPtr(int64(fee))
+	}
+
+	if res.Msg.Blockhash != "" {
+		if height == nil || blockHash != res.Msg.Blockhash {
+			block, err := core.GetBlock(ctx, connect.NewRequest(&corepb.GetBlockRequest{
+				Hash:      res.Msg.Blockhash,
+				Verbosity: corepb.GetBlockRequest_VERBOSITY_BLOCK_INFO,
+			}))
+			if err != nil {
+				return nil, fmt.Errorf("bitcoind: get block %s: %w", res.Msg.Blockhash, err)
+			}
+			height = &block.Msg.Height
+		}
+
+		tx.Status = TxStatus{
+			Confirmed:   true,
+			BlockHeight: height,
+			BlockHash:   res.Msg.Blockhash,
+			BlockTime:   res.Msg.BlockTime.AsTime().Unix(),
+		}
+	}
+
+	return tx, nil
+}
+
+func decodeTx(raw *corepb.RawTransaction) (*wire.MsgTx, error) {
+	data := raw.GetData()
+	if len(data) == 0 {
+		var err error
+		data, err = hex.DecodeString(raw.GetHex())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var tx wire.MsgTx
+	if err := tx.Deserialize(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// getPrevouts looks up the outputs spent by the transaction, first in the
+// address index and then in Bitcoin Core. Prevouts we can't find are left
+// out.
+func (s *Server) getPrevouts(ctx context.Context, tx *wire.MsgTx) (map[wire.OutPoint]*wire.TxOut, error) {
+	prevouts := make(map[wire.OutPoint]*wire.TxOut)
+	if blockchain.IsCoinBaseTx(tx) {
+		return prevouts, nil
+	}
+
+	outpoints := lo.Map(tx.TxIn, func(in *wire.TxIn, _ int) wire.OutPoint {
+		return in.PreviousOutPoint
+	})
+
+	if s.config.IndexAddresses {
+		var err error
+		prevouts, err = addresshistory.GetOutputs(ctx, s.database, outpoints)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	core, err := s.bitcoind.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fetched := make(map[chainhash.Hash]*wire.MsgTx)
+	for _, outpoint := range outpoints {
+		if _, ok := prevouts[outpoint]; ok {
+			continue
+		}
+
+		prevTx, ok := fetched[outpoint.Hash]
+		if !ok {
+			// Only works with -txindex, or if the transaction is in the mempool
+			res, err := core.GetRawTransaction(ctx, connect.NewRequest(&corepb.GetRawTransactionRequest{
+				Txid:      outpoint.Hash.String(),
+				Verbosity: corepb.GetRawTransactionRequest_VERBOSITY_RAW_DATA,
+			}))
+			if err != nil {
+				zerolog.Ctx(ctx).Debug().Err(err).
+					Msgf("esplora: could not get prevout %s", outpoint)
+				fetched[outpoint.Hash] = nil
+				continue
+			}
+
+			prevTx, err = decodeTx(res.Msg.Tx)
+			if err != nil {
+				return nil, fmt.Errorf("decode transaction %s: %w", outpoint.Hash, err)
+			}
+			fetched[outpoint.Hash] = prevTx
+		}
+
+		if prevTx != nil && outpoint.Index < uint32(len(prevTx.TxOut)) {
+			prevouts[outpoint] = prevTx.TxOut[outpoint.Index]
+		}
+	}
+
+	return prevouts, nil
+}
+
+func (s *Server) txToEsplora(tx *wire.MsgTx, prevouts map[wire.OutPoint]*wire.TxOut) *Tx {
+	isCoinbase := blockchain.IsCoinBaseTx(tx)
+
+	var (
+		in           int64
+		haveAllPrevs = true
+	)
+	vin := make([]Vin, 0, len(tx.TxIn))
+	for _, txIn := range tx.TxIn {
+		input := Vin{
+			TxID:       txIn.PreviousOutPoint.Hash.String(),
+			Vout:       txIn.PreviousOutPoint.Index,
+			ScriptSig:  hex.EncodeToString(txIn.SignatureScript),
+			IsCoinbase: isCoinbase,
+			Sequence:   txIn.Sequence,
+		}
+		for _, item := range txIn.Witness {
+			input.Witness = append(input.Witness, hex.EncodeToString(item))
+		}
+
+		if prevout, ok := prevouts[txIn.PreviousOutPoint]; ok {
+			input.Prevout = lo.ToPtr(s.outputToEsplora(prevout))
+			in += prevout.Value
+		} else {
+			haveAllPrevs = false
+		}
+
+		vin = append(vin, input)
+	}
+
+	var out int64
+	vout := make([]Vout, 0, len(tx.TxOut))
+	for _, txOut := range tx.TxOut {
+		vout = append(vout, s.outputToEsplora(txOut))
+		out += txOut.Value
+	}
+
+	var fee *int64
+	switch {
+	case isCoinbase:
+		fee = lo.ToPtr(int64(0))
+	case haveAllPrevs:
+		fee = lo.ToPtr(in - out)
+	}
+
+	return &Tx{
+		TxID:     tx.TxID(),
+		Version:  tx.Version,
+		Locktime: tx.LockTime,
+		Vin:      vin,
+		Vout:     vout,
+		Size:     tx.SerializeSize(),
+		Weight:   blockchain.GetTransactionWeight(btcutil.NewTx(tx)),
+		Fee:      fee,
+	}
+}
+
+func (s *Server) outputToEsplora(out *wire.TxOut) Vout {
+	vout := Vout{
+		ScriptPubKey: hex.EncodeToString(out.PkScript),
+		Value:        out.Value,
+	}
+
+	class := txscript.GetScriptClass(out.PkScript)
+	switch {
+	case len(out.PkScript) == 0:
+		vout.ScriptPubKeyType = "empty"
+	case out.PkScript[0] == txscript.OP_RETURN:
+		vout.ScriptPubKeyType = "op_return"
+	case class == txscript.PubKeyTy:
+		vout.ScriptPubKeyType = "p2pk"
+	case class == txscript.PubKeyHashTy:
+		vout.ScriptPubKeyType = "p2pkh"
+	case class == txscript.ScriptHashTy:
+		vout.ScriptPubKeyType = "p2sh"
+	case class == txscript.WitnessV0PubKeyHashTy:
+		vout.ScriptPubKeyType = "v0_p2wpkh"
+	case class == txscript.WitnessV0ScriptHashTy:
+		vout.ScriptPubKeyType = "v0_p2wsh"
+	case class == txscript.WitnessV1TaprootTy:
+		vout.ScriptPubKeyType = "v1_p2tr"
+	default:
+		vout.ScriptPubKeyType = "unknown"
+	}
+
+	// Esplora doesn't give P2PK outputs an address
+	if class != txscript.PubKeyTy {
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(out.PkScript, s.chainParams)
+		if err == nil && len(addrs) == 1 {
+			vout.ScriptPubKeyAddress = addrs[0].EncodeAddress()
+		}
+	}
+
+	return vout
+}
+
+func (s *Server) feeEstimatesHandler(w http.ResponseWriter, r *http.Request) error {
+	estimates, err := s.getFeeEstimates(r.Context())
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, estimates)
+}
+
+// getFeeEstimates returns fee estimates in sat/vB, keyed by confirmation
+// target. Targets Bitcoin Core can't estimate are left out.
+func (s *Server) getFeeEstimates(ctx context.Context) (map[string]float64, error) {
+	s.feeEstimatesMu.Lock()
+	defer s.feeEstimatesMu.Unlock()
+
+	if s.feeEstimates != nil && time.Since(s.feeEstimatesAt) < feeEstimatesTTL {
+		return s.feeEstimates, nil
+	}
+
+	core, err := s.bitcoind.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	estimates := make(map[string]float64, len(feeEstimateTargets))
+	for _, target := range feeEstimateTargets {
+		res, err := core.EstimateSmartFee(ctx, connect.NewRequest(&corepb.EstimateSmartFeeRequest{
+			ConfTarget: target,
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("bitcoind: estimate smart fee for %d blocks: %w", target, err)
+		}
+		if res.Msg.FeeRate <= 0 {
+			continue
+		}
+
+		// BTC/kvB to sat/vB
+		estimates[strconv.FormatInt(target, 10)] = res.Msg.FeeRate * btcutil.SatoshiPerBitcoin / 1000
+	}
+
+	s.feeEstimates = estimates
+	s.feeEstimatesAt = time.Now()
+
+	return estimates, nil
+}
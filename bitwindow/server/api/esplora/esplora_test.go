@@ -0,0 +1,392 @@
+package api_esplora_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	api_esplora "github.com/LayerTwo-Labs/sidesail/bitwindow/server/api/esplora"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/config"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/addresshistory"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blocks"
+	service "github.com/LayerTwo-Labs/sidesail/bitwindow/server/service"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests/mocks"
+	corepb "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha"
+	corerpc "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha/bitcoindv1alphaconnect"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// P2WPKH of 20×0x01 on signet
+const aliceAddress = "tb1qqyqszqgpqyqszqgpqyqszqgpqyqszqgpw0yxjz"
+
+var alice = append([]byte{txscript.OP_0, txscript.OP_DATA_20}, bytes.Repeat([]byte{0x01}, 20)...)
+
+func newServer(t *testing.T, conf config.Config) (*httptest.Server, *mocks.MockBitcoinServiceClient, *sql.DB) {
+	t.Helper()
+
+	db := database.Test(t)
+	core := mocks.NewMockBitcoinServiceClient(gomock.NewController(t))
+	bitcoind := service.New("bitcoind", func(ctx context.Context) (corerpc.BitcoinServiceClient, error) {
+		return core, nil
+	})
+
+	srv := httptest.NewServer(api_esplora.New(db, bitcoind, &chaincfg.SigNetParams, conf).Handler())
+	t.Cleanup(srv.Close)
+
+	return srv, core, db
+}
+
+func get(t *testing.T, srv *httptest.Server, path string) (int, []byte) {
+	t.Helper()
+
+	res, err := srv.Client().Get(srv.URL + path)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	return res.StatusCode, body
+}
+
+func TestServer_TipHeight(t *testing.T) {
+	t.Parallel()
+
+	srv, core, _ := newServer(t, config.Config{})
+	core.EXPECT().
+		GetBlockchainInfo(gomock.Any(), gomock.Any()).
+		Return(connect.NewResponse(&corepb.GetBlockchainInfoResponse{Blocks: 1234, BestBlockHash: "abcd"}), nil).
+		Times(2)
+
+	status, body := get(t, srv, "/blocks/tip/height")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "1234", string(body))
+
+	status, body = get(t, srv, "/blocks/tip/hash")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "abcd", string(body))
+}
+
+func TestServer_Block(t *testing.T) {
+	t.Parallel()
+
+	hash := chaincfg.SigNetParams.GenesisHash.String()
+
+	t.Run("found", func(t *testing.T) {
+		t.Parallel()
+
+		srv, core, _ := newServer(t, config.Config{})
+		core.EXPECT().
+			GetBlock(gomock.Any(), tests.Connect(&corepb.GetBlockRequest{
+				Hash:      hash,
+				Verbosity: corepb.GetBlockRequest_VERBOSITY_BLOCK_INFO,
+			})).
+			Return(connect.NewResponse(&corepb.GetBlockResponse{
+				Hash:       hash,
+				Height:     0,
+				Version:    1,
+				MerkleRoot: "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b",
+				Time:       timestamppb.New(time.Unix(1598918400, 0)),
+				Nonce:      52613770,
+				Bits:       "1e0377ae",
+				Difficulty: 0.001126515290698186,
+				Size:       285,
+				Weight:     1140,
+				Txids:      []string{"4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"},
+			}), nil)
+
+		status, body := get(t, srv, "/block/"+hash)
+		require.Equal(t, http.StatusOK, status, string(body))
+
+		var block api_esplora.Block
+		require.NoError(t, json.Unmarshal(body, &block))
+		assert.Equal(t, api_esplora.Block{
+			ID:         hash,
+			Version:    1,
+			Timestamp:  1598918400,
+			TxCount:    1,
+			Size:       285,
+			Weight:     1140,
+			MerkleRoot: "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b",
+			Nonce:      52613770,
+			Bits:       0x1e0377ae,
+			Difficulty: 0.001126515290698186,
+		}, block)
+		// Genesis has no previous block
+		assert.Contains(t, string(body), `"previousblockhash":null`)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		srv, core, _ := newServer(t, config.Config{})
+		core.EXPECT().
+			GetBlock(gomock.Any(), gomock.Any()).
+			Return(nil, connect.NewError(connect.CodeNotFound, assert.AnError))
+
+		status, body := get(t, srv, "/block/"+hash)
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, "Block not found\n", string(body))
+	})
+
+	t.Run("invalid hash", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _, _ := newServer(t, config.Config{})
+
+		status, _ := get(t, srv, "/block/nothex")
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestServer_Tx(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	coinbase := wire.NewMsgTx(2)
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), []byte{0x01}, nil))
+	coinbase.AddTxOut(wire.NewTxOut(5_000, alice))
+
+	coinbaseHash := coinbase.TxHash()
+	payment := wire.NewMsgTx(2)
+	payment.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&coinbaseHash, 0), nil, [][]byte{{0xaa}}))
+	payment.AddTxOut(wire.NewTxOut(4_000, alice))
+	payment.AddTxOut(wire.NewTxOut(0, []byte{txscript.OP_RETURN, txscript.OP_DATA_1, 0x01}))
+
+	blockHash := chainhash.Hash{1}
+	blockTime := time.Unix(1700000000, 0)
+
+	expectRawTx := func(core *mocks.MockBitcoinServiceClient, tx *wire.MsgTx, hint string) {
+		var buf bytes.Buffer
+		require.NoError(t, tx.Serialize(&buf))
+
+		core.EXPECT().
+			GetRawTransaction(gomock.Any(), tests.Connect(&corepb.GetRawTransactionRequest{
+				Txid:      tx.TxID(),
+				Verbosity: corepb.GetRawTransactionRequest_VERBOSITY_TX_PREVOUT_INFO,
+				Blockhash: hint,
+			})).
+			Return(connect.NewResponse(&corepb.GetRawTransactionResponse{
+				Tx:            &corepb.RawTransaction{Data: buf.Bytes()},
+				Txid:          tx.TxID(),
+				Blockhash:     blockHash.String(),
+				Confirmations: 1,
+				BlockTime:     timestamppb.New(blockTime),
+			}), nil)
+	}
+
+	t.Run("with address index", func(t *testing.T) {
+		t.Parallel()
+
+		srv, core, db := newServer(t, config.Config{IndexAddresses: true})
+		require.NoError(t, addresshistory.IndexBlock(ctx, db, 7, &wire.MsgBlock{
+			Transactions: []*wire.MsgTx{coinbase, payment},
+		}))
+		require.NoError(t, blocks.MarkBlocksProcessed(ctx, db, []blocks.ProcessedBlock{{
+			Height: 7, Hash: blockHash, BlockTime: blockTime,
+		}}))
+
+		// The index knows the block, and the prevout
+		expectRawTx(core, payment, blockHash.String())
+
+		status, body := get(t, srv, "/tx/"+payment.TxID())
+		require.Equal(t, http.StatusOK, status, string(body))
+
+		var tx api_esplora.Tx
+		require.NoError(t, json.Unmarshal(body, &tx))
+		assert.Equal(t, payment.TxID(), tx.TxID)
+		assert.Equal(t, api_esplora.TxStatus{
+			Confirmed:   true,
+			BlockHeight: lo.ToPtr(uint32(7)),
+			BlockHash:   blockHash.String(),
+			BlockTime:   blockTime.Unix(),
+		}, tx.Status)
+		require.NotNil(t, tx.Fee)
+		assert.Equal(t, int64(1_000), *tx.Fee)
+
+		require.Len(t, tx.Vin, 1)
+		assert.Equal(t, coinbase.TxID(), tx.Vin[0].TxID)
+		assert.Equal(t, []string{"aa"}, tx.Vin[0].Witness)
+		assert.Equal(t, &api_esplora.Vout{
+			ScriptPubKey:        "0014" + "0101010101010101010101010101010101010101",
+			ScriptPubKeyType:    "v0_p2wpkh",
+			ScriptPubKeyAddress: aliceAddress,
+			Value:               5_000,
+		}, tx.Vin[0].Prevout)
+
+		require.Len(t, tx.Vout, 2)
+		assert.Equal(t, "op_return", tx.Vout[1].ScriptPubKeyType)
+		assert.Empty(t, tx.Vout[1].ScriptPubKeyAddress)
+	})
+
+	t.Run("without address index", func(t *testing.T) {
+		t.Parallel()
+
+		srv, core, _ := newServer(t, config.Config{})
+
+		expectRawTx(core, payment, "")
+		// Height comes from the block
+		core.EXPECT().
+			GetBlock(gomock.Any(), tests.Connect(&corepb.GetBlockRequest{
+				Hash:      blockHash.String(),
+				Verbosity: corepb.GetBlockRequest_VERBOSITY_BLOCK_INFO,
+			})).
+			Return(connect.NewResponse(&corepb.GetBlockResponse{Height: 7}), nil)
+		// Prevout can't be found without -txindex
+		core.EXPECT().
+			GetRawTransaction(gomock.Any(), tests.Connect(&corepb.GetRawTransactionRequest{
+				Txid:      coinbase.TxID(),
+				Verbosity: corepb.GetRawTransactionRequest_VERBOSITY_RAW_DATA,
+			})).
+			Return(nil, connect.NewError(connect.CodeNotFound, assert.AnError))
+
+		status, body := get(t, srv, "/tx/"+payment.TxID())
+		require.Equal(t, http.StatusOK, status, string(body))
+
+		var tx api_esplora.Tx
+		require.NoError(t, json.Unmarshal(body, &tx))
+		assert.Equal(t, lo.ToPtr(uint32(7)), tx.Status.BlockHeight)
+		assert.Nil(t, tx.Fee)
+		require.Len(t, tx.Vin, 1)
+		assert.Nil(t, tx.Vin[0].Prevout)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		srv, core, _ := newServer(t, config.Config{})
+		core.EXPECT().
+			GetRawTransaction(gomock.Any(), gomock.Any()).
+			Return(nil, connect.NewError(connect.CodeNotFound, assert.AnError))
+
+		status, body := get(t, srv, "/tx/"+chainhash.Hash{2}.String())
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, "Transaction not found\n", string(body))
+	})
+}
+
+func TestServer_AddressTxs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("index not enabled", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _, _ := newServer(t, config.Config{})
+
+		status, _ := get(t, srv, "/address/"+aliceAddress+"/txs")
+		assert.Equal(t, http.StatusNotImplemented, status)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _, _ := newServer(t, config.Config{IndexAddresses: true})
+
+		// Mainnet address
+		status, _ := get(t, srv, "/address/bc1qqyqszqgpqyqszqgpqyqszqgpqyqszqgpjxz8pj/txs")
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("paginates", func(t *testing.T) {
+		t.Parallel()
+
+		srv, core, db := newServer(t, config.Config{IndexAddresses: true})
+
+		var txs []*wire.MsgTx
+		for height := uint32(1); height <= 3; height++ {
+			coinbase := wire.NewMsgTx(2)
+			coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), []byte{byte(height)}, nil))
+			coinbase.AddTxOut(wire.NewTxOut(5_000, alice))
+			txs = append(txs, coinbase)
+
+			hash := chainhash.Hash{byte(height)}
+			require.NoError(t, addresshistory.IndexBlock(ctx, db, height, &wire.MsgBlock{
+				Transactions: []*wire.MsgTx{coinbase},
+			}))
+			require.NoError(t, blocks.MarkBlocksProcessed(ctx, db, []blocks.ProcessedBlock{{
+				Height: height, Hash: hash, BlockTime: time.Unix(int64(height), 0),
+			}}))
+
+			var buf bytes.Buffer
+			require.NoError(t, coinbase.Serialize(&buf))
+			core.EXPECT().
+				GetRawTransaction(gomock.Any(), tests.Connect(&corepb.GetRawTransactionRequest{
+					Txid:      coinbase.TxID(),
+					Verbosity: corepb.GetRawTransactionRequest_VERBOSITY_TX_PREVOUT_INFO,
+					Blockhash: hash.String(),
+				})).
+				Return(connect.NewResponse(&corepb.GetRawTransactionResponse{
+					Tx:        &corepb.RawTransaction{Data: buf.Bytes()},
+					Blockhash: hash.String(),
+					BlockTime: timestamppb.New(time.Unix(int64(height), 0)),
+				}), nil).
+				AnyTimes()
+		}
+
+		status, body := get(t, srv, "/address/"+aliceAddress+"/txs")
+		require.Equal(t, http.StatusOK, status, string(body))
+
+		var page []api_esplora.Tx
+		require.NoError(t, json.Unmarshal(body, &page))
+		require.Len(t, page, 3)
+		// Newest first
+		assert.Equal(t, txs[2].TxID(), page[0].TxID)
+		assert.Equal(t, lo.ToPtr(uint32(3)), page[0].Status.BlockHeight)
+		assert.Equal(t, txs[0].TxID(), page[2].TxID)
+
+		status, body = get(t, srv, "/address/"+aliceAddress+"/txs/chain/"+txs[1].TxID())
+		require.Equal(t, http.StatusOK, status, string(body))
+		require.NoError(t, json.Unmarshal(body, &page))
+		require.Len(t, page, 1)
+		assert.Equal(t, txs[0].TxID(), page[0].TxID)
+	})
+}
+
+func TestServer_FeeEstimates(t *testing.T) {
+	t.Parallel()
+
+	srv, core, _ := newServer(t, config.Config{})
+
+	// Once for every target, the second request is cached
+	core.EXPECT().
+		EstimateSmartFee(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *connect.Request[corepb.EstimateSmartFeeRequest]) (*connect.Response[corepb.EstimateSmartFeeResponse], error) {
+			if req.Msg.ConfTarget == 1008 {
+				return connect.NewResponse(&corepb.EstimateSmartFeeResponse{Errors: []string{"Insufficient data or no feerate found"}}), nil
+			}
+			return connect.NewResponse(&corepb.EstimateSmartFeeResponse{FeeRate: 0.00002}), nil
+		}).
+		Times(28)
+
+	for range 2 {
+		status, body := get(t, srv, "/fee-estimates")
+		require.Equal(t, http.StatusOK, status, string(body))
+
+		var estimates map[string]float64
+		require.NoError(t, json.Unmarshal(body, &estimates))
+		assert.Len(t, estimates, 27)
+		assert.InDelta(t, 2.0, estimates["1"], 1e-9)
+		assert.InDelta(t, 2.0, estimates["504"], 1e-9)
+		assert.NotContains(t, estimates, "1008")
+	}
+}
@@ -2,11 +2,19 @@ package api_misc
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
+	"math"
+	"net"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/engines"
@@ -18,6 +26,8 @@ import (
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/opreturns"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/timestamps"
 	service "github.com/LayerTwo-Labs/sidesail/bitwindow/server/service"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/rs/zerolog"
 	"github.com/samber/lo"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -32,32 +42,169 @@ var _ rpc.MiscServiceHandler = new(Server)
 func New(
 	database *sql.DB,
 	wallet *service.Service[validatorrpc.WalletServiceClient],
+	walletEngine *engines.WalletEngine,
 	timestampEngine *engines.TimestampEngine,
+	bitcoinEngine *engines.Parser,
 ) *Server {
 	return &Server{
 		database:        database,
 		wallet:          wallet,
+		walletEngine:    walletEngine,
 		timestampEngine: timestampEngine,
+		bitcoinEngine:   bitcoinEngine,
 	}
 }
 
 type Server struct {
 	database        *sql.DB
 	wallet          *service.Service[validatorrpc.WalletServiceClient]
+	walletEngine    *engines.WalletEngine
 	timestampEngine *engines.TimestampEngine
+	bitcoinEngine   *engines.Parser
 }
 
 // ListOPReturn implements miscv1connect.MiscServiceHandler.
-func (s *Server) ListOPReturn(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[miscv1.ListOPReturnResponse], error) {
-	opReturns, err := opreturns.List(ctx, s.database)
+func (s *Server) ListOPReturn(ctx context.Context, req *connect.Request[miscv1.ListOPReturnRequest]) (*connect.Response[miscv1.ListOPReturnResponse], error) {
+	protocols := make([]opreturns.Protocol, 0, len(req.Msg.Protocols))
+	for _, protocol := range req.Msg.Protocols {
+		converted, ok := protocolFromProto[protocol]
+		if !ok {
+			err := fmt.Errorf("invalid protocol: %s", protocol)
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		protocols = append(protocols, converted)
+	}
+
+	filter, err := searchFilterFromProto(req.Msg.Filter)
 	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	filter.Protocols = protocols
+
+	var after int64
+	if req.Msg.PageToken != "" {
+		after, err = strconv.ParseInt(req.Msg.PageToken, 10, 64)
+		if err != nil {
+			err := fmt.Errorf("invalid page token %q", req.Msg.PageToken)
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	opReturns, next, err := opreturns.Search(ctx, s.database, filter, after, int(req.Msg.PageSize))
+	if errors.Is(err, opreturns.ErrInvalidQuery) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	} else if err != nil {
 		zerolog.Ctx(ctx).Error().Err(err).Msg("could not list op returns")
 		return nil, err
 	}
 
-	return connect.NewResponse(&miscv1.ListOPReturnResponse{
+	resp := &miscv1.ListOPReturnResponse{
 		OpReturns: lo.Map(opReturns, opReturnToProto),
-	}), nil
+	}
+	if next != 0 {
+		resp.NextPageToken = strconv.FormatInt(next, 10)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// WatchOPReturns implements miscv1connect.MiscServiceHandler.
+func (s *Server) WatchOPReturns(ctx context.Context, req *connect.Request[miscv1.WatchOPReturnsRequest], stream *connect.ServerStream[miscv1.WatchOPReturnsResponse]) error {
+	protocols := make(map[opreturns.Protocol]bool, len(req.Msg.Protocols))
+	for _, protocol := range req.Msg.Protocols {
+		converted, ok := protocolFromProto[protocol]
+		if !ok {
+			err := fmt.Errorf("invalid protocol: %s", protocol)
+			return connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		protocols[converted] = true
+	}
+
+	return s.watchOPReturns(ctx, stream.Conn(), func(event engines.OPReturnEvent) error {
+		for _, opReturn := range event.OPReturns {
+			if len(protocols) > 0 && !protocols[opReturn.Protocol] {
+				continue
+			}
+			err := stream.Send(&miscv1.WatchOPReturnsResponse{
+				OpReturn: opReturnToProto(opReturn, 0),
+				Event:    watchEventToProto(event),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// watchOPReturns hands OP_RETURN events to send until the client goes away.
+func (s *Server) watchOPReturns(ctx context.Context, conn connect.StreamingHandlerConn, send func(event engines.OPReturnEvent) error) error {
+	events := s.bitcoinEngine.WatchOPReturns(ctx)
+
+	// Sends the response headers, so clients can tell when they're watching
+	if err := conn.Send(nil); err != nil {
+		return err
+	}
+
+	for event := range events {
+		if err := send(event); err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Msg("could not send OP_RETURN event")
+			return err
+		}
+	}
+
+	// Closed without the client going away, so it might've missed events
+	if ctx.Err() == nil {
+		err := errors.New("fell too far behind, list to catch up and watch again")
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	}
+
+	return ctx.Err()
+}
+
+func watchEventToProto(event engines.OPReturnEvent) miscv1.WatchEvent {
+	if event.Confirmed {
+		return miscv1.WatchEvent_WATCH_EVENT_CONFIRMED
+	}
+	return miscv1.WatchEvent_WATCH_EVENT_UNCONFIRMED
+}
+
+func searchFilterFromProto(filter *miscv1.SearchFilter) (opreturns.Filter, error) {
+	if filter == nil {
+		return opreturns.Filter{}, nil
+	}
+
+	result := opreturns.Filter{
+		Query:     filter.Query,
+		MinHeight: filter.MinHeight,
+		MaxHeight: filter.MaxHeight,
+		MinFee:    btcutil.Amount(filter.MinFeeSats),
+	}
+	if filter.MinFeeRate < 0 || math.IsNaN(filter.MinFeeRate) {
+		return opreturns.Filter{}, fmt.Errorf("invalid min fee rate %v", filter.MinFeeRate)
+	}
+	result.MinFeeRate = filter.MinFeeRate
+	if filter.StartTime != nil {
+		result.Start = lo.ToPtr(filter.StartTime.AsTime())
+	}
+	if filter.EndTime != nil {
+		result.End = lo.ToPtr(filter.EndTime.AsTime())
+	}
+	if filter.TxidPrefix != "" {
+		prefix, err := opreturns.ValidTxIDPrefix(filter.TxidPrefix)
+		if err != nil {
+			return opreturns.Filter{}, err
+		}
+		result.TxIDPrefix = prefix
+	}
+	if filter.Topic != nil {
+		topic, err := opreturns.ValidNewsTopicID(*filter.Topic)
+		if err != nil {
+			return opreturns.Filter{}, err
+		}
+		result.Topic = &topic
+	}
+
+	return result, nil
 }
 
 func opReturnToProto(opReturn opreturns.OPReturn, _ int) *miscv1.OPReturn {
@@ -66,17 +213,53 @@ func opReturnToProto(opReturn opreturns.OPReturn, _ int) *miscv1.OPReturn {
 		height = lo.ToPtr(int32(*opReturn.Height))
 	}
 	return &miscv1.OPReturn{
-		Id:         opReturn.ID,
-		Message:    opreturns.OPReturnToReadable(opReturn.Data),
-		Txid:       opReturn.TxID,
-		Vout:       opReturn.Vout,
-		Height:     height,
-		CreateTime: timestamppb.New(lo.FromPtr(opReturn.CreatedAt)),
+		Id:             opReturn.ID,
+		Message:        opreturns.OPReturnToReadable(opReturn.Data),
+		Txid:           opReturn.TxID,
+		Vout:           opReturn.Vout,
+		Height:         height,
+		CreateTime:     timestamppb.New(lo.FromPtr(opReturn.CreatedAt)),
+		Status:         opReturnStatusToProto(opReturn.Status),
+		ReplacedByTxid: opReturn.ReplacedByTxID,
+		Protocol:       protocolToProto[opReturn.Protocol],
+		Fields:         opReturn.Fields,
+	}
+}
+
+var protocolToProto = map[opreturns.Protocol]miscv1.Protocol{
+	opreturns.ProtocolUnknown:            miscv1.Protocol_PROTOCOL_UNKNOWN,
+	opreturns.ProtocolCoinNews:           miscv1.Protocol_PROTOCOL_COIN_NEWS,
+	opreturns.ProtocolCoinNewsTopic:      miscv1.Protocol_PROTOCOL_COIN_NEWS_TOPIC,
+	opreturns.ProtocolCoinNewsModeration: miscv1.Protocol_PROTOCOL_COIN_NEWS_MODERATION,
+	opreturns.ProtocolChunk:              miscv1.Protocol_PROTOCOL_CHUNK,
+	opreturns.ProtocolBIP300:             miscv1.Protocol_PROTOCOL_BIP300,
+	opreturns.ProtocolRunes:              miscv1.Protocol_PROTOCOL_RUNES,
+	opreturns.ProtocolOmni:               miscv1.Protocol_PROTOCOL_OMNI,
+	opreturns.ProtocolTimestamp:          miscv1.Protocol_PROTOCOL_TIMESTAMP,
+	opreturns.ProtocolText:               miscv1.Protocol_PROTOCOL_TEXT,
+}
+
+var protocolFromProto = lo.Invert(protocolToProto)
+
+func opReturnStatusToProto(status opreturns.Status) miscv1.OPReturn_Status {
+	switch status {
+	case opreturns.StatusActive:
+		return miscv1.OPReturn_STATUS_ACTIVE
+	case opreturns.StatusDropped:
+		return miscv1.OPReturn_STATUS_DROPPED
+	case opreturns.StatusReplaced:
+		return miscv1.OPReturn_STATUS_REPLACED
+	default:
+		return miscv1.OPReturn_STATUS_UNSPECIFIED
 	}
 }
 
 // BroadcastNews implements miscv1connect.MiscServiceHandler.
 func (s *Server) BroadcastNews(ctx context.Context, req *connect.Request[miscv1.BroadcastNewsRequest]) (*connect.Response[miscv1.BroadcastNewsResponse], error) {
+	if req.Msg.ReplyTo != nil {
+		return s.broadcastReply(ctx, req.Msg)
+	}
+
 	if req.Msg.Topic == "" {
 		err := errors.New("topic must be set")
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
@@ -103,22 +286,82 @@ func (s *Server) BroadcastNews(ctx context.Context, req *connect.Request[miscv1.
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
-	wallet, err := s.wallet.Get(ctx)
+	message := opreturns.EncodeNewsMessage(topicID, req.Msg.Headline, req.Msg.Content)
+	return s.broadcastNewsMessage(ctx, req.Msg, message)
+}
+
+// broadcastReply broadcasts a reply, in the topic of the post it's to.
+func (s *Server) broadcastReply(ctx context.Context, req *miscv1.BroadcastNewsRequest) (*connect.Response[miscv1.BroadcastNewsResponse], error) {
+	if req.Headline != "" {
+		err := errors.New("replies have no headline")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if req.Content == "" {
+		err := errors.New("content must be set")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	parent, parentHash, err := s.findNewsPost(ctx, *req.ReplyTo)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := wallet.SendTransaction(ctx,
-		connect.NewRequest(&validatorpb.SendTransactionRequest{
-			OpReturnMessage: &commonv1.Hex{
-				Hex: &wrapperspb.StringValue{
-					Value: hex.EncodeToString(
-						opreturns.EncodeNewsMessage(
-							topicID, req.Msg.Headline, req.Msg.Content,
-						),
-					),
-				},
-			},
-		}))
+	if req.Topic != "" && req.Topic != parent.Topic.String() {
+		err := fmt.Errorf("replies are in the topic of the post, %s", parent.Topic)
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	message := opreturns.EncodeReplyMessage(parent.Topic, parentHash, req.Content)
+	return s.broadcastNewsMessage(ctx, req, message)
+}
+
+// findNewsPost finds the post or reply with the given txid.
+func (s *Server) findNewsPost(ctx context.Context, txid string) (opreturns.CoinNews, chainhash.Hash, error) {
+	hash, err := chainhash.NewHashFromStr(txid)
+	if err != nil || len(txid) != chainhash.MaxHashStringSize {
+		err := fmt.Errorf("invalid txid %q", txid)
+		return opreturns.CoinNews{}, chainhash.Hash{}, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	reader, err := opreturns.NewNewsReader(ctx, s.database)
+	if err != nil {
+		return opreturns.CoinNews{}, chainhash.Hash{}, fmt.Errorf("read topics: %w", err)
+	}
+	post, ok, err := reader.FindPost(ctx, hash.String())
+	if err != nil {
+		return opreturns.CoinNews{}, chainhash.Hash{}, fmt.Errorf("find coin news post: %w", err)
+	}
+	if !ok {
+		err := fmt.Errorf("no coin news post with txid %s", hash)
+		return opreturns.CoinNews{}, chainhash.Hash{}, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	return post, *hash, nil
+}
+
+// broadcastNewsMessage signs the message if asked to, and broadcasts it.
+func (s *Server) broadcastNewsMessage(ctx context.Context, req *miscv1.BroadcastNewsRequest, message []byte) (*connect.Response[miscv1.BroadcastNewsResponse], error) {
+	topicID := opreturns.TopicID(message[:opreturns.TopicIdLength])
+
+	var (
+		author string
+		err    error
+	)
+	if req.Sign {
+		message, author, err = s.signNewsMessage(message)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	chunks := [][]byte{message}
+	if req.Chunked && len(message) > opreturns.MaxOPReturnSize {
+		chunks, err = opreturns.EncodeChunks(message, opreturns.MaxOPReturnSize)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	txids, err := s.sendOPReturns(ctx, chunks)
 	if err != nil {
 		return nil, fmt.Errorf("broadcast news: %w", err)
 	}
@@ -126,15 +369,152 @@ func (s *Server) BroadcastNews(ctx context.Context, req *connect.Request[miscv1.
 	log := zerolog.Ctx(ctx)
 	log.Info().
 		Hex("topic", topicID[:]).
-		Str("headline", req.Msg.Headline).
-		Str("txid", resp.Msg.Txid.String()).
+		Str("headline", req.Headline).
+		Str("reply-to", req.GetReplyTo()).
+		Str("author", author).
+		Strs("txids", txids).
 		Msg("broadcast news transaction")
 
-	return connect.NewResponse(&miscv1.BroadcastNewsResponse{
-		Txid: resp.Msg.Txid.Hex.Value,
+	resp := &miscv1.BroadcastNewsResponse{
+		Txid:   txids[0],
+		Author: author,
+	}
+	if len(txids) > 1 {
+		resp.ChunkTxids = txids
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// signNewsMessage signs a news message with this wallet's news key.
+func (s *Server) signNewsMessage(message []byte) ([]byte, string, error) {
+	key, err := s.walletEngine.NewsSigningKey()
+	if err != nil {
+		err := fmt.Errorf("get news signing key: %w", err)
+		return nil, "", connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	return opreturns.SignNewsMessage(message, key), opreturns.NewsAuthor(key.PubKey()), nil
+}
+
+// React implements miscv1connect.MiscServiceHandler.
+func (s *Server) React(ctx context.Context, req *connect.Request[miscv1.ReactRequest]) (*connect.Response[miscv1.ReactResponse], error) {
+	if !opreturns.ValidReaction(req.Msg.Reaction) {
+		err := fmt.Errorf("reaction must be 1 to %d bytes", opreturns.MaxReactionLength)
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	parent, parentHash, err := s.findNewsPost(ctx, req.Msg.Txid)
+	if err != nil {
+		return nil, err
+	}
+
+	message := opreturns.EncodeReactionMessage(parent.Topic, parentHash, req.Msg.Reaction)
+	var author string
+	if req.Msg.Sign {
+		message, author, err = s.signNewsMessage(message)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	txids, err := s.sendOPReturns(ctx, [][]byte{message})
+	if err != nil {
+		return nil, fmt.Errorf("broadcast reaction: %w", err)
+	}
+
+	zerolog.Ctx(ctx).Info().
+		Str("reply-to", parent.TxID).
+		Str("reaction", req.Msg.Reaction).
+		Str("txid", txids[0]).
+		Msg("broadcast reaction transaction")
+
+	return connect.NewResponse(&miscv1.ReactResponse{
+		Txid:   txids[0],
+		Author: author,
 	}), nil
 }
 
+// sendOPReturns sends one transaction per message, in order. If one of them
+// fails, the ones sent before it are not undone.
+func (s *Server) sendOPReturns(ctx context.Context, messages [][]byte) ([]string, error) {
+	wallet, err := s.wallet.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	txids := make([]string, 0, len(messages))
+	for i, message := range messages {
+		resp, err := wallet.SendTransaction(ctx,
+			connect.NewRequest(&validatorpb.SendTransactionRequest{
+				OpReturnMessage: &commonv1.Hex{
+					Hex: &wrapperspb.StringValue{
+						Value: hex.EncodeToString(message),
+					},
+				},
+			}))
+		if err != nil {
+			if len(messages) == 1 {
+				return nil, err
+			}
+			return nil, fmt.Errorf("send chunk %d of %d (sent %v): %w", i+1, len(messages), txids, err)
+		}
+		txids = append(txids, resp.Msg.Txid.Hex.Value)
+	}
+
+	return txids, nil
+}
+
+// BroadcastChunked implements miscv1connect.MiscServiceHandler.
+func (s *Server) BroadcastChunked(ctx context.Context, req *connect.Request[miscv1.BroadcastChunkedRequest]) (*connect.Response[miscv1.BroadcastChunkedResponse], error) {
+	chunkSize := int(lo.FromPtrOr(req.Msg.ChunkSize, opreturns.MaxOPReturnSize))
+	chunks, err := opreturns.EncodeChunks(req.Msg.Data, chunkSize)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	txids, err := s.sendOPReturns(ctx, chunks)
+	if err != nil {
+		return nil, fmt.Errorf("broadcast chunked: %w", err)
+	}
+
+	id := opreturns.NewChunkID(req.Msg.Data)
+	zerolog.Ctx(ctx).Info().
+		Stringer("message_id", id).
+		Int("size", len(req.Msg.Data)).
+		Strs("txids", txids).
+		Msg("broadcast chunked message")
+
+	return connect.NewResponse(&miscv1.BroadcastChunkedResponse{
+		MessageId: id.String(),
+		Txids:     txids,
+	}), nil
+}
+
+// ListChunkedMessages implements miscv1connect.MiscServiceHandler.
+func (s *Server) ListChunkedMessages(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[miscv1.ListChunkedMessagesResponse], error) {
+	messages, err := opreturns.ListChunkedMessages(ctx, s.database)
+	if err != nil {
+		return nil, fmt.Errorf("list chunked messages: %w", err)
+	}
+
+	return connect.NewResponse(&miscv1.ListChunkedMessagesResponse{
+		Messages: lo.Map(messages, chunkedMessageToProto),
+	}), nil
+}
+
+func chunkedMessageToProto(message opreturns.ChunkedMessage, _ int) *miscv1.ChunkedMessage {
+	return &miscv1.ChunkedMessage{
+		MessageId:      message.ID.String(),
+		TotalChunks:    uint32(message.Total),
+		ReceivedChunks: uint32(message.Received()),
+		Txids:          message.TxIDs,
+		Complete:       message.Complete,
+		Data:           message.Data,
+		FeeSats:        int64(message.Fee),
+		Height:         message.Height,
+		CreateTime:     timestamppb.New(lo.FromPtr(message.CreatedAt)),
+	}
+}
+
 // CreateTopic implements miscv1connect.MiscServiceHandler.
 func (s *Server) CreateTopic(ctx context.Context, req *connect.Request[miscv1.CreateTopicRequest]) (*connect.Response[miscv1.CreateTopicResponse], error) {
 	topicID, err := opreturns.ValidNewsTopicID(req.Msg.Topic)
@@ -165,6 +545,18 @@ func (s *Server) CreateTopic(ctx context.Context, req *connect.Request[miscv1.Cr
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
+	message := opreturns.EncodeTopicCreationMessage(topicID, req.Msg.Name)
+	var owner string
+	if req.Msg.Owned {
+		key, err := s.walletEngine.NewsSigningKey()
+		if err != nil {
+			err := fmt.Errorf("get news signing key: %w", err)
+			return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+		}
+		message = opreturns.EncodeOwnedTopicCreationMessage(topicID, req.Msg.Name, key)
+		owner = opreturns.NewsAuthor(key.PubKey())
+	}
+
 	// Send the transaction
 	wallet, err := s.wallet.Get(ctx)
 	if err != nil {
@@ -174,9 +566,7 @@ func (s *Server) CreateTopic(ctx context.Context, req *connect.Request[miscv1.Cr
 		connect.NewRequest(&validatorpb.SendTransactionRequest{
 			OpReturnMessage: &commonv1.Hex{
 				Hex: &wrapperspb.StringValue{
-					Value: hex.EncodeToString(
-						opreturns.EncodeTopicCreationMessage(topicID, req.Msg.Name),
-					),
+					Value: hex.EncodeToString(message),
 				},
 			},
 		}))
@@ -188,11 +578,13 @@ func (s *Server) CreateTopic(ctx context.Context, req *connect.Request[miscv1.Cr
 	log.Info().
 		Stringer("topic", topicID).
 		Str("title", req.Msg.Name).
+		Str("owner", owner).
 		Str("txid", resp.Msg.Txid.String()).
 		Msg("broadcast create topic transaction")
 
 	return connect.NewResponse(&miscv1.CreateTopicResponse{
-		Txid: resp.Msg.Txid.Hex.Value,
+		Txid:  resp.Msg.Txid.Hex.Value,
+		Owner: owner,
 	}), nil
 }
 
@@ -203,57 +595,489 @@ func (s *Server) ListTopics(ctx context.Context, req *connect.Request[emptypb.Em
 		return nil, err
 	}
 
+	moderation, err := opreturns.GetModeration(ctx, s.database, topics)
+	if err != nil {
+		return nil, fmt.Errorf("get moderation: %w", err)
+	}
+
 	return connect.NewResponse(&miscv1.ListTopicsResponse{
-		Topics: lo.Map(topics, topicToProto),
+		Topics: lo.Map(topics, func(topic opreturns.Topic, _ int) *miscv1.Topic {
+			return topicToProto(topic, moderation[topic.Topic])
+		}),
 	}), nil
 }
 
-func topicToProto(topic opreturns.Topic, _ int) *miscv1.Topic {
-	return &miscv1.Topic{
+func topicToProto(topic opreturns.Topic, moderation *opreturns.Moderation) *miscv1.Topic {
+	res := &miscv1.Topic{
 		Id:         topic.ID,
 		Topic:      topic.Topic.String(),
 		Name:       topic.Name,
 		CreateTime: timestamppb.New(topic.CreatedAt),
+		Owner:      topic.Owner,
+		Subscription: &miscv1.TopicSubscription{
+			Subscribed:       topic.Subscription.Subscribed,
+			Muted:            topic.Subscription.Muted,
+			MinFeeSats:       int64(topic.Subscription.MinFee),
+			IgnoreModeration: topic.Subscription.IgnoreModeration,
+		},
+		Txid:     topic.TxID,
+		Imported: topic.Imported,
+	}
+	if moderation != nil {
+		res.Name = lo.CoalesceOrEmpty(moderation.Name, topic.Name)
+		res.Posters = lo.Keys(moderation.Posters)
+		sort.Strings(res.Posters)
 	}
+	return res
+}
+
+// SetTopicSubscription implements miscv1connect.MiscServiceHandler.
+func (s *Server) SetTopicSubscription(ctx context.Context, req *connect.Request[miscv1.SetTopicSubscriptionRequest]) (*connect.Response[miscv1.SetTopicSubscriptionResponse], error) {
+	topicID, err := opreturns.ValidNewsTopicID(req.Msg.Topic)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if req.Msg.Subscription == nil {
+		err := errors.New("subscription must be set")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if req.Msg.Subscription.MinFeeSats < 0 {
+		err := errors.New("min fee cannot be negative")
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	topics, err := opreturns.ListTopics(ctx, s.database)
+	if err != nil {
+		return nil, err
+	}
+	topic, ok := lo.Find(topics, func(topic opreturns.Topic) bool {
+		return topic.Topic == topicID
+	})
+	if !ok {
+		err := fmt.Errorf("topic %s not found", topicID)
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	topic.Subscription = opreturns.Subscription{
+		Topic:            topicID,
+		Subscribed:       req.Msg.Subscription.Subscribed,
+		Muted:            req.Msg.Subscription.Muted,
+		MinFee:           btcutil.Amount(req.Msg.Subscription.MinFeeSats),
+		IgnoreModeration: req.Msg.Subscription.IgnoreModeration,
+	}
+	if err := opreturns.SetSubscription(ctx, s.database, topic.Subscription); err != nil {
+		return nil, err
+	}
+
+	moderation, err := opreturns.GetModeration(ctx, s.database, []opreturns.Topic{topic})
+	if err != nil {
+		return nil, fmt.Errorf("get moderation: %w", err)
+	}
+
+	return connect.NewResponse(&miscv1.SetTopicSubscriptionResponse{
+		Topic: topicToProto(topic, moderation[topicID]),
+	}), nil
+}
+
+// ExportTopics implements miscv1connect.MiscServiceHandler.
+func (s *Server) ExportTopics(ctx context.Context, req *connect.Request[miscv1.ExportTopicsRequest]) (*connect.Response[miscv1.ExportTopicsResponse], error) {
+	topicIDs := make([]opreturns.TopicID, 0, len(req.Msg.Topics))
+	for _, topic := range req.Msg.Topics {
+		topicID, err := opreturns.ValidNewsTopicID(topic)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		topicIDs = append(topicIDs, topicID)
+	}
+
+	set, err := opreturns.ExportTopics(ctx, s.database, topicIDs)
+	if errors.Is(err, opreturns.ErrUnknownTopic) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode topic set: %w", err)
+	}
+
+	return connect.NewResponse(&miscv1.ExportTopicsResponse{
+		TopicSet: string(encoded),
+	}), nil
+}
+
+// ImportTopics implements miscv1connect.MiscServiceHandler.
+func (s *Server) ImportTopics(ctx context.Context, req *connect.Request[miscv1.ImportTopicsRequest]) (*connect.Response[miscv1.ImportTopicsResponse], error) {
+	set, err := opreturns.ParseTopicSet([]byte(req.Msg.TopicSet))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	imported, err := opreturns.ImportTopics(ctx, s.database, set)
+	if err != nil {
+		return nil, err
+	}
+
+	// So posts in the new topics are picked up
+	if err := s.bitcoinEngine.ReloadTopics(ctx); err != nil {
+		return nil, err
+	}
+
+	zerolog.Ctx(ctx).Info().
+		Int("imported", imported).
+		Int("topics", len(set.Topics)).
+		Msg("imported topic set")
+
+	return connect.NewResponse(&miscv1.ImportTopicsResponse{
+		Imported:     uint32(imported),
+		AlreadyKnown: uint32(len(set.Topics) - imported),
+	}), nil
+}
+
+// subscribedNews leaves out the posts topic subscriptions don't show.
+// requested is whether a topic was asked for, rather than the whole feed.
+func (s *Server) subscribedNews(ctx context.Context, news []opreturns.CoinNews, requested bool) ([]opreturns.CoinNews, error) {
+	subscriptions, err := opreturns.ListSubscriptions(ctx, s.database)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Filter(news, func(coinNews opreturns.CoinNews, _ int) bool {
+		subscription, ok := subscriptions[coinNews.Topic]
+		if !ok {
+			subscription = opreturns.DefaultSubscription(coinNews.Topic)
+		}
+		return subscription.Shows(coinNews, requested)
+	}), nil
+}
+
+// ModerateTopic implements miscv1connect.MiscServiceHandler.
+func (s *Server) ModerateTopic(ctx context.Context, req *connect.Request[miscv1.ModerateTopicRequest]) (*connect.Response[miscv1.ModerateTopicResponse], error) {
+	topicID, err := opreturns.ValidNewsTopicID(req.Msg.Topic)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	op := opreturns.ModerationOp{Topic: topicID}
+	switch action := req.Msg.Action.(type) {
+	case *miscv1.ModerateTopicRequest_Rename:
+		op.Kind = opreturns.ModerationRename
+		op.Payload = []byte(action.Rename)
+
+	case *miscv1.ModerateTopicRequest_AddPoster:
+		op.Kind = opreturns.ModerationAddPoster
+		op.Payload, err = opreturns.PosterHash(action.AddPoster)
+
+	case *miscv1.ModerateTopicRequest_RemovePoster:
+		op.Kind = opreturns.ModerationRemovePoster
+		op.Payload, err = opreturns.PosterHash(action.RemovePoster)
+
+	case *miscv1.ModerateTopicRequest_HideTxid:
+		op.Kind = opreturns.ModerationHidePost
+		var txid *chainhash.Hash
+		txid, err = chainhash.NewHashFromStr(action.HideTxid)
+		if err == nil {
+			op.Payload = txid[:]
+		}
+
+	default:
+		err = errors.New("action must be set")
+	}
+	if err == nil {
+		err = op.Validate()
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	topics, err := opreturns.ListTopics(ctx, s.database)
+	if err != nil {
+		return nil, err
+	}
+	topic, ok := lo.Find(topics, func(topic opreturns.Topic) bool {
+		return topic.Topic == topicID
+	})
+	if !ok {
+		err := errors.New("topic does not exist")
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if topic.Owner == "" {
+		err := errors.New("topic has no owner, and can't be moderated")
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+
+	key, err := s.walletEngine.NewsSigningKey()
+	if err != nil {
+		err := fmt.Errorf("get news signing key: %w", err)
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	if opreturns.NewsAuthor(key.PubKey()) != topic.Owner {
+		err := fmt.Errorf("topic is owned by %s, not this wallet", topic.Owner)
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	moderation, err := opreturns.GetModeration(ctx, s.database, []opreturns.Topic{topic})
+	if err != nil {
+		return nil, fmt.Errorf("get moderation: %w", err)
+	}
+	// Operations we've broadcast but haven't seen in the mempool yet don't
+	// count here. If two of them end up with the same sequence number,
+	// only one is applied.
+	op.Sequence = moderation[topicID].Sequence + 1
+
+	wallet, err := s.wallet.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := wallet.SendTransaction(ctx,
+		connect.NewRequest(&validatorpb.SendTransactionRequest{
+			OpReturnMessage: &commonv1.Hex{
+				Hex: &wrapperspb.StringValue{
+					Value: hex.EncodeToString(opreturns.EncodeModerationMessage(op, key)),
+				},
+			},
+		}))
+	if err != nil {
+		return nil, fmt.Errorf("broadcast topic moderation: %w", err)
+	}
+
+	zerolog.Ctx(ctx).Info().
+		Stringer("topic", topicID).
+		Uint8("kind", uint8(op.Kind)).
+		Uint32("sequence", op.Sequence).
+		Str("txid", resp.Msg.Txid.String()).
+		Msg("broadcast topic moderation transaction")
+
+	return connect.NewResponse(&miscv1.ModerateTopicResponse{
+		Txid: resp.Msg.Txid.Hex.Value,
+	}), nil
 }
 
 // ListCoinNews implements miscv1connect.MiscServiceHandler.
 func (s *Server) ListCoinNews(ctx context.Context, req *connect.Request[miscv1.ListCoinNewsRequest]) (*connect.Response[miscv1.ListCoinNewsResponse], error) {
-	var topicID opreturns.TopicID
-	if req.Msg.Topic != nil {
-		var err error
-		topicID, err = opreturns.ValidNewsTopicID(*req.Msg.Topic)
+	topic, author, err := newsTopicAndAuthor(req.Msg.Topic, req.Msg.Author)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	filter, err := searchFilterFromProto(req.Msg.Filter)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	ranked, window, err := newsSortFromProto(req.Msg.Sort)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	// Paying more is how a post gets seen, but only for a while
+	if ranked {
+		since := time.Now().Add(-window)
+		if filter.Start == nil || filter.Start.Before(since) {
+			filter.Start = &since
+		}
+	}
+
+	query := opreturns.NewsQuery{
+		Filter:           filter,
+		Topic:            topic,
+		Author:           author,
+		IgnoreModeration: req.Msg.IgnoreModeration,
+		Subscriptions:    !req.Msg.IgnoreSubscriptions,
+		Ranked:           ranked,
+		Limit:            int(req.Msg.PageSize),
+	}
+	if req.Msg.PageToken != "" {
+		cursor, err := opreturns.ParseNewsCursor(req.Msg.PageToken, ranked)
 		if err != nil {
 			return nil, connect.NewError(connect.CodeInvalidArgument, err)
 		}
+		query.After = &cursor
 	}
-	news, err := opreturns.ListCoinNews(ctx, s.database)
+
+	reader, err := opreturns.NewNewsReader(ctx, s.database)
 	if err != nil {
 		return nil, fmt.Errorf("list coin news: %w", err)
 	}
+	news, next, err := reader.Search(ctx, query)
+	if errors.Is(err, opreturns.ErrInvalidQuery) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	} else if err != nil {
+		return nil, fmt.Errorf("list coin news: %w", err)
+	}
 
-	// Filter by topic if provided
-	if req.Msg.Topic != nil {
-		news = lo.Filter(news, func(coinNews opreturns.CoinNews, _ int) bool {
-			return coinNews.Topic == topicID
-		})
+	// Replies and reactions are only counted here, they're listed with
+	// their thread
+	summaries, err := reader.Summarize(ctx, news, req.Msg.IgnoreModeration)
+	if err != nil {
+		return nil, fmt.Errorf("summarize threads: %w", err)
 	}
 
-	// Sort all news by recency (most recent first)
-	sort.Slice(news, func(i, j int) bool {
-		return lo.FromPtr(news[i].CreatedAt).After(lo.FromPtr(news[j].CreatedAt))
-	})
+	resp := &miscv1.ListCoinNewsResponse{
+		CoinNews: lo.Map(news, func(coinNews opreturns.CoinNews, _ int) *miscv1.CoinNews {
+			return withThreadSummary(coinNewsToProto(coinNews, 0), summaries[coinNews.TxID])
+		}),
+	}
+	if next != nil {
+		resp.NextPageToken = next.String()
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// ListCoinNewsThread implements miscv1connect.MiscServiceHandler.
+func (s *Server) ListCoinNewsThread(ctx context.Context, req *connect.Request[miscv1.ListCoinNewsThreadRequest]) (*connect.Response[miscv1.ListCoinNewsThreadResponse], error) {
+	hash, err := chainhash.NewHashFromStr(req.Msg.Txid)
+	if err != nil || len(req.Msg.Txid) != chainhash.MaxHashStringSize {
+		err := fmt.Errorf("invalid txid %q", req.Msg.Txid)
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 
-	// Take up to 100 entries
-	if len(news) > 100 {
-		news = news[:100]
+	reader, err := opreturns.NewNewsReader(ctx, s.database)
+	if err != nil {
+		return nil, fmt.Errorf("read topics: %w", err)
+	}
+	thread, ok, err := reader.Thread(ctx, hash.String(), req.Msg.IgnoreModeration)
+	if err != nil {
+		return nil, fmt.Errorf("list thread: %w", err)
+	}
+	if !ok {
+		err := fmt.Errorf("no coin news post with txid %s", hash)
+		return nil, connect.NewError(connect.CodeNotFound, err)
 	}
 
-	return connect.NewResponse(&miscv1.ListCoinNewsResponse{
-		CoinNews: lo.Map(news, coinNewsToProto),
+	return connect.NewResponse(&miscv1.ListCoinNewsThreadResponse{
+		Thread: threadToProto(thread),
 	}), nil
 }
 
+func threadToProto(thread *opreturns.Thread) *miscv1.CoinNewsThread {
+	res := &miscv1.CoinNewsThread{
+		Replies: lo.Map(thread.Replies, func(reply *opreturns.Thread, _ int) *miscv1.CoinNewsThread {
+			return threadToProto(reply)
+		}),
+	}
+
+	summary := opreturns.ThreadSummary{Reactions: thread.Reactions}
+	for _, reply := range res.Replies {
+		summary.Replies += 1 + int(reply.Post.ReplyCount)
+	}
+	res.Post = withThreadSummary(coinNewsToProto(thread.Post, 0), summary)
+
+	return res
+}
+
+func withThreadSummary(coinNews *miscv1.CoinNews, summary opreturns.ThreadSummary) *miscv1.CoinNews {
+	coinNews.ReplyCount = uint32(summary.Replies)
+	if len(summary.Reactions) > 0 {
+		coinNews.Reactions = lo.MapValues(summary.Reactions, func(count int, _ string) uint32 {
+			return uint32(count)
+		})
+	}
+	return coinNews
+}
+
+// newsTopicAndAuthor validates the topic and author news is asked for, if
+// set. The author is lowercased.
+func newsTopicAndAuthor(topic, author *string) (*opreturns.TopicID, string, error) {
+	var topicID *opreturns.TopicID
+	if topic != nil {
+		parsed, err := opreturns.ValidNewsTopicID(*topic)
+		if err != nil {
+			return nil, "", err
+		}
+		topicID = &parsed
+	}
+	if author == nil {
+		return topicID, "", nil
+	}
+	if _, err := opreturns.PosterHash(*author); err != nil {
+		return nil, "", err
+	}
+	return topicID, strings.ToLower(*author), nil
+}
+
+// newsMatcher returns whether a post is for the given topic and author, if
+// set. Moderated posts only match if moderation is ignored.
+func newsMatcher(topic, author *string, ignoreModeration bool) (func(opreturns.CoinNews) bool, error) {
+	topicID, authorHash, err := newsTopicAndAuthor(topic, author)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(coinNews opreturns.CoinNews) bool {
+		switch {
+		case topicID != nil && coinNews.Topic != *topicID:
+			return false
+		case !ignoreModeration && coinNews.Moderated:
+			return false
+		// Unverified posts could be from anyone
+		case author != nil && !(coinNews.Verified && coinNews.Author == authorHash):
+			return false
+		}
+		return true
+	}, nil
+}
+
+// WatchCoinNews implements miscv1connect.MiscServiceHandler.
+func (s *Server) WatchCoinNews(ctx context.Context, req *connect.Request[miscv1.WatchCoinNewsRequest], stream *connect.ServerStream[miscv1.WatchCoinNewsResponse]) error {
+	matches, err := newsMatcher(req.Msg.Topic, req.Msg.Author, req.Msg.IgnoreModeration)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	// Topics and their moderation are read again when OP_RETURNs creating or
+	// moderating a topic come in, not for every event
+	var reader *opreturns.NewsReader
+	return s.watchOPReturns(ctx, stream.Conn(), func(event engines.OPReturnEvent) error {
+		if reader == nil || opreturns.ChangesTopics(event.OPReturns) {
+			reader, err = opreturns.NewNewsReader(ctx, s.database)
+			if err != nil {
+				return fmt.Errorf("read topics: %w", err)
+			}
+		}
+
+		news, err := reader.CoinNewsFor(ctx, event.OPReturns)
+		if err != nil {
+			return fmt.Errorf("get coin news: %w", err)
+		}
+		if len(news) > 0 && !req.Msg.IgnoreSubscriptions {
+			news, err = s.subscribedNews(ctx, news, req.Msg.Topic != nil)
+			if err != nil {
+				return fmt.Errorf("apply subscriptions: %w", err)
+			}
+		}
+
+		for _, coinNews := range news {
+			if !matches(coinNews) {
+				continue
+			}
+			err := stream.Send(&miscv1.WatchCoinNewsResponse{
+				CoinNews: coinNewsToProto(coinNews, 0),
+				Event:    watchEventToProto(event),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// newsSortFromProto returns whether news is ranked by fee rate, and if so,
+// how far back posts are ranked.
+func newsSortFromProto(order miscv1.CoinNewsSort) (bool, time.Duration, error) {
+	switch order {
+	case miscv1.CoinNewsSort_COIN_NEWS_SORT_UNSPECIFIED, miscv1.CoinNewsSort_COIN_NEWS_SORT_NEWEST:
+		return false, 0, nil
+	case miscv1.CoinNewsSort_COIN_NEWS_SORT_TOP_DAY:
+		return true, 24 * time.Hour, nil
+	case miscv1.CoinNewsSort_COIN_NEWS_SORT_TOP_WEEK:
+		return true, 7 * 24 * time.Hour, nil
+	default:
+		return false, 0, fmt.Errorf("unknown sort %s", order)
+	}
+}
+
 func coinNewsToProto(coinNews opreturns.CoinNews, _ int) *miscv1.CoinNews {
 	return &miscv1.CoinNews{
 		Id:         coinNews.ID,
@@ -261,7 +1085,16 @@ func coinNewsToProto(coinNews opreturns.CoinNews, _ int) *miscv1.CoinNews {
 		Headline:   coinNews.Headline,
 		Content:    coinNews.Content,
 		FeeSats:    int64(coinNews.Fee),
+		Vsize:      coinNews.VSize,
+		FeeRate:    coinNews.FeeRate(),
 		CreateTime: timestamppb.New(lo.FromPtr(coinNews.CreatedAt)),
+		Author:     coinNews.Author,
+		Verified:   coinNews.Verified,
+		Moderated:  coinNews.Moderated,
+		Txid:       coinNews.TxID,
+		Height:     coinNews.Height,
+		ReplyTo:    coinNews.ReplyTo,
+		Reaction:   coinNews.Reaction,
 	}
 }
 
@@ -274,8 +1107,14 @@ func (s *Server) TimestampFile(ctx context.Context, req *connect.Request[miscv1.
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("file data must be set"))
 	}
 
-	ts, err := s.timestampEngine.TimestampFile(ctx, req.Msg.Filename, req.Msg.FileData)
-	if err != nil {
+	ts, err := s.timestampEngine.TimestampFile(ctx, req.Msg.Filename, req.Msg.FileData, engines.SendOptions{
+		WalletID:       req.Msg.WalletId,
+		FeeSatPerVbyte: req.Msg.FeeSatPerVbyte,
+	})
+	switch {
+	case errors.Is(err, engines.ErrBatchedSendOptions):
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	case err != nil:
 		return nil, fmt.Errorf("timestamp file: %w", err)
 	}
 
@@ -291,6 +1130,109 @@ func (s *Server) TimestampFile(ctx context.Context, req *connect.Request[miscv1.
 	}), nil
 }
 
+// TimestampFileStream implements miscv1connect.MiscServiceHandler.
+func (s *Server) TimestampFileStream(ctx context.Context, stream *connect.ClientStream[miscv1.TimestampFileStreamRequest]) (*connect.Response[miscv1.TimestampFileResponse], error) {
+	var (
+		filename string
+		opts     engines.SendOptions
+		received int
+		size     int64
+		hash     = sha256.New()
+	)
+	for stream.Receive() {
+		if received == 0 {
+			filename = stream.Msg().Filename
+			if filename == "" {
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("filename must be set"))
+			}
+			opts = engines.SendOptions{
+				WalletID:       stream.Msg().WalletId,
+				FeeSatPerVbyte: stream.Msg().FeeSatPerVbyte,
+			}
+		}
+		received++
+
+		hash.Write(stream.Msg().Chunk)
+		size += int64(len(stream.Msg().Chunk))
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("file data must be set"))
+	}
+
+	zerolog.Ctx(ctx).Info().
+		Str("filename", filename).
+		Int64("size", size).
+		Int("chunks", received).
+		Msg("received file to timestamp")
+
+	ts, err := s.timestampEngine.TimestampHash(ctx, filename, [32]byte(hash.Sum(nil)), opts)
+	switch {
+	case errors.Is(err, engines.ErrBatchedSendOptions):
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	case err != nil:
+		return nil, fmt.Errorf("timestamp file: %w", err)
+	}
+
+	return connect.NewResponse(&miscv1.TimestampFileResponse{
+		Id:       ts.ID,
+		FileHash: ts.FileHash,
+		Txid:     lo.FromPtr(ts.TxID),
+	}), nil
+}
+
+// TimestampPath implements miscv1connect.MiscServiceHandler.
+func (s *Server) TimestampPath(ctx context.Context, req *connect.Request[miscv1.TimestampPathRequest]) (*connect.Response[miscv1.TimestampPathResponse], error) {
+	// Otherwise anyone who can reach the API can make us read any file we
+	// have access to
+	if !isLocalCaller(req.Peer()) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("paths can only be timestamped from the same machine"))
+	}
+	if req.Msg.Path == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("path must be set"))
+	}
+
+	ts, manifest, err := s.timestampEngine.TimestampPath(ctx, req.Msg.Path, engines.SendOptions{
+		WalletID:       req.Msg.WalletId,
+		FeeSatPerVbyte: req.Msg.FeeSatPerVbyte,
+	})
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, engines.ErrEmptyDirectory):
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, engines.ErrBatchedSendOptions):
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	case err != nil:
+		return nil, fmt.Errorf("timestamp path: %w", err)
+	}
+
+	resp := &miscv1.TimestampPathResponse{
+		Timestamp: timestampToProto(*ts, 0),
+		FileCount: 1,
+	}
+	if manifest != nil {
+		resp.Manifest = lo.ToPtr(manifest.String())
+		resp.FileCount = int64(len(manifest.Files))
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// isLocalCaller returns whether the request came in over a loopback
+// address.
+func isLocalCaller(peer connect.Peer) bool {
+	host, _, err := net.SplitHostPort(peer.Addr)
+	if err != nil {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 // ListTimestamps implements miscv1connect.MiscServiceHandler.
 func (s *Server) ListTimestamps(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[miscv1.ListTimestampsResponse], error) {
 	tsList, err := s.timestampEngine.ListTimestamps(ctx)
@@ -321,23 +1263,135 @@ func timestampToProto(ts timestamps.FileTimestamp, _ int) *miscv1.FileTimestamp
 	if ts.ConfirmedAt != nil {
 		proto.ConfirmedAt = timestamppb.New(*ts.ConfirmedAt)
 	}
+	proto.BlockHash = ts.BlockHash
+	proto.FailureReason = ts.FailureReason
+	proto.MerkleRoot = ts.MerkleRoot
+	proto.MerklePath = lo.Map(ts.MerklePath, func(step timestamps.MerkleStep, _ int) *miscv1.MerkleStep {
+		return &miscv1.MerkleStep{Hash: step.Hash, Left: step.Left}
+	})
 
 	return proto
 }
 
+// RebroadcastTimestamp implements miscv1connect.MiscServiceHandler.
+func (s *Server) RebroadcastTimestamp(ctx context.Context, req *connect.Request[miscv1.RebroadcastTimestampRequest]) (*connect.Response[miscv1.RebroadcastTimestampResponse], error) {
+	ts, err := s.timestampEngine.RebroadcastTimestamp(ctx, req.Msg.Id)
+	switch {
+	case errors.Is(err, engines.ErrTimestampNotFound):
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, timestamps.ErrNotFailed):
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	case err != nil:
+		return nil, fmt.Errorf("rebroadcast timestamp: %w", err)
+	}
+
+	return connect.NewResponse(&miscv1.RebroadcastTimestampResponse{
+		Timestamp: timestampToProto(*ts, 0),
+	}), nil
+}
+
 // VerifyTimestamp implements miscv1connect.MiscServiceHandler.
 func (s *Server) VerifyTimestamp(ctx context.Context, req *connect.Request[miscv1.VerifyTimestampRequest]) (*connect.Response[miscv1.VerifyTimestampResponse], error) {
 	if len(req.Msg.FileData) == 0 {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("file data must be set"))
 	}
 
+	if req.Msg.ChainOnly {
+		return s.verifyTimestampOnChain(ctx, req.Msg)
+	}
+	if req.Msg.StartHeight != nil || req.Msg.EndHeight != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("heights can only be set for chain only verification"))
+	}
+
 	ts, err := s.timestampEngine.VerifyTimestamp(ctx, req.Msg.FileData)
-	if err != nil {
+	switch {
+	case errors.Is(err, engines.ErrCommitmentMismatch):
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	case err != nil:
 		return nil, connect.NewError(connect.CodeNotFound, err)
 	}
 
+	message := "File is queued for the next timestamp batch"
+	if ts.TxID != nil {
+		message = fmt.Sprintf("File verified! Transaction: %s", *ts.TxID)
+	}
+
 	return connect.NewResponse(&miscv1.VerifyTimestampResponse{
 		Timestamp: timestampToProto(*ts, 0),
-		Message:   fmt.Sprintf("File verified! Transaction: %s", *ts.TxID),
+		Message:   message,
+	}), nil
+}
+
+func (s *Server) verifyTimestampOnChain(ctx context.Context, req *miscv1.VerifyTimestampRequest) (*connect.Response[miscv1.VerifyTimestampResponse], error) {
+	if req.StartHeight != nil && req.EndHeight != nil && *req.StartHeight > *req.EndHeight {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("start height must not be above end height"))
+	}
+
+	found, err := s.timestampEngine.VerifyTimestampOnChain(ctx, req.FileData, req.StartHeight, req.EndHeight)
+	switch {
+	case errors.Is(err, engines.ErrTimestampNotOnChain):
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, engines.ErrScanRangeTooLarge):
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	case err != nil:
+		return nil, fmt.Errorf("verify timestamp on chain: %w", err)
+	}
+
+	return connect.NewResponse(&miscv1.VerifyTimestampResponse{
+		Timestamp: &miscv1.FileTimestamp{
+			FileHash:    found.FileHash,
+			Txid:        &found.TxID,
+			BlockHeight: lo.ToPtr(int64(found.Height)),
+			BlockHash:   &found.BlockHash,
+			Status:      string(timestamps.StatusConfirmed),
+			CreatedAt:   timestamppb.New(found.BlockTime),
+			ConfirmedAt: timestamppb.New(found.BlockTime),
+		},
+		Message: fmt.Sprintf("File verified on chain! Transaction: %s", found.TxID),
+	}), nil
+}
+
+// ExportTimestampProof implements miscv1connect.MiscServiceHandler.
+func (s *Server) ExportTimestampProof(ctx context.Context, req *connect.Request[miscv1.ExportTimestampProofRequest]) (*connect.Response[miscv1.ExportTimestampProofResponse], error) {
+	ts, proof, err := s.timestampEngine.ExportProof(ctx, req.Msg.Id)
+	switch {
+	case errors.Is(err, engines.ErrTimestampNotFound):
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, engines.ErrTimestampNotConfirmed), errors.Is(err, timestamps.ErrProofTooLarge):
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	case err != nil:
+		return nil, fmt.Errorf("export timestamp proof: %w", err)
+	}
+
+	return connect.NewResponse(&miscv1.ExportTimestampProofResponse{
+		Proof:    proof,
+		Filename: ts.Filename + ".ots",
+	}), nil
+}
+
+// VerifyTimestampProof implements miscv1connect.MiscServiceHandler.
+func (s *Server) VerifyTimestampProof(ctx context.Context, req *connect.Request[miscv1.VerifyTimestampProofRequest]) (*connect.Response[miscv1.VerifyTimestampProofResponse], error) {
+	if len(req.Msg.FileData) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("file data must be set"))
+	}
+	if len(req.Msg.Proof) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("proof must be set"))
+	}
+
+	verification, err := s.timestampEngine.VerifyProof(ctx, req.Msg.FileData, req.Msg.Proof)
+	switch {
+	case errors.Is(err, timestamps.ErrInvalidProof):
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, engines.ErrCommitmentMismatch):
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	case err != nil:
+		return nil, fmt.Errorf("verify timestamp proof: %w", err)
+	}
+
+	return connect.NewResponse(&miscv1.VerifyTimestampProofResponse{
+		FileHash:    verification.FileHash,
+		BlockHeight: verification.BlockHeight,
+		BlockHash:   verification.BlockHash,
+		BlockTime:   timestamppb.New(verification.BlockTime),
 	}), nil
 }
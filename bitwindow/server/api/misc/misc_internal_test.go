@@ -0,0 +1,25 @@
+package api_misc
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsLocalCaller(t *testing.T) {
+	t.Parallel()
+
+	for addr, local := range map[string]bool{
+		"127.0.0.1:51234":   true,
+		"127.1.2.3:51234":   true,
+		"[::1]:51234":       true,
+		"192.168.1.10:2122": false,
+		"[fe80::1]:2122":    false,
+		"localhost:2122":    false,
+		"127.0.0.1":         false,
+		"":                  false,
+	} {
+		assert.Equal(t, local, isLocalCaller(connect.Peer{Addr: addr}), addr)
+	}
+}
@@ -4,12 +4,18 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"connectrpc.com/connect"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/config"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
 	commonv1 "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/cusf/common/v1"
 	pb "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/cusf/mainchain/v1"
@@ -19,11 +25,18 @@ import (
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests/apitests"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests/mocks"
+	corepb "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -36,7 +49,7 @@ func TestService_ListOPReturn(t *testing.T) {
 		database := database.Test(t)
 		cli := miscv1connect.NewMiscServiceClient(apitests.API(t, database))
 
-		resp, err := cli.ListOPReturn(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+		resp, err := cli.ListOPReturn(context.Background(), connect.NewRequest(&miscv1.ListOPReturnRequest{}))
 		require.NoError(t, err)
 		assert.Empty(t, resp.Msg.OpReturns)
 	})
@@ -60,7 +73,7 @@ func TestService_ListOPReturn(t *testing.T) {
 
 		cli := miscv1connect.NewMiscServiceClient(apitests.API(t, database))
 
-		resp, err := cli.ListOPReturn(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+		resp, err := cli.ListOPReturn(context.Background(), connect.NewRequest(&miscv1.ListOPReturnRequest{}))
 		require.NoError(t, err)
 		require.Len(t, resp.Msg.OpReturns, 1)
 		assert.Equal(t, "test message", resp.Msg.OpReturns[0].Message)
@@ -68,6 +81,238 @@ func TestService_ListOPReturn(t *testing.T) {
 		assert.EqualValues(t, 0, resp.Msg.OpReturns[0].Vout)
 		assert.EqualValues(t, 100, lo.FromPtr(resp.Msg.OpReturns[0].Height))
 	})
+
+	t.Run("classify and filter by protocol", func(t *testing.T) {
+		t.Parallel()
+
+		database := database.Test(t)
+		ctx := context.Background()
+		topicID := validTopicID()
+		classifiers := opreturns.NewClassifiers([]opreturns.TopicID{topicID})
+
+		runestone, err := txscript.NewScriptBuilder().
+			AddOp(txscript.OP_RETURN).AddOp(txscript.OP_13).
+			// Etching of rune AA, mintable at 1:5, with one edict
+			AddData([]byte{2, 1, 4, 26, 20, 1, 20, 5, 0, 1, 2, 3, 4}).
+			Script()
+		require.NoError(t, err)
+
+		hash := bytes.Repeat([]byte{0xab}, 32)
+		m3 := slices.Concat([]byte{0xd4, 0x5a, 0xa9, 0x43, 0x09}, hash)
+		omni := slices.Concat([]byte("omni"), []byte{0, 0, 0, 0, 0, 0, 0, 31, 0, 0, 0, 0, 0, 0, 0x27, 0x10})
+		for i, output := range []struct {
+			script []byte
+			data   []byte
+		}{
+			{data: []byte("hello world")},
+			{data: hash},
+			{data: m3},
+			{data: omni},
+			{data: opreturns.EncodeTopicCreationMessage(topicID, "Announcements")},
+			{data: opreturns.EncodeNewsMessage(topicID, "Breaking", "content")},
+			{data: []byte{0xff, 0x00, 0xfe}},
+			{script: runestone, data: []byte{2, 1, 4, 26, 20, 1, 20, 5, 0, 1, 2, 3, 4}},
+		} {
+			if output.script == nil {
+				output.script, err = txscript.NullDataScript(output.data)
+				require.NoError(t, err)
+			}
+			classification := classifiers.Classify(output.script, output.data)
+			require.NoError(t, opreturns.Persist(ctx, database, []opreturns.OPReturn{{
+				TxID:     fmt.Sprintf("txid%d", i),
+				Data:     output.data,
+				Protocol: classification.Protocol,
+				Fields:   classification.Fields,
+			}}))
+		}
+
+		// Stored before classification existed
+		require.NoError(t, opreturns.Persist(ctx, database, []opreturns.OPReturn{{
+			TxID: "unclassified", Data: []byte("old text"),
+		}}))
+		require.NoError(t, opreturns.ClassifyUnclassified(ctx, database, classifiers))
+
+		cli := miscv1connect.NewMiscServiceClient(apitests.API(t, database))
+
+		resp, err := cli.ListOPReturn(ctx, connect.NewRequest(&miscv1.ListOPReturnRequest{}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.OpReturns, 9)
+		byTxid := lo.KeyBy(resp.Msg.OpReturns, func(opReturn *miscv1.OPReturn) string {
+			return opReturn.Txid
+		})
+
+		assert.Equal(t, miscv1.Protocol_PROTOCOL_TEXT, byTxid["txid0"].Protocol)
+		assert.Equal(t, map[string]string{"text": "hello world"}, byTxid["txid0"].Fields)
+		assert.Equal(t, miscv1.Protocol_PROTOCOL_TIMESTAMP, byTxid["txid1"].Protocol)
+		assert.Equal(t, map[string]string{
+			"message": "M3", "sidechain": "9", "bundle_hash": hex.EncodeToString(hash),
+		}, byTxid["txid2"].Fields)
+		assert.Equal(t, map[string]string{
+			"version": "0", "type": "0", "type_name": "simple_send", "property": "31", "amount": "10000",
+		}, byTxid["txid3"].Fields)
+		assert.Equal(t, miscv1.Protocol_PROTOCOL_COIN_NEWS_TOPIC, byTxid["txid4"].Protocol)
+		assert.Equal(t, "Announcements", byTxid["txid4"].Fields["name"])
+		assert.Equal(t, miscv1.Protocol_PROTOCOL_COIN_NEWS, byTxid["txid5"].Protocol)
+		assert.Equal(t, "Breaking", byTxid["txid5"].Fields["headline"])
+		assert.Equal(t, miscv1.Protocol_PROTOCOL_UNKNOWN, byTxid["txid6"].Protocol)
+		assert.Empty(t, byTxid["txid6"].Fields)
+		assert.Equal(t, map[string]string{
+			"etching": "true", "rune": "AA", "mint": "1:5", "edicts": "1",
+		}, byTxid["txid7"].Fields)
+		assert.Equal(t, miscv1.Protocol_PROTOCOL_TEXT, byTxid["unclassified"].Protocol)
+
+		resp, err = cli.ListOPReturn(ctx, connect.NewRequest(&miscv1.ListOPReturnRequest{
+			Protocols: []miscv1.Protocol{miscv1.Protocol_PROTOCOL_TEXT, miscv1.Protocol_PROTOCOL_RUNES},
+		}))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"txid0", "txid7", "unclassified"}, lo.Map(resp.Msg.OpReturns, func(opReturn *miscv1.OPReturn, _ int) string {
+			return opReturn.Txid
+		}))
+
+		_, err = cli.ListOPReturn(ctx, connect.NewRequest(&miscv1.ListOPReturnRequest{
+			Protocols: []miscv1.Protocol{miscv1.Protocol_PROTOCOL_UNSPECIFIED},
+		}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
+
+func TestService_SearchOPReturns(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	database := database.Test(t)
+	topicID := validTopicID()
+	require.NoError(t, opreturns.CreateTopic(ctx, database, topicID, "Test Topic", "topic_txid"))
+	classifiers := opreturns.NewClassifiers([]opreturns.TopicID{topicID})
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, data := range [][]byte{
+		[]byte("gm bitcoin"),
+		[]byte("hello world"),
+		opreturns.EncodeNewsMessage(topicID, "Bitcoin hits new high", "satoshis everywhere"),
+		opreturns.EncodeNewsMessage(topicID, "Quiet day", "nothing happened"),
+		[]byte("bitcoin fixes this"),
+	} {
+		classification := classifiers.Classify(nil, data)
+		require.NoError(t, opreturns.Persist(ctx, database, []opreturns.OPReturn{{
+			TxID:      fmt.Sprintf("%02x%062x", i, i),
+			Data:      data,
+			Fee:       btcutil.Amount(i * 1000),
+			Height:    lo.ToPtr(uint32(100 + i)),
+			CreatedAt: lo.ToPtr(start.Add(time.Duration(i) * time.Hour)),
+			Protocol:  classification.Protocol,
+			Fields:    classification.Fields,
+		}}))
+	}
+	// Unconfirmed
+	require.NoError(t, opreturns.Persist(ctx, database, []opreturns.OPReturn{{
+		TxID: fmt.Sprintf("%064x", 99), Data: []byte("bitcoin mempool"),
+	}}))
+
+	cli := miscv1connect.NewMiscServiceClient(apitests.API(t, database))
+
+	search := func(t *testing.T, filter *miscv1.SearchFilter) []string {
+		resp, err := cli.ListOPReturn(ctx, connect.NewRequest(&miscv1.ListOPReturnRequest{Filter: filter}))
+		require.NoError(t, err)
+		return lo.Map(resp.Msg.OpReturns, func(opReturn *miscv1.OPReturn, _ int) string {
+			return opReturn.Txid[:2]
+		})
+	}
+
+	t.Run("filters", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{"00", "04", "02", "00"}, search(t, &miscv1.SearchFilter{Query: "bitcoin"}))
+		assert.Equal(t, []string{"02"}, search(t, &miscv1.SearchFilter{Query: "sat*"}))
+		assert.Equal(t, []string{"04", "02"}, search(t, &miscv1.SearchFilter{Query: "bitcoin", MinHeight: lo.ToPtr(uint32(101))}))
+		assert.Equal(t, []string{"02", "01"}, search(t, &miscv1.SearchFilter{
+			MinHeight: lo.ToPtr(uint32(101)), MaxHeight: lo.ToPtr(uint32(102)),
+		}))
+		assert.Equal(t, []string{"03", "02"}, search(t, &miscv1.SearchFilter{
+			StartTime: timestamppb.New(start.Add(2 * time.Hour)),
+			EndTime:   timestamppb.New(start.Add(3 * time.Hour)),
+		}))
+		assert.Equal(t, []string{"03"}, search(t, &miscv1.SearchFilter{TxidPrefix: "03"}))
+		assert.Equal(t, []string{"04", "03"}, search(t, &miscv1.SearchFilter{MinFeeSats: 3000}))
+		assert.Equal(t, []string{"03", "02"}, search(t, &miscv1.SearchFilter{Topic: lo.ToPtr(topicID.String())}))
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			txids []string
+			token string
+			pages int
+		)
+		for {
+			resp, err := cli.ListOPReturn(ctx, connect.NewRequest(&miscv1.ListOPReturnRequest{
+				PageSize:  4,
+				PageToken: token,
+			}))
+			require.NoError(t, err)
+			pages++
+			for _, opReturn := range resp.Msg.OpReturns {
+				txids = append(txids, opReturn.Txid)
+			}
+			if resp.Msg.NextPageToken == "" {
+				break
+			}
+			token = resp.Msg.NextPageToken
+		}
+		// The topic creation isn't an OP_RETURN
+		assert.Equal(t, 2, pages)
+		assert.Len(t, txids, 6)
+		assert.Len(t, lo.Uniq(txids), 6)
+	})
+
+	t.Run("coin news", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := cli.ListCoinNews(ctx, connect.NewRequest(&miscv1.ListCoinNewsRequest{
+			Filter: &miscv1.SearchFilter{Query: "satoshis"},
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.CoinNews, 1)
+		assert.Equal(t, "Bitcoin hits new high", resp.Msg.CoinNews[0].Headline)
+		assert.EqualValues(t, 102, resp.Msg.CoinNews[0].GetHeight())
+
+		resp, err = cli.ListCoinNews(ctx, connect.NewRequest(&miscv1.ListCoinNewsRequest{PageSize: 1}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.CoinNews, 1)
+		assert.Equal(t, "Quiet day", resp.Msg.CoinNews[0].Headline)
+		require.NotEmpty(t, resp.Msg.NextPageToken)
+
+		resp, err = cli.ListCoinNews(ctx, connect.NewRequest(&miscv1.ListCoinNewsRequest{
+			PageSize:  1,
+			PageToken: resp.Msg.NextPageToken,
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.CoinNews, 1)
+		assert.Equal(t, "Bitcoin hits new high", resp.Msg.CoinNews[0].Headline)
+		assert.Empty(t, resp.Msg.NextPageToken)
+
+		resp, err = cli.ListCoinNews(ctx, connect.NewRequest(&miscv1.ListCoinNewsRequest{
+			Filter: &miscv1.SearchFilter{MinFeeSats: 3000},
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.CoinNews, 1)
+		assert.Equal(t, "Quiet day", resp.Msg.CoinNews[0].Headline)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, req := range []*miscv1.ListOPReturnRequest{
+			{Filter: &miscv1.SearchFilter{TxidPrefix: "xyz"}},
+			{Filter: &miscv1.SearchFilter{Topic: lo.ToPtr("nope")}},
+			{Filter: &miscv1.SearchFilter{Query: `"unbalanced`}},
+			{PageToken: "nope"},
+		} {
+			_, err := cli.ListOPReturn(ctx, connect.NewRequest(req))
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), req.String())
+		}
+	})
 }
 
 func TestService_BroadcastNews(t *testing.T) {
@@ -659,6 +904,354 @@ func TestService_ListCoinNews(t *testing.T) {
 	})
 }
 
+func TestService_SignedCoinNews(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sign without a wallet", func(t *testing.T) {
+		t.Parallel()
+
+		database := database.Test(t)
+		topicID := validTopicID()
+		require.NoError(t, opreturns.CreateTopic(context.Background(), database, topicID, "Test Topic", "topic_txid"))
+
+		cli := miscv1connect.NewMiscServiceClient(apitests.API(t, database))
+
+		_, err := cli.BroadcastNews(context.Background(), connect.NewRequest(&miscv1.BroadcastNewsRequest{
+			Topic:    topicID.String(),
+			Headline: "Test News Headline",
+			Sign:     true,
+		}))
+		require.Error(t, err)
+		assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	})
+
+	t.Run("verifies signatures", func(t *testing.T) {
+		t.Parallel()
+
+		database := database.Test(t)
+		ctx := context.Background()
+		topicID := validTopicID()
+		require.NoError(t, opreturns.CreateTopic(ctx, database, topicID, "Test Topic", "topic_txid"))
+
+		alice, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		bob, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		// Bob claims to be alice by swapping in her pubkey hash
+		forged := opreturns.EncodeSignedNewsMessage(topicID, "Forged", "Content", bob)
+		aliceHash, err := hex.DecodeString(opreturns.NewsAuthor(alice.PubKey()))
+		require.NoError(t, err)
+		copy(forged[opreturns.TopicIdLength+1:], aliceHash)
+
+		height := uint32(100)
+		for i, data := range [][]byte{
+			opreturns.EncodeNewsMessage(topicID, "Unsigned", "Content"),
+			opreturns.EncodeSignedNewsMessage(topicID, "By alice", "Content", alice),
+			forged,
+		} {
+			require.NoError(t, opreturns.Persist(ctx, database, []opreturns.OPReturn{{
+				Height: &height,
+				TxID:   fmt.Sprintf("news_txid%d", i),
+				Data:   data,
+			}}))
+		}
+
+		cli := miscv1connect.NewMiscServiceClient(apitests.API(t, database))
+
+		resp, err := cli.ListCoinNews(ctx, connect.NewRequest(&miscv1.ListCoinNewsRequest{}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.CoinNews, 3)
+
+		news := lo.SliceToMap(resp.Msg.CoinNews, func(news *miscv1.CoinNews) (string, *miscv1.CoinNews) {
+			return news.Headline, news
+		})
+		require.Contains(t, news, "Unsigned")
+		assert.Empty(t, news["Unsigned"].Author)
+		assert.False(t, news["Unsigned"].Verified)
+
+		require.Contains(t, news, "By alice")
+		assert.Equal(t, opreturns.NewsAuthor(alice.PubKey()), news["By alice"].Author)
+		assert.Equal(t, "Content", news["By alice"].Content)
+		assert.True(t, news["By alice"].Verified)
+
+		require.Contains(t, news, "Forged")
+		assert.Equal(t, opreturns.NewsAuthor(alice.PubKey()), news["Forged"].Author)
+		assert.False(t, news["Forged"].Verified)
+
+		// Only alice's own post
+		resp, err = cli.ListCoinNews(ctx, connect.NewRequest(&miscv1.ListCoinNewsRequest{
+			Author: lo.ToPtr(opreturns.NewsAuthor(alice.PubKey())),
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.CoinNews, 1)
+		assert.Equal(t, "By alice", resp.Msg.CoinNews[0].Headline)
+
+		_, err = cli.ListCoinNews(ctx, connect.NewRequest(&miscv1.ListCoinNewsRequest{
+			Author: lo.ToPtr("alice"),
+		}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
+
+func TestService_TopicModeration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	owner, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	poster, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	spammer, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	t.Run("owner moderates", func(t *testing.T) {
+		t.Parallel()
+
+		database := database.Test(t)
+		topicID := validTopicID()
+
+		info, ok := opreturns.IsCreateTopic(opreturns.EncodeOwnedTopicCreationMessage(topicID, "Announcements", owner))
+		require.True(t, ok)
+		assert.Equal(t, "Announcements", info.Name)
+		assert.Equal(t, opreturns.NewsAuthor(owner.PubKey()), info.Owner)
+		require.NoError(t, opreturns.CreateOwnedTopic(ctx, database, info, "topic_txid"))
+
+		mod := func(sequence uint32, kind opreturns.ModerationKind, payload []byte, key *btcec.PrivateKey) []byte {
+			return opreturns.EncodeModerationMessage(opreturns.ModerationOp{
+				Topic: topicID, Sequence: sequence, Kind: kind, Payload: payload,
+			}, key)
+		}
+		posterHash, err := opreturns.PosterHash(opreturns.NewsAuthor(poster.PubKey()))
+		require.NoError(t, err)
+		spamTxid := chainhash.Hash{0xaa}
+
+		height := uint32(100)
+		for _, op := range []opreturns.OPReturn{
+			{TxID: "renamed", Data: mod(1, opreturns.ModerationRename, []byte("Official"), owner)},
+			{TxID: "poster", Data: mod(2, opreturns.ModerationAddPoster, posterHash, owner)},
+			// Not signed by the owner
+			{TxID: "forged", Data: mod(3, opreturns.ModerationRemovePoster, posterHash, spammer)},
+			// Reuses a sequence number
+			{TxID: "replayed", Data: mod(2, opreturns.ModerationRename, []byte("Replayed"), owner)},
+			{TxID: "hidden", Data: mod(3, opreturns.ModerationHidePost, spamTxid[:], owner)},
+
+			{TxID: "news_by_poster", Data: opreturns.EncodeSignedNewsMessage(topicID, "By poster", "", poster)},
+			{TxID: "news_by_owner", Data: opreturns.EncodeSignedNewsMessage(topicID, "By owner", "", owner)},
+			{TxID: "news_by_spammer", Data: opreturns.EncodeSignedNewsMessage(topicID, "By spammer", "", spammer)},
+			{TxID: "news_unsigned", Data: opreturns.EncodeNewsMessage(topicID, "Unsigned", "")},
+			{TxID: spamTxid.String(), Data: opreturns.EncodeSignedNewsMessage(topicID, "Hidden", "", poster)},
+		} {
+			op.Height = &height
+			require.NoError(t, opreturns.Persist(ctx, database, []opreturns.OPReturn{op}))
+		}
+
+		cli := miscv1connect.NewMiscServiceClient(apitests.API(t, database))
+
+		topics, err := cli.ListTopics(ctx, connect.NewRequest(&emptypb.Empty{}))
+		require.NoError(t, err)
+		topic, ok := lo.Find(topics.Msg.Topics, func(topic *miscv1.Topic) bool {
+			return topic.Topic == topicID.String()
+		})
+		require.True(t, ok)
+		assert.Equal(t, "Official", topic.Name)
+		assert.Equal(t, opreturns.NewsAuthor(owner.PubKey()), topic.Owner)
+		assert.Equal(t, []string{opreturns.NewsAuthor(poster.PubKey())}, topic.Posters)
+
+		resp, err := cli.ListCoinNews(ctx, connect.NewRequest(&miscv1.ListCoinNewsRequest{}))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"By poster", "By owner"}, lo.Map(resp.Msg.CoinNews, func(news *miscv1.CoinNews, _ int) string {
+			return news.Headline
+		}))
+
+		resp, err = cli.ListCoinNews(ctx, connect.NewRequest(&miscv1.ListCoinNewsRequest{IgnoreModeration: true}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.CoinNews, 5)
+		moderated := lo.FilterMap(resp.Msg.CoinNews, func(news *miscv1.CoinNews, _ int) (string, bool) {
+			return news.Headline, news.Moderated
+		})
+		assert.ElementsMatch(t, []string{"By spammer", "Unsigned", "Hidden"}, moderated)
+
+		// Or for good, through the topic subscription
+		subscribed, err := cli.SetTopicSubscription(ctx, connect.NewRequest(&miscv1.SetTopicSubscriptionRequest{
+			Topic:        topicID.String(),
+			Subscription: &miscv1.TopicSubscription{Subscribed: true, IgnoreModeration: true},
+		}))
+		require.NoError(t, err)
+		assert.True(t, subscribed.Msg.Topic.Subscription.IgnoreModeration)
+
+		resp, err = cli.ListCoinNews(ctx, connect.NewRequest(&miscv1.ListCoinNewsRequest{}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.CoinNews, 5)
+		assert.False(t, lo.SomeBy(resp.Msg.CoinNews, func(news *miscv1.CoinNews) bool { return news.Moderated }))
+	})
+
+	t.Run("moderate topic without owner", func(t *testing.T) {
+		t.Parallel()
+
+		database := database.Test(t)
+		topicID := validTopicID()
+		require.NoError(t, opreturns.CreateTopic(ctx, database, topicID, "Test Topic", "topic_txid"))
+
+		cli := miscv1connect.NewMiscServiceClient(apitests.API(t, database))
+
+		_, err := cli.ModerateTopic(ctx, connect.NewRequest(&miscv1.ModerateTopicRequest{
+			Topic:  topicID.String(),
+			Action: &miscv1.ModerateTopicRequest_Rename{Rename: "New name"},
+		}))
+		assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+		_, err = cli.ModerateTopic(ctx, connect.NewRequest(&miscv1.ModerateTopicRequest{
+			Topic:  validTopicID().String(),
+			Action: &miscv1.ModerateTopicRequest_Rename{Rename: "New name"},
+		}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+		_, err = cli.ModerateTopic(ctx, connect.NewRequest(&miscv1.ModerateTopicRequest{
+			Topic:  topicID.String(),
+			Action: &miscv1.ModerateTopicRequest_AddPoster{AddPoster: "not hex"},
+		}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
+
+func TestService_ChunkedMessages(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// Records what was sent, so it can be persisted as if it was seen on
+	// chain
+	recordingWallet := func(t *testing.T, sent *[]opreturns.OPReturn) *mocks.MockWalletServiceClient {
+		ctrl := gomock.NewController(t)
+		mockWallet := mocks.NewMockWalletServiceClient(ctrl)
+		mockWallet.EXPECT().
+			SendTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *connect.Request[pb.SendTransactionRequest]) (*connect.Response[pb.SendTransactionResponse], error) {
+				data, err := hex.DecodeString(req.Msg.OpReturnMessage.Hex.Value)
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(data), opreturns.MaxOPReturnSize)
+
+				txid := chainhash.HashH(data).String()
+				*sent = append(*sent, opreturns.OPReturn{TxID: txid, Data: data, Fee: 100})
+				return connect.NewResponse(&pb.SendTransactionResponse{
+					Txid: &commonv1.ReverseHex{Hex: &wrapperspb.StringValue{Value: txid}},
+				}), nil
+			}).
+			AnyTimes()
+		return mockWallet
+	}
+
+	t.Run("broadcast and reassemble", func(t *testing.T) {
+		t.Parallel()
+
+		var sent []opreturns.OPReturn
+		database := database.Test(t)
+		cli := miscv1connect.NewMiscServiceClient(apitests.API(t, database, apitests.WithWallet(recordingWallet(t, &sent))))
+
+		complete := bytes.Repeat([]byte("a long article "), 20)
+		resp, err := cli.BroadcastChunked(ctx, connect.NewRequest(&miscv1.BroadcastChunkedRequest{Data: complete}))
+		require.NoError(t, err)
+		assert.Equal(t, opreturns.NewChunkID(complete).String(), resp.Msg.MessageId)
+		require.Len(t, resp.Msg.Txids, 5)
+		require.NoError(t, opreturns.Persist(ctx, database, sent))
+
+		sent = nil
+		incomplete := bytes.Repeat([]byte("a small file "), 10)
+		_, err = cli.BroadcastChunked(ctx, connect.NewRequest(&miscv1.BroadcastChunkedRequest{
+			Data:      incomplete,
+			ChunkSize: lo.ToPtr(uint32(50)),
+		}))
+		require.NoError(t, err)
+		require.Len(t, sent, 5)
+		// Out of order, and missing the first chunk
+		slices.Reverse(sent)
+		require.NoError(t, opreturns.Persist(ctx, database, sent[:4]))
+
+		messages, err := cli.ListChunkedMessages(ctx, connect.NewRequest(&emptypb.Empty{}))
+		require.NoError(t, err)
+		require.Len(t, messages.Msg.Messages, 2)
+
+		byID := lo.KeyBy(messages.Msg.Messages, func(message *miscv1.ChunkedMessage) string {
+			return message.MessageId
+		})
+
+		message := byID[opreturns.NewChunkID(complete).String()]
+		require.NotNil(t, message)
+		assert.True(t, message.Complete)
+		assert.Equal(t, complete, message.Data)
+		assert.Equal(t, resp.Msg.Txids, message.Txids)
+		assert.Equal(t, uint32(5), message.ReceivedChunks)
+		assert.Equal(t, int64(500), message.FeeSats)
+
+		message = byID[opreturns.NewChunkID(incomplete).String()]
+		require.NotNil(t, message)
+		assert.False(t, message.Complete)
+		assert.Empty(t, message.Data)
+		assert.Equal(t, uint32(5), message.TotalChunks)
+		assert.Equal(t, uint32(4), message.ReceivedChunks)
+		assert.Empty(t, message.Txids[0])
+	})
+
+	t.Run("chunked news", func(t *testing.T) {
+		t.Parallel()
+
+		var sent []opreturns.OPReturn
+		database := database.Test(t)
+		topicID := validTopicID()
+		require.NoError(t, opreturns.CreateTopic(ctx, database, topicID, "Test Topic", "topic_txid"))
+
+		cli := miscv1connect.NewMiscServiceClient(apitests.API(t, database, apitests.WithWallet(recordingWallet(t, &sent))))
+
+		content := strings.Repeat("Much longer than one OP_RETURN. ", 10)
+		resp, err := cli.BroadcastNews(ctx, connect.NewRequest(&miscv1.BroadcastNewsRequest{
+			Topic:    topicID.String(),
+			Headline: "Long read",
+			Content:  content,
+			Chunked:  true,
+		}))
+		require.NoError(t, err)
+		require.Greater(t, len(resp.Msg.ChunkTxids), 1)
+		assert.Equal(t, resp.Msg.ChunkTxids[0], resp.Msg.Txid)
+
+		news, err := cli.ListCoinNews(ctx, connect.NewRequest(&miscv1.ListCoinNewsRequest{}))
+		require.NoError(t, err)
+		assert.Empty(t, news.Msg.CoinNews)
+
+		require.NoError(t, opreturns.Persist(ctx, database, sent))
+
+		news, err = cli.ListCoinNews(ctx, connect.NewRequest(&miscv1.ListCoinNewsRequest{}))
+		require.NoError(t, err)
+		require.Len(t, news.Msg.CoinNews, 1)
+		assert.Equal(t, "Long read", news.Msg.CoinNews[0].Headline)
+		assert.Equal(t, content, news.Msg.CoinNews[0].Content)
+		assert.Equal(t, int64(100*len(sent)), news.Msg.CoinNews[0].FeeSats)
+
+		// Short posts still go in one transaction
+		sent = nil
+		resp, err = cli.BroadcastNews(ctx, connect.NewRequest(&miscv1.BroadcastNewsRequest{
+			Topic:    topicID.String(),
+			Headline: "Short",
+			Chunked:  true,
+		}))
+		require.NoError(t, err)
+		assert.Len(t, sent, 1)
+		assert.Empty(t, resp.Msg.ChunkTxids)
+	})
+
+	t.Run("invalid chunk size", func(t *testing.T) {
+		t.Parallel()
+
+		cli := miscv1connect.NewMiscServiceClient(apitests.API(t, database.Test(t)))
+		_, err := cli.BroadcastChunked(ctx, connect.NewRequest(&miscv1.BroadcastChunkedRequest{
+			Data:      []byte("data"),
+			ChunkSize: lo.ToPtr(uint32(10)),
+		}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
+
 func validTopicID() opreturns.TopicID {
 	buf := make([]byte, 8)
 	if _, err := rand.Read(buf); err != nil {
@@ -666,3 +1259,622 @@ func validTopicID() opreturns.TopicID {
 	}
 	return opreturns.TopicID(buf)
 }
+
+func TestService_WatchOPReturns(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockBitcoind := mocks.NewMockBitcoinServiceClient(gomock.NewController(t))
+	mockBitcoind.EXPECT().
+		ListWallets(gomock.Any(), gomock.Any()).
+		Return(connect.NewResponse(&corepb.ListWalletsResponse{}), nil).
+		AnyTimes()
+	mockBitcoind.EXPECT().
+		CreateWallet(gomock.Any(), gomock.Any()).
+		Return(connect.NewResponse(&corepb.CreateWalletResponse{Name: "cheque_watch"}), nil).
+		AnyTimes()
+	// Fees of coin news
+	mockBitcoind.EXPECT().
+		GetRawTransaction(gomock.Any(), gomock.Any()).
+		Return(connect.NewResponse(&corepb.GetRawTransactionResponse{Fee: 0.0001}), nil).
+		AnyTimes()
+
+	srv, httpClient, url := apitests.Server(t, database.Test(t), apitests.WithBitcoind(mockBitcoind))
+	cli := miscv1connect.NewMiscServiceClient(httpClient, url)
+	parser := srv.BitcoinEngine
+
+	var prevOut byte
+	newTx := func(data []byte) *wire.MsgTx {
+		prevOut++
+		script, err := txscript.NewScriptBuilder().AddOp(txscript.OP_RETURN).AddData(data).Script()
+		require.NoError(t, err)
+		return &wire.MsgTx{
+			TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{prevOut}}}},
+			TxOut: []*wire.TxOut{{PkScript: script}},
+		}
+	}
+
+	topicID := validTopicID()
+	require.NoError(t, parser.HandleNewRawTransaction(ctx, newTx(opreturns.EncodeTopicCreationMessage(topicID, "Watched"))))
+
+	opReturnStream, err := cli.WatchOPReturns(ctx, connect.NewRequest(&miscv1.WatchOPReturnsRequest{
+		Protocols: []miscv1.Protocol{miscv1.Protocol_PROTOCOL_COIN_NEWS},
+	}))
+	require.NoError(t, err)
+	defer opReturnStream.Close()
+	newsStream, err := cli.WatchCoinNews(ctx, connect.NewRequest(&miscv1.WatchCoinNewsRequest{
+		Topic: lo.ToPtr(topicID.String()),
+	}))
+	require.NoError(t, err)
+	defer newsStream.Close()
+
+	// Headers are sent once the server is watching
+	opReturnStream.ResponseHeader()
+	newsStream.ResponseHeader()
+
+	opReturns := make(chan *miscv1.WatchOPReturnsResponse, 100)
+	go func() {
+		for opReturnStream.Receive() {
+			opReturns <- opReturnStream.Msg()
+		}
+	}()
+	news := make(chan *miscv1.WatchCoinNewsResponse, 100)
+	go func() {
+		for newsStream.Receive() {
+			news <- newsStream.Msg()
+		}
+	}()
+
+	nextOPReturn := func() *miscv1.WatchOPReturnsResponse {
+		select {
+		case msg := <-opReturns:
+			return msg
+		case <-time.After(5 * time.Second):
+			t.Fatal("no OP_RETURN pushed")
+			return nil
+		}
+	}
+	nextNews := func() *miscv1.WatchCoinNewsResponse {
+		select {
+		case msg := <-news:
+			return msg
+		case <-time.After(5 * time.Second):
+			t.Fatal("no coin news pushed")
+			return nil
+		}
+	}
+
+	first := newTx(opreturns.EncodeNewsMessage(topicID, "First", "first post"))
+	require.NoError(t, parser.HandleNewRawTransaction(ctx, first))
+
+	firstOPReturn := nextOPReturn()
+	assert.Equal(t, miscv1.WatchEvent_WATCH_EVENT_UNCONFIRMED, firstOPReturn.Event)
+	assert.Equal(t, first.TxID(), firstOPReturn.OpReturn.Txid)
+	assert.Equal(t, miscv1.Protocol_PROTOCOL_COIN_NEWS, firstOPReturn.OpReturn.Protocol)
+	assert.Nil(t, firstOPReturn.OpReturn.Height)
+
+	firstNews := nextNews()
+	assert.Equal(t, miscv1.WatchEvent_WATCH_EVENT_UNCONFIRMED, firstNews.Event)
+	assert.Equal(t, "First", firstNews.CoinNews.Headline)
+	assert.Equal(t, "first post", firstNews.CoinNews.Content)
+	assert.EqualValues(t, 10_000, firstNews.CoinNews.FeeSats)
+	assert.Nil(t, firstNews.CoinNews.Height)
+
+	// Neither coin news, nor in the topic being watched
+	otherTopic := validTopicID()
+	require.NoError(t, parser.HandleNewRawTransaction(ctx, newTx([]byte("just some text"))))
+	require.NoError(t, parser.HandleNewRawTransaction(ctx, newTx(opreturns.EncodeTopicCreationMessage(otherTopic, "Other"))))
+	require.NoError(t, parser.HandleNewRawTransaction(ctx, newTx(opreturns.EncodeNewsMessage(otherTopic, "Elsewhere", ""))))
+
+	second := newTx(opreturns.EncodeNewsMessage(topicID, "Second", "second post"))
+	require.NoError(t, parser.HandleNewRawTransaction(ctx, second))
+
+	// The post in the other topic is coin news too
+	assert.Equal(t, "Elsewhere", nextOPReturn().OpReturn.Fields["headline"])
+	assert.Equal(t, second.TxID(), nextOPReturn().OpReturn.Txid)
+	assert.Equal(t, "Second", nextNews().CoinNews.Headline)
+
+	t.Run("invalid requests", func(t *testing.T) {
+		ctx := context.Background()
+		cli := miscv1connect.NewMiscServiceClient(apitests.API(t, database.Test(t)))
+
+		stream, err := cli.WatchOPReturns(ctx, connect.NewRequest(&miscv1.WatchOPReturnsRequest{
+			Protocols: []miscv1.Protocol{miscv1.Protocol(99)},
+		}))
+		require.NoError(t, err)
+		assert.False(t, stream.Receive())
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(stream.Err()))
+
+		newsStream, err := cli.WatchCoinNews(ctx, connect.NewRequest(&miscv1.WatchCoinNewsRequest{
+			Topic: lo.ToPtr("not a topic"),
+		}))
+		require.NoError(t, err)
+		assert.False(t, newsStream.Receive())
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(newsStream.Err()))
+	})
+}
+
+func TestService_TopicSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := database.Test(t)
+	cli := miscv1connect.NewMiscServiceClient(apitests.API(t, db))
+
+	topicA, topicB := validTopicID(), validTopicID()
+	require.NoError(t, opreturns.CreateTopic(ctx, db, topicA, "Topic A", strings.Repeat("aa", 32)))
+	require.NoError(t, opreturns.CreateTopic(ctx, db, topicB, "Topic B", strings.Repeat("bb", 32)))
+	require.NoError(t, opreturns.Persist(ctx, db, []opreturns.OPReturn{
+		{TxID: "cheap_a", Data: opreturns.EncodeNewsMessage(topicA, "Cheap A", ""), Fee: 100},
+		{TxID: "pricey_a", Data: opreturns.EncodeNewsMessage(topicA, "Pricey A", ""), Fee: 10_000},
+		{TxID: "post_b", Data: opreturns.EncodeNewsMessage(topicB, "Post B", ""), Fee: 100},
+	}))
+
+	listNews := func(req *miscv1.ListCoinNewsRequest) []string {
+		resp, err := cli.ListCoinNews(ctx, connect.NewRequest(req))
+		require.NoError(t, err)
+		headlines := lo.Map(resp.Msg.CoinNews, func(news *miscv1.CoinNews, _ int) string {
+			return news.Headline
+		})
+		slices.Sort(headlines)
+		return headlines
+	}
+	subscribe := func(topic opreturns.TopicID, subscription *miscv1.TopicSubscription) *miscv1.Topic {
+		resp, err := cli.SetTopicSubscription(ctx, connect.NewRequest(&miscv1.SetTopicSubscriptionRequest{
+			Topic:        topic.String(),
+			Subscription: subscription,
+		}))
+		require.NoError(t, err)
+		return resp.Msg.Topic
+	}
+
+	// Everything is subscribed to by default
+	assert.Equal(t, []string{"Cheap A", "Post B", "Pricey A"}, listNews(&miscv1.ListCoinNewsRequest{}))
+
+	// Unsubscribed topics are left out of the feed, but can be asked for
+	topic := subscribe(topicA, &miscv1.TopicSubscription{Subscribed: false})
+	assert.False(t, topic.Subscription.Subscribed)
+	assert.Equal(t, []string{"Post B"}, listNews(&miscv1.ListCoinNewsRequest{}))
+	assert.Equal(t, []string{"Cheap A", "Pricey A"}, listNews(&miscv1.ListCoinNewsRequest{
+		Topic: lo.ToPtr(topicA.String()),
+	}))
+
+	// Muted topics aren't listed at all
+	subscribe(topicB, &miscv1.TopicSubscription{Subscribed: true, Muted: true})
+	assert.Empty(t, listNews(&miscv1.ListCoinNewsRequest{Topic: lo.ToPtr(topicB.String())}))
+	assert.Equal(t, []string{"Cheap A", "Post B", "Pricey A"}, listNews(&miscv1.ListCoinNewsRequest{
+		IgnoreSubscriptions: true,
+	}))
+
+	subscribe(topicA, &miscv1.TopicSubscription{Subscribed: true, MinFeeSats: 1_000})
+	assert.Equal(t, []string{"Pricey A"}, listNews(&miscv1.ListCoinNewsRequest{}))
+
+	topics, err := cli.ListTopics(ctx, connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	listed, ok := lo.Find(topics.Msg.Topics, func(topic *miscv1.Topic) bool {
+		return topic.Topic == topicA.String()
+	})
+	require.True(t, ok)
+	assert.EqualValues(t, 1_000, listed.Subscription.MinFeeSats)
+	assert.Equal(t, strings.Repeat("aa", 32), listed.Txid)
+	assert.False(t, listed.Imported)
+
+	t.Run("export and import", func(t *testing.T) {
+		t.Parallel()
+
+		exported, err := cli.ExportTopics(ctx, connect.NewRequest(&miscv1.ExportTopicsRequest{
+			Topics: []string{topicA.String(), topicB.String()},
+		}))
+		require.NoError(t, err)
+
+		set, err := opreturns.ParseTopicSet([]byte(exported.Msg.TopicSet))
+		require.NoError(t, err)
+		assert.Equal(t, []opreturns.ExportedTopic{
+			{Topic: topicA.String(), Name: "Topic A", TxID: strings.Repeat("aa", 32)},
+			{Topic: topicB.String(), Name: "Topic B", TxID: strings.Repeat("bb", 32)},
+		}, set.Topics)
+
+		other := database.Test(t)
+		otherCli := miscv1connect.NewMiscServiceClient(apitests.API(t, other))
+		require.NoError(t, opreturns.CreateTopic(ctx, other, topicB, "Topic B", strings.Repeat("bb", 32)))
+		require.NoError(t, opreturns.SetSubscription(ctx, other, opreturns.Subscription{Topic: topicB, Muted: true}))
+
+		imported, err := otherCli.ImportTopics(ctx, connect.NewRequest(&miscv1.ImportTopicsRequest{
+			TopicSet: exported.Msg.TopicSet,
+		}))
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, imported.Msg.Imported)
+		assert.EqualValues(t, 1, imported.Msg.AlreadyKnown)
+
+		topics, err := otherCli.ListTopics(ctx, connect.NewRequest(&emptypb.Empty{}))
+		require.NoError(t, err)
+		byID := lo.KeyBy(topics.Msg.Topics, func(topic *miscv1.Topic) string { return topic.Topic })
+		require.Contains(t, byID, topicA.String())
+		assert.True(t, byID[topicA.String()].Imported)
+		assert.Equal(t, "Topic A", byID[topicA.String()].Name)
+		assert.True(t, byID[topicA.String()].Subscription.Subscribed)
+		// Importing subscribes, but leaves muting alone
+		assert.True(t, byID[topicB.String()].Subscription.Subscribed)
+		assert.True(t, byID[topicB.String()].Subscription.Muted)
+
+		// What's on chain replaces what was imported
+		require.NoError(t, opreturns.CreateTopic(ctx, other, topicA, "Renamed A", strings.Repeat("cc", 32)))
+		topics, err = otherCli.ListTopics(ctx, connect.NewRequest(&emptypb.Empty{}))
+		require.NoError(t, err)
+		byID = lo.KeyBy(topics.Msg.Topics, func(topic *miscv1.Topic) string { return topic.Topic })
+		assert.False(t, byID[topicA.String()].Imported)
+		assert.Equal(t, "Renamed A", byID[topicA.String()].Name)
+		assert.Equal(t, strings.Repeat("cc", 32), byID[topicA.String()].Txid)
+
+		// Exports only subscribed topics by default
+		require.NoError(t, opreturns.SetSubscription(ctx, other, opreturns.Subscription{Topic: topicB}))
+		exported, err = otherCli.ExportTopics(ctx, connect.NewRequest(&miscv1.ExportTopicsRequest{}))
+		require.NoError(t, err)
+		set, err = opreturns.ParseTopicSet([]byte(exported.Msg.TopicSet))
+		require.NoError(t, err)
+		assert.NotContains(t, lo.Map(set.Topics, func(topic opreturns.ExportedTopic, _ int) string {
+			return topic.Topic
+		}), topicB.String())
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := cli.SetTopicSubscription(ctx, connect.NewRequest(&miscv1.SetTopicSubscriptionRequest{
+			Topic:        validTopicID().String(),
+			Subscription: &miscv1.TopicSubscription{},
+		}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+		_, err = cli.SetTopicSubscription(ctx, connect.NewRequest(&miscv1.SetTopicSubscriptionRequest{
+			Topic:        topicA.String(),
+			Subscription: &miscv1.TopicSubscription{MinFeeSats: -1},
+		}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = cli.ExportTopics(ctx, connect.NewRequest(&miscv1.ExportTopicsRequest{
+			Topics: []string{validTopicID().String()},
+		}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+		for _, topicSet := range []string{
+			`not json`,
+			`{"version": 2, "topics": []}`,
+			`{"version": 1, "topics": [{"topic": "zz", "name": "Bad"}]}`,
+			fmt.Sprintf(`{"version": 1, "topics": [{"topic": %q, "name": ""}]}`, topicA),
+			fmt.Sprintf(`{"version": 1, "topics": [{"topic": %q, "name": "A", "txid": "abc"}]}`, topicA),
+			fmt.Sprintf(`{"version": 1, "topics": [{"topic": %q, "name": "A"}, {"topic": %q, "name": "A"}]}`, topicA, topicA),
+		} {
+			_, err := cli.ImportTopics(ctx, connect.NewRequest(&miscv1.ImportTopicsRequest{TopicSet: topicSet}))
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), topicSet)
+		}
+	})
+}
+
+func TestService_CoinNewsThreads(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := database.Test(t)
+
+	topicID := validTopicID()
+	require.NoError(t, opreturns.CreateTopic(ctx, db, topicID, "Test Topic", "topic_txid"))
+
+	txid := func(b byte) chainhash.Hash { return chainhash.Hash{b} }
+	post, reply, nested, other := txid(1), txid(2), txid(3), txid(4)
+
+	at := time.Now().Add(-time.Hour)
+	persist := func(hash chainhash.Hash, data []byte) {
+		at = at.Add(time.Minute)
+		require.NoError(t, opreturns.Persist(ctx, db, []opreturns.OPReturn{{
+			TxID:      hash.String(),
+			Data:      data,
+			CreatedAt: lo.ToPtr(at),
+		}}))
+	}
+	persist(post, opreturns.EncodeNewsMessage(topicID, "Original post", "Content"))
+	persist(reply, opreturns.EncodeReplyMessage(topicID, post, "First reply"))
+	persist(nested, opreturns.EncodeReplyMessage(topicID, reply, "Reply to the reply"))
+	persist(other, opreturns.EncodeReplyMessage(topicID, post, "Second reply"))
+	persist(txid(5), opreturns.EncodeReactionMessage(topicID, post, "+1"))
+	persist(txid(6), opreturns.EncodeReactionMessage(topicID, post, "+1"))
+	persist(txid(7), opreturns.EncodeReactionMessage(topicID, reply, "🔥"))
+	// Too long to be a reaction
+	persist(txid(8), opreturns.EncodeReactionMessage(topicID, post, strings.Repeat("x", opreturns.MaxReactionLength+1)))
+
+	ctrl := gomock.NewController(t)
+	mockWallet := mocks.NewMockWalletServiceClient(ctrl)
+	var sent []string
+	mockWallet.EXPECT().
+		SendTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *connect.Request[pb.SendTransactionRequest]) (*connect.Response[pb.SendTransactionResponse], error) {
+			sent = append(sent, req.Msg.OpReturnMessage.Hex.Value)
+			return connect.NewResponse(&pb.SendTransactionResponse{
+				Txid: &commonv1.ReverseHex{Hex: &wrapperspb.StringValue{Value: "sent_txid"}},
+			}), nil
+		}).
+		AnyTimes()
+
+	cli := miscv1connect.NewMiscServiceClient(apitests.API(t, db, apitests.WithWallet(mockWallet)))
+
+	t.Run("list counts replies and reactions", func(t *testing.T) {
+		resp, err := cli.ListCoinNews(ctx, connect.NewRequest(&miscv1.ListCoinNewsRequest{}))
+		require.NoError(t, err)
+
+		// Replies and reactions are only listed with their thread
+		require.Len(t, resp.Msg.CoinNews, 1)
+		listed := resp.Msg.CoinNews[0]
+		assert.Equal(t, "Original post", listed.Headline)
+		assert.EqualValues(t, 3, listed.ReplyCount)
+		assert.Equal(t, map[string]uint32{"+1": 2}, listed.Reactions)
+	})
+
+	t.Run("thread", func(t *testing.T) {
+		resp, err := cli.ListCoinNewsThread(ctx, connect.NewRequest(&miscv1.ListCoinNewsThreadRequest{
+			Txid: post.String(),
+		}))
+		require.NoError(t, err)
+
+		thread := resp.Msg.Thread
+		assert.Equal(t, "Original post", thread.Post.Headline)
+		assert.EqualValues(t, 3, thread.Post.ReplyCount)
+		require.Len(t, thread.Replies, 2)
+
+		first := thread.Replies[0]
+		assert.Equal(t, "First reply", first.Post.Content)
+		assert.Empty(t, first.Post.Headline)
+		assert.Equal(t, post.String(), first.Post.ReplyTo)
+		assert.EqualValues(t, 1, first.Post.ReplyCount)
+		assert.Equal(t, map[string]uint32{"🔥": 1}, first.Post.Reactions)
+		require.Len(t, first.Replies, 1)
+		assert.Equal(t, "Reply to the reply", first.Replies[0].Post.Content)
+		assert.Empty(t, first.Replies[0].Replies)
+
+		assert.Equal(t, "Second reply", thread.Replies[1].Post.Content)
+
+		// Part of a thread
+		resp, err = cli.ListCoinNewsThread(ctx, connect.NewRequest(&miscv1.ListCoinNewsThreadRequest{
+			Txid: reply.String(),
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "First reply", resp.Msg.Thread.Post.Content)
+		assert.Len(t, resp.Msg.Thread.Replies, 1)
+	})
+
+	t.Run("reply and react", func(t *testing.T) {
+		resp, err := cli.BroadcastNews(ctx, connect.NewRequest(&miscv1.BroadcastNewsRequest{
+			ReplyTo: lo.ToPtr(reply.String()),
+			Content: "Another reply",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "sent_txid", resp.Msg.Txid)
+
+		reacted, err := cli.React(ctx, connect.NewRequest(&miscv1.ReactRequest{
+			Txid:     nested.String(),
+			Reaction: "+1",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "sent_txid", reacted.Msg.Txid)
+
+		require.Len(t, sent, 2)
+		assert.Equal(t, hex.EncodeToString(opreturns.EncodeReplyMessage(topicID, reply, "Another reply")), sent[0])
+		assert.Equal(t, hex.EncodeToString(opreturns.EncodeReactionMessage(topicID, nested, "+1")), sent[1])
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for name, req := range map[string]*miscv1.BroadcastNewsRequest{
+			"headline":      {ReplyTo: lo.ToPtr(post.String()), Headline: "Headline", Content: "Content"},
+			"no content":    {ReplyTo: lo.ToPtr(post.String())},
+			"invalid txid":  {ReplyTo: lo.ToPtr("txid"), Content: "Content"},
+			"other topic":   {ReplyTo: lo.ToPtr(post.String()), Topic: validTopicID().String(), Content: "Content"},
+			"reply to none": {ReplyTo: lo.ToPtr(txid(9).String()), Content: "Content"},
+		} {
+			_, err := cli.BroadcastNews(ctx, connect.NewRequest(req))
+			if name == "reply to none" {
+				assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err), name)
+			} else {
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), name)
+			}
+		}
+
+		_, err := cli.React(ctx, connect.NewRequest(&miscv1.ReactRequest{Txid: post.String()}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		// Can't react to a reaction
+		_, err = cli.React(ctx, connect.NewRequest(&miscv1.ReactRequest{Txid: txid(5).String(), Reaction: "+1"}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+		_, err = cli.ListCoinNewsThread(ctx, connect.NewRequest(&miscv1.ListCoinNewsThreadRequest{Txid: "txid"}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+		_, err = cli.ListCoinNewsThread(ctx, connect.NewRequest(&miscv1.ListCoinNewsThreadRequest{Txid: txid(5).String()}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
+
+func TestService_CoinNewsRanking(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := database.Test(t)
+
+	topicID := validTopicID()
+	require.NoError(t, opreturns.CreateTopic(ctx, db, topicID, "Test Topic", "topic_txid"))
+
+	now := time.Now()
+	for _, post := range []struct {
+		headline string
+		age      time.Duration
+		fee      btcutil.Amount
+		vsize    int64
+	}{
+		{"Last week", 8 * 24 * time.Hour, 20_000, 200},
+		{"Three days ago", 3 * 24 * time.Hour, 10_000, 200},
+		{"Two hours ago", 2 * time.Hour, 4_000, 200},
+		{"An hour ago", time.Hour, 2_000, 200},
+		{"Unknown size", 30 * time.Minute, 100_000, 0},
+	} {
+		require.NoError(t, opreturns.Persist(ctx, db, []opreturns.OPReturn{{
+			TxID:      post.headline,
+			Data:      opreturns.EncodeNewsMessage(topicID, post.headline, ""),
+			Fee:       post.fee,
+			VSize:     post.vsize,
+			CreatedAt: lo.ToPtr(now.Add(-post.age)),
+		}}))
+	}
+
+	cli := miscv1connect.NewMiscServiceClient(apitests.API(t, db))
+
+	list := func(req *miscv1.ListCoinNewsRequest) ([]string, string) {
+		resp, err := cli.ListCoinNews(ctx, connect.NewRequest(req))
+		require.NoError(t, err)
+		return lo.Map(resp.Msg.CoinNews, func(news *miscv1.CoinNews, _ int) string {
+			return news.Headline
+		}), resp.Msg.NextPageToken
+	}
+
+	newest, _ := list(&miscv1.ListCoinNewsRequest{})
+	assert.Equal(t, []string{"Unknown size", "An hour ago", "Two hours ago", "Three days ago", "Last week"}, newest)
+	sorted, _ := list(&miscv1.ListCoinNewsRequest{Sort: miscv1.CoinNewsSort_COIN_NEWS_SORT_NEWEST})
+	assert.Equal(t, newest, sorted)
+
+	day, _ := list(&miscv1.ListCoinNewsRequest{Sort: miscv1.CoinNewsSort_COIN_NEWS_SORT_TOP_DAY})
+	assert.Equal(t, []string{"Two hours ago", "An hour ago", "Unknown size"}, day)
+
+	week, _ := list(&miscv1.ListCoinNewsRequest{Sort: miscv1.CoinNewsSort_COIN_NEWS_SORT_TOP_WEEK})
+	assert.Equal(t, []string{"Three days ago", "Two hours ago", "An hour ago", "Unknown size"}, week)
+
+	// Posts with an unknown fee rate can't pay for visibility
+	expensive, _ := list(&miscv1.ListCoinNewsRequest{
+		Filter: &miscv1.SearchFilter{MinFeeRate: 15},
+	})
+	assert.Equal(t, []string{"Two hours ago", "Three days ago", "Last week"}, expensive)
+
+	resp, err := cli.ListCoinNews(ctx, connect.NewRequest(&miscv1.ListCoinNewsRequest{}))
+	require.NoError(t, err)
+	threeDays, ok := lo.Find(resp.Msg.CoinNews, func(news *miscv1.CoinNews) bool {
+		return news.Headline == "Three days ago"
+	})
+	require.True(t, ok)
+	assert.EqualValues(t, 200, threeDays.Vsize)
+	assert.InDelta(t, 50, threeDays.FeeRate, 0.001)
+
+	t.Run("pages", func(t *testing.T) {
+		first, token := list(&miscv1.ListCoinNewsRequest{
+			Sort:     miscv1.CoinNewsSort_COIN_NEWS_SORT_TOP_WEEK,
+			PageSize: 2,
+		})
+		assert.Equal(t, []string{"Three days ago", "Two hours ago"}, first)
+		require.NotEmpty(t, token)
+
+		second, token := list(&miscv1.ListCoinNewsRequest{
+			Sort:      miscv1.CoinNewsSort_COIN_NEWS_SORT_TOP_WEEK,
+			PageSize:  2,
+			PageToken: token,
+		})
+		assert.Equal(t, []string{"An hour ago", "Unknown size"}, second)
+		assert.Empty(t, token)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, unranked := list(&miscv1.ListCoinNewsRequest{PageSize: 1})
+		_, ranked := list(&miscv1.ListCoinNewsRequest{
+			Sort:     miscv1.CoinNewsSort_COIN_NEWS_SORT_TOP_DAY,
+			PageSize: 1,
+		})
+
+		for name, req := range map[string]*miscv1.ListCoinNewsRequest{
+			"unknown sort":      {Sort: miscv1.CoinNewsSort(42)},
+			"negative fee rate": {Filter: &miscv1.SearchFilter{MinFeeRate: -1}},
+			"unranked token":    {Sort: miscv1.CoinNewsSort_COIN_NEWS_SORT_TOP_DAY, PageToken: unranked},
+			"ranked token":      {PageToken: ranked},
+		} {
+			_, err := cli.ListCoinNews(ctx, connect.NewRequest(req))
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), name)
+		}
+	})
+}
+
+func TestService_TimestampLargeFiles(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	// Batching queues timestamps without sending anything
+	cli := miscv1connect.NewMiscServiceClient(apitests.API(t, database.Test(t),
+		apitests.WithConfig(config.Config{TimestampBatchWindow: time.Hour}),
+	))
+
+	t.Run("stream", func(t *testing.T) {
+		data := make([]byte, 3<<20)
+		_, err := rand.Read(data)
+		require.NoError(t, err)
+
+		stream := cli.TimestampFileStream(ctx)
+		for chunk := range slices.Chunk(data, 1<<20) {
+			require.NoError(t, stream.Send(&miscv1.TimestampFileStreamRequest{
+				Filename: "release.tar.gz",
+				Chunk:    chunk,
+			}))
+		}
+		resp, err := stream.CloseAndReceive()
+		require.NoError(t, err)
+
+		hash := sha256.Sum256(data)
+		assert.Equal(t, hex.EncodeToString(hash[:]), resp.Msg.FileHash)
+		assert.Empty(t, resp.Msg.Txid, "queued for the next batch")
+
+		// The same as sending it in one go
+		single, err := cli.TimestampFile(ctx, connect.NewRequest(&miscv1.TimestampFileRequest{
+			Filename: "release.tar.gz", FileData: data,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, resp.Msg.Id, single.Msg.Id)
+
+		stream = cli.TimestampFileStream(ctx)
+		require.NoError(t, stream.Send(&miscv1.TimestampFileStreamRequest{Chunk: []byte("no name")}))
+		_, err = stream.CloseAndReceive()
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "a.txt"), []byte("a"), 0o644))
+
+		resp, err := cli.TimestampPath(ctx, connect.NewRequest(&miscv1.TimestampPathRequest{Path: dir}))
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, resp.Msg.FileCount)
+		assert.Equal(t, filepath.Base(dir)+"/", resp.Msg.Timestamp.Filename)
+
+		hashOf := func(data string) string {
+			hash := sha256.Sum256([]byte(data))
+			return hex.EncodeToString(hash[:])
+		}
+		manifest := fmt.Sprintf("%s  b.txt\n%s  sub/a.txt\n", hashOf("b"), hashOf("a"))
+		assert.Equal(t, manifest, resp.Msg.GetManifest())
+		assert.Equal(t, hashOf(manifest), resp.Msg.Timestamp.FileHash)
+
+		file, err := cli.TimestampPath(ctx, connect.NewRequest(&miscv1.TimestampPathRequest{Path: filepath.Join(dir, "b.txt")}))
+		require.NoError(t, err)
+		assert.Nil(t, file.Msg.Manifest)
+		assert.Equal(t, hashOf("b"), file.Msg.Timestamp.FileHash)
+		assert.Equal(t, "b.txt", file.Msg.Timestamp.Filename)
+
+		_, err = cli.TimestampPath(ctx, connect.NewRequest(&miscv1.TimestampPathRequest{Path: filepath.Join(dir, "missing")}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "empty"), 0o755))
+		_, err = cli.TimestampPath(ctx, connect.NewRequest(&miscv1.TimestampPathRequest{Path: filepath.Join(dir, "empty")}))
+		assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+		// A symlink to the directory is followed
+		link := filepath.Join(t.TempDir(), "link")
+		require.NoError(t, os.Symlink(dir, link))
+		linked, err := cli.TimestampPath(ctx, connect.NewRequest(&miscv1.TimestampPathRequest{Path: link}))
+		require.NoError(t, err)
+		assert.Equal(t, resp.Msg.GetManifest(), linked.Msg.GetManifest())
+		assert.EqualValues(t, 2, linked.Msg.FileCount)
+	})
+}
@@ -90,12 +90,17 @@ func New(
 	chequeEngine := engines.NewChequeEngine(walletEngine, svcs.ChainParams, bitcoindSvc)
 
 	// Create timestamp engine for file timestamping
-	walletAdapter := engines.NewWalletAdapter(walletSvc)
-	timestampEngine := engines.NewTimestampEngine(svcs.Database, zerolog.Ctx(ctx).With().Str("component", "timestamp").Logger(), walletAdapter)
+	walletAdapter := engines.NewWalletAdapter(svcs.Database, walletEngine, walletSvc, bitcoindSvc)
+	timestampEngine := engines.NewTimestampEngine(
+		svcs.Database, zerolog.Ctx(ctx).With().Str("component", "timestamp").Logger(), walletAdapter, bitcoindSvc,
+		conf.TimestampBatchWindow,
+	)
 
 	// Create M4 engine for M4 Explorer
 	m4Engine := engines.NewM4Engine(svcs.Database)
 
+	bitcoinEngine := NewBitcoinEngine(bitcoindSvc, svcs.Database, svcs.ChainParams, conf)
+
 	srv := &Server{
 		mux:             mux,
 		Bitcoind:        bitcoindSvc,
@@ -106,10 +111,11 @@ func New(
 		ChequeEngine:    chequeEngine,
 		TimestampEngine: timestampEngine,
 		M4Engine:        m4Engine,
+		BitcoinEngine:   bitcoinEngine,
 	}
 
 	Register(srv, bitwindowdv1connect.NewBitwindowdServiceHandler, bitwindowdv1connect.BitwindowdServiceHandler(api_bitwindowd.New(
-		onShutdown, svcs.Database, validatorSvc, walletSvc, bitcoindSvc, walletEngine, conf,
+		onShutdown, svcs.Database, validatorSvc, walletSvc, bitcoindSvc, walletEngine, bitcoinEngine, svcs.ChainParams, conf,
 	)))
 
 	// Dynamically forward all Bitcoin Core RPCs to the Bitcoin Core proxy.
@@ -180,7 +186,7 @@ func New(
 		ctx, svcs.Database, bitcoindSvc, walletSvc, cryptoSvc, chequeEngine, walletEngine, svcs.WalletDir,
 	)))
 	Register(srv, miscv1connect.NewMiscServiceHandler, miscv1connect.MiscServiceHandler(api_misc.New(
-		svcs.Database, walletSvc, timestampEngine,
+		svcs.Database, walletSvc, walletEngine, timestampEngine, bitcoinEngine,
 	)))
 	Register(srv, healthv1connect.NewHealthServiceHandler, healthv1connect.HealthServiceHandler(api_health.New(
 		svcs.Database, bitcoindSvc, validatorSvc, walletSvc, cryptoSvc,
@@ -214,6 +220,26 @@ type Server struct {
 	ChequeEngine    *engines.ChequeEngine
 	TimestampEngine *engines.TimestampEngine
 	M4Engine        *engines.M4Engine
+	BitcoinEngine   *engines.Parser
+}
+
+// NewBitcoinEngine creates the block parser, with all block processors
+// registered.
+func NewBitcoinEngine(
+	bitcoind *service.Service[corerpc.BitcoinServiceClient], db *sql.DB,
+	chainParams *chaincfg.Params, conf config.Config,
+) *engines.Parser {
+	bitcoinEngine := engines.NewBitcoind(bitcoind, db, conf)
+	if conf.BitcoinCoreBlocksDir != "" {
+		bitcoinEngine.SetBlocksDir(conf.BitcoinCoreBlocksDir, chainParams.Net)
+	}
+	bitcoinEngine.RegisterProcessor(engines.NewM4Engine(db))
+	if conf.IndexAddresses {
+		bitcoinEngine.RegisterProcessor(engines.NewAddressIndex(db))
+	}
+	// Uses the address index for fees, so has to come after it
+	bitcoinEngine.RegisterProcessor(engines.NewBlockStats(db, chainParams, conf.IndexAddresses))
+	return bitcoinEngine
 }
 
 func (s *Server) Handler() http.Handler {
@@ -0,0 +1,240 @@
+// Package blkfile reads blocks straight from the blk*.dat files in Bitcoin
+// Core's blocks directory, without going through RPC.
+package blkfile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// Every block in a blk file is prefixed by the network magic and the size
+// of the block.
+const recordHeaderSize = 8
+
+// location is where a block is stored.
+type location struct {
+	file   int
+	offset int64
+	size   uint32
+	prev   chainhash.Hash
+}
+
+// Store is an index of all blocks in a blocks directory, built by scanning
+// the block headers in every blk file. Blocks are stored in the order they
+// were downloaded in, which is not the order of the chain, and can include
+// blocks that were reorged out.
+//
+// Blocks Bitcoin Core writes after the store was opened are not included.
+type Store struct {
+	dir    string
+	xorKey []byte
+
+	blocks map[chainhash.Hash]location
+
+	mu    sync.Mutex
+	files map[int]*os.File
+}
+
+// Open scans all blk files in the given blocks directory, e.g.
+// ~/.bitcoin/signet/blocks.
+func Open(dir string, net wire.BitcoinNet) (*Store, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "blk*.dat"))
+	if err != nil {
+		return nil, fmt.Errorf("list blk files: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no blk files found in %s", dir)
+	}
+	sort.Strings(paths)
+
+	// Bitcoin Core 28 and later obfuscate blk files, with the key in xor.dat
+	xorKey, err := os.ReadFile(filepath.Join(dir, "xor.dat"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read xor key: %w", err)
+	}
+	if bytes.Count(xorKey, []byte{0}) == len(xorKey) {
+		xorKey = nil
+	}
+
+	store := &Store{
+		dir:    dir,
+		xorKey: xorKey,
+		blocks: make(map[chainhash.Hash]location),
+		files:  make(map[int]*os.File),
+	}
+
+	for _, path := range paths {
+		var number int
+		if _, err := fmt.Sscanf(filepath.Base(path), "blk%05d.dat", &number); err != nil {
+			continue
+		}
+
+		if err := store.scan(path, number, net); err != nil {
+			return nil, fmt.Errorf("scan %s: %w", filepath.Base(path), err)
+		}
+	}
+
+	return store, nil
+}
+
+// scan adds all blocks in the given blk file to the store.
+func (s *Store) scan(path string, number int, net wire.BitcoinNet) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	var (
+		offset int64
+		record [recordHeaderSize + wire.MaxBlockHeaderPayload]byte
+	)
+	for offset+int64(len(record)) <= info.Size() {
+		if _, err := file.ReadAt(record[:], offset); err != nil {
+			return fmt.Errorf("read block at %d: %w", offset, err)
+		}
+		s.xor(record[:], offset)
+
+		magic := wire.BitcoinNet(binary.LittleEndian.Uint32(record[:4]))
+		// Core preallocates blk files, the rest is zeros
+		if magic == 0 {
+			break
+		}
+		if magic != net {
+			return fmt.Errorf("unexpected network magic %s at %d", magic, offset)
+		}
+
+		size := binary.LittleEndian.Uint32(record[4:8])
+		if offset+recordHeaderSize+int64(size) > info.Size() {
+			// Partially written block
+			break
+		}
+
+		var header wire.BlockHeader
+		if err := header.Deserialize(bytes.NewReader(record[recordHeaderSize:])); err != nil {
+			return fmt.Errorf("deserialize header at %d: %w", offset, err)
+		}
+
+		s.blocks[header.BlockHash()] = location{
+			file:   number,
+			offset: offset + recordHeaderSize,
+			size:   size,
+			prev:   header.PrevBlock,
+		}
+		offset += recordHeaderSize + int64(size)
+	}
+
+	return nil
+}
+
+// xor undoes the obfuscation of data read at the given offset of a blk file.
+func (s *Store) xor(data []byte, offset int64) {
+	if len(s.xorKey) == 0 {
+		return
+	}
+
+	for i := range data {
+		data[i] ^= s.xorKey[(offset+int64(i))%int64(len(s.xorKey))]
+	}
+}
+
+// Len returns the number of blocks in the store.
+func (s *Store) Len() int {
+	return len(s.blocks)
+}
+
+// Chain returns the hashes of the chain ending in the given block, indexed
+// by height.
+func (s *Store) Chain(tip chainhash.Hash) ([]chainhash.Hash, error) {
+	var chain []chainhash.Hash
+	for hash := tip; hash != (chainhash.Hash{}); {
+		loc, ok := s.blocks[hash]
+		if !ok {
+			return nil, fmt.Errorf("block %s not found in %s", hash, s.dir)
+		}
+
+		chain = append(chain, hash)
+		hash = loc.prev
+	}
+
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+
+	return chain, nil
+}
+
+// Block reads the block with the given hash. Safe for concurrent use.
+func (s *Store) Block(hash chainhash.Hash) (*wire.MsgBlock, error) {
+	loc, ok := s.blocks[hash]
+	if !ok {
+		return nil, fmt.Errorf("block %s not found in %s", hash, s.dir)
+	}
+
+	file, err := s.file(loc.file)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, loc.size)
+	if _, err := file.ReadAt(data, loc.offset); err != nil {
+		return nil, fmt.Errorf("read block %s: %w", hash, err)
+	}
+	s.xor(data, loc.offset)
+
+	var block wire.MsgBlock
+	if err := block.Deserialize(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("deserialize block %s: %w", hash, err)
+	}
+
+	if block.BlockHash() != hash {
+		return nil, fmt.Errorf("block at %s does not match %s", file.Name(), hash)
+	}
+
+	return &block, nil
+}
+
+func (s *Store) file(number int) (*os.File, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if file, ok := s.files[number]; ok {
+		return file, nil
+	}
+
+	file, err := os.Open(filepath.Join(s.dir, fmt.Sprintf("blk%05d.dat", number)))
+	if err != nil {
+		return nil, err
+	}
+	s.files[number] = file
+
+	return file, nil
+}
+
+// Close closes all open blk files.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	for number, file := range s.files {
+		errs = append(errs, file.Close())
+		delete(s.files, number)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,70 @@
+package blkfile
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Generated by testdata/gen. Regtest, 12 blocks on top of genesis spread out
+// of order over two obfuscated blk files, plus a stale block at height 5.
+const (
+	fixtureDir = "testdata/blocks"
+	fixtureTip = "0578d7c35dfac3f1c04ae6aec48adbf541d193bc9da79eddef0dedd67c0985df"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	store, err := Open(fixtureDir, wire.TestNet)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, store.Close()) })
+
+	// Including the stale block
+	assert.Equal(t, 14, store.Len())
+
+	tip, err := chainhash.NewHashFromStr(fixtureTip)
+	require.NoError(t, err)
+
+	chain, err := store.Chain(*tip)
+	require.NoError(t, err)
+	require.Len(t, chain, 13)
+	assert.Equal(t, *chaincfg.RegressionNetParams.GenesisHash, chain[0])
+	assert.Equal(t, *tip, chain[12])
+
+	for height, hash := range chain {
+		block, err := store.Block(hash)
+		require.NoError(t, err)
+		assert.Equal(t, hash, block.BlockHash())
+
+		if height > 0 {
+			assert.Equal(t, chain[height-1], block.Header.PrevBlock)
+		}
+	}
+
+	t.Run("unknown block", func(t *testing.T) {
+		_, err := store.Chain(chainhash.Hash{1})
+		require.ErrorContains(t, err, "not found")
+
+		_, err = store.Block(chainhash.Hash{1})
+		require.ErrorContains(t, err, "not found")
+	})
+}
+
+func TestOpen(t *testing.T) {
+	t.Parallel()
+
+	t.Run("wrong network", func(t *testing.T) {
+		_, err := Open(fixtureDir, wire.MainNet)
+		require.ErrorContains(t, err, "unexpected network magic")
+	})
+
+	t.Run("no blk files", func(t *testing.T) {
+		_, err := Open(t.TempDir(), wire.TestNet)
+		require.ErrorContains(t, err, "no blk files")
+	})
+}
@@ -0,0 +1,128 @@
+// Generates the blk file fixture in testdata/blocks: a regtest chain of 12
+// blocks on top of genesis, stored out of order across two obfuscated blk
+// files, plus a stale block forking off at height 4.
+//
+//	go run ./blkfile/testdata/gen
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+var (
+	params = &chaincfg.RegressionNetParams
+	xorKey = []byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0}
+)
+
+func main() {
+	dir := filepath.Join("blkfile", "testdata", "blocks")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		log.Fatal(err)
+	}
+
+	chain := []*wire.MsgBlock{params.GenesisBlock}
+	for height := int32(1); height <= 12; height++ {
+		chain = append(chain, mine(chain[height-1], height, 0))
+	}
+	stale := mine(chain[4], 5, 1)
+
+	files := [][]*wire.MsgBlock{
+		{chain[0], chain[1], chain[2], chain[3], chain[4], chain[5], stale, chain[6], chain[7], chain[8], chain[10]},
+		{chain[9], chain[11], chain[12]},
+	}
+	for i, blocks := range files {
+		var buf bytes.Buffer
+		for _, block := range blocks {
+			var raw bytes.Buffer
+			if err := block.Serialize(&raw); err != nil {
+				log.Fatal(err)
+			}
+			_ = binary.Write(&buf, binary.LittleEndian, uint32(params.Net))
+			_ = binary.Write(&buf, binary.LittleEndian, uint32(raw.Len()))
+			buf.Write(raw.Bytes())
+		}
+		// Core preallocates blk files
+		buf.Write(make([]byte, 256))
+
+		data := buf.Bytes()
+		for j := range data {
+			data[j] ^= xorKey[j%len(xorKey)]
+		}
+
+		path := filepath.Join(dir, fmt.Sprintf("blk%05d.dat", i))
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "xor.dat"), xorKey, 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("tip: %s", chain[12].BlockHash())
+}
+
+// mine creates a block on top of prev, with a coinbase and a transaction
+// spending the previous coinbase into an OP_RETURN.
+func mine(prev *wire.MsgBlock, height int32, extraNonce byte) *wire.MsgBlock {
+	heightScript, err := txscript.NewScriptBuilder().AddInt64(int64(height)).AddData([]byte{extraNonce}).Script()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	coinbase := wire.NewMsgTx(2)
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), heightScript, nil))
+	coinbase.AddTxOut(wire.NewTxOut(blockchain.CalcBlockSubsidy(height, params), []byte{txscript.OP_TRUE}))
+
+	txs := []*wire.MsgTx{coinbase}
+	if height > 1 {
+		// Spend the previous coinbase
+		prevCoinbase := prev.Transactions[0].TxHash()
+		tx := wire.NewMsgTx(2)
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&prevCoinbase, 0), nil, nil))
+		tx.AddTxOut(wire.NewTxOut(prev.Transactions[0].TxOut[0].Value-1_000, []byte{txscript.OP_TRUE}))
+		tx.AddTxOut(wire.NewTxOut(0, []byte{txscript.OP_RETURN, txscript.OP_DATA_2, byte(height), extraNonce}))
+		txs = append(txs, tx)
+	}
+
+	block := &wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Version:   4,
+			PrevBlock: prev.BlockHash(),
+			Timestamp: prev.Header.Timestamp.Add(10 * time.Minute),
+			Bits:      params.PowLimitBits,
+		},
+		Transactions: txs,
+	}
+	block.Header.MerkleRoot = blockchain.CalcMerkleRoot(utilTxs(txs), false)
+
+	target := blockchain.CompactToBig(params.PowLimitBits)
+	for {
+		hash := block.Header.BlockHash()
+		if blockchain.HashToBig(&hash).Cmp(target) <= 0 {
+			return block
+		}
+		block.Header.Nonce++
+	}
+}
+
+func utilTxs(txs []*wire.MsgTx) []*btcutil.Tx {
+	res := make([]*btcutil.Tx, len(txs))
+	for i, tx := range txs {
+		res[i] = btcutil.NewTx(tx)
+	}
+	return res
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	dir "github.com/LayerTwo-Labs/sidesail/bitwindow/server/dir"
 	"github.com/jessevdk/go-flags"
@@ -41,16 +42,50 @@ type Config struct {
 	GuiBootedEnforcer  bool `long:"gui-booted-enforcer" description:"Set to true if GUI booted this process. Used by this application to shutdown everything correctly."`
 
 	SyncToHeight uint32 `long:"sync-to-height" description:"Sync to this height and then exit"`
+
+	BitcoinCoreBlocksDir string `long:"bitcoincore.blocksdir" description:"Path to Bitcoin Core's blocks directory, e.g. <bitcoin datadir>/signet/blocks. If set, initial sync reads blocks straight from disk instead of over RPC"`
+
+	IndexAddresses bool `long:"index.addresses" description:"Index the history of every address in the chain. Needed for address history lookups, takes up a lot of disk space"`
+
+	TimestampBatchWindow time.Duration `long:"timestamp.batch-window" description:"Queue files to timestamp for this long, and commit to all of them in a single transaction. 0 sends a transaction per file" default:"0s"`
+
+	EsploraHost string `long:"esplora.host" description:"host:port to serve an Esplora compatible REST API on. Address lookups need --index.addresses (default: disabled)"`
+
+	Reindex ReindexConfig `command:"reindex" description:"Roll back and re-run block processors over a range of blocks, then exit"`
+
+	// Name of the command that was invoked, if any
+	Command string `no-flag:"true"`
+}
+
+type ReindexConfig struct {
+	From uint32   `long:"from" description:"First block to re-run" default:"1"`
+	To   uint32   `long:"to" description:"Last block to re-run (default: last processed block)"`
+	Only []string `long:"only" description:"Comma-separated block processors to re-run, e.g. m4,opreturns (default: all)"`
 }
 
 func Parse() (Config, error) {
 	var conf Config
 	parser := flags.NewParser(&conf, flags.AllowBoolValues|flags.Default)
+	parser.SubcommandsOptional = true
 
 	if _, err := parser.Parse(); err != nil {
 		return Config{}, err
 	}
 
+	if parser.Active != nil {
+		conf.Command = parser.Active.Name
+	}
+
+	var only []string
+	for _, names := range conf.Reindex.Only {
+		for _, name := range strings.Split(names, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				only = append(only, name)
+			}
+		}
+	}
+	conf.Reindex.Only = only
+
 	if conf.BitcoinCoreCookie == "" && (conf.BitcoinCoreRpcPassword == "" || conf.BitcoinCoreRpcUser == "") {
 		return Config{}, errors.New("no Bitcoin Core auth provided")
 	}
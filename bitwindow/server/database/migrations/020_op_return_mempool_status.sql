@@ -0,0 +1,17 @@
+-- Unconfirmed OP_RETURNs can be evicted from the mempool, or replaced by
+-- (or conflicted with) another transaction spending the same inputs.
+ALTER TABLE op_returns ADD COLUMN status TEXT NOT NULL DEFAULT 'active';
+ALTER TABLE op_returns ADD COLUMN replaced_by_txid TEXT;
+ALTER TABLE op_returns ADD COLUMN status_updated_at TIMESTAMP;
+
+-- Outpoints spent by unconfirmed OP_RETURN transactions. Used to find out
+-- which transaction replaced them.
+CREATE TABLE op_return_spends (
+    txid TEXT NOT NULL,
+    prev_txid TEXT NOT NULL,
+    prev_vout INTEGER NOT NULL,
+
+    UNIQUE(txid, prev_txid, prev_vout)
+);
+
+CREATE INDEX op_return_spends_outpoint ON op_return_spends (prev_txid, prev_vout);
@@ -0,0 +1,16 @@
+-- Tracks how far each block processor has gotten. Processors are
+-- identified by name, and can be behind the processed_blocks tip.
+CREATE TABLE block_processor_cursors (
+    name TEXT PRIMARY KEY,
+    height INTEGER NOT NULL,
+    block_hash TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- OP_RETURNs and M4 messages were processed in lockstep with
+-- processed_blocks up until now, so they start out at the tip.
+INSERT INTO block_processor_cursors (name, height, block_hash)
+SELECT 'opreturns', height, block_hash FROM processed_blocks ORDER BY height DESC LIMIT 1;
+
+INSERT INTO block_processor_cursors (name, height, block_hash)
+SELECT 'm4', height, block_hash FROM processed_blocks ORDER BY height DESC LIMIT 1;
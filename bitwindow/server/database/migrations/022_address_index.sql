@@ -0,0 +1,20 @@
+-- Every spendable output in the best chain, and what spent it. Only
+-- filled when the address index is enabled.
+CREATE TABLE address_outputs (
+    txid TEXT NOT NULL,
+    vout INTEGER NOT NULL,
+    script_pubkey BLOB NOT NULL,
+    -- in satoshis
+    value INTEGER NOT NULL,
+    block_height INTEGER NOT NULL,
+
+    spent_txid TEXT,
+    spent_vin INTEGER,
+    spent_height INTEGER,
+
+    PRIMARY KEY (txid, vout)
+);
+
+CREATE INDEX address_outputs_script_pubkey ON address_outputs(script_pubkey);
+CREATE INDEX address_outputs_block_height ON address_outputs(block_height);
+CREATE INDEX address_outputs_spent_height ON address_outputs(spent_height);
@@ -0,0 +1,32 @@
+-- Per-block fee and transaction statistics, for charting the chain
+-- without asking Bitcoin Core.
+CREATE TABLE block_stats (
+    height INTEGER PRIMARY KEY,
+    block_hash TEXT NOT NULL,
+    block_time TIMESTAMP NOT NULL,
+    -- compact difficulty target from the block header
+    bits INTEGER NOT NULL,
+
+    -- including the coinbase
+    tx_count INTEGER NOT NULL,
+    weight INTEGER NOT NULL,
+    vsize INTEGER NOT NULL,
+    -- in satoshis
+    total_fee INTEGER NOT NULL,
+
+    segwit_tx_count INTEGER NOT NULL,
+    taproot_tx_count INTEGER NOT NULL,
+
+    op_return_count INTEGER NOT NULL,
+    op_return_bytes INTEGER NOT NULL,
+
+    -- sat/vB, weighted by vsize. NULL if the fees of the individual
+    -- transactions weren't known when the block was processed.
+    feerate_p10 REAL,
+    feerate_p25 REAL,
+    feerate_p50 REAL,
+    feerate_p75 REAL,
+    feerate_p90 REAL
+);
+
+CREATE INDEX block_stats_block_time ON block_stats(block_time);
@@ -0,0 +1,3 @@
+-- hash160 of the key allowed to moderate the topic, hex encoded. NULL for
+-- topics created without an owner, which can't be moderated.
+ALTER TABLE coin_news_topics ADD COLUMN owner TEXT;
@@ -0,0 +1,8 @@
+-- What protocol an OP_RETURN belongs to, and what we could decode from it.
+-- NULL for OP_RETURNs stored before classification existed, those are
+-- classified when the parser starts.
+ALTER TABLE op_returns ADD COLUMN protocol TEXT;
+-- JSON object of decoded fields, with string values
+ALTER TABLE op_returns ADD COLUMN protocol_fields TEXT;
+
+CREATE INDEX op_returns_protocol ON op_returns(protocol);
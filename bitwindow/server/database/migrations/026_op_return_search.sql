@@ -0,0 +1,14 @@
+-- Full-text index over decoded OP_RETURN messages, keyed by op_returns.id.
+-- FTS5 is only compiled into go-sqlite3 with the sqlite_fts5 build tag,
+-- FTS4 is always there and supports the same queries we need.
+CREATE VIRTUAL TABLE op_returns_fts USING fts4(body, tokenize=unicode61);
+
+CREATE TRIGGER op_returns_fts_delete AFTER DELETE ON op_returns BEGIN
+    DELETE FROM op_returns_fts WHERE docid = old.id;
+END;
+
+-- Classifying also indexes, so this has the parser fill in the index for
+-- everything already stored when it starts.
+UPDATE op_returns SET protocol = NULL, protocol_fields = NULL;
+
+CREATE INDEX op_returns_height ON op_returns(height);
@@ -0,0 +1,15 @@
+-- What the user wants to see of each topic. Kept apart from the topics, as
+-- topics are deleted on reorgs and can be subscribed to before they're seen.
+-- Topics without a row are subscribed, unmuted, without a minimum fee.
+CREATE TABLE coin_news_subscriptions (
+    topic TEXT PRIMARY KEY, -- 8 bytes (16 hex characters)
+    subscribed BOOLEAN NOT NULL DEFAULT TRUE,
+    muted BOOLEAN NOT NULL DEFAULT FALSE,
+    min_fee_sats INTEGER NOT NULL DEFAULT 0,
+
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Topics imported from someone else's export, not seen on chain yet. The
+-- creation replaces them once it is.
+ALTER TABLE coin_news_topics ADD COLUMN imported BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+-- Virtual size of the transaction carrying the OP_RETURN, so coin news can be
+-- ranked by fee rate. 0 means unknown, as for fee_sats.
+ALTER TABLE op_returns ADD COLUMN vsize INTEGER NOT NULL DEFAULT 0;
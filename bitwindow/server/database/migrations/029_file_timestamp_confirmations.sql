@@ -0,0 +1,5 @@
+-- Where a timestamp was confirmed, and why it failed to be.
+ALTER TABLE file_timestamps ADD COLUMN block_hash TEXT;
+ALTER TABLE file_timestamps ADD COLUMN failure_reason TEXT;
+
+CREATE INDEX file_timestamps_txid ON file_timestamps(txid);
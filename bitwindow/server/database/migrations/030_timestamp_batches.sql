@@ -0,0 +1,6 @@
+-- Batched timestamps commit to the root of a merkle tree over many file
+-- hashes. Every file in a batch has the batch's txid and root, and its own
+-- path to the root, as a JSON array of steps. Timestamps that commit to the
+-- file hash directly have neither.
+ALTER TABLE file_timestamps ADD COLUMN merkle_root TEXT;
+ALTER TABLE file_timestamps ADD COLUMN merkle_path TEXT;
@@ -0,0 +1,2 @@
+-- Lets us look up timestamps of file hashes on chain.
+CREATE INDEX op_returns_data ON op_returns(op_return_data);
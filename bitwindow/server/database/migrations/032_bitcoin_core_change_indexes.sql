@@ -0,0 +1,7 @@
+-- Next unused index on the change chain of each Bitcoin Core wallet, for
+-- the change outputs of timestamp transactions we sign ourselves. Core has
+-- no RPC to hand out change addresses through btc-buf.
+CREATE TABLE bitcoin_core_change_indexes (
+    wallet_id TEXT PRIMARY KEY,
+    next_index INTEGER NOT NULL
+);
@@ -0,0 +1,2 @@
+-- Topics the user reads as posted, without what the topic owner hides
+ALTER TABLE coin_news_subscriptions ADD COLUMN ignore_moderation BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,36 @@
+package engines
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/addresshistory"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// AddressIndexName is the name of the address index block processor.
+const AddressIndexName = "addresses"
+
+// AddressIndex records the funding and spending history of every script
+// in the best chain. It's opt-in, because it takes up a lot of space.
+type AddressIndex struct {
+	db *sql.DB
+}
+
+func NewAddressIndex(db *sql.DB) *AddressIndex {
+	return &AddressIndex{db: db}
+}
+
+var _ BlockProcessor = new(AddressIndex)
+
+func (a *AddressIndex) Name() string {
+	return AddressIndexName
+}
+
+func (a *AddressIndex) ProcessBlock(ctx context.Context, height uint32, block *wire.MsgBlock) error {
+	return addresshistory.IndexBlock(ctx, a.db, height, block)
+}
+
+func (a *AddressIndex) Rollback(ctx context.Context, height uint32) error {
+	return addresshistory.DeleteAboveHeight(ctx, a.db, height)
+}
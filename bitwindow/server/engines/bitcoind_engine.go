@@ -2,11 +2,13 @@ package engines
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"database/sql"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -16,9 +18,11 @@ import (
 	logpool "github.com/LayerTwo-Labs/sidesail/bitwindow/server/logpool"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blocks"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/opreturns"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/timestamps"
 	service "github.com/LayerTwo-Labs/sidesail/bitwindow/server/service"
 	corepb "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha"
 	corerpc "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha/bitcoindv1alphaconnect"
+	"github.com/btcsuite/btcd/blockchain"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
@@ -32,12 +36,17 @@ func NewBitcoind(
 	db *sql.DB,
 	conf config.Config,
 ) *Parser {
-	return &Parser{
+	p := &Parser{
 		bitcoind: bitcoind,
 		db:       db,
 		conf:     conf,
-		m4Engine: NewM4Engine(db),
+
+		blockNotifications: make(chan struct{}, 1),
+		processorRetryAt:   make(map[string]time.Time),
 	}
+	p.RegisterProcessor(&opReturnProcessor{parser: p})
+
+	return p
 }
 
 // Parser is responsible for parsing blocks from bitcoind and storing OP_RETURN data in SQLite
@@ -46,9 +55,51 @@ type Parser struct {
 	db       *sql.DB
 	conf     config.Config
 
-	mu       sync.Mutex
-	topics   []opreturns.TopicInfo
-	m4Engine *M4Engine
+	mu         sync.Mutex
+	topics     []opreturns.TopicInfo
+	processors []BlockProcessor
+
+	// When to hand blocks to processors that failed again. Only touched
+	// from the block tick.
+	processorRetryAt map[string]time.Time
+
+	// Signalled when Bitcoin Core tells us a block was connected or
+	// disconnected, to not have to wait for the next poll.
+	blockNotifications chan struct{}
+
+	lastMempoolReconcile time.Time
+
+	syncStatus syncStatus
+
+	opReturnWatchers opReturnWatchers
+
+	// Set if initial sync can read blocks from Bitcoin Core's blk files.
+	// Only touched from the block tick.
+	diskSync *diskSync
+}
+
+const (
+	// How often to poll for new blocks when there's no other way of
+	// knowing that the chain has changed.
+	blockPollInterval = 2 * time.Second
+
+	// How often to poll for new blocks once we're receiving block
+	// notifications. Only needed in case a notification gets lost.
+	blockPollFallbackInterval = 30 * time.Second
+
+	// How often to compare unconfirmed OP_RETURNs against the mempool, to
+	// catch removals we didn't get a notification for.
+	mempoolReconcileInterval = time.Minute
+)
+
+// NotifyBlock tells the parser that the best chain has changed, triggering
+// a block tick right away. Never blocks.
+func (p *Parser) NotifyBlock() {
+	select {
+	case p.blockNotifications <- struct{}{}:
+	default:
+		// A tick is already pending, which picks up this change as well
+	}
 }
 
 func (p *Parser) isKnownTopic(data []byte) bool {
@@ -64,24 +115,35 @@ func (p *Parser) isKnownTopic(data []byte) bool {
 	})
 }
 
+// classifiers returns the OP_RETURN classifiers for the topics known right
+// now.
+func (p *Parser) classifiers() opreturns.Classifiers {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return opreturns.NewClassifiers(lo.Map(p.topics, func(t opreturns.TopicInfo, _ int) opreturns.TopicID {
+		return t.ID
+	}))
+}
+
 // Run runs the engine. It checks if a new block has been mined,
-// and if so, handles it!
+// and if so, handles it! Checks happen whenever NotifyBlock is called, and
+// on a timer as a fallback.
 //
 // Should be started in a goroutine.
 func (p *Parser) Run(ctx context.Context) error {
-	alertTicker := time.NewTicker(2 * time.Second)
+	alertTicker := time.NewTicker(blockPollInterval)
 	defer alertTicker.Stop()
+	defer p.stopDiskSync(ctx)
 
-	topics, err := opreturns.ListTopics(ctx, p.db)
-	if err != nil {
-		return fmt.Errorf("list topics: %w", err)
+	if err := p.loadTopics(ctx); err != nil {
+		return err
+	}
+
+	// OP_RETURNs stored before we knew how to classify them
+	if err := opreturns.ClassifyUnclassified(ctx, p.db, p.classifiers()); err != nil {
+		return err
 	}
-	p.topics = lo.Map(topics, func(t opreturns.Topic, _ int) opreturns.TopicInfo {
-		return opreturns.TopicInfo{
-			ID:   t.Topic,
-			Name: t.Name,
-		}
-	})
 
 	zerolog.Ctx(ctx).Info().
 		Msgf("bitcoind_engine/parser: started parser ticker")
@@ -93,21 +155,111 @@ func (p *Parser) Run(ctx context.Context) error {
 				Msgf("bitcoind_engine/parser: stopping parser ticker")
 			return nil
 
-		case <-alertTicker.C:
+		case <-p.blockNotifications:
+			// We're being notified about new blocks, so polling is only
+			// needed as a fallback from now on.
+			alertTicker.Reset(blockPollFallbackInterval)
 
 			zerolog.Ctx(ctx).Trace().
-				Msgf("bitcoind_engine/parser: processing block tick")
+				Msgf("bitcoind_engine/parser: processing block notification")
 
-			if err := p.handleBlockTick(ctx); err != nil {
-				zerolog.Ctx(ctx).Err(err).Msgf("unable to handle block tick")
-				continue
-			}
+			p.tick(ctx)
 
-			zerolog.Ctx(ctx).Trace().
-				Msgf("bitcoind_engine/parser: finished processing block tick")
+		case <-alertTicker.C:
+			p.tick(ctx)
+		}
+	}
+}
+
+func (p *Parser) tick(ctx context.Context) {
+	zerolog.Ctx(ctx).Trace().
+		Msgf("bitcoind_engine/parser: processing block tick")
 
+	if err := p.handleBlockTick(ctx); err != nil {
+		zerolog.Ctx(ctx).Err(err).Msgf("unable to handle block tick")
+		return
+	}
+
+	zerolog.Ctx(ctx).Trace().
+		Msgf("bitcoind_engine/parser: finished processing block tick")
+
+	if time.Since(p.lastMempoolReconcile) < mempoolReconcileInterval {
+		return
+	}
+
+	if err := p.reconcileMempool(ctx); err != nil {
+		zerolog.Ctx(ctx).Err(err).Msgf("unable to reconcile mempool")
+		return
+	}
+	p.lastMempoolReconcile = time.Now()
+}
+
+// reconcileMempool marks all unconfirmed OP_RETURNs that are no longer in
+// the mempool as dropped. Must only be called when we've processed all
+// blocks, otherwise transactions that just confirmed would be marked as well.
+// Those are set back to active once their block is processed, though.
+func (p *Parser) reconcileMempool(ctx context.Context) error {
+	unconfirmed, err := opreturns.ListUnconfirmedTxIDs(ctx, p.db)
+	if err != nil {
+		return err
+	}
+
+	if len(unconfirmed) == 0 {
+		return nil
+	}
+
+	bitcoind, err := p.bitcoind.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	mempool, err := bitcoind.GetRawMempool(ctx, connect.NewRequest(&corepb.GetRawMempoolRequest{}))
+	if err != nil {
+		return fmt.Errorf("bitcoind: get raw mempool: %w", err)
+	}
+
+	inMempool := lo.SliceToMap(mempool.Msg.Txids, func(txid string) (string, struct{}) {
+		return txid, struct{}{}
+	})
+
+	for _, txid := range unconfirmed {
+		if _, ok := inMempool[txid]; ok {
+			continue
 		}
+
+		if err := p.HandleRemovedTransaction(ctx, txid); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReloadTopics picks up coin news topics that were added without the
+// parser seeing them, like imported ones.
+func (p *Parser) ReloadTopics(ctx context.Context) error {
+	return p.loadTopics(ctx)
+}
+
+// loadTopics (re)loads the known coin news topics from the database.
+func (p *Parser) loadTopics(ctx context.Context) error {
+	topics, err := opreturns.ListTopics(ctx, p.db)
+	if err != nil {
+		return fmt.Errorf("list topics: %w", err)
 	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.topics = lo.Map(topics, func(t opreturns.Topic, _ int) opreturns.TopicInfo {
+		return opreturns.TopicInfo{
+			ID:    t.Topic,
+			Name:  t.Name,
+			Owner: t.Owner,
+		}
+	})
+
+	return nil
 }
 
 // BlockResult represents the result of processing a single block
@@ -124,11 +276,13 @@ func (p *Parser) handleBlockTick(ctx context.Context) error {
 
 		zerolog.Ctx(ctx).Info().
 			Msgf("bitcoind_engine/parser: still in IBD, waiting for header download..")
+		p.setSyncState(SyncStateWaitingForIBD, nil)
 		return nil
 
 	case connect.CodeOf(err) == connect.CodeUnavailable:
 		zerolog.Ctx(ctx).Warn().Err(err).
 			Msgf("bitcoind_engine/parser: bitcoin core is not available, waiting for connection..")
+		p.setSyncState(SyncStateCoreUnavailable, err)
 		return nil
 
 	case err != nil:
@@ -157,7 +311,7 @@ func (p *Parser) handleBlockTick(ctx context.Context) error {
 	}
 
 	// Get current blockchain height
-	currentHeight, currentHash, err := p.currentHeight(ctx)
+	currentHeight, headerHeight, currentHash, err := p.currentHeight(ctx)
 	if err != nil {
 		return fmt.Errorf("fetch current height: %w", err)
 	}
@@ -165,89 +319,91 @@ func (p *Parser) handleBlockTick(ctx context.Context) error {
 	zerolog.Ctx(ctx).Trace().
 		Msgf("bitcoind_engine/parser: found current height: %d", currentHeight)
 
-	if lastProcessedHeight == currentHeight && lastProcessedHash != currentHash {
-		// probably some sort of reorg, process the last 20 blocks again!
-		// doesnt handle very deep reorgs, but thats okay
-		lastProcessedHeight -= 20
-		zerolog.Ctx(ctx).Trace().
-			Msgf("bitcoind_engine/parser: detected reorg, processing last 20 blocks")
-	}
-
-	const batchSize = 30
-
-	zerolog.Ctx(ctx).Trace().
-		Uint32("last-processed-height", lastProcessedHeight).
-		Uint32("batch-size", batchSize).
-		Msgf("bitcoind_engine/parser: processing blocks")
-
-	for batchStart := lastProcessedHeight + 1; batchStart <= currentHeight; batchStart += batchSize {
-		batchEnd := min(batchStart+batchSize-1, currentHeight)
-		if p.conf.SyncToHeight > 0 {
-			batchEnd = min(batchEnd, p.conf.SyncToHeight)
+	if lastProcessedBlock != nil {
+		reorged, err := p.isReorged(ctx, *lastProcessedBlock, currentHeight, currentHash)
+		if err != nil {
+			return fmt.Errorf("check for reorg: %w", err)
 		}
 
-		// Make sure to not apply any timeouts here. Bitcoin Core can hang in
-		// instances of Core being busy processing blocks, where RPC requests
-		// go unanswered for a little while.
-		pool := logpool.NewWithResults[lo.Tuple2[uint32, *wire.MsgBlock]](ctx, "bitcoind_engine/processBlocks").
-			WithCancelOnError().
-			WithFirstError()
-
-		for height := batchStart; height <= batchEnd; height++ {
-			pool.Go(fmt.Sprintf("block-%d", height), func(ctx context.Context) (lo.Tuple2[uint32, *wire.MsgBlock], error) {
-				log := zerolog.Ctx(ctx).With().
-					Int32("height", int32(height)).
-					Logger()
+		if reorged {
+			forkHeight, err := p.findForkPoint(ctx, min(lastProcessedHeight, currentHeight))
+			if err != nil {
+				return fmt.Errorf("find fork point: %w", err)
+			}
 
-				ctx = log.WithContext(ctx)
+			zerolog.Ctx(ctx).Info().
+				Uint32("processed-height", lastProcessedHeight).
+				Str("processed-hash", lastProcessedHash.String()).
+				Uint32("fork-height", forkHeight).
+				Msgf("bitcoind_engine/parser: detected reorg, rolling back %d blocks", lastProcessedHeight-forkHeight)
 
-				start := time.Now()
+			// The blocks we'd read from disk might not be in the best chain anymore
+			p.stopDiskSync(ctx)
 
-				zerolog.Ctx(ctx).Trace().
-					Msgf("bitcoind_engine/parser: processing block %d", height)
+			if err := p.rollbackTo(ctx, forkHeight); err != nil {
+				return fmt.Errorf("roll back to %d: %w", forkHeight, err)
+			}
 
-				block, err := p.getBlock(ctx, height)
+			reorg := Reorg{FromHeight: lastProcessedHeight, ForkHeight: forkHeight}
+			lastProcessedHeight, lastProcessedHash = forkHeight, chainhash.Hash{}
+			if forkHeight > 0 {
+				forkBlock, err := blocks.GetProcessedBlock(ctx, p.db, forkHeight)
 				if err != nil {
-					return lo.Tuple2[uint32, *wire.MsgBlock]{}, err
+					return err
 				}
+				lastProcessedHash = forkBlock.Hash
+			}
+			p.setSyncReorg(reorg, lastProcessedHash)
+		}
+	}
 
-				// If the block only has one transaction it's uninteresting,
-				// because it only has a coinbase transaction, e.g: an empty block
-				if len(block.Transactions) <= 1 {
-					return lo.T2(height, block), nil
-				}
+	// Processors that are behind, e.g. because they were just added or
+	// failed earlier, are handed the blocks they're missing first.
+	cursors, err := p.loadProcessorCursors(ctx, p.runnableProcessors())
+	if err != nil {
+		return fmt.Errorf("load processor cursors: %w", err)
+	}
 
-				log.Trace().
-					Msgf("bitcoind_engine/parser: block has more than one transaction, inspecting transactions for OP returns")
+	const batchSize = 30
 
-				for _, tx := range block.Transactions {
-					blockTime := block.Header.Timestamp
-					if err := p.opReturnForTXID(ctx, tx, &height, &blockTime); err != nil {
-						return lo.Tuple2[uint32, *wire.MsgBlock]{}, fmt.Errorf("process transaction %s: %w", tx.TxID(), err)
-					}
-				}
+	zerolog.Ctx(ctx).Trace().
+		Uint32("last-processed-height", lastProcessedHeight).
+		Uint32("next-height", nextHeight(lastProcessedHeight, cursors)).
+		Uint32("batch-size", batchSize).
+		Msgf("bitcoind_engine/parser: processing blocks")
 
-				log.Trace().
-					Msgf("bitcoind_engine/parser: finished processing %d transactions for block %d in %s",
-						len(block.Transactions), height, time.Since(start),
-					)
+	for batchStart := nextHeight(lastProcessedHeight, cursors); batchStart <= currentHeight; batchStart = nextHeight(lastProcessedHeight, cursors) {
+		p.prepareDiskSync(ctx, batchStart, currentHeight)
 
-				return lo.T2(height, block), nil
-			})
+		batchEnd := min(batchStart+batchSize-1, currentHeight)
+		if p.conf.SyncToHeight > 0 {
+			batchEnd = min(batchEnd, p.conf.SyncToHeight)
 		}
 
-		zerolog.Ctx(ctx).Trace().
-			Msgf("bitcoind_engine/parser: waiting for block processing to finish")
+		start := time.Now()
 
-		results, err := pool.Wait(ctx)
+		results, err := p.fetchBlocks(ctx, batchStart, batchEnd)
 		if err != nil {
-			return fmt.Errorf("process blocks: %w", err)
+			return err
 		}
 
-		if err := p.processBlocks(ctx, results); err != nil {
+		if err := p.processBlocks(ctx, results, lastProcessedHeight, cursors); err != nil {
 			return fmt.Errorf("process blocks: %w", err)
 		}
 
+		// Only blocks we hadn't processed before count towards the sync
+		// progress, not the ones processors are catching up on.
+		if batchEnd > lastProcessedHeight && len(results) > 0 {
+			processed := batchEnd - max(lastProcessedHeight, batchStart-1)
+			lastProcessedHeight = batchEnd
+			lastProcessedHash = results[len(results)-1].B.BlockHash()
+
+			p.setSyncProgress(
+				lastProcessedHeight, lastProcessedHash, currentHeight, headerHeight,
+				processed, time.Since(start),
+			)
+		}
+
 		if p.conf.SyncToHeight > 0 && batchEnd >= p.conf.SyncToHeight {
 			return fmt.Errorf("reached sync-to-height goal: %d", p.conf.SyncToHeight)
 		}
@@ -256,15 +412,70 @@ func (p *Parser) handleBlockTick(ctx context.Context) error {
 	zerolog.Ctx(ctx).Trace().
 		Msgf("bitcoind_engine/parser: finished processing blocks")
 
+	p.setSyncProgress(lastProcessedHeight, lastProcessedHash, currentHeight, headerHeight, 0, 0)
+
 	return nil
 }
 
-// processBlock processes a single block: checks if it contains any OP_RETURN transactions, inserts any found into the database,
-// and marks the block as processed.
-func (p *Parser) processBlocks(ctx context.Context, coreBlocks []lo.Tuple2[uint32, *wire.MsgBlock]) error {
+// fetchBlocks fetches the blocks in the given range from Bitcoin Core, in
+// order of height.
+func (p *Parser) fetchBlocks(ctx context.Context, from, to uint32) ([]lo.Tuple2[uint32, *wire.MsgBlock], error) {
+	// Make sure to not apply any timeouts here. Bitcoin Core can hang in
+	// instances of Core being busy processing blocks, where RPC requests
+	// go unanswered for a little while.
+	pool := logpool.NewWithResults[lo.Tuple2[uint32, *wire.MsgBlock]](ctx, "bitcoind_engine/fetchBlocks").
+		WithCancelOnError().
+		WithFirstError()
+
+	for height := from; height <= to; height++ {
+		pool.Go(fmt.Sprintf("block-%d", height), func(ctx context.Context) (lo.Tuple2[uint32, *wire.MsgBlock], error) {
+			log := zerolog.Ctx(ctx).With().
+				Int32("height", int32(height)).
+				Logger()
+
+			ctx = log.WithContext(ctx)
+
+			zerolog.Ctx(ctx).Trace().
+				Msgf("bitcoind_engine/parser: fetching block %d", height)
+
+			block, err := p.fetchBlock(ctx, height)
+			if err != nil {
+				return lo.Tuple2[uint32, *wire.MsgBlock]{}, err
+			}
+
+			return lo.T2(height, block), nil
+		})
+	}
+
+	zerolog.Ctx(ctx).Trace().
+		Msgf("bitcoind_engine/parser: waiting for block fetching to finish")
+
+	results, err := pool.Wait(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch blocks: %w", err)
+	}
+
+	// The pool hands back results in order of completion, but processors
+	// need the blocks in order.
+	slices.SortFunc(results, func(a, b lo.Tuple2[uint32, *wire.MsgBlock]) int {
+		return cmp.Compare(a.A, b.A)
+	})
+
+	return results, nil
+}
+
+// processBlocks marks the blocks we haven't seen before as processed, and
+// hands them to the block processors.
+func (p *Parser) processBlocks(
+	ctx context.Context, coreBlocks []lo.Tuple2[uint32, *wire.MsgBlock],
+	lastProcessedHeight uint32, cursors []*processorCursor,
+) error {
+	newBlocks := lo.Filter(coreBlocks, func(t lo.Tuple2[uint32, *wire.MsgBlock], _ int) bool {
+		return t.A > lastProcessedHeight
+	})
 
 	// Insert the processed blocks
-	if err := blocks.MarkBlocksProcessed(ctx, p.db, lo.Map(coreBlocks, func(t lo.Tuple2[uint32, *wire.MsgBlock], _ int) blocks.ProcessedBlock {
+	if err := blocks.MarkBlocksProcessed(ctx, p.db, lo.Map(newBlocks, func(t lo.Tuple2[uint32, *wire.MsgBlock], _ int) blocks.ProcessedBlock {
 		height, block := t.Unpack()
 		return blocks.ProcessedBlock{
 			Height:    height,
@@ -279,45 +490,199 @@ func (p *Parser) processBlocks(ctx context.Context, coreBlocks []lo.Tuple2[uint3
 	}
 
 	zerolog.Ctx(ctx).Trace().
-		Int32("height", int32(len(coreBlocks))).
+		Int32("height", int32(len(newBlocks))).
 		Msgf("bitcoind_engine/parser: successfully inserted blocks")
 
-	// Process M4 messages from blocks
-	for _, t := range coreBlocks {
-		height, block := t.Unpack()
-		if err := p.m4Engine.ProcessBlock(ctx, height, block); err != nil {
-			zerolog.Ctx(ctx).Warn().Err(err).
-				Uint32("height", height).
-				Msg("bitcoind_engine/parser: failed to process M4 message")
-			// Don't fail the whole batch for M4 errors
+	return p.runProcessors(ctx, cursors, coreBlocks)
+}
+
+// OPReturnIndexName is the name of the OP_RETURN block processor.
+const OPReturnIndexName = "opreturns"
+
+// opReturnProcessor stores the OP_RETURN outputs of confirmed
+// transactions, including coin news and topics.
+type opReturnProcessor struct {
+	parser *Parser
+
+	// Txids of the file timestamps waiting for a confirmation, loaded once
+	// per batch
+	unconfirmedTimestamps map[string]bool
+}
+
+var _ BatchBlockProcessor = new(opReturnProcessor)
+
+func (o *opReturnProcessor) StartBatch() {
+	o.unconfirmedTimestamps = nil
+}
+
+func (o *opReturnProcessor) Name() string {
+	return OPReturnIndexName
+}
+
+func (o *opReturnProcessor) ProcessBlock(ctx context.Context, height uint32, block *wire.MsgBlock) error {
+	// If the block only has one transaction it's uninteresting,
+	// because it only has a coinbase transaction, e.g: an empty block
+	if len(block.Transactions) <= 1 {
+		return nil
+	}
+
+	blockTime := block.Header.Timestamp
+	classifiers := o.parser.classifiers()
+	for _, tx := range block.Transactions {
+		if err := o.parser.opReturnForTXID(ctx, tx, &classifiers, &height, &blockTime); err != nil {
+			return fmt.Errorf("process transaction %s: %w", tx.TxID(), err)
+		}
+	}
+
+	// Any unconfirmed OP_RETURN spending the same inputs as a transaction
+	// in this block is never going to confirm.
+	if err := o.parser.markReplacements(ctx, block.Transactions); err != nil {
+		return fmt.Errorf("mark replaced OP_RETURNs: %w", err)
+	}
+
+	if o.unconfirmedTimestamps == nil {
+		unconfirmed, err := timestamps.ListUnconfirmedTxIDs(ctx, o.parser.db)
+		if err != nil {
+			return err
 		}
+		o.unconfirmedTimestamps = unconfirmed
+	}
+	if err := o.parser.confirmTimestamps(ctx, height, block, o.unconfirmedTimestamps); err != nil {
+		return fmt.Errorf("confirm timestamps: %w", err)
 	}
 
 	return nil
 }
 
+func (o *opReturnProcessor) Rollback(ctx context.Context, height uint32) error {
+	o.unconfirmedTimestamps = nil
+
+	if err := opreturns.DeleteAboveHeight(ctx, o.parser.db, height); err != nil {
+		return fmt.Errorf("delete OP_RETURNs: %w", err)
+	}
+
+	return o.parser.loadTopics(ctx)
+}
+
+// confirmTimestamps confirms the file timestamps with a transaction in the
+// block. unconfirmed holds the txids of the timestamps still waiting for a
+// confirmation, and the confirmed ones are removed from it.
+func (p *Parser) confirmTimestamps(ctx context.Context, height uint32, block *wire.MsgBlock, unconfirmed map[string]bool) error {
+	if len(unconfirmed) == 0 {
+		return nil
+	}
+
+	var txids []string
+	for _, tx := range block.Transactions {
+		if txid := tx.TxID(); unconfirmed[txid] {
+			txids = append(txids, txid)
+		}
+	}
+
+	_, err := timestamps.Confirm(ctx, p.db, txids, height, block.BlockHash().String(), block.Header.Timestamp)
+	if err != nil {
+		return err
+	}
+	for _, txid := range txids {
+		delete(unconfirmed, txid)
+	}
+	return nil
+}
+
 // HandleNewRawTransaction can be called on a brand new transaction
 // from the mempool.
 func (p *Parser) HandleNewRawTransaction(
 	ctx context.Context, tx *wire.MsgTx,
 ) error {
-	if err := p.opReturnForTXID(ctx, tx, nil, nil); err != nil {
+	classifiers := p.classifiers()
+	if err := p.opReturnForTXID(ctx, tx, &classifiers, nil, nil); err != nil {
 		return fmt.Errorf("find op return for txid: %w", err)
 	}
 
+	if err := p.markReplacements(ctx, []*wire.MsgTx{tx}); err != nil {
+		return fmt.Errorf("mark replaced OP_RETURNs: %w", err)
+	}
+
+	if err := opreturns.PersistSpends(ctx, p.db, tx); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-// Nil height means unconfirmed. Nil time means we don't know the TX time
+// HandleRemovedTransaction can be called when a transaction is removed from
+// the mempool for any other reason than being included in a block.
+func (p *Parser) HandleRemovedTransaction(ctx context.Context, txid string) error {
+	dropped, err := opreturns.MarkDropped(ctx, p.db, txid)
+	if err != nil {
+		return err
+	}
+
+	if dropped {
+		zerolog.Ctx(ctx).Info().
+			Str("txid", txid).
+			Msgf("bitcoind_engine/parser: OP_RETURN transaction dropped from mempool")
+	}
+
+	if _, err := timestamps.MarkFailed(ctx, p.db, txid, "dropped from the mempool"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// markReplacements marks unconfirmed OP_RETURNs as replaced, if any of the
+// given transactions spends the same outpoints.
+func (p *Parser) markReplacements(ctx context.Context, txs []*wire.MsgTx) error {
+	spends, err := opreturns.ListUnconfirmedSpends(ctx, p.db)
+	if err != nil {
+		return err
+	}
+
+	if len(spends) == 0 {
+		return nil
+	}
+
+	for _, tx := range txs {
+		txid := tx.TxID()
+		for _, in := range tx.TxIn {
+			spender, ok := spends[in.PreviousOutPoint]
+			if !ok || spender == txid {
+				continue
+			}
+
+			replaced, err := opreturns.MarkReplaced(ctx, p.db, spender, txid)
+			if err != nil {
+				return err
+			}
+
+			if replaced {
+				zerolog.Ctx(ctx).Info().
+					Str("txid", spender).
+					Str("replaced-by", txid).
+					Msgf("bitcoind_engine/parser: OP_RETURN transaction replaced")
+			}
+
+			if _, err := timestamps.MarkFailed(ctx, p.db, spender, "replaced by "+txid); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Nil height means unconfirmed. Nil time means we don't know the TX time.
+// Classifiers are rebuilt when a topic is created, see handleOpReturns.
 func (p *Parser) opReturnForTXID(
-	ctx context.Context, tx *wire.MsgTx,
+	ctx context.Context, tx *wire.MsgTx, classifiers *opreturns.Classifiers,
 	height *uint32, createdAt *time.Time,
 ) error {
 	if createdAt != nil && createdAt.IsZero() {
 		panic("PROGRAMMER ERROR: non-nil, zero create time")
 	}
 
-	opReturns, err := p.handleOpReturns(ctx, tx, height)
+	opReturns, err := p.handleOpReturns(ctx, tx, classifiers, height)
 	if err != nil {
 		return fmt.Errorf("find OP_RETURNs: %w", err)
 	}
@@ -326,6 +691,12 @@ func (p *Parser) opReturnForTXID(
 		return err
 	}
 
+	if len(opReturns) > 0 {
+		if err := p.notifyOPReturns(ctx, tx.TxID(), height != nil); err != nil {
+			return fmt.Errorf("notify OP_RETURN watchers: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -336,55 +707,158 @@ func (p *Parser) handleCreateTopic(
 	zerolog.Ctx(ctx).Info().
 		Msgf("bitcoind_engine/parser: found create topic: %s", info.Name)
 
-	if err := opreturns.CreateTopic(
-		ctx, p.db, info.ID, info.Name, txid,
-	); err != nil {
+	if err := opreturns.CreateOwnedTopic(ctx, p.db, info, txid); err != nil {
 		return fmt.Errorf("persist create topic: %w", err)
 	}
 
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	p.topics = append(p.topics, opreturns.TopicInfo{
-		ID:   info.ID,
-		Name: info.Name,
-	})
+	p.topics = append(p.topics, info)
 
 	return nil
 }
 
-func (p *Parser) currentHeight(ctx context.Context) (uint32, chainhash.Hash, error) {
+// currentHeight returns the height and hash of the best chain, as well as
+// how many headers Bitcoin Core knows about.
+func (p *Parser) currentHeight(ctx context.Context) (uint32, uint32, chainhash.Hash, error) {
 	bitcoind, err := p.bitcoind.Get(ctx)
 	if err != nil {
-		return 0, chainhash.Hash{}, err
+		return 0, 0, chainhash.Hash{}, err
 	}
 
 	resp, err := bitcoind.GetBlockchainInfo(ctx, &connect.Request[corepb.GetBlockchainInfoRequest]{})
 	if err != nil {
-		return 0, chainhash.Hash{}, err
+		return 0, 0, chainhash.Hash{}, err
 	}
 
 	hash, err := chainhash.NewHashFromStr(resp.Msg.BestBlockHash)
 	if err != nil {
-		return 0, chainhash.Hash{}, fmt.Errorf("parse best block hash: %w", err)
+		return 0, 0, chainhash.Hash{}, fmt.Errorf("parse best block hash: %w", err)
+	}
+
+	return resp.Msg.Blocks, resp.Msg.Headers, *hash, nil
+}
+
+// isReorged checks whether the block we last processed is still part of the
+// best chain.
+func (p *Parser) isReorged(
+	ctx context.Context, tip blocks.ProcessedBlock,
+	currentHeight uint32, currentHash chainhash.Hash,
+) (bool, error) {
+	switch {
+	case tip.Height > currentHeight:
+		// The best chain is shorter than what we've processed
+		return true, nil
+
+	case tip.Height == currentHeight:
+		return tip.Hash != currentHash, nil
+	}
+
+	hash, err := p.getBlockHash(ctx, tip.Height)
+	if err != nil {
+		return false, err
+	}
+
+	return tip.Hash != hash, nil
+}
+
+// findForkPoint walks processed_blocks backwards from the given height,
+// comparing each stored hash with the hash Bitcoin Core has at the same
+// height. It returns the highest height where the two agree, or 0 if they
+// don't agree on anything.
+func (p *Parser) findForkPoint(ctx context.Context, from uint32) (uint32, error) {
+	for height := from; height > 0; height-- {
+		processed, err := blocks.GetProcessedBlock(ctx, p.db, height)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		} else if err != nil {
+			return 0, err
+		}
+
+		hash, err := p.getBlockHash(ctx, height)
+		if err != nil {
+			return 0, err
+		}
+
+		if processed.Hash == hash {
+			return height, nil
+		}
+
+		zerolog.Ctx(ctx).Debug().
+			Uint32("height", height).
+			Str("processed-hash", processed.Hash.String()).
+			Str("core-hash", hash.String()).
+			Msgf("bitcoind_engine/parser: block is no longer in the best chain")
+	}
+
+	return 0, nil
+}
+
+// rollbackTo removes everything derived from blocks strictly above the given
+// height, so that those blocks are processed again from the best chain.
+// Derived data is removed before the processed blocks themselves, such that
+// an interrupted rollback is detected and redone on the next tick.
+func (p *Parser) rollbackTo(ctx context.Context, height uint32) error {
+	if err := p.rollbackProcessors(ctx, height); err != nil {
+		return fmt.Errorf("roll back block processors: %w", err)
+	}
+
+	if err := timestamps.UnconfirmAboveHeight(ctx, p.db, height); err != nil {
+		return fmt.Errorf("unconfirm timestamps: %w", err)
+	}
+
+	if err := blocks.DeleteProcessedBlocksAbove(ctx, p.db, height); err != nil {
+		return fmt.Errorf("delete processed blocks: %w", err)
 	}
 
-	return resp.Msg.Blocks, *hash, nil
+	return nil
+}
+
+func (p *Parser) getBlockHash(ctx context.Context, height uint32) (chainhash.Hash, error) {
+	return fetchBlockHash(ctx, p.bitcoind, height)
 }
 
 func (p *Parser) getBlock(ctx context.Context, height uint32) (*wire.MsgBlock, error) {
-	start := time.Now()
+	return fetchBlock(ctx, p.bitcoind, height)
+}
 
-	bitcoind, err := p.bitcoind.Get(ctx)
+func fetchBlockHash(
+	ctx context.Context, svc *service.Service[corerpc.BitcoinServiceClient], height uint32,
+) (chainhash.Hash, error) {
+	bitcoind, err := svc.Get(ctx)
 	if err != nil {
-		return nil, err
+		return chainhash.Hash{}, err
 	}
 
-	hash, err := bitcoind.GetBlockHash(ctx, connect.NewRequest(&corepb.GetBlockHashRequest{
+	resp, err := bitcoind.GetBlockHash(ctx, connect.NewRequest(&corepb.GetBlockHashRequest{
 		Height: height,
 	}))
 	if err != nil {
-		return nil, fmt.Errorf("bitcoind: get block hash %d: %w", height, err)
+		return chainhash.Hash{}, fmt.Errorf("bitcoind: get block hash %d: %w", height, err)
+	}
+
+	hash, err := chainhash.NewHashFromStr(resp.Msg.Hash)
+	if err != nil {
+		return chainhash.Hash{}, fmt.Errorf("parse block hash %d: %w", height, err)
+	}
+
+	return *hash, nil
+}
+
+func fetchBlock(
+	ctx context.Context, svc *service.Service[corerpc.BitcoinServiceClient], height uint32,
+) (*wire.MsgBlock, error) {
+	start := time.Now()
+
+	hash, err := fetchBlockHash(ctx, svc, height)
+	if err != nil {
+		return nil, err
+	}
+
+	bitcoind, err := svc.Get(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	// We want to minimize the network call count. We therefore fetch the raw
@@ -393,7 +867,7 @@ func (p *Parser) getBlock(ctx context.Context, height uint32) (*wire.MsgBlock, e
 	const verbosity = corepb.GetBlockRequest_VERBOSITY_RAW_DATA
 	resp, err := bitcoind.GetBlock(ctx, &connect.Request[corepb.GetBlockRequest]{
 		Msg: &corepb.GetBlockRequest{
-			Hash:      hash.Msg.Hash,
+			Hash:      hash.String(),
 			Verbosity: verbosity,
 		},
 	})
@@ -416,9 +890,11 @@ func (p *Parser) getBlock(ctx context.Context, height uint32) (*wire.MsgBlock, e
 	return &msgBlock, nil
 }
 
-// finds all OP_RETURN outputs for a specific tx
+// finds all OP_RETURN outputs for a specific tx. The classifiers are shared
+// by all transactions in a block, and are rebuilt when a topic is created so
+// posts to it later in the block are recognised.
 func (p *Parser) handleOpReturns(
-	ctx context.Context, tx *wire.MsgTx, height *uint32,
+	ctx context.Context, tx *wire.MsgTx, classifiers *opreturns.Classifiers, height *uint32,
 ) ([]opreturns.OPReturn, error) {
 	txid := tx.TxID()
 
@@ -440,6 +916,10 @@ func (p *Parser) handleOpReturns(
 	// Only fetch this a single time if handling multiple outputs
 	var rawTx *corepb.GetRawTransactionResponse
 
+	// Coin news is ranked by fee rate
+	weight := blockchain.GetTransactionWeight(btcutil.NewTx(tx))
+	vsize := (weight + blockchain.WitnessScaleFactor - 1) / blockchain.WitnessScaleFactor
+
 	var opReturns []opreturns.OPReturn
 	for vout, txout := range tx.TxOut {
 		if len(txout.PkScript) < 2 {
@@ -451,10 +931,6 @@ func (p *Parser) handleOpReturns(
 		if isCoinbaseReturn {
 			continue
 		}
-		if shouldSkip(txout.PkScript) {
-			continue
-		}
-
 		if !isOPReturn {
 			continue
 		}
@@ -465,11 +941,13 @@ func (p *Parser) handleOpReturns(
 		if !ok {
 			continue
 		}
+		classification := classifiers.Classify(txout.PkScript, data)
 
 		if info, ok := opreturns.IsCreateTopic(data); ok {
 			if err := p.handleCreateTopic(ctx, info, txid); err != nil {
 				return nil, err
 			}
+			*classifiers = p.classifiers()
 		}
 
 		zerolog.Ctx(ctx).Debug().
@@ -480,8 +958,9 @@ func (p *Parser) handleOpReturns(
 		var fee btcutil.Amount
 
 		// If this is a coin news message, we need to figoure out the fee
-		// paid.
-		if p.isKnownTopic(data) {
+		// paid. Chunks might be part of one.
+		_, isChunk := opreturns.ParseChunk(data)
+		if p.isKnownTopic(data) || isChunk {
 			if rawTx == nil {
 				core, err := p.bitcoind.Get(ctx)
 				if err != nil {
@@ -506,11 +985,14 @@ func (p *Parser) handleOpReturns(
 		}
 
 		opReturns = append(opReturns, opreturns.OPReturn{
-			TxID:   txid,
-			Data:   data,
-			Vout:   int32(vout),
-			Height: height,
-			Fee:    fee,
+			TxID:     txid,
+			Data:     data,
+			Vout:     int32(vout),
+			Height:   height,
+			Fee:      fee,
+			VSize:    vsize,
+			Protocol: classification.Protocol,
+			Fields:   classification.Fields,
 		})
 	}
 
@@ -524,6 +1006,10 @@ func parseOPReturnData(script []byte) ([]byte, bool) {
 		return nil, false
 	}
 
+	if payload, ok := opreturns.RunestonePayload(script); ok {
+		return payload, payload != nil
+	}
+
 	// Skip OP_RETURN
 	script = script[1:]
 
@@ -578,32 +1064,9 @@ func parseOPReturnData(script []byte) ([]byte, bool) {
 	}
 }
 
-func shouldSkip(pkScript []byte) bool {
-	data := pkScript[2:]
-	// heck if i know what these are, but they are related to sidechains!
-	switch {
-	case strings.HasPrefix(opreturns.OPReturnToReadable(data), "d1617368"):
-		return true
-	case strings.HasPrefix(opreturns.OPReturnToReadable(data), "d77d177601"):
-		return true
-		// thunder sidechain creation
-	case opreturns.OPReturnToReadable(data) == "d6e1c5df09879b5f8ebc8bab50ca9218849c2e173a596d1ac0e84b9f10981c3078da6571af":
-		return true
-	case opreturns.OPReturnToReadable(data) == "d6e1c5df02f82573d5420e910fe350d5e1b61da16551a269d682b51f35f2343c265285c056":
-		return true
-	case opreturns.OPReturnToReadable(data) == "64656164626565664e6f7277656769616e2042c3b8726765204272656e6465206265636f6d657320707265736964656e74206f6620574546":
-		return true
-	case opreturns.OPReturnToReadable(data) == "d5e0c4af0900077468756e6465727468756e646572206465736372697074696f6e00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000":
-		return true
-	case opreturns.OPReturnToReadable(data) == "d45aa943091303c6de5739c2fb3a021a8bd2b8c9fa8bcd8f8c18954bec00aa8f9b2cf13602":
-		return true
-	}
-	return false
-}
-
 // ensureSyncIsHealthy checks if the hash of block at height 1 differs
-// from whats in our database. If so, it wipes everything in processed_blocks,
-// to force a re-sync.
+// from whats in our database. If so, it rolls back everything we've
+// processed, to force a re-sync.
 func (p *Parser) ensureSyncIsHealthy(ctx context.Context) error {
 	// Get block at height 1 to check for chain switch
 	block1, err := p.getBlock(ctx, 1)
@@ -619,6 +1082,7 @@ func (p *Parser) ensureSyncIsHealthy(ctx context.Context) error {
 	savedBlock1, err := blocks.GetProcessedBlock(ctx, p.db, 1)
 	if errors.Is(err, sql.ErrNoRows) {
 		// no blocks have been processed yet
+		return nil
 	} else if err != nil {
 		return fmt.Errorf("detect chain deletion: get latest processed height: %w", err)
 	}
@@ -626,7 +1090,7 @@ func (p *Parser) ensureSyncIsHealthy(ctx context.Context) error {
 	if block1 == nil || !savedBlock1.Hash.IsEqual(lo.ToPtr(block1.Header.BlockHash())) {
 		zerolog.Ctx(ctx).Info().
 			Msgf("bitcoind_engine/parser: detected chain switch, reprocessing all blocks")
-		return blocks.WipeProcessedBlocks(ctx, p.db)
+		return p.rollbackTo(ctx, 0)
 	}
 
 	return nil
@@ -5,10 +5,13 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blocks"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/opreturns"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/timestamps"
 	service "github.com/LayerTwo-Labs/sidesail/bitwindow/server/service"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests/mocks"
@@ -86,6 +89,11 @@ func TestOpReturnHandling(t *testing.T) {
 				Value:    0,
 				PkScript: pkScript(t, opreturns.EncodeNewsMessage(newTopicID, "The New Topic", "The New Content")),
 			},
+			{
+				Value: 0,
+				// Runestone, which doesn't push its data right after OP_RETURN
+				PkScript: []byte{txscript.OP_RETURN, txscript.OP_13, txscript.OP_DATA_2, 0, 1},
+			},
 		},
 	}
 
@@ -103,7 +111,8 @@ func TestOpReturnHandling(t *testing.T) {
 	// 2. Skipped coins news for an unknown topic
 	// 3. Created the brand new topic
 	// 4. Persisted coin news for the new topic
-	require.NoError(t, parser.opReturnForTXID(ctx, tx, nil, nil))
+	classifiers := parser.classifiers()
+	require.NoError(t, parser.opReturnForTXID(ctx, tx, &classifiers, nil, nil))
 
 	assert.Len(t, parser.topics, 2)
 
@@ -127,6 +136,180 @@ func TestOpReturnHandling(t *testing.T) {
 
 	assert.Equal(t, "The Known Topic", news[0].Headline)
 	assert.Equal(t, "The New Topic", news[1].Headline)
+
+	// Every OP_RETURN is classified as it's stored
+	stored, err := opreturns.List(ctx, db)
+	require.NoError(t, err)
+	protocols := lo.SliceToMap(stored, func(opReturn opreturns.OPReturn) (int32, opreturns.Protocol) {
+		return opReturn.Vout, opReturn.Protocol
+	})
+	assert.Equal(t, map[int32]opreturns.Protocol{
+		0: opreturns.ProtocolCoinNews,
+		// Unknown topic, so just bytes
+		1: opreturns.ProtocolUnknown,
+		2: opreturns.ProtocolCoinNewsTopic,
+		3: opreturns.ProtocolCoinNews,
+		4: opreturns.ProtocolRunes,
+	}, protocols)
+}
+
+func TestReorgRollback(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := database.Test(t)
+
+	core := mocks.NewMockBitcoinServiceClient(gomock.NewController(t))
+
+	parser := &Parser{
+		db: db,
+		bitcoind: service.New("bitcoind", func(ctx context.Context) (corerpc.BitcoinServiceClient, error) {
+			return core, nil
+		}),
+	}
+	parser.RegisterProcessor(&opReturnProcessor{parser: parser})
+	parser.RegisterProcessor(NewM4Engine(db))
+
+	hashAt := func(height uint32, fork byte) chainhash.Hash {
+		return chainhash.Hash{byte(height), fork}
+	}
+
+	// We've processed blocks 1-5, and the chain has since forked off at
+	// height 2.
+	require.NoError(t, blocks.MarkBlocksProcessed(ctx, db, lo.Map([]uint32{1, 2, 3, 4, 5}, func(height uint32, _ int) blocks.ProcessedBlock {
+		return blocks.ProcessedBlock{
+			Height:    height,
+			Hash:      hashAt(height, 0),
+			BlockTime: time.Now(),
+		}
+	})))
+	for _, name := range []string{"opreturns", "m4"} {
+		require.NoError(t, blocks.SetProcessorCursor(ctx, db, name, 5, hashAt(5, 0)))
+	}
+	for height := uint32(5); height >= 2; height-- {
+		fork := byte(1)
+		if height == 2 {
+			fork = 0
+		}
+		core.EXPECT().
+			GetBlockHash(gomock.Any(), tests.Connect(&corepb.GetBlockHashRequest{Height: height})).
+			Return(connect.NewResponse(&corepb.GetBlockHashResponse{
+				Hash: hashAt(height, fork).String(),
+			}), nil)
+	}
+
+	topicID, err := opreturns.ValidNewsTopicID("deadbeefdeadbeef")
+	require.NoError(t, err)
+	require.NoError(t, opreturns.CreateTopic(ctx, db, topicID, "Stale Topic", "stale-topic-txid"))
+	require.NoError(t, opreturns.Persist(ctx, db, []opreturns.OPReturn{
+		{TxID: "kept-txid", Vout: 0, Data: []byte("kept"), Height: lo.ToPtr(uint32(2))},
+		{TxID: "stale-txid", Vout: 0, Data: []byte("stale"), Height: lo.ToPtr(uint32(4))},
+		{TxID: "stale-topic-txid", Vout: 0, Data: opreturns.EncodeTopicCreationMessage(topicID, "Stale Topic"), Height: lo.ToPtr(uint32(5))},
+		{TxID: "mempool-txid", Vout: 0, Data: []byte("mempool")},
+	}))
+
+	timestampID, err := timestamps.Create(ctx, db, timestamps.FileTimestamp{
+		Filename:  "file.txt",
+		FileHash:  "deadbeef",
+		TxID:      lo.ToPtr("stale-txid"),
+		Status:    timestamps.StatusConfirming,
+		CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, timestamps.Update(ctx, db, timestampID, nil, lo.ToPtr(int64(4)), timestamps.StatusConfirmed, lo.ToPtr(time.Now())))
+
+	forkHeight, err := parser.findForkPoint(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), forkHeight)
+
+	require.NoError(t, parser.rollbackTo(ctx, forkHeight))
+
+	tip, err := blocks.GetProcessedTip(ctx, db)
+	require.NoError(t, err)
+	require.NotNil(t, tip)
+	assert.Equal(t, uint32(2), tip.Height)
+
+	for _, name := range []string{"opreturns", "m4"} {
+		cursor, err := blocks.GetProcessorCursor(ctx, db, name)
+		require.NoError(t, err)
+		require.NotNil(t, cursor)
+		assert.Equal(t, uint32(2), cursor.Height, name)
+		assert.Equal(t, hashAt(2, 0), cursor.Hash, name)
+	}
+
+	remaining, err := opreturns.List(ctx, db)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"kept-txid", "mempool-txid"}, lo.Map(remaining, func(o opreturns.OPReturn, _ int) string {
+		return o.TxID
+	}))
+
+	exists, err := opreturns.TopicExists(ctx, db, topicID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	assert.False(t, lo.ContainsBy(parser.topics, func(t opreturns.TopicInfo) bool {
+		return t.ID == topicID
+	}))
+
+	timestamp, err := timestamps.Get(ctx, db, timestampID)
+	require.NoError(t, err)
+	assert.Equal(t, timestamps.StatusConfirming, timestamp.Status)
+	assert.Nil(t, timestamp.BlockHeight)
+	assert.Nil(t, timestamp.ConfirmedAt)
+}
+
+func TestMempoolReplacement(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := database.Test(t)
+
+	parser := &Parser{db: db}
+
+	spending := func(prev wire.OutPoint, outs ...*wire.TxOut) *wire.MsgTx {
+		return &wire.MsgTx{
+			TxIn:  []*wire.TxIn{{PreviousOutPoint: prev}},
+			TxOut: outs,
+		}
+	}
+	opReturn := func(data string) *wire.TxOut {
+		return &wire.TxOut{PkScript: pkScript(t, []byte(data))}
+	}
+
+	shared := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 0}
+
+	original := spending(shared, opReturn("original"))
+	evicted := spending(wire.OutPoint{Hash: chainhash.Hash{2}, Index: 0}, opReturn("evicted"))
+	require.NoError(t, parser.HandleNewRawTransaction(ctx, original))
+	require.NoError(t, parser.HandleNewRawTransaction(ctx, evicted))
+
+	// Dropped from the mempool, and replaced by a transaction without any
+	// OP_RETURN
+	require.NoError(t, parser.HandleRemovedTransaction(ctx, original.TxID()))
+	replacement := spending(shared, &wire.TxOut{Value: 1000, PkScript: []byte{txscript.OP_TRUE}})
+	require.NoError(t, parser.HandleNewRawTransaction(ctx, replacement))
+
+	require.NoError(t, parser.HandleRemovedTransaction(ctx, evicted.TxID()))
+
+	all, err := opreturns.List(ctx, db)
+	require.NoError(t, err)
+	byTxID := lo.KeyBy(all, func(o opreturns.OPReturn) string { return o.TxID })
+	require.Len(t, byTxID, 2)
+
+	assert.Equal(t, opreturns.StatusReplaced, byTxID[original.TxID()].Status)
+	assert.Equal(t, lo.ToPtr(replacement.TxID()), byTxID[original.TxID()].ReplacedByTxID)
+
+	assert.Equal(t, opreturns.StatusDropped, byTxID[evicted.TxID()].Status)
+	assert.Nil(t, byTxID[evicted.TxID()].ReplacedByTxID)
+
+	// Showing up again, for example in a block, makes it active again
+	require.NoError(t, parser.HandleNewRawTransaction(ctx, evicted))
+
+	all, err = opreturns.List(ctx, db)
+	require.NoError(t, err)
+	byTxID = lo.KeyBy(all, func(o opreturns.OPReturn) string { return o.TxID })
+	assert.Equal(t, opreturns.StatusActive, byTxID[evicted.TxID()].Status)
 }
 
 func pkScript(t *testing.T, data []byte) []byte {
@@ -0,0 +1,301 @@
+package engines
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blocks"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+)
+
+// BlockProcessor derives state from the blocks in the best chain. Each
+// processor has its own cursor, so it can be added to an existing database
+// or re-run without touching the other processors.
+type BlockProcessor interface {
+	// Name identifies the processor, and is the key of its cursor. Must
+	// never change.
+	Name() string
+
+	// ProcessBlock is called once for every block in the best chain, in
+	// order of height.
+	ProcessBlock(ctx context.Context, height uint32, block *wire.MsgBlock) error
+
+	// Rollback removes all state derived from blocks strictly above the
+	// given height.
+	Rollback(ctx context.Context, height uint32) error
+}
+
+// TxBlockProcessor is a BlockProcessor whose state can't be derived twice
+// from the same block, e.g. because it keeps running totals. Its writes and
+// the cursor update are committed in one transaction, such that a block is
+// either fully applied and skipped from then on, or not applied at all.
+type TxBlockProcessor interface {
+	BlockProcessor
+
+	// ProcessBlockTx is like ProcessBlock, but writes through the given
+	// transaction. It must not commit or roll it back.
+	ProcessBlockTx(ctx context.Context, tx *sql.Tx, height uint32, block *wire.MsgBlock) error
+}
+
+// BatchBlockProcessor is a BlockProcessor that keeps state for a batch of
+// blocks, rather than loading it for every block.
+type BatchBlockProcessor interface {
+	BlockProcessor
+
+	// StartBatch is called before a processor is handed the blocks of a
+	// batch. Whatever it kept for the previous batch can be out of date.
+	StartBatch()
+}
+
+// How long to wait before handing a processor more blocks, after it
+// failed to process one.
+const processorRetryInterval = time.Minute
+
+// RegisterProcessor adds a processor to the block pipeline. Processors that
+// are behind the rest, e.g. because they are new, catch up on their own.
+//
+// Must be called before Run.
+func (p *Parser) RegisterProcessor(processor BlockProcessor) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.processors = append(p.processors, processor)
+}
+
+// RewindProcessor rolls back a single processor to the given height, such
+// that it processes all blocks above it again.
+func (p *Parser) RewindProcessor(ctx context.Context, name string, height uint32) error {
+	processor, ok := lo.Find(p.registeredProcessors(), func(processor BlockProcessor) bool {
+		return processor.Name() == name
+	})
+	if !ok {
+		return fmt.Errorf("unknown block processor: %q", name)
+	}
+
+	return p.rewindProcessor(ctx, processor, height)
+}
+
+func (p *Parser) registeredProcessors() []BlockProcessor {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return slices.Clone(p.processors)
+}
+
+// rewindProcessor rolls back the given processor to the given height. Does
+// nothing if the processor hasn't gotten further than that.
+func (p *Parser) rewindProcessor(ctx context.Context, processor BlockProcessor, height uint32) error {
+	cursor, err := blocks.GetProcessorCursor(ctx, p.db, processor.Name())
+	if err != nil {
+		return err
+	}
+
+	if cursor == nil || cursor.Height <= height {
+		return nil
+	}
+
+	if err := processor.Rollback(ctx, height); err != nil {
+		return fmt.Errorf("roll back %s: %w", processor.Name(), err)
+	}
+
+	if height == 0 {
+		return blocks.DeleteProcessorCursor(ctx, p.db, processor.Name())
+	}
+
+	block, err := blocks.GetProcessedBlock(ctx, p.db, height)
+	if err != nil {
+		return err
+	}
+
+	return blocks.SetProcessorCursor(ctx, p.db, processor.Name(), height, block.Hash)
+}
+
+// rollbackProcessors rolls back all processors that have processed blocks
+// above the given height.
+func (p *Parser) rollbackProcessors(ctx context.Context, height uint32) error {
+	for _, processor := range p.registeredProcessors() {
+		if err := p.rewindProcessor(ctx, processor, height); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// processorCursor is the progress of a single processor within a block tick.
+type processorCursor struct {
+	processor BlockProcessor
+	height    uint32
+
+	// Set if the processor failed during this tick. It's not handed any
+	// more blocks until it's retried.
+	err error
+}
+
+// runnableProcessors returns the registered processors, except the ones
+// that failed recently.
+func (p *Parser) runnableProcessors() []BlockProcessor {
+	return lo.Reject(p.registeredProcessors(), func(processor BlockProcessor, _ int) bool {
+		return time.Now().Before(p.processorRetryAt[processor.Name()])
+	})
+}
+
+// loadProcessorCursors returns the cursors of the given processors.
+func (p *Parser) loadProcessorCursors(ctx context.Context, processors []BlockProcessor) ([]*processorCursor, error) {
+	var cursors []*processorCursor
+	for _, processor := range processors {
+		cursor, err := blocks.GetProcessorCursor(ctx, p.db, processor.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		var height uint32
+		if cursor != nil {
+			height = cursor.Height
+
+			// Processors that weren't registered during a reorg can be
+			// left on a stale chain. We don't know where they forked off,
+			// so they have to start over.
+			processed, err := p.isProcessedBlock(ctx, cursor.Height, cursor.Hash)
+			if err != nil {
+				return nil, err
+			}
+
+			if !processed {
+				zerolog.Ctx(ctx).Warn().
+					Str("processor", processor.Name()).
+					Uint32("height", cursor.Height).
+					Str("hash", cursor.Hash.String()).
+					Msgf("bitcoind_engine/parser: block processor is not on the best chain, starting over")
+
+				if err := p.rewindProcessor(ctx, processor, 0); err != nil {
+					return nil, err
+				}
+				height = 0
+			}
+		}
+
+		cursors = append(cursors, &processorCursor{
+			processor: processor,
+			height:    height,
+		})
+	}
+
+	return cursors, nil
+}
+
+func (p *Parser) isProcessedBlock(ctx context.Context, height uint32, hash chainhash.Hash) (bool, error) {
+	block, err := blocks.GetProcessedBlock(ctx, p.db, height)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return block.Hash == hash, nil
+}
+
+// nextHeight returns the lowest height that's either not processed yet, or
+// that's needed by a processor to catch up.
+func nextHeight(processedHeight uint32, cursors []*processorCursor) uint32 {
+	next := processedHeight
+	for _, cursor := range cursors {
+		if cursor.err == nil {
+			next = min(next, cursor.height)
+		}
+	}
+
+	return next + 1
+}
+
+// runProcessors hands the given blocks to all processors that haven't
+// processed them yet. A processor that fails is skipped until it's retried,
+// without affecting the other processors.
+func (p *Parser) runProcessors(
+	ctx context.Context, cursors []*processorCursor,
+	coreBlocks []lo.Tuple2[uint32, *wire.MsgBlock],
+) error {
+	for _, cursor := range cursors {
+		name := cursor.processor.Name()
+		if batchProcessor, ok := cursor.processor.(BatchBlockProcessor); ok {
+			batchProcessor.StartBatch()
+		}
+
+		for _, t := range coreBlocks {
+			if cursor.err != nil {
+				break
+			}
+
+			height, block := t.Unpack()
+			if height <= cursor.height {
+				continue
+			}
+
+			if height != cursor.height+1 {
+				return fmt.Errorf("block processor %s: expected block %d, got %d", name, cursor.height+1, height)
+			}
+
+			start := time.Now()
+			if err := p.processBlock(ctx, cursor.processor, height, block); err != nil {
+				zerolog.Ctx(ctx).Error().Err(err).
+					Str("processor", name).
+					Uint32("height", height).
+					Msgf("bitcoind_engine/parser: block processor failed, retrying in %s", processorRetryInterval)
+
+				cursor.err = fmt.Errorf("block processor %s: process block %d: %w", name, height, err)
+				if p.processorRetryAt == nil {
+					p.processorRetryAt = make(map[string]time.Time)
+				}
+				p.processorRetryAt[name] = time.Now().Add(processorRetryInterval)
+				break
+			}
+			cursor.height = height
+
+			zerolog.Ctx(ctx).Trace().
+				Str("processor", name).
+				Msgf("bitcoind_engine/parser: processed block %d in %s", height, time.Since(start))
+		}
+
+		if cursor.err == nil {
+			delete(p.processorRetryAt, name)
+		}
+	}
+
+	return nil
+}
+
+// processBlock hands a single block to the given processor, and moves its
+// cursor past it.
+func (p *Parser) processBlock(ctx context.Context, processor BlockProcessor, height uint32, block *wire.MsgBlock) error {
+	txProcessor, ok := processor.(TxBlockProcessor)
+	if !ok {
+		if err := processor.ProcessBlock(ctx, height, block); err != nil {
+			return err
+		}
+
+		return blocks.SetProcessorCursor(ctx, p.db, processor.Name(), height, block.BlockHash())
+	}
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := txProcessor.ProcessBlockTx(ctx, tx, height, block); err != nil {
+		return err
+	}
+
+	if err := blocks.SetProcessorCursor(ctx, tx, processor.Name(), height, block.BlockHash()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
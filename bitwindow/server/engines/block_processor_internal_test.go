@@ -0,0 +1,181 @@
+package engines
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blocks"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/opreturns"
+	service "github.com/LayerTwo-Labs/sidesail/bitwindow/server/service"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests/mocks"
+	corepb "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha"
+	corerpc "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha/bitcoindv1alphaconnect"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeProcessor struct {
+	name      string
+	failAt    uint32
+	processed []uint32
+	batches   int
+}
+
+func (f *fakeProcessor) StartBatch() { f.batches++ }
+
+func (f *fakeProcessor) Name() string { return f.name }
+
+func (f *fakeProcessor) ProcessBlock(_ context.Context, height uint32, _ *wire.MsgBlock) error {
+	if height == f.failAt {
+		return errors.New("boom")
+	}
+	f.processed = append(f.processed, height)
+	return nil
+}
+
+func (f *fakeProcessor) Rollback(_ context.Context, height uint32) error {
+	f.processed = lo.Filter(f.processed, func(h uint32, _ int) bool { return h <= height })
+	return nil
+}
+
+func TestBlockProcessors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := database.Test(t)
+
+	parser := &Parser{db: db}
+
+	upToDate := &fakeProcessor{name: "up-to-date"}
+	added := &fakeProcessor{name: "added"}
+	failing := &fakeProcessor{name: "failing", failAt: 3}
+	for _, processor := range []*fakeProcessor{upToDate, added, failing} {
+		parser.RegisterProcessor(processor)
+	}
+
+	coreBlocks := lo.Map([]uint32{1, 2, 3, 4}, func(height uint32, _ int) lo.Tuple2[uint32, *wire.MsgBlock] {
+		return lo.T2(height, &wire.MsgBlock{Header: wire.BlockHeader{Nonce: height}})
+	})
+	require.NoError(t, blocks.MarkBlocksProcessed(ctx, db, lo.Map(coreBlocks, func(t lo.Tuple2[uint32, *wire.MsgBlock], _ int) blocks.ProcessedBlock {
+		return blocks.ProcessedBlock{Height: t.A, Hash: t.B.BlockHash()}
+	})))
+	require.NoError(t, blocks.SetProcessorCursor(ctx, db, upToDate.name, 4, coreBlocks[3].B.BlockHash()))
+
+	cursors, err := parser.loadProcessorCursors(ctx, parser.runnableProcessors())
+	require.NoError(t, err)
+	require.Len(t, cursors, 3)
+
+	// The new processors have to start from the beginning
+	assert.Equal(t, uint32(1), nextHeight(4, cursors))
+
+	require.NoError(t, parser.runProcessors(ctx, cursors, coreBlocks))
+
+	assert.Empty(t, upToDate.processed)
+	assert.Equal(t, []uint32{1, 2, 3, 4}, added.processed)
+	assert.Equal(t, []uint32{1, 2}, failing.processed)
+	assert.Equal(t, 1, added.batches)
+
+	// The failed processor doesn't hold back the rest
+	assert.Equal(t, uint32(5), nextHeight(4, cursors))
+
+	cursor, err := blocks.GetProcessorCursor(ctx, db, failing.name)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), cursor.Height)
+
+	// ... and isn't handed any blocks until it's time to retry
+	cursors, err = parser.loadProcessorCursors(ctx, parser.runnableProcessors())
+	require.NoError(t, err)
+	assert.Len(t, cursors, 2)
+
+	// Rewinding a single processor leaves the others alone
+	require.NoError(t, parser.RewindProcessor(ctx, added.name, 1))
+	assert.Equal(t, []uint32{1}, added.processed)
+
+	cursor, err = blocks.GetProcessorCursor(ctx, db, added.name)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), cursor.Height)
+
+	cursor, err = blocks.GetProcessorCursor(ctx, db, upToDate.name)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(4), cursor.Height)
+
+	assert.Error(t, parser.RewindProcessor(ctx, "unknown", 0))
+}
+
+func TestReindex(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := database.Test(t)
+
+	core := mocks.NewMockBitcoinServiceClient(gomock.NewController(t))
+
+	parser := &Parser{
+		db: db,
+		bitcoind: service.New("bitcoind", func(ctx context.Context) (corerpc.BitcoinServiceClient, error) {
+			return core, nil
+		}),
+	}
+
+	reindexed := &fakeProcessor{name: "reindexed"}
+	untouched := &fakeProcessor{name: "untouched"}
+	parser.RegisterProcessor(reindexed)
+	parser.RegisterProcessor(untouched)
+
+	coreBlocks := lo.Map([]uint32{1, 2, 3, 4, 5}, func(height uint32, _ int) *wire.MsgBlock {
+		return &wire.MsgBlock{Header: wire.BlockHeader{Nonce: height}}
+	})
+	require.NoError(t, blocks.MarkBlocksProcessed(ctx, db, lo.Map(coreBlocks, func(block *wire.MsgBlock, i int) blocks.ProcessedBlock {
+		return blocks.ProcessedBlock{Height: uint32(i + 1), Hash: block.BlockHash()}
+	})))
+	for _, processor := range []*fakeProcessor{reindexed, untouched} {
+		processor.processed = []uint32{1, 2, 3, 4, 5}
+		require.NoError(t, blocks.SetProcessorCursor(ctx, db, processor.name, 5, coreBlocks[4].BlockHash()))
+	}
+
+	for _, height := range []uint32{2, 3, 4} {
+		block := coreBlocks[height-1]
+
+		var buf bytes.Buffer
+		require.NoError(t, block.Serialize(&buf))
+
+		core.EXPECT().
+			GetBlockHash(gomock.Any(), tests.Connect(&corepb.GetBlockHashRequest{Height: height})).
+			Return(connect.NewResponse(&corepb.GetBlockHashResponse{Hash: block.BlockHash().String()}), nil)
+		core.EXPECT().
+			GetBlock(gomock.Any(), tests.Connect(&corepb.GetBlockRequest{
+				Hash:      block.BlockHash().String(),
+				Verbosity: corepb.GetBlockRequest_VERBOSITY_RAW_DATA,
+			})).
+			Return(connect.NewResponse(&corepb.GetBlockResponse{Hex: hex.EncodeToString(buf.Bytes())}), nil)
+	}
+
+	assert.Error(t, parser.Reindex(ctx, 2, 4, []string{"unknown"}))
+	assert.Error(t, parser.Reindex(ctx, 2, 6, nil), "above the processed tip")
+
+	// Classifying coin news in the range needs the topics, even if the
+	// opreturns processor isn't rewound
+	topic := opreturns.TopicID([]byte("news0001"))
+	require.NoError(t, opreturns.CreateTopic(ctx, db, topic, "News", "topic_txid"))
+
+	require.NoError(t, parser.Reindex(ctx, 2, 4, []string{reindexed.name}))
+	assert.True(t, lo.SomeBy(parser.topics, func(info opreturns.TopicInfo) bool { return info.ID == topic }))
+
+	assert.Equal(t, []uint32{1, 2, 3, 4}, reindexed.processed)
+	assert.Equal(t, []uint32{1, 2, 3, 4, 5}, untouched.processed)
+
+	cursor, err := blocks.GetProcessorCursor(ctx, db, reindexed.name)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(4), cursor.Height)
+}
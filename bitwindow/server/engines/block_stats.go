@@ -0,0 +1,79 @@
+package engines
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/addresshistory"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blocks"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blockstats"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// BlockStatsName is the name of the block stats block processor.
+const BlockStatsName = "blockstats"
+
+// BlockStats records fee and transaction statistics for every block in
+// the best chain.
+//
+// Fees of individual transactions can only be calculated when the address
+// index is enabled, as that's where the spent outputs are found. Without
+// it, the total fee is taken from the coinbase and feerates are left out.
+type BlockStats struct {
+	db     *sql.DB
+	params *chaincfg.Params
+
+	// Look up spent outputs in the address index. Must be registered
+	// after the address index, such that it's caught up.
+	withPrevouts bool
+}
+
+func NewBlockStats(db *sql.DB, params *chaincfg.Params, withPrevouts bool) *BlockStats {
+	return &BlockStats{db: db, params: params, withPrevouts: withPrevouts}
+}
+
+var _ BlockProcessor = new(BlockStats)
+
+func (b *BlockStats) Name() string {
+	return BlockStatsName
+}
+
+func (b *BlockStats) ProcessBlock(ctx context.Context, height uint32, block *wire.MsgBlock) error {
+	prevouts, err := b.prevouts(ctx, height, block)
+	if err != nil {
+		return err
+	}
+
+	stats, err := blockstats.Compute(height, block, b.params, prevouts)
+	if err != nil {
+		return err
+	}
+
+	return blockstats.Save(ctx, b.db, stats)
+}
+
+// prevouts returns the outputs spent by the block, or nil if they can't be
+// looked up.
+func (b *BlockStats) prevouts(ctx context.Context, height uint32, block *wire.MsgBlock) (map[wire.OutPoint]*wire.TxOut, error) {
+	if !b.withPrevouts {
+		return nil, nil
+	}
+
+	cursor, err := blocks.GetProcessorCursor(ctx, b.db, AddressIndexName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Failing here gets us retried once the address index has caught up
+	if cursor == nil || cursor.Height < height {
+		return nil, fmt.Errorf("address index is behind block %d", height)
+	}
+
+	return addresshistory.GetOutputs(ctx, b.db, blockstats.Prevouts(block))
+}
+
+func (b *BlockStats) Rollback(ctx context.Context, height uint32) error {
+	return blockstats.DeleteAboveHeight(ctx, b.db, height)
+}
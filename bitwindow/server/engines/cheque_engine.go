@@ -63,12 +63,17 @@ func (e *ChequeEngine) GetChainParams() *chaincfg.Params {
 
 // deriveChequeKey derives the HD key at m/44'/0'/999'/{index}
 func (e *ChequeEngine) deriveChequeKey(seedHex string, index uint32) (*hdkeychain.ExtendedKey, error) {
+	return deriveAccountKey(seedHex, e.chainParams, chequeAccount, index)
+}
+
+// deriveAccountKey derives the HD key at m/44'/0'/{account}'/{index}
+func deriveAccountKey(seedHex string, chainParams *chaincfg.Params, account, index uint32) (*hdkeychain.ExtendedKey, error) {
 	seedBytes, err := hex.DecodeString(seedHex)
 	if err != nil {
 		return nil, fmt.Errorf("decode seed: %w", err)
 	}
 
-	masterKey, err := hdkeychain.NewMaster(seedBytes, e.chainParams)
+	masterKey, err := hdkeychain.NewMaster(seedBytes, chainParams)
 	if err != nil {
 		return nil, fmt.Errorf("create master key: %w", err)
 	}
@@ -85,19 +90,19 @@ func (e *ChequeEngine) deriveChequeKey(seedHex string, index uint32) (*hdkeychai
 		return nil, fmt.Errorf("derive coin type: %w", err)
 	}
 
-	// m/44'/0'/999'
-	chequeAcct, err := coinType.Derive(hdkeychain.HardenedKeyStart + chequeAccount)
+	// m/44'/0'/{account}'
+	acct, err := coinType.Derive(hdkeychain.HardenedKeyStart + account)
 	if err != nil {
-		return nil, fmt.Errorf("derive cheque account: %w", err)
+		return nil, fmt.Errorf("derive account %d: %w", account, err)
 	}
 
-	// m/44'/0'/999'/{index} - index is NOT hardened per BIP44
-	chequeKey, err := chequeAcct.Derive(index)
+	// m/44'/0'/{account}'/{index} - index is NOT hardened per BIP44
+	key, err := acct.Derive(index)
 	if err != nil {
-		return nil, fmt.Errorf("derive cheque key: %w", err)
+		return nil, fmt.Errorf("derive key: %w", err)
 	}
 
-	return chequeKey, nil
+	return key, nil
 }
 
 // DeriveChequeAddress derives the native segwit address at m/44'/0'/999'/{index}
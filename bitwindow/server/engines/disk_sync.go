@@ -0,0 +1,135 @@
+package engines
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/blkfile"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// How far behind the tip we have to be before reading blocks from disk
+	// is worth scanning the blk files.
+	diskSyncMinBlocks = 1000
+
+	// How far from the tip to hand off to RPC. Blocks this deep are very
+	// unlikely to be reorged out while we're reading them.
+	diskSyncHandoff = 100
+)
+
+// diskSync reads blocks from Bitcoin Core's blk files during initial sync.
+type diskSync struct {
+	dir string
+	net wire.BitcoinNet
+
+	minBlocks uint32
+	handoff   uint32
+
+	// Set while we're reading blocks from disk
+	store *blkfile.Store
+	// The best chain up to the handoff height, indexed by height
+	chain []chainhash.Hash
+}
+
+// SetBlocksDir makes the parser read blocks straight from the blk files in
+// the given Bitcoin Core blocks directory while it's far behind the tip,
+// instead of fetching them over RPC. Only works if the directory is on the
+// same machine, and Bitcoin Core isn't pruning.
+//
+// Must be called before Run.
+func (p *Parser) SetBlocksDir(dir string, net wire.BitcoinNet) {
+	p.diskSync = &diskSync{
+		dir:       dir,
+		net:       net,
+		minBlocks: diskSyncMinBlocks,
+		handoff:   diskSyncHandoff,
+	}
+}
+
+// prepareDiskSync starts reading blocks from disk if we're far enough
+// behind the tip, and stops once we've gotten past the handoff height.
+func (p *Parser) prepareDiskSync(ctx context.Context, nextHeight, currentHeight uint32) {
+	disk := p.diskSync
+	if disk == nil {
+		return
+	}
+
+	if disk.store != nil {
+		if nextHeight >= uint32(len(disk.chain)) {
+			zerolog.Ctx(ctx).Info().
+				Msgf("bitcoind_engine/disk_sync: read all blocks up to %d from disk, handing off to RPC", len(disk.chain)-1)
+			p.stopDiskSync(ctx)
+		}
+		return
+	}
+
+	if currentHeight < nextHeight+disk.minBlocks {
+		return
+	}
+
+	handoff := currentHeight - disk.handoff
+	if err := p.startDiskSync(ctx, handoff); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).
+			Str("dir", disk.dir).
+			Msgf("bitcoind_engine/disk_sync: could not read blocks from disk, fetching them over RPC")
+
+		// Don't scan the blk files again on every tick
+		p.diskSync = nil
+	}
+}
+
+func (p *Parser) startDiskSync(ctx context.Context, handoff uint32) error {
+	disk := p.diskSync
+
+	// Core tells us which chain is the best one, the blk files don't
+	hash, err := p.getBlockHash(ctx, handoff)
+	if err != nil {
+		return err
+	}
+
+	store, err := blkfile.Open(disk.dir, disk.net)
+	if err != nil {
+		return err
+	}
+
+	chain, err := store.Chain(hash)
+	if err != nil {
+		return fmt.Errorf("build chain: %w", err)
+	}
+
+	zerolog.Ctx(ctx).Info().
+		Int("blocks", store.Len()).
+		Msgf("bitcoind_engine/disk_sync: reading blocks up to %d from %s", handoff, disk.dir)
+
+	disk.store = store
+	disk.chain = chain
+	return nil
+}
+
+// stopDiskSync closes the blk files. Blocks are fetched over RPC until disk
+// sync starts again.
+func (p *Parser) stopDiskSync(ctx context.Context) {
+	disk := p.diskSync
+	if disk == nil || disk.store == nil {
+		return
+	}
+
+	if err := disk.store.Close(); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msgf("bitcoind_engine/disk_sync: could not close blk files")
+	}
+	disk.store = nil
+	disk.chain = nil
+}
+
+// fetchBlock reads the block at the given height from disk if we can, and
+// fetches it from Bitcoin Core otherwise.
+func (p *Parser) fetchBlock(ctx context.Context, height uint32) (*wire.MsgBlock, error) {
+	if disk := p.diskSync; disk != nil && disk.store != nil && height < uint32(len(disk.chain)) {
+		return disk.store.Block(disk.chain[height])
+	}
+
+	return p.getBlock(ctx, height)
+}
@@ -0,0 +1,131 @@
+package engines
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/blkfile"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
+	service "github.com/LayerTwo-Labs/sidesail/bitwindow/server/service"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests/mocks"
+	corepb "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha"
+	corerpc "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha/bitcoindv1alphaconnect"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// Regtest chain of 12 blocks on top of genesis, see blkfile/testdata/gen
+const (
+	blkFixtureDir = "../blkfile/testdata/blocks"
+	blkFixtureTip = "0578d7c35dfac3f1c04ae6aec48adbf541d193bc9da79eddef0dedd67c0985df"
+)
+
+func TestDiskSync(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := blkfile.Open(blkFixtureDir, wire.TestNet)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = fixture.Close() })
+
+	tip, err := chainhash.NewHashFromStr(blkFixtureTip)
+	require.NoError(t, err)
+	chain, err := fixture.Chain(*tip)
+	require.NoError(t, err)
+
+	newParser := func(t *testing.T, dir string) (*Parser, *mocks.MockBitcoinServiceClient) {
+		core := mocks.NewMockBitcoinServiceClient(gomock.NewController(t))
+
+		parser := &Parser{
+			db: database.Test(t),
+			bitcoind: service.New("bitcoind", func(ctx context.Context) (corerpc.BitcoinServiceClient, error) {
+				return core, nil
+			}),
+		}
+		parser.SetBlocksDir(dir, wire.TestNet)
+		parser.diskSync.minBlocks = 5
+		parser.diskSync.handoff = 2
+
+		return parser, core
+	}
+
+	expectBlockHash := func(core *mocks.MockBitcoinServiceClient, height uint32) {
+		core.EXPECT().
+			GetBlockHash(gomock.Any(), tests.Connect(&corepb.GetBlockHashRequest{Height: height})).
+			Return(connect.NewResponse(&corepb.GetBlockHashResponse{Hash: chain[height].String()}), nil)
+	}
+
+	t.Run("reads from disk up to the handoff", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		parser, core := newParser(t, blkFixtureDir)
+
+		// Core is at 12, so disk sync hands off at 10
+		expectBlockHash(core, 10)
+		parser.prepareDiskSync(ctx, 1, 12)
+		require.NotNil(t, parser.diskSync.store)
+
+		// No RPC calls expected
+		results, err := parser.fetchBlocks(ctx, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, lo.RangeFrom(uint32(1), 10), lo.Map(results, func(r lo.Tuple2[uint32, *wire.MsgBlock], _ int) uint32 {
+			return r.A
+		}))
+		for _, result := range results {
+			assert.Equal(t, chain[result.A], result.B.BlockHash())
+		}
+
+		// Above the handoff, blocks come from Core
+		for _, height := range []uint32{11, 12} {
+			block, err := fixture.Block(chain[height])
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, block.Serialize(&buf))
+
+			expectBlockHash(core, height)
+			core.EXPECT().
+				GetBlock(gomock.Any(), tests.Connect(&corepb.GetBlockRequest{
+					Hash:      chain[height].String(),
+					Verbosity: corepb.GetBlockRequest_VERBOSITY_RAW_DATA,
+				})).
+				Return(connect.NewResponse(&corepb.GetBlockResponse{Hex: hex.EncodeToString(buf.Bytes())}), nil)
+		}
+
+		parser.prepareDiskSync(ctx, 11, 12)
+		assert.Nil(t, parser.diskSync.store)
+
+		results, err = parser.fetchBlocks(ctx, 11, 12)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, chain[12], results[1].B.BlockHash())
+	})
+
+	t.Run("close to the tip", func(t *testing.T) {
+		t.Parallel()
+
+		parser, _ := newParser(t, blkFixtureDir)
+
+		parser.prepareDiskSync(context.Background(), 8, 12)
+		require.NotNil(t, parser.diskSync)
+		assert.Nil(t, parser.diskSync.store)
+	})
+
+	t.Run("falls back to RPC", func(t *testing.T) {
+		t.Parallel()
+
+		parser, core := newParser(t, t.TempDir())
+
+		expectBlockHash(core, 10)
+		parser.prepareDiskSync(context.Background(), 1, 12)
+		assert.Nil(t, parser.diskSync)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/m4"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/rs/zerolog"
+	"github.com/samber/lo"
 )
 
 // M4Engine manages the SCDB state and M4 message processing
@@ -20,8 +21,32 @@ func NewM4Engine(db *sql.DB) *M4Engine {
 	return &M4Engine{db: db}
 }
 
+var _ TxBlockProcessor = new(M4Engine)
+
+func (e *M4Engine) Name() string {
+	return "m4"
+}
+
 // ProcessBlock processes a block for M3/M4 messages and updates SCDB state
 func (e *M4Engine) ProcessBlock(ctx context.Context, height uint32, block *wire.MsgBlock) error {
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := e.ProcessBlockTx(ctx, tx, height, block); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ProcessBlockTx is like ProcessBlock, but writes through the given
+// transaction. Votes add to the bundle work scores, so the block pipeline
+// moves our cursor in the same transaction, and a block is never counted
+// twice.
+func (e *M4Engine) ProcessBlockTx(ctx context.Context, tx *sql.Tx, height uint32, block *wire.MsgBlock) error {
 	log := zerolog.Ctx(ctx).With().
 		Uint32("height", height).
 		Logger()
@@ -34,28 +59,26 @@ func (e *M4Engine) ProcessBlock(ctx context.Context, height uint32, block *wire.
 	coinbase := block.Transactions[0]
 
 	// First, process M3 (withdrawal bundle proposals)
-	m3Msgs, err := e.extractM3FromCoinbase(coinbase)
-	if err == nil && len(m3Msgs) > 0 {
-		for _, m3Msg := range m3Msgs {
-			m3Msg.BlockHeight = height
-			m3Msg.BlockHash = block.BlockHash().String()
-			m3Msg.BlockTime = block.Header.Timestamp
-
-			if err := e.persistM3Message(ctx, m3Msg); err != nil {
-				log.Warn().Err(err).Msg("failed to persist M3 message")
-			} else {
-				log.Debug().
-					Uint8("sidechain", m3Msg.SidechainSlot).
-					Str("bundle", m3Msg.BundleHash[:16]+"...").
-					Msg("processed M3 (bundle proposal)")
-			}
+	for _, m3Msg := range e.extractM3FromCoinbase(coinbase) {
+		m3Msg.BlockHeight = height
+		m3Msg.BlockHash = block.BlockHash().String()
+		m3Msg.BlockTime = block.Header.Timestamp
+
+		if err := e.persistM3Message(ctx, tx, m3Msg); err != nil {
+			return fmt.Errorf("persist M3: %w", err)
 		}
+
+		log.Debug().
+			Uint8("sidechain", m3Msg.SidechainSlot).
+			Str("bundle", m3Msg.BundleHash[:16]+"...").
+			Msg("processed M3 (bundle proposal)")
 	}
 
 	// Then, process M4 (withdrawal bundle votes)
-	m4Msg, err := e.extractM4FromCoinbase(coinbase)
-	if err != nil {
-		// Not finding an M4 is OK - not all blocks have them
+	m4Msg := e.extractM4FromCoinbase(ctx, coinbase)
+
+	// Not finding an M4 is OK - not all blocks have them
+	if m4Msg == nil {
 		log.Trace().Msg("no M4 found in coinbase")
 	} else {
 		// Store the M4 message
@@ -63,13 +86,13 @@ func (e *M4Engine) ProcessBlock(ctx context.Context, height uint32, block *wire.
 		m4Msg.BlockHash = block.BlockHash().String()
 		m4Msg.BlockTime = block.Header.Timestamp
 
-		if err := e.persistM4Message(ctx, m4Msg); err != nil {
+		if err := e.persistM4Message(ctx, tx, m4Msg); err != nil {
 			return fmt.Errorf("persist M4: %w", err)
 		}
 
 		// Apply M4 votes to update bundle work scores
-		if err := e.applyM4Votes(ctx, height, m4Msg); err != nil {
-			log.Warn().Err(err).Msg("failed to apply M4 votes")
+		if err := e.applyM4Votes(ctx, tx, height, m4Msg); err != nil {
+			return fmt.Errorf("apply M4 votes: %w", err)
 		}
 
 		log.Debug().
@@ -79,15 +102,17 @@ func (e *M4Engine) ProcessBlock(ctx context.Context, height uint32, block *wire.
 	}
 
 	// Update all bundle states (decrement blocks_left, check expiration)
-	if err := e.updateBundleStates(ctx); err != nil {
-		log.Warn().Err(err).Msg("failed to update bundle states")
+	if err := e.updateBundleStates(ctx, tx); err != nil {
+		return fmt.Errorf("update bundle states: %w", err)
 	}
 
 	return nil
 }
 
-// extractM4FromCoinbase finds and parses M4 from coinbase OP_RETURNs
-func (e *M4Engine) extractM4FromCoinbase(coinbase *wire.MsgTx) (*m4.M4Message, error) {
+// extractM4FromCoinbase finds and parses M4 from coinbase OP_RETURNs.
+// Returns nil if the coinbase has no valid M4 commitment. Anyone can mine a
+// malformed one, so that's logged rather than failing the block.
+func (e *M4Engine) extractM4FromCoinbase(ctx context.Context, coinbase *wire.MsgTx) *m4.M4Message {
 	// Check all outputs for M4 commitment
 	for _, txout := range coinbase.TxOut {
 		script := txout.PkScript
@@ -98,23 +123,20 @@ func (e *M4Engine) extractM4FromCoinbase(coinbase *wire.MsgTx) (*m4.M4Message, e
 		// Try to parse as M4
 		msg, err := m4.ParseM4Bytes(script)
 		if err != nil {
-			return nil, fmt.Errorf("parse M4: %w", err)
+			zerolog.Ctx(ctx).Warn().Err(err).
+				Str("coinbase", coinbase.TxID()).
+				Msg("ignoring malformed M4 commitment")
+			return nil
 		}
 
-		return msg, nil
+		return msg
 	}
 
-	return nil, fmt.Errorf("no M4 commitment found in coinbase")
+	return nil
 }
 
 // persistM4Message stores an M4 message and its votes in the database
-func (e *M4Engine) persistM4Message(ctx context.Context, msg *m4.M4Message) error {
-	tx, err := e.db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = tx.Rollback() }()
-
+func (e *M4Engine) persistM4Message(ctx context.Context, tx *sql.Tx, msg *m4.M4Message) error {
 	// Insert M4 message
 	result, err := tx.ExecContext(ctx, `
 		INSERT INTO m4_messages (
@@ -164,7 +186,7 @@ func (e *M4Engine) persistM4Message(ctx context.Context, msg *m4.M4Message) erro
 		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 // GetM4History returns the last N M4 messages
@@ -303,7 +325,7 @@ func (e *M4Engine) SetVotePreference(ctx context.Context, sidechainSlot uint8, v
 }
 
 // extractM3FromCoinbase finds and parses M3 messages from coinbase OP_RETURNs
-func (e *M4Engine) extractM3FromCoinbase(coinbase *wire.MsgTx) ([]*m4.M3Message, error) {
+func (e *M4Engine) extractM3FromCoinbase(coinbase *wire.MsgTx) []*m4.M3Message {
 	var messages []*m4.M3Message
 
 	// Check all outputs for M3 commitments
@@ -322,23 +344,13 @@ func (e *M4Engine) extractM3FromCoinbase(coinbase *wire.MsgTx) ([]*m4.M3Message,
 		messages = append(messages, msg)
 	}
 
-	if len(messages) == 0 {
-		return nil, fmt.Errorf("no M3 commitment found in coinbase")
-	}
-
-	return messages, nil
+	return messages
 }
 
 // persistM3Message stores an M3 message and creates the corresponding withdrawal bundle
-func (e *M4Engine) persistM3Message(ctx context.Context, msg *m4.M3Message) error {
-	tx, err := e.db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = tx.Rollback() }()
-
+func (e *M4Engine) persistM3Message(ctx context.Context, tx *sql.Tx, msg *m4.M3Message) error {
 	// Insert M3 message
-	_, err = tx.ExecContext(ctx, `
+	_, err := tx.ExecContext(ctx, `
 		INSERT INTO m3_messages (
 			block_height, block_hash, block_time, sidechain_slot, bundle_hash
 		) VALUES (?, ?, ?, ?, ?)
@@ -360,26 +372,29 @@ func (e *M4Engine) persistM3Message(ctx context.Context, msg *m4.M3Message) erro
 		return fmt.Errorf("insert withdrawal bundle: %w", err)
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 // applyM4Votes applies M4 votes to update withdrawal bundle work scores
-func (e *M4Engine) applyM4Votes(ctx context.Context, height uint32, msg *m4.M4Message) error {
+func (e *M4Engine) applyM4Votes(ctx context.Context, tx *sql.Tx, height uint32, msg *m4.M4Message) error {
 	for _, vote := range msg.Votes {
 		switch vote.VoteType {
 		case m4.VoteTypeUpvote:
 			// Increment work score for upvoted bundle
 			// We need to find the bundle by sidechain slot and index
 			if vote.BundleIndex != nil {
-				_, err := e.db.ExecContext(ctx, `
+				_, err := tx.ExecContext(ctx, `
 					UPDATE withdrawal_bundles
 					SET work_score = work_score + 1,
 					    last_updated_height = ?,
 					    updated_at = CURRENT_TIMESTAMP
-					WHERE sidechain_slot = ?
-					  AND status = 'pending'
-					ORDER BY first_seen_height ASC
-					LIMIT 1 OFFSET ?
+					WHERE id = (
+						SELECT id FROM withdrawal_bundles
+						WHERE sidechain_slot = ?
+						  AND status = 'pending'
+						ORDER BY first_seen_height ASC, id ASC
+						LIMIT 1 OFFSET ?
+					)
 				`, height, vote.SidechainSlot, *vote.BundleIndex)
 				if err != nil {
 					return fmt.Errorf("upvote bundle: %w", err)
@@ -388,7 +403,7 @@ func (e *M4Engine) applyM4Votes(ctx context.Context, height uint32, msg *m4.M4Me
 
 		case m4.VoteTypeAlarm:
 			// Decrement work score for all bundles on this sidechain
-			_, err := e.db.ExecContext(ctx, `
+			_, err := tx.ExecContext(ctx, `
 				UPDATE withdrawal_bundles
 				SET work_score = MAX(0, work_score - 1),
 				    last_updated_height = ?,
@@ -402,7 +417,7 @@ func (e *M4Engine) applyM4Votes(ctx context.Context, height uint32, msg *m4.M4Me
 
 		case m4.VoteTypeAbstain:
 			// No score change, but update last_updated_height
-			_, err := e.db.ExecContext(ctx, `
+			_, err := tx.ExecContext(ctx, `
 				UPDATE withdrawal_bundles
 				SET last_updated_height = ?
 				WHERE sidechain_slot = ?
@@ -418,9 +433,9 @@ func (e *M4Engine) applyM4Votes(ctx context.Context, height uint32, msg *m4.M4Me
 }
 
 // updateBundleStates decrements blocks_left and updates status for all pending bundles
-func (e *M4Engine) updateBundleStates(ctx context.Context) error {
+func (e *M4Engine) updateBundleStates(ctx context.Context, tx *sql.Tx) error {
 	// Decrement blocks_left for all pending bundles
-	_, err := e.db.ExecContext(ctx, `
+	_, err := tx.ExecContext(ctx, `
 		UPDATE withdrawal_bundles
 		SET blocks_left = blocks_left - 1,
 		    updated_at = CURRENT_TIMESTAMP
@@ -432,7 +447,7 @@ func (e *M4Engine) updateBundleStates(ctx context.Context) error {
 	}
 
 	// Mark bundles as approved if work_score >= 13150
-	_, err = e.db.ExecContext(ctx, `
+	_, err = tx.ExecContext(ctx, `
 		UPDATE withdrawal_bundles
 		SET status = 'approved'
 		WHERE status = 'pending'
@@ -443,7 +458,7 @@ func (e *M4Engine) updateBundleStates(ctx context.Context) error {
 	}
 
 	// Mark bundles as failed if blocks_left = 0 and work_score < 13150
-	_, err = e.db.ExecContext(ctx, `
+	_, err = tx.ExecContext(ctx, `
 		UPDATE withdrawal_bundles
 		SET status = 'failed'
 		WHERE status = 'pending'
@@ -455,7 +470,7 @@ func (e *M4Engine) updateBundleStates(ctx context.Context) error {
 	}
 
 	// Mark bundles as expired if blocks_left = 0 (regardless of score)
-	_, err = e.db.ExecContext(ctx, `
+	_, err = tx.ExecContext(ctx, `
 		UPDATE withdrawal_bundles
 		SET status = 'expired'
 		WHERE status = 'pending'
@@ -468,6 +483,226 @@ func (e *M4Engine) updateBundleStates(ctx context.Context) error {
 	return nil
 }
 
+// Rollback removes all M3 and M4 messages found in blocks strictly above
+// the given height, and re-derives the withdrawal bundle state from the
+// messages that remain. Used when the chain reorgs below our processed tip.
+func (e *M4Engine) Rollback(ctx context.Context, height uint32) error {
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM m4_votes
+		WHERE m4_message_id IN (SELECT id FROM m4_messages WHERE block_height > ?)
+	`, height)
+	if err != nil {
+		return fmt.Errorf("delete M4 votes: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM m4_messages WHERE block_height > ?`, height); err != nil {
+		return fmt.Errorf("delete M4 messages: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM m3_messages WHERE block_height > ?`, height); err != nil {
+		return fmt.Errorf("delete M3 messages: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return e.rebuildWithdrawalBundles(ctx, height)
+}
+
+// rebuildWithdrawalBundles throws away the current withdrawal bundle state,
+// and replays all persisted M3 and M4 messages up to and including the given
+// height.
+func (e *M4Engine) rebuildWithdrawalBundles(ctx context.Context, height uint32) error {
+	m3Rows, err := e.db.QueryContext(ctx, `
+		SELECT block_height, sidechain_slot, bundle_hash
+		FROM m3_messages
+		WHERE block_height <= ?
+		ORDER BY block_height, id
+	`, height)
+	if err != nil {
+		return fmt.Errorf("query M3 messages: %w", err)
+	}
+	defer m3Rows.Close()
+
+	var m3Msgs []m4.M3Message
+	for m3Rows.Next() {
+		var msg m4.M3Message
+		if err := m3Rows.Scan(&msg.BlockHeight, &msg.SidechainSlot, &msg.BundleHash); err != nil {
+			return fmt.Errorf("scan M3 message: %w", err)
+		}
+		m3Msgs = append(m3Msgs, msg)
+	}
+	if err := m3Rows.Err(); err != nil {
+		return err
+	}
+
+	voteRows, err := e.db.QueryContext(ctx, `
+		SELECT m.block_height, v.sidechain_slot, v.vote_type, v.bundle_index
+		FROM m4_votes v
+		JOIN m4_messages m ON m.id = v.m4_message_id
+		WHERE m.block_height <= ?
+		ORDER BY m.block_height, m.id, v.id
+	`, height)
+	if err != nil {
+		return fmt.Errorf("query M4 votes: %w", err)
+	}
+	defer voteRows.Close()
+
+	votes := make(map[uint32][]m4.M4Vote)
+	for voteRows.Next() {
+		var (
+			voteHeight uint32
+			vote       m4.M4Vote
+		)
+		if err := voteRows.Scan(&voteHeight, &vote.SidechainSlot, &vote.VoteType, &vote.BundleIndex); err != nil {
+			return fmt.Errorf("scan M4 vote: %w", err)
+		}
+		votes[voteHeight] = append(votes[voteHeight], vote)
+	}
+	if err := voteRows.Err(); err != nil {
+		return err
+	}
+
+	bundles := replayWithdrawalBundles(m3Msgs, votes, height)
+
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM withdrawal_bundles`); err != nil {
+		return fmt.Errorf("delete withdrawal bundles: %w", err)
+	}
+
+	for _, b := range bundles {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO withdrawal_bundles (
+				sidechain_slot, bundle_hash, work_score, blocks_left, max_age,
+				first_seen_height, last_updated_height, status
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`, b.SidechainSlot, b.BundleHash, b.WorkScore, b.BlocksLeft, b.MaxAge,
+			b.FirstSeenHeight, b.LastUpdatedHeight, b.Status)
+		if err != nil {
+			return fmt.Errorf("insert withdrawal bundle: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	zerolog.Ctx(ctx).Info().
+		Uint32("height", height).
+		Int("bundles", len(bundles)).
+		Msg("rebuilt withdrawal bundles")
+
+	return nil
+}
+
+// replayWithdrawalBundles computes the withdrawal bundle state at the given
+// height, by applying M3 messages and M4 votes block by block. It mirrors
+// what ProcessBlock does through persistM3Message, applyM4Votes and
+// updateBundleStates.
+func replayWithdrawalBundles(m3Msgs []m4.M3Message, votes map[uint32][]m4.M4Vote, height uint32) []*m4.WithdrawalBundle {
+	if len(m3Msgs) == 0 {
+		return nil
+	}
+
+	proposals := make(map[uint32][]m4.M3Message)
+	for _, msg := range m3Msgs {
+		proposals[msg.BlockHeight] = append(proposals[msg.BlockHeight], msg)
+	}
+
+	var bundles []*m4.WithdrawalBundle
+
+	pendingForSlot := func(slot uint8) []*m4.WithdrawalBundle {
+		var pending []*m4.WithdrawalBundle
+		for _, b := range bundles {
+			if b.SidechainSlot == slot && b.Status == m4.BundleStatusPending {
+				pending = append(pending, b)
+			}
+		}
+		// Bundles are appended in order of appearance, so this is already
+		// sorted by first_seen_height.
+		return pending
+	}
+
+	for h := m3Msgs[0].BlockHeight; h <= height; h++ {
+		for _, msg := range proposals[h] {
+			exists := lo.ContainsBy(bundles, func(b *m4.WithdrawalBundle) bool {
+				return b.SidechainSlot == msg.SidechainSlot && b.BundleHash == msg.BundleHash
+			})
+			if exists {
+				continue
+			}
+
+			bundles = append(bundles, &m4.WithdrawalBundle{
+				SidechainSlot:     msg.SidechainSlot,
+				BundleHash:        msg.BundleHash,
+				WorkScore:         1,
+				BlocksLeft:        m4.WithdrawalMaxAge,
+				MaxAge:            m4.WithdrawalMaxAge,
+				FirstSeenHeight:   h,
+				LastUpdatedHeight: h,
+				Status:            m4.BundleStatusPending,
+			})
+		}
+
+		for _, vote := range votes[h] {
+			pending := pendingForSlot(vote.SidechainSlot)
+
+			switch vote.VoteType {
+			case m4.VoteTypeUpvote:
+				if vote.BundleIndex != nil && int(*vote.BundleIndex) < len(pending) {
+					b := pending[*vote.BundleIndex]
+					b.WorkScore++
+					b.LastUpdatedHeight = h
+				}
+
+			case m4.VoteTypeAlarm:
+				for _, b := range pending {
+					if b.WorkScore > 0 {
+						b.WorkScore--
+					}
+					b.LastUpdatedHeight = h
+				}
+
+			case m4.VoteTypeAbstain:
+				for _, b := range pending {
+					b.LastUpdatedHeight = h
+				}
+			}
+		}
+
+		for _, b := range bundles {
+			if b.Status != m4.BundleStatusPending {
+				continue
+			}
+
+			if b.BlocksLeft > 0 {
+				b.BlocksLeft--
+			}
+
+			switch {
+			case b.WorkScore >= m4.MinWorkScore:
+				b.Status = m4.BundleStatusApproved
+			case b.BlocksLeft == 0:
+				b.Status = m4.BundleStatusFailed
+			}
+		}
+	}
+
+	return bundles
+}
+
 // GetWithdrawalBundles returns active withdrawal bundles for a sidechain
 func (e *M4Engine) GetWithdrawalBundles(ctx context.Context, sidechainSlot *uint8) ([]m4.WithdrawalBundle, error) {
 	query := `
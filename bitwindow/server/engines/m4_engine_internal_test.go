@@ -0,0 +1,107 @@
+package engines
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blocks"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/m4"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayWithdrawalBundles(t *testing.T) {
+	t.Parallel()
+
+	m3Msgs := []m4.M3Message{
+		{BlockHeight: 10, SidechainSlot: 1, BundleHash: "first"},
+		{BlockHeight: 11, SidechainSlot: 1, BundleHash: "second"},
+		// Proposed again, should not create a new bundle
+		{BlockHeight: 12, SidechainSlot: 1, BundleHash: "first"},
+	}
+	votes := map[uint32][]m4.M4Vote{
+		11: {{SidechainSlot: 1, VoteType: m4.VoteTypeUpvote, BundleIndex: lo.ToPtr(uint16(1))}},
+		12: {{SidechainSlot: 1, VoteType: m4.VoteTypeUpvote, BundleIndex: lo.ToPtr(uint16(1))}},
+		13: {{SidechainSlot: 1, VoteType: m4.VoteTypeAlarm}},
+		// Above the replay height, must be ignored
+		20: {{SidechainSlot: 1, VoteType: m4.VoteTypeUpvote, BundleIndex: lo.ToPtr(uint16(0))}},
+	}
+
+	bundles := replayWithdrawalBundles(m3Msgs, votes, 15)
+	require.Len(t, bundles, 2)
+
+	first, second := bundles[0], bundles[1]
+
+	assert.Equal(t, "first", first.BundleHash)
+	assert.Equal(t, uint16(0), first.WorkScore)
+	assert.Equal(t, uint32(10), first.FirstSeenHeight)
+	assert.Equal(t, uint32(13), first.LastUpdatedHeight)
+	assert.Equal(t, uint32(m4.WithdrawalMaxAge-6), first.BlocksLeft)
+	assert.Equal(t, m4.BundleStatusPending, first.Status)
+
+	assert.Equal(t, "second", second.BundleHash)
+	assert.Equal(t, uint16(2), second.WorkScore)
+	assert.Equal(t, uint32(11), second.FirstSeenHeight)
+	assert.Equal(t, uint32(m4.WithdrawalMaxAge-5), second.BlocksLeft)
+
+	assert.Empty(t, replayWithdrawalBundles(nil, votes, 15))
+}
+
+func TestM4EngineProcessBlock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := database.Test(t)
+
+	parser := &Parser{db: db}
+	engine := NewM4Engine(db)
+	parser.RegisterProcessor(engine)
+
+	commitment := func(header uint32, payload ...byte) []byte {
+		script := []byte{txscript.OP_RETURN, 0, 0, 0, 0}
+		binary.LittleEndian.PutUint32(script[1:], header)
+		return append(script, payload...)
+	}
+	coinbase := func(height uint32, scripts ...[]byte) lo.Tuple2[uint32, *wire.MsgBlock] {
+		tx := wire.NewMsgTx(wire.TxVersion)
+		for _, script := range scripts {
+			tx.AddTxOut(wire.NewTxOut(0, script))
+		}
+		return lo.T2(height, &wire.MsgBlock{
+			Header:       wire.BlockHeader{Nonce: height},
+			Transactions: []*wire.MsgTx{tx},
+		})
+	}
+
+	coreBlocks := []lo.Tuple2[uint32, *wire.MsgBlock]{
+		// Proposes a bundle for sidechain 1
+		coinbase(1, commitment(m4.M3CommitmentHeader, append(make([]byte, 32), 1)...)),
+		// Abstains on sidechain 0, upvotes the bundle
+		coinbase(2, commitment(m4.M4CommitmentHeader, 0x01, 0xFF, 0x00)),
+		// Upvotes again, but the version 0x02 vector has an odd length
+		coinbase(3, commitment(m4.M4CommitmentHeader, 0x02, 0x00)),
+	}
+
+	cursors, err := parser.loadProcessorCursors(ctx, parser.runnableProcessors())
+	require.NoError(t, err)
+	require.NoError(t, parser.runProcessors(ctx, cursors, coreBlocks))
+
+	// The malformed M4 is treated as no M4 at all. The block still counts
+	// down the bundle, and the cursor moves past it.
+	cursor, err := blocks.GetProcessorCursor(ctx, db, engine.Name())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), cursor.Height)
+
+	bundles, err := engine.GetWithdrawalBundles(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+	assert.Equal(t, uint16(2), bundles[0].WorkScore)
+	assert.Equal(t, uint32(m4.WithdrawalMaxAge-3), bundles[0].BlocksLeft)
+	assert.Equal(t, uint32(2), bundles[0].LastUpdatedHeight)
+}
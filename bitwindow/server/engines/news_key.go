@@ -0,0 +1,33 @@
+package engines
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+// Derivation path for the key Coin News posts are signed with:
+// m/44'/0'/998'/0
+const newsAccount = 998
+
+// NewsSigningKey derives the key this wallet signs Coin News posts with. The
+// same seed always gives the same key, so readers can recognize the author
+// across posts.
+func (e *WalletEngine) NewsSigningKey() (*btcec.PrivateKey, error) {
+	seedHex, err := e.GetEnforcerSeed()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := deriveAccountKey(seedHex, e.chainParams, newsAccount, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	privKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, fmt.Errorf("get private key: %w", err)
+	}
+
+	return privKey, nil
+}
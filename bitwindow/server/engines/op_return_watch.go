@@ -0,0 +1,116 @@
+package engines
+
+import (
+	"context"
+	"sync"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/opreturns"
+	"github.com/rs/zerolog"
+)
+
+// OPReturnEvent is sent when the OP_RETURNs of a transaction are stored.
+// That happens first when the transaction enters the mempool, and again
+// when it's confirmed.
+type OPReturnEvent struct {
+	TxID string
+	// As stored, so with IDs and when they were first seen
+	OPReturns []opreturns.OPReturn
+	Confirmed bool
+}
+
+// How many events a watcher can fall behind before it's dropped. Large
+// enough for a block full of OP_RETURNs.
+const opReturnWatcherBuffer = 4096
+
+// opReturnWatchers passes OP_RETURN events on to everyone watching.
+type opReturnWatchers struct {
+	mu       sync.Mutex
+	watchers map[chan OPReturnEvent]struct{}
+}
+
+func (w *opReturnWatchers) active() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return len(w.watchers) > 0
+}
+
+// send passes the event on to all watchers. Watchers that are too far
+// behind are dropped, rather than silently missing events.
+func (w *opReturnWatchers) send(ctx context.Context, event OPReturnEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for watcher := range w.watchers {
+		select {
+		case watcher <- event:
+		default:
+			zerolog.Ctx(ctx).Warn().
+				Msgf("bitcoind_engine/parser: OP_RETURN watcher fell behind, dropping it")
+			w.remove(watcher)
+		}
+	}
+}
+
+func (w *opReturnWatchers) watch(ctx context.Context) <-chan OPReturnEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.watchers == nil {
+		w.watchers = make(map[chan OPReturnEvent]struct{})
+	}
+
+	watcher := make(chan OPReturnEvent, opReturnWatcherBuffer)
+	w.watchers[watcher] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		w.remove(watcher)
+	}()
+
+	return watcher
+}
+
+// remove closes the watcher, if it's not already removed. Has to be called
+// with the lock held.
+func (w *opReturnWatchers) remove(watcher chan OPReturnEvent) {
+	if _, ok := w.watchers[watcher]; !ok {
+		return
+	}
+	delete(w.watchers, watcher)
+	close(watcher)
+}
+
+// WatchOPReturns returns a channel that receives an event whenever the
+// OP_RETURNs of a transaction are stored, both for the mempool and for
+// blocks. The channel is closed when the context is cancelled, or if the
+// receiver falls too far behind.
+func (p *Parser) WatchOPReturns(ctx context.Context) <-chan OPReturnEvent {
+	return p.opReturnWatchers.watch(ctx)
+}
+
+// notifyOPReturns tells watchers that the OP_RETURNs of a transaction were
+// stored.
+func (p *Parser) notifyOPReturns(ctx context.Context, txid string, confirmed bool) error {
+	// Saves a query per transaction while syncing
+	if !p.opReturnWatchers.active() {
+		return nil
+	}
+
+	stored, err := opreturns.ListByTxID(ctx, p.db, txid)
+	if err != nil {
+		return err
+	}
+
+	p.opReturnWatchers.send(ctx, OPReturnEvent{
+		TxID:      txid,
+		OPReturns: stored,
+		Confirmed: confirmed,
+	})
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package engines
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/opreturns"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchOPReturns(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := database.Test(t)
+	parser := &Parser{db: db}
+
+	events := parser.WatchOPReturns(ctx)
+	receive := func() OPReturnEvent {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(time.Second):
+			t.Fatal("no OP_RETURN event")
+			return OPReturnEvent{}
+		}
+	}
+
+	tx := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{1}}}},
+		TxOut: []*wire.TxOut{
+			{PkScript: pkScript(t, []byte("hello from the mempool"))},
+		},
+	}
+	// Nothing to store, so nothing to tell
+	noOPReturn := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{2}}}},
+		TxOut: []*wire.TxOut{{PkScript: []byte{0x51, 0x51}}},
+	}
+
+	require.NoError(t, parser.HandleNewRawTransaction(ctx, noOPReturn))
+	require.NoError(t, parser.HandleNewRawTransaction(ctx, tx))
+
+	unconfirmed := receive()
+	assert.Equal(t, tx.TxID(), unconfirmed.TxID)
+	assert.False(t, unconfirmed.Confirmed)
+	require.Len(t, unconfirmed.OPReturns, 1)
+	assert.Equal(t, "hello from the mempool", string(unconfirmed.OPReturns[0].Data))
+	assert.Equal(t, opreturns.ProtocolText, unconfirmed.OPReturns[0].Protocol)
+	assert.Nil(t, unconfirmed.OPReturns[0].Height)
+	// No witness, so the virtual size is the size
+	assert.EqualValues(t, tx.SerializeSize(), unconfirmed.OPReturns[0].VSize)
+	assert.NotZero(t, unconfirmed.OPReturns[0].ID)
+
+	block := &wire.MsgBlock{
+		Header:       wire.BlockHeader{Timestamp: time.Now()},
+		Transactions: []*wire.MsgTx{noOPReturn, tx},
+	}
+	require.NoError(t, (&opReturnProcessor{parser: parser}).ProcessBlock(ctx, 10, block))
+
+	confirmed := receive()
+	assert.Equal(t, tx.TxID(), confirmed.TxID)
+	assert.True(t, confirmed.Confirmed)
+	require.Len(t, confirmed.OPReturns, 1)
+	assert.Equal(t, unconfirmed.OPReturns[0].ID, confirmed.OPReturns[0].ID, "same row, now confirmed")
+	assert.Equal(t, uint32(10), *confirmed.OPReturns[0].Height)
+
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event: %+v", event)
+	default:
+	}
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		_, ok := <-events
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestWatchOPReturns_SlowWatcher(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	parser := &Parser{}
+
+	slow := parser.WatchOPReturns(ctx)
+	for range opReturnWatcherBuffer + 1 {
+		parser.opReturnWatchers.send(ctx, OPReturnEvent{TxID: "txid"})
+	}
+
+	// Gets what was buffered, and then nothing, so it knows to catch up
+	for range opReturnWatcherBuffer {
+		_, ok := <-slow
+		require.True(t, ok)
+	}
+	_, ok := <-slow
+	assert.False(t, ok)
+	assert.False(t, parser.opReturnWatchers.active())
+}
@@ -0,0 +1,133 @@
+package engines
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blocks"
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+)
+
+// Reindex rolls back the processors with the given names, or all of them if
+// no names are given, to just below from, and re-runs them over all blocks
+// up to and including to. A zero to means the last processed block.
+//
+// Processed state above to is gone after this, and is re-derived by the
+// regular sync the next time the parser runs.
+func (p *Parser) Reindex(ctx context.Context, from, to uint32, names []string) error {
+	processors := p.registeredProcessors()
+	if len(names) > 0 {
+		known := lo.Map(processors, func(processor BlockProcessor, _ int) string {
+			return processor.Name()
+		})
+		if unknown := lo.Without(names, known...); len(unknown) > 0 {
+			return fmt.Errorf("unknown block processors %q, must be one of %q", unknown, known)
+		}
+
+		processors = lo.Filter(processors, func(processor BlockProcessor, _ int) bool {
+			return lo.Contains(names, processor.Name())
+		})
+	}
+
+	tip, err := blocks.GetProcessedTip(ctx, p.db)
+	if err != nil {
+		return fmt.Errorf("get processed tip: %w", err)
+	}
+	if tip == nil {
+		return fmt.Errorf("no blocks have been processed yet")
+	}
+
+	if to == 0 {
+		to = tip.Height
+	}
+
+	switch {
+	case from == 0:
+		return fmt.Errorf("from must be at least 1")
+	case from > to:
+		return fmt.Errorf("from (%d) is above to (%d)", from, to)
+	case to > tip.Height:
+		return fmt.Errorf("can only reindex processed blocks, last processed block is %d", tip.Height)
+	}
+
+	names = lo.Map(processors, func(processor BlockProcessor, _ int) string {
+		return processor.Name()
+	})
+
+	zerolog.Ctx(ctx).Info().
+		Msgf("bitcoind_engine/reindex: rolling back %s to block %d", strings.Join(names, ", "), from-1)
+
+	for _, processor := range processors {
+		if err := p.rewindProcessor(ctx, processor, from-1); err != nil {
+			return err
+		}
+	}
+
+	// Rewinding the opreturns processor reloads the topics, but it's skipped
+	// if the processor is already below from
+	if err := p.loadTopics(ctx); err != nil {
+		return err
+	}
+
+	// Processors that were behind already have to start from their cursor
+	cursors, err := p.loadProcessorCursors(ctx, processors)
+	if err != nil {
+		return fmt.Errorf("load processor cursors: %w", err)
+	}
+
+	const batchSize = 30
+
+	var (
+		start = time.Now()
+		first = nextHeight(to, cursors)
+	)
+	for batchStart := first; batchStart <= to; batchStart += batchSize {
+		batchEnd := min(batchStart+batchSize-1, to)
+
+		coreBlocks, err := p.fetchBlocks(ctx, batchStart, batchEnd)
+		if err != nil {
+			return err
+		}
+
+		// The rest of our state is derived from the blocks we processed, so
+		// we can't mix in blocks from a different chain.
+		for _, t := range coreBlocks {
+			height, block := t.Unpack()
+
+			processed, err := p.isProcessedBlock(ctx, height, block.BlockHash())
+			if err != nil {
+				return err
+			}
+			if !processed {
+				return fmt.Errorf("block %d has changed since it was processed, start bitwindowd to sync with the best chain first", height)
+			}
+		}
+
+		if err := p.runProcessors(ctx, cursors, coreBlocks); err != nil {
+			return err
+		}
+
+		for _, cursor := range cursors {
+			if cursor.err != nil {
+				return cursor.err
+			}
+		}
+
+		done := batchEnd - first + 1
+		elapsed := time.Since(start)
+		zerolog.Ctx(ctx).Info().
+			Msgf("bitcoind_engine/reindex: processed block %d of %d (%.1f%%), %.1f blocks/sec",
+				batchEnd, to,
+				100*float64(done)/float64(to-first+1),
+				float64(done)/elapsed.Seconds(),
+			)
+	}
+
+	zerolog.Ctx(ctx).Info().
+		Msgf("bitcoind_engine/reindex: reindexed blocks %d-%d in %s", first, to, time.Since(start))
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package engines
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// SyncState describes what the block parser is currently doing.
+type SyncState string
+
+const (
+	SyncStateSyncing         SyncState = "syncing"
+	SyncStateSynced          SyncState = "synced"
+	SyncStateWaitingForIBD   SyncState = "waiting_for_ibd"
+	SyncStateCoreUnavailable SyncState = "core_unavailable"
+)
+
+// Reorg describes a reorg the block parser rolled back.
+type Reorg struct {
+	// The processed tip before the reorg
+	FromHeight uint32
+	// The last block we had processed that's still in the best chain
+	ForkHeight uint32
+}
+
+// SyncStatus is a snapshot of the progress of the block parser.
+type SyncStatus struct {
+	State SyncState
+
+	// Zero if nothing has been processed yet
+	ProcessedHeight uint32
+	ProcessedHash   chainhash.Hash
+
+	// The best chain according to Bitcoin Core. Zero if unknown.
+	BlockHeight  uint32
+	HeaderHeight uint32
+
+	// Zero if we're not processing blocks
+	BlocksPerSecond float64
+	// Zero if unknown
+	ETA time.Duration
+
+	// Set if this update was caused by a reorg
+	Reorg *Reorg
+
+	// Set if Bitcoin Core is unavailable
+	Err error
+
+	UpdatedAt time.Time
+}
+
+// How much weight the most recent batch gets in the blocks per second
+// estimate. The rest goes to the previous estimate.
+const blockRateSmoothing = 0.3
+
+// syncStatus keeps track of the latest sync status, and passes it on to
+// everyone watching.
+type syncStatus struct {
+	mu       sync.Mutex
+	status   SyncStatus
+	watchers map[chan SyncStatus]struct{}
+}
+
+func (s *syncStatus) get() SyncStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.status
+}
+
+// update applies the given change to the current status, and sends the
+// result to all watchers.
+func (s *syncStatus) update(change func(status *SyncStatus)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Only ever describes the update it was sent with
+	s.status.Reorg = nil
+	change(&s.status)
+	s.status.UpdatedAt = time.Now()
+
+	for watcher := range s.watchers {
+		sendLatest(watcher, s.status)
+	}
+}
+
+func (s *syncStatus) watch(ctx context.Context) <-chan SyncStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.watchers == nil {
+		s.watchers = make(map[chan SyncStatus]struct{})
+	}
+
+	watcher := make(chan SyncStatus, 1)
+	watcher <- s.status
+	s.watchers[watcher] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		delete(s.watchers, watcher)
+		close(watcher)
+	}()
+
+	return watcher
+}
+
+// sendLatest sends the status to the given channel, replacing any status
+// that hasn't been received yet. Slow watchers only miss intermediate
+// updates, never the latest one.
+func sendLatest(ch chan SyncStatus, status SyncStatus) {
+	for {
+		select {
+		case ch <- status:
+			return
+		default:
+		}
+
+		select {
+		case <-ch:
+		default:
+		}
+	}
+}
+
+// SyncStatus returns the current sync status of the parser.
+func (p *Parser) SyncStatus() SyncStatus {
+	return p.syncStatus.get()
+}
+
+// WatchSyncStatus returns a channel that receives the current sync status
+// right away, and then again whenever it changes. The channel is closed
+// when the context is cancelled.
+func (p *Parser) WatchSyncStatus(ctx context.Context) <-chan SyncStatus {
+	return p.syncStatus.watch(ctx)
+}
+
+// setSyncState updates the state, if it changed.
+func (p *Parser) setSyncState(state SyncState, err error) {
+	if p.syncStatus.get().State == state {
+		return
+	}
+
+	p.syncStatus.update(func(status *SyncStatus) {
+		status.State = state
+		status.Err = err
+		if state != SyncStateSyncing {
+			status.BlocksPerSecond = 0
+			status.ETA = 0
+		}
+	})
+}
+
+// setSyncProgress records that blocks up to and including the given block
+// have been processed, after spending the given time on the blocks since the
+// previous update.
+func (p *Parser) setSyncProgress(
+	height uint32, hash chainhash.Hash, blockHeight, headerHeight uint32,
+	processed uint32, elapsed time.Duration,
+) {
+	current := p.syncStatus.get()
+	unchanged := current.ProcessedHeight == height && current.ProcessedHash == hash &&
+		current.BlockHeight == blockHeight && current.HeaderHeight == headerHeight
+	if processed == 0 && unchanged && current.State == SyncStateSynced {
+		return
+	}
+
+	p.syncStatus.update(func(status *SyncStatus) {
+		status.ProcessedHeight = height
+		status.ProcessedHash = hash
+		status.BlockHeight = blockHeight
+		status.HeaderHeight = headerHeight
+		status.Err = nil
+
+		if height >= blockHeight {
+			status.State = SyncStateSynced
+			status.BlocksPerSecond = 0
+			status.ETA = 0
+			return
+		}
+
+		status.State = SyncStateSyncing
+		if processed > 0 && elapsed > 0 {
+			rate := float64(processed) / elapsed.Seconds()
+			if status.BlocksPerSecond == 0 {
+				status.BlocksPerSecond = rate
+			} else {
+				status.BlocksPerSecond = blockRateSmoothing*rate + (1-blockRateSmoothing)*status.BlocksPerSecond
+			}
+		}
+
+		status.ETA = 0
+		if status.BlocksPerSecond > 0 {
+			remaining := float64(max(blockHeight, headerHeight) - height)
+			status.ETA = time.Duration(remaining / status.BlocksPerSecond * float64(time.Second))
+		}
+	})
+}
+
+// setSyncReorg records that we rolled back to the given height.
+func (p *Parser) setSyncReorg(reorg Reorg, hash chainhash.Hash) {
+	p.syncStatus.update(func(status *SyncStatus) {
+		status.State = SyncStateSyncing
+		status.ProcessedHeight = reorg.ForkHeight
+		status.ProcessedHash = hash
+		status.Reorg = &reorg
+	})
+}
@@ -0,0 +1,78 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	parser := &Parser{}
+
+	updates := parser.WatchSyncStatus(ctx)
+	receive := func() SyncStatus {
+		select {
+		case status := <-updates:
+			return status
+		case <-time.After(time.Second):
+			t.Fatal("no sync status update")
+			return SyncStatus{}
+		}
+	}
+
+	// The current status is sent right away
+	assert.Equal(t, SyncState(""), receive().State)
+
+	parser.setSyncState(SyncStateCoreUnavailable, errors.New("connection refused"))
+	status := receive()
+	assert.Equal(t, SyncStateCoreUnavailable, status.State)
+	assert.EqualError(t, status.Err, "connection refused")
+
+	// Unchanged state is not sent again
+	parser.setSyncState(SyncStateCoreUnavailable, errors.New("connection refused"))
+
+	parser.setSyncProgress(100, chainhash.Hash{1}, 1100, 1100, 100, 10*time.Second)
+	status = receive()
+	assert.Equal(t, SyncStateSyncing, status.State)
+	assert.Equal(t, uint32(100), status.ProcessedHeight)
+	assert.NoError(t, status.Err)
+	assert.InDelta(t, 10, status.BlocksPerSecond, 0.001)
+	assert.Equal(t, 100*time.Second, status.ETA)
+
+	parser.setSyncReorg(Reorg{FromHeight: 100, ForkHeight: 98}, chainhash.Hash{2})
+	status = receive()
+	require.NotNil(t, status.Reorg)
+	assert.Equal(t, uint32(98), status.ProcessedHeight)
+	assert.Equal(t, uint32(100), status.Reorg.FromHeight)
+
+	parser.setSyncProgress(1100, chainhash.Hash{3}, 1100, 1100, 1002, 10*time.Second)
+	status = receive()
+	assert.Equal(t, SyncStateSynced, status.State)
+	assert.Nil(t, status.Reorg, "reorg is only included in the update it caused")
+	assert.Zero(t, status.BlocksPerSecond)
+	assert.Zero(t, status.ETA)
+
+	// Nothing new to process
+	parser.setSyncProgress(1100, chainhash.Hash{3}, 1100, 1100, 0, 0)
+	select {
+	case status := <-updates:
+		t.Fatalf("unexpected update: %+v", status)
+	default:
+	}
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		_, ok := <-updates
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
@@ -1,49 +1,97 @@
 package engines
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
+	"connectrpc.com/connect"
+	corepb "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha"
+	corerpc "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha/bitcoindv1alphaconnect"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/rs/zerolog"
+	"github.com/samber/lo"
 
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blocks"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/opreturns"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/timestamps"
+	service "github.com/LayerTwo-Labs/sidesail/bitwindow/server/service"
 )
 
 type TimestampEngine struct {
-	db     *sql.DB
-	log    zerolog.Logger
-	wallet WalletService
+	db       *sql.DB
+	log      zerolog.Logger
+	wallet   WalletService
+	bitcoind *service.Service[corerpc.BitcoinServiceClient]
+
+	// If set, files are queued for this long and committed to in a single
+	// transaction
+	batchWindow time.Duration
+	// Only one batch is sent at a time
+	batchMu sync.Mutex
 }
 
 // WalletService interface for sending transactions
 type WalletService interface {
-	SendTransaction(ctx context.Context, opReturnData []byte) (string, error)
+	SendTransaction(ctx context.Context, opReturnData []byte, opts SendOptions) (string, error)
 }
 
-func NewTimestampEngine(db *sql.DB, log zerolog.Logger, wallet WalletService) *TimestampEngine {
+// SendOptions picks the wallet and fee rate of a timestamp transaction.
+type SendOptions struct {
+	// Wallet to send from. The active wallet if empty.
+	WalletID string
+	// Fee rate in sat/vB. Estimated if zero.
+	FeeSatPerVbyte uint64
+}
+
+func NewTimestampEngine(
+	db *sql.DB, log zerolog.Logger, wallet WalletService,
+	bitcoind *service.Service[corerpc.BitcoinServiceClient],
+	batchWindow time.Duration,
+) *TimestampEngine {
 	return &TimestampEngine{
-		db:     db,
-		log:    log.With().Str("component", "timestamp_engine").Logger(),
-		wallet: wallet,
+		db:          db,
+		log:         log.With().Str("component", "timestamp_engine").Logger(),
+		wallet:      wallet,
+		bitcoind:    bitcoind,
+		batchWindow: batchWindow,
 	}
 }
 
-func (e *TimestampEngine) TimestampFile(ctx context.Context, filename string, fileData []byte) (*timestamps.FileTimestamp, error) {
-	hash := sha256.Sum256(fileData)
-	fileHash := hex.EncodeToString(hash[:])
-
+func (e *TimestampEngine) TimestampFile(ctx context.Context, filename string, fileData []byte, opts SendOptions) (*timestamps.FileTimestamp, error) {
 	e.log.Info().
 		Str("filename", filename).
-		Str("hash", fileHash).
 		Int("size", len(fileData)).
 		Msg("creating timestamp for file")
 
+	return e.TimestampHash(ctx, filename, sha256.Sum256(fileData), opts)
+}
+
+// TimestampHash timestamps a file by its SHA256 hash, for files that are
+// hashed as they're read instead of held in memory. Batched timestamps are
+// sent from the active wallet at the estimated fee rate, so setting opts
+// while batching returns ErrBatchedSendOptions.
+func (e *TimestampEngine) TimestampHash(ctx context.Context, filename string, hash [32]byte, opts SendOptions) (*timestamps.FileTimestamp, error) {
+	if e.batchWindow > 0 && opts != (SendOptions{}) {
+		return nil, ErrBatchedSendOptions
+	}
+
+	fileHash := hex.EncodeToString(hash[:])
+
 	// Check if already timestamped
 	existing, err := timestamps.GetByHash(ctx, e.db, fileHash)
 	if err != nil {
@@ -58,12 +106,16 @@ func (e *TimestampEngine) TimestampFile(ctx context.Context, filename string, fi
 		return existing, nil
 	}
 
+	if e.batchWindow > 0 {
+		return e.queueTimestamp(ctx, filename, fileHash)
+	}
+
 	// Create Bitcoin OP_RETURN transaction with file hash
 	if e.wallet == nil {
 		return nil, fmt.Errorf("wallet service not available")
 	}
 
-	txid, err := e.wallet.SendTransaction(ctx, hash[:])
+	txid, err := e.wallet.SendTransaction(ctx, hash[:], opts)
 	if err != nil {
 		return nil, fmt.Errorf("send timestamp transaction: %w", err)
 	}
@@ -99,17 +151,149 @@ func (e *TimestampEngine) TimestampFile(ctx context.Context, filename string, fi
 	return &timestamp, nil
 }
 
+var ErrBatchedSendOptions = errors.New("wallet and fee rate can't be set when timestamps are batched")
+
+// queueTimestamp adds a file to the next batch.
+func (e *TimestampEngine) queueTimestamp(ctx context.Context, filename, fileHash string) (*timestamps.FileTimestamp, error) {
+	timestamp := timestamps.FileTimestamp{
+		Filename:  filename,
+		FileHash:  fileHash,
+		Status:    timestamps.StatusPending,
+		CreatedAt: time.Now(),
+	}
+
+	id, err := timestamps.Create(ctx, e.db, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("create timestamp record: %w", err)
+	}
+	timestamp.ID = id
+
+	e.log.Info().
+		Int64("id", id).
+		Str("filename", filename).
+		Str("hash", fileHash).
+		Msgf("queued file timestamp for the next batch, sent within %s", e.batchWindow)
+
+	return &timestamp, nil
+}
+
+// CommitBatch sends a single transaction committing to all queued files,
+// through the root of a merkle tree over their hashes. Returns the txid, or
+// an empty string if nothing was queued. The batch is saved before it's sent,
+// so a batch that was sent is never sent again.
+func (e *TimestampEngine) CommitBatch(ctx context.Context) (string, error) {
+	e.batchMu.Lock()
+	defer e.batchMu.Unlock()
+
+	if err := e.recoverBatches(ctx); err != nil {
+		return "", err
+	}
+
+	pending, err := timestamps.ListPending(ctx, e.db)
+	if err != nil {
+		return "", err
+	}
+	if len(pending) == 0 {
+		return "", nil
+	}
+
+	leaves := make([][32]byte, len(pending))
+	for i, timestamp := range pending {
+		hash, err := hex.DecodeString(timestamp.FileHash)
+		if err != nil || len(hash) != sha256.Size {
+			return "", fmt.Errorf("timestamp %d: invalid file hash %q", timestamp.ID, timestamp.FileHash)
+		}
+		leaves[i] = [32]byte(hash)
+	}
+	root, paths := timestamps.BuildMerkleTree(leaves)
+	rootHex := hex.EncodeToString(root[:])
+
+	if e.wallet == nil {
+		return "", fmt.Errorf("wallet service not available")
+	}
+
+	byID := make(map[int64]timestamps.MerklePath, len(pending))
+	for i, timestamp := range pending {
+		byID[timestamp.ID] = paths[i]
+	}
+	if err := timestamps.StartBatch(ctx, e.db, rootHex, byID); err != nil {
+		return "", err
+	}
+
+	txid, err := e.wallet.SendTransaction(ctx, root[:], SendOptions{})
+	if err != nil {
+		if abortErr := timestamps.AbortBatch(ctx, e.db, rootHex); abortErr != nil {
+			e.log.Error().Err(abortErr).Str("merkle_root", rootHex).Msg("could not queue timestamp batch again")
+		}
+		return "", fmt.Errorf("send timestamp batch transaction: %w", err)
+	}
+
+	if err := timestamps.CommitBatch(ctx, e.db, txid, rootHex); err != nil {
+		// Picked up through the OP_RETURN index by the next batch
+		return "", err
+	}
+
+	e.log.Info().
+		Int("files", len(pending)).
+		Str("txid", txid).
+		Str("merkle_root", rootHex).
+		Msg("created timestamp batch transaction on blockchain")
+
+	return txid, nil
+}
+
+// recoverBatches finishes batches that were sent, but never recorded as
+// committed. The transaction is looked up through the OP_RETURN index. When
+// it isn't there, the batch is marked as failed instead of sending it again,
+// as it might still have made it out. Failed batches can be rebroadcast.
+func (e *TimestampEngine) recoverBatches(ctx context.Context) error {
+	roots, err := timestamps.ListSendingBatches(ctx, e.db)
+	if err != nil {
+		return err
+	}
+
+	for _, root := range roots {
+		data, err := hex.DecodeString(root)
+		if err != nil {
+			return fmt.Errorf("batch %s: invalid merkle root: %w", root, err)
+		}
+
+		indexed, err := opreturns.ListByData(ctx, e.db, data)
+		if err != nil {
+			return err
+		}
+		sent, found := lo.Find(indexed, func(opReturn opreturns.OPReturn) bool {
+			return opReturn.Status == opreturns.StatusActive
+		})
+		if !found {
+			e.log.Warn().Str("merkle_root", root).Msg("timestamp batch transaction was not seen, marking as failed")
+			if err := timestamps.FailBatch(ctx, e.db, root, "batch transaction was not seen after sending"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := timestamps.CommitBatch(ctx, e.db, sent.TxID, root); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (e *TimestampEngine) ListTimestamps(ctx context.Context) ([]timestamps.FileTimestamp, error) {
 	return timestamps.List(ctx, e.db)
 }
 
+var ErrTimestampNotFound = errors.New("timestamp not found")
+
 func (e *TimestampEngine) GetTimestamp(ctx context.Context, id int64) (*timestamps.FileTimestamp, error) {
 	timestamp, err := timestamps.Get(ctx, e.db, id)
 	if err != nil {
 		return nil, fmt.Errorf("get timestamp: %w", err)
 	}
 	if timestamp == nil {
-		return nil, fmt.Errorf("timestamp not found")
+		return nil, ErrTimestampNotFound
 	}
 
 	return timestamp, nil
@@ -128,14 +312,72 @@ func (e *TimestampEngine) VerifyTimestamp(ctx context.Context, fileData []byte)
 		return nil, fmt.Errorf("no timestamp found for this file")
 	}
 
+	if err := e.verifyCommitment(ctx, hash, timestamp); err != nil {
+		return nil, err
+	}
+
 	e.log.Info().
 		Str("hash", fileHash).
-		Str("txid", *timestamp.TxID).
+		Str("txid", lo.FromPtr(timestamp.TxID)).
 		Msg("file timestamp verified")
 
 	return timestamp, nil
 }
 
+var ErrCommitmentMismatch = errors.New("timestamp does not commit to the file")
+
+// commitment is what the transaction of a timestamp commits to: the merkle
+// root for batched timestamps, the file hash otherwise.
+func commitment(timestamp *timestamps.FileTimestamp) ([]byte, error) {
+	return hex.DecodeString(lo.FromPtrOr(timestamp.MerkleRoot, timestamp.FileHash))
+}
+
+// verifyCommitment checks that the timestamp commits to the file hash. For
+// batched timestamps, the merkle path has to lead to the root. Once
+// confirmed, the transaction has to commit to the root.
+func (e *TimestampEngine) verifyCommitment(ctx context.Context, hash [32]byte, timestamp *timestamps.FileTimestamp) error {
+	expected, err := commitment(timestamp)
+	if err != nil {
+		return fmt.Errorf("decode commitment: %w", err)
+	}
+
+	if timestamp.MerkleRoot != nil {
+		root, err := timestamp.MerklePath.Root(hash)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrCommitmentMismatch, err)
+		}
+		if !bytes.Equal(root[:], expected) {
+			return fmt.Errorf("%w: merkle path leads to %x, not %s", ErrCommitmentMismatch, root, *timestamp.MerkleRoot)
+		}
+	} else if !bytes.Equal(hash[:], expected) {
+		return ErrCommitmentMismatch
+	}
+
+	if timestamp.Status != timestamps.StatusConfirmed || timestamp.TxID == nil {
+		return nil
+	}
+
+	opReturns, err := opreturns.ListByTxID(ctx, e.db, *timestamp.TxID)
+	if err != nil {
+		return err
+	}
+	// Not seen by the parser, e.g. because it's still syncing
+	if len(opReturns) == 0 {
+		return nil
+	}
+
+	if !lo.ContainsBy(opReturns, func(opReturn opreturns.OPReturn) bool {
+		return bytes.Equal(opReturn.Data, expected)
+	}) {
+		return fmt.Errorf("%w: transaction %s commits to something else", ErrCommitmentMismatch, *timestamp.TxID)
+	}
+
+	return nil
+}
+
+// UpgradeTimestamp checks whether the transaction of a timestamp confirmed,
+// or is never going to. Returns whether the timestamp changed, and the
+// height it was confirmed at.
 func (e *TimestampEngine) UpgradeTimestamp(ctx context.Context, id int64) (bool, *int64, string, error) {
 	timestamp, err := e.GetTimestamp(ctx, id)
 	if err != nil {
@@ -150,17 +392,112 @@ func (e *TimestampEngine) UpgradeTimestamp(ctx context.Context, id int64) (bool,
 	if timestamp.TxID == nil {
 		return false, nil, "no transaction ID", nil
 	}
-
-	// TODO: Query Bitcoin node to check if transaction is confirmed
-	// For now, we'll just mark it as pending upgrade
-	// This would need to be implemented with actual RPC calls to bitcoind
+	txid := *timestamp.TxID
 
 	e.log.Debug().
 		Int64("id", id).
-		Str("txid", *timestamp.TxID).
+		Str("txid", txid).
 		Msg("checking transaction confirmation status")
 
-	return false, nil, "confirmation check not yet implemented - check txid on block explorer", nil
+	confirmation, failure, err := e.findConfirmation(ctx, txid)
+	if err != nil {
+		return false, nil, "", fmt.Errorf("find confirmation of %s: %w", txid, err)
+	}
+
+	switch {
+	case confirmation != nil:
+		confirmed, err := timestamps.Confirm(ctx, e.db, []string{txid}, confirmation.height, confirmation.hash, confirmation.time)
+		if err != nil {
+			return false, nil, "", err
+		}
+		height := int64(confirmation.height)
+		return confirmed > 0, &height, fmt.Sprintf("confirmed at block %d", height), nil
+
+	case failure != "":
+		failed, err := timestamps.MarkFailed(ctx, e.db, txid, failure)
+		if err != nil {
+			return false, nil, "", err
+		}
+		return failed, nil, failure, nil
+	}
+
+	return false, nil, "waiting for confirmation", nil
+}
+
+type txConfirmation struct {
+	height uint32
+	hash   string
+	time   time.Time
+}
+
+// findConfirmation looks for the block a transaction was confirmed in, first
+// among the blocks walked by the parser, and then by asking Core. If there is
+// none, returns why the transaction is never going to confirm, if we know.
+func (e *TimestampEngine) findConfirmation(ctx context.Context, txid string) (*txConfirmation, string, error) {
+	opReturns, err := opreturns.ListByTxID(ctx, e.db, txid)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, opReturn := range opReturns {
+		switch {
+		case opReturn.Height != nil:
+			block, err := blocks.GetProcessedBlock(ctx, e.db, *opReturn.Height)
+			if err != nil {
+				return nil, "", fmt.Errorf("get processed block %d: %w", *opReturn.Height, err)
+			}
+			return &txConfirmation{
+				height: block.Height,
+				hash:   block.Hash.String(),
+				time:   block.BlockTime,
+			}, "", nil
+
+		case opReturn.Status == opreturns.StatusReplaced:
+			return nil, "replaced by " + lo.FromPtr(opReturn.ReplacedByTxID), nil
+
+		case opReturn.Status == opreturns.StatusDropped:
+			return nil, "dropped from the mempool", nil
+		}
+	}
+
+	if e.bitcoind == nil {
+		return nil, "", nil
+	}
+	core, err := e.bitcoind.Get(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tx, err := core.GetRawTransaction(ctx, connect.NewRequest(&corepb.GetRawTransactionRequest{
+		Txid:      txid,
+		Verbosity: corepb.GetRawTransactionRequest_VERBOSITY_TX_INFO,
+	}))
+	// Without -txindex, Core only knows of confirmed transactions of its
+	// own wallets
+	if connect.CodeOf(err) == connect.CodeNotFound {
+		return nil, "", nil
+	} else if err != nil {
+		return nil, "", fmt.Errorf("get raw transaction: %w", err)
+	}
+
+	// Still in the mempool
+	if tx.Msg.Blockhash == "" {
+		return nil, "", nil
+	}
+
+	block, err := core.GetBlock(ctx, connect.NewRequest(&corepb.GetBlockRequest{
+		Hash:      tx.Msg.Blockhash,
+		Verbosity: corepb.GetBlockRequest_VERBOSITY_BLOCK_INFO,
+	}))
+	if err != nil {
+		return nil, "", fmt.Errorf("get block %s: %w", tx.Msg.Blockhash, err)
+	}
+
+	return &txConfirmation{
+		height: block.Msg.Height,
+		hash:   block.Msg.Hash,
+		time:   block.Msg.Time.AsTime(),
+	}, "", nil
 }
 
 func (e *TimestampEngine) UpgradeAllPending(ctx context.Context) error {
@@ -192,6 +529,469 @@ func (e *TimestampEngine) UpgradeAllPending(ctx context.Context) error {
 	return nil
 }
 
+// How often timestamps that aren't confirmed yet are checked
+const timestampUpgradeInterval = time.Minute
+
+// Run upgrades pending timestamps until the context is cancelled. Most are
+// confirmed by the parser as it walks the chain, this catches the ones it
+// can't see, e.g. while it's still syncing.
+func (e *TimestampEngine) Run(ctx context.Context) error {
+	e.log.Info().Msg("starting timestamp engine")
+
+	ticker := time.NewTicker(timestampUpgradeInterval)
+	defer ticker.Stop()
+
+	// Nil channels block forever, so no batches are sent if disabled
+	var batches <-chan time.Time
+	if e.batchWindow > 0 {
+		batchTicker := time.NewTicker(e.batchWindow)
+		defer batchTicker.Stop()
+		batches = batchTicker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.log.Info().Msg("timestamp engine shutting down")
+			return nil
+
+		case <-ticker.C:
+			if err := e.UpgradeAllPending(ctx); err != nil {
+				e.log.Warn().Err(err).Msg("upgrade pending timestamps")
+			}
+
+		case <-batches:
+			// Left queued if it fails to send, so retried with the next batch
+			if _, err := e.CommitBatch(ctx); err != nil {
+				e.log.Warn().Err(err).Msg("commit timestamp batch")
+			}
+		}
+	}
+}
+
+// RebroadcastTimestamp sends a new transaction for a failed timestamp.
+func (e *TimestampEngine) RebroadcastTimestamp(ctx context.Context, id int64) (*timestamps.FileTimestamp, error) {
+	timestamp, err := e.GetTimestamp(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if timestamp.Status != timestamps.StatusFailed {
+		return nil, fmt.Errorf("rebroadcast timestamp %d: %w", id, timestamps.ErrNotFailed)
+	}
+
+	data, err := commitment(timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("decode commitment: %w", err)
+	}
+
+	if e.wallet == nil {
+		return nil, fmt.Errorf("wallet service not available")
+	}
+	txid, err := e.wallet.SendTransaction(ctx, data, SendOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("send timestamp transaction: %w", err)
+	}
+
+	if err := timestamps.Rebroadcast(ctx, e.db, id, txid); err != nil {
+		return nil, err
+	}
+
+	e.log.Info().
+		Int64("id", id).
+		Str("txid", txid).
+		Str("previous-txid", lo.FromPtr(timestamp.TxID)).
+		Msg("rebroadcast timestamp transaction")
+
+	return e.GetTimestamp(ctx, id)
+}
+
+var ErrTimestampNotConfirmed = errors.New("timestamp is not confirmed yet")
+
+// ExportProof builds an OpenTimestamps proof for a confirmed timestamp. It
+// can be verified by anyone with the block headers, without our database.
+func (e *TimestampEngine) ExportProof(ctx context.Context, id int64) (*timestamps.FileTimestamp, []byte, error) {
+	timestamp, err := e.GetTimestamp(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if timestamp.Status != timestamps.StatusConfirmed || timestamp.BlockHash == nil || timestamp.BlockHeight == nil {
+		return nil, nil, fmt.Errorf("export proof of timestamp %d: %w", id, ErrTimestampNotConfirmed)
+	}
+
+	if e.bitcoind == nil {
+		return nil, nil, fmt.Errorf("bitcoind service not available")
+	}
+	core, err := e.bitcoind.Get(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The proof needs the whole transaction, and the txids of the block
+	resp, err := core.GetBlock(ctx, connect.NewRequest(&corepb.GetBlockRequest{
+		Hash:      *timestamp.BlockHash,
+		Verbosity: corepb.GetBlockRequest_VERBOSITY_RAW_DATA,
+	}))
+	if err != nil {
+		return nil, nil, fmt.Errorf("bitcoind: get block %s: %w", *timestamp.BlockHash, err)
+	}
+	blockBytes, err := hex.DecodeString(resp.Msg.Hex)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode block hex: %w", err)
+	}
+	var block wire.MsgBlock
+	if err := block.Deserialize(bytes.NewReader(blockBytes)); err != nil {
+		return nil, nil, fmt.Errorf("deserialize block: %w", err)
+	}
+
+	proof, err := timestamps.NewProof(*timestamp, &block, uint32(*timestamp.BlockHeight))
+	if err != nil {
+		return nil, nil, fmt.Errorf("build proof of timestamp %d: %w", id, err)
+	}
+	encoded, err := proof.MarshalBinary()
+	if err != nil {
+		return nil, nil, fmt.Errorf("encode proof: %w", err)
+	}
+
+	return timestamp, encoded, nil
+}
+
+// ProofVerification is the block an OpenTimestamps proof was verified
+// against.
+type ProofVerification struct {
+	FileHash    string
+	BlockHeight uint32
+	BlockHash   string
+	BlockTime   time.Time
+}
+
+// VerifyProof checks that an OpenTimestamps proof leads from the file to the
+// merkle root of a block in our chain. Only the proof and the block headers
+// are used, so it works for proofs made by anyone.
+func (e *TimestampEngine) VerifyProof(ctx context.Context, fileData, proofData []byte) (*ProofVerification, error) {
+	proof, err := timestamps.ParseProof(proofData)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(fileData)
+	if hash != proof.FileHash {
+		return nil, fmt.Errorf("%w: proof is for file hash %x", ErrCommitmentMismatch, proof.FileHash)
+	}
+	merkleRoot, err := proof.BlockMerkleRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	if e.bitcoind == nil {
+		return nil, fmt.Errorf("bitcoind service not available")
+	}
+	core, err := e.bitcoind.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockHash, err := core.GetBlockHash(ctx, connect.NewRequest(&corepb.GetBlockHashRequest{
+		Height: proof.Height,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("bitcoind: get block hash %d: %w", proof.Height, err)
+	}
+	block, err := core.GetBlock(ctx, connect.NewRequest(&corepb.GetBlockRequest{
+		Hash:      blockHash.Msg.Hash,
+		Verbosity: corepb.GetBlockRequest_VERBOSITY_BLOCK_INFO,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("bitcoind: get block %s: %w", blockHash.Msg.Hash, err)
+	}
+
+	expected, err := chainhash.NewHashFromStr(block.Msg.MerkleRoot)
+	if err != nil {
+		return nil, fmt.Errorf("parse merkle root of block %d: %w", proof.Height, err)
+	}
+	if !bytes.Equal(expected[:], merkleRoot) {
+		return nil, fmt.Errorf("%w: proof does not lead to the merkle root of block %d", ErrCommitmentMismatch, proof.Height)
+	}
+
+	e.log.Info().
+		Str("hash", hex.EncodeToString(hash[:])).
+		Uint32("height", proof.Height).
+		Msg("timestamp proof verified")
+
+	return &ProofVerification{
+		FileHash:    hex.EncodeToString(hash[:]),
+		BlockHeight: proof.Height,
+		BlockHash:   block.Msg.Hash,
+		BlockTime:   block.Msg.Time.AsTime(),
+	}, nil
+}
+
+// ChainTimestamp is a transaction committing to a file hash, found on chain.
+type ChainTimestamp struct {
+	FileHash  string
+	TxID      string
+	Height    uint32
+	BlockHash string
+	BlockTime time.Time
+}
+
+// Scanning blocks through Core is slow, this is about two weeks of blocks
+const maxTimestampScanBlocks = 2016
+
+var (
+	ErrTimestampNotOnChain = errors.New("no transaction commits to this file")
+	ErrScanRangeTooLarge   = errors.New("too many blocks to scan")
+)
+
+// VerifyTimestampOnChain looks for an OP_RETURN with exactly the hash of the
+// file, without looking at our own timestamps. This works for files anyone
+// timestamped, and on a fresh install. Indexed OP_RETURNs are searched first,
+// and blocks the index doesn't cover yet are scanned through Core. Start and
+// end narrow down the blocks to search, and are inclusive.
+func (e *TimestampEngine) VerifyTimestampOnChain(ctx context.Context, fileData []byte, start, end *uint32) (*ChainTimestamp, error) {
+	hash := sha256.Sum256(fileData)
+	inRange := func(height uint32) bool {
+		return height >= lo.FromPtr(start) && (end == nil || height <= *end)
+	}
+
+	indexed, err := opreturns.ListByData(ctx, e.db, hash[:])
+	if err != nil {
+		return nil, err
+	}
+	for _, opReturn := range indexed {
+		if opReturn.Height == nil || !inRange(*opReturn.Height) {
+			continue
+		}
+
+		block, err := blocks.GetProcessedBlock(ctx, e.db, *opReturn.Height)
+		if err != nil {
+			return nil, fmt.Errorf("get processed block %d: %w", *opReturn.Height, err)
+		}
+		return &ChainTimestamp{
+			FileHash:  hex.EncodeToString(hash[:]),
+			TxID:      opReturn.TxID,
+			Height:    block.Height,
+			BlockHash: block.Hash.String(),
+			BlockTime: block.BlockTime,
+		}, nil
+	}
+
+	found, err := e.scanForTimestamp(ctx, hash, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, ErrTimestampNotOnChain
+	}
+
+	e.log.Info().
+		Str("hash", found.FileHash).
+		Str("txid", found.TxID).
+		Uint32("height", found.Height).
+		Msg("found file timestamp on chain")
+
+	return found, nil
+}
+
+// scanForTimestamp scans the blocks not covered by the OP_RETURN index for
+// one committing to the hash.
+func (e *TimestampEngine) scanForTimestamp(ctx context.Context, hash [32]byte, start, end *uint32) (*ChainTimestamp, error) {
+	if e.bitcoind == nil {
+		return nil, fmt.Errorf("bitcoind service not available")
+	}
+	core, err := e.bitcoind.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := core.GetBlockchainInfo(ctx, connect.NewRequest(&corepb.GetBlockchainInfoRequest{}))
+	if err != nil {
+		return nil, fmt.Errorf("bitcoind: get blockchain info: %w", err)
+	}
+
+	cursor, err := blocks.GetProcessorCursor(ctx, e.db, OPReturnIndexName)
+	if err != nil {
+		return nil, err
+	}
+
+	from := lo.FromPtr(start)
+	if cursor != nil {
+		from = max(from, cursor.Height+1)
+	}
+	to := min(lo.FromPtrOr(end, info.Msg.Blocks), info.Msg.Blocks)
+	if from > to {
+		return nil, nil
+	}
+	if to-from+1 > maxTimestampScanBlocks {
+		return nil, fmt.Errorf(
+			"%w: blocks %d to %d aren't indexed, narrow down the range to %d blocks",
+			ErrScanRangeTooLarge, from, to, maxTimestampScanBlocks,
+		)
+	}
+
+	e.log.Info().
+		Uint32("from", from).
+		Uint32("to", to).
+		Msg("scanning blocks for file timestamp")
+
+	for height := from; height <= to; height++ {
+		block, err := fetchBlock(ctx, e.bitcoind, height)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tx := range block.Transactions {
+			for _, txout := range tx.TxOut {
+				if len(txout.PkScript) == 0 || txout.PkScript[0] != txscript.OP_RETURN {
+					continue
+				}
+				data, ok := parseOPReturnData(txout.PkScript)
+				if !ok || !bytes.Equal(data, hash[:]) {
+					continue
+				}
+
+				return &ChainTimestamp{
+					FileHash:  hex.EncodeToString(hash[:]),
+					TxID:      tx.TxID(),
+					Height:    height,
+					BlockHash: block.BlockHash().String(),
+					BlockTime: block.Header.Timestamp,
+				}, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// TimestampPath timestamps a file on disk. Directories are timestamped
+// through a manifest of the hashes of all files in them, see BuildManifest.
+// Returns the manifest for directories. The path itself may be a symlink,
+// symlinks inside directories are skipped.
+func (e *TimestampEngine) TimestampPath(ctx context.Context, path string, opts SendOptions) (*timestamps.FileTimestamp, *Manifest, error) {
+	path = filepath.Clean(path)
+	// Named after the path we're given, not what it links to
+	name := filepath.Base(path)
+
+	// WalkDir doesn't follow a symlink to a directory, not even at the root
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolve %s: %w", path, err)
+	}
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return nil, nil, fmt.Errorf("stat %s: %w", path, err)
+	}
+	path = resolved
+
+	if !info.IsDir() {
+		fileHash, err := HashFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		hash, err := hex.DecodeString(fileHash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decode file hash: %w", err)
+		}
+
+		timestamp, err := e.TimestampHash(ctx, name, [32]byte(hash), opts)
+		return timestamp, nil, err
+	}
+
+	manifest, err := BuildManifest(ctx, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	e.log.Info().
+		Str("path", path).
+		Int("files", len(manifest.Files)).
+		Msg("creating timestamp for directory manifest")
+
+	timestamp, err := e.TimestampHash(ctx, name+"/", manifest.Hash(), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return timestamp, manifest, nil
+}
+
+var ErrEmptyDirectory = errors.New("directory has no files")
+
+// ManifestEntry is a file in a directory manifest.
+type ManifestEntry struct {
+	// Relative to the directory, with forward slashes
+	Path string
+	// Hex encoded SHA256
+	Hash string
+}
+
+// Manifest lists the hashes of all files in a directory.
+type Manifest struct {
+	Files []ManifestEntry
+}
+
+// BuildManifest hashes all regular files in a directory and its
+// subdirectories. Symlinks and other special files are skipped. The
+// manifest only depends on the paths and contents of the files, so it can
+// be rebuilt to verify the directory later.
+func BuildManifest(ctx context.Context, dir string) (*Manifest, error) {
+	var manifest Manifest
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		hash, err := HashFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		manifest.Files = append(manifest.Files, ManifestEntry{
+			Path: filepath.ToSlash(relative),
+			Hash: hash,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("build manifest of %s: %w", dir, err)
+	}
+	if len(manifest.Files) == 0 {
+		return nil, fmt.Errorf("build manifest of %s: %w", dir, ErrEmptyDirectory)
+	}
+
+	// WalkDir goes through directories in lexical order, but the file
+	// order has to be stable no matter how it's built
+	slices.SortFunc(manifest.Files, func(a, b ManifestEntry) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return &manifest, nil
+}
+
+// String formats the manifest like the output of sha256sum, so it can be
+// checked with `sha256sum -c`.
+func (m Manifest) String() string {
+	var manifest strings.Builder
+	for _, file := range m.Files {
+		fmt.Fprintf(&manifest, "%s  %s\n", file.Hash, file.Path)
+	}
+	return manifest.String()
+}
+
+// Hash is what timestamping a directory commits to.
+func (m Manifest) Hash() [32]byte {
+	return sha256.Sum256([]byte(m.String()))
+}
+
 func HashFile(path string) (string, error) {
 	f, err := os.Open(path)
 	if err != nil {
@@ -0,0 +1,592 @@
+package engines
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/blocks"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/opreturns"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/timestamps"
+	service "github.com/LayerTwo-Labs/sidesail/bitwindow/server/service"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests/mocks"
+	corepb "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha"
+	corerpc "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha/bitcoindv1alphaconnect"
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakeTimestampWallet struct {
+	sent [][]byte
+	txs  []*wire.MsgTx
+	err  error
+}
+
+func (w *fakeTimestampWallet) SendTransaction(_ context.Context, opReturnData []byte, _ SendOptions) (string, error) {
+	if w.err != nil {
+		return "", w.err
+	}
+	script, err := txscript.NullDataScript(opReturnData)
+	if err != nil {
+		return "", err
+	}
+	tx := &wire.MsgTx{
+		TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: chainhash.HashH(opReturnData)}}},
+		TxOut: []*wire.TxOut{{PkScript: script}},
+	}
+
+	w.sent = append(w.sent, opReturnData)
+	w.txs = append(w.txs, tx)
+	return tx.TxID(), nil
+}
+
+func createTimestamp(t *testing.T, db *sql.DB, content string, txid string) int64 {
+	t.Helper()
+
+	hash := sha256.Sum256([]byte(content))
+	id, err := timestamps.Create(context.Background(), db, timestamps.FileTimestamp{
+		Filename:  content + ".txt",
+		FileHash:  hex.EncodeToString(hash[:]),
+		TxID:      &txid,
+		Status:    timestamps.StatusConfirming,
+		CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+	return id
+}
+
+func TestTimestampConfirmation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := database.Test(t)
+	parser := &Parser{db: db}
+	wallet := &fakeTimestampWallet{}
+	engine := NewTimestampEngine(db, zerolog.Nop(), wallet, nil, 0)
+
+	spending := func(prev byte, content string) *wire.MsgTx {
+		hash := sha256.Sum256([]byte(content))
+		return &wire.MsgTx{
+			TxIn:  []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{prev}}}},
+			TxOut: []*wire.TxOut{{PkScript: pkScript(t, hash[:])}},
+		}
+	}
+	get := func(id int64) *timestamps.FileTimestamp {
+		timestamp, err := timestamps.Get(ctx, db, id)
+		require.NoError(t, err)
+		return timestamp
+	}
+
+	confirmedTx := spending(1, "confirmed")
+	droppedTx := spending(2, "dropped")
+	replacedTx := spending(3, "replaced")
+	confirmed := createTimestamp(t, db, "confirmed", confirmedTx.TxID())
+	dropped := createTimestamp(t, db, "dropped", droppedTx.TxID())
+	replaced := createTimestamp(t, db, "replaced", replacedTx.TxID())
+
+	for _, tx := range []*wire.MsgTx{confirmedTx, droppedTx, replacedTx} {
+		require.NoError(t, parser.HandleNewRawTransaction(ctx, tx))
+	}
+
+	require.NoError(t, parser.HandleRemovedTransaction(ctx, droppedTx.TxID()))
+	replacement := &wire.MsgTx{
+		TxIn:  replacedTx.TxIn,
+		TxOut: []*wire.TxOut{{Value: 1000, PkScript: []byte{txscript.OP_TRUE}}},
+	}
+	require.NoError(t, parser.HandleNewRawTransaction(ctx, replacement))
+
+	blockTime := time.Unix(1_700_000_000, 0)
+	block := &wire.MsgBlock{
+		Header:       wire.BlockHeader{Timestamp: blockTime},
+		Transactions: []*wire.MsgTx{spending(9, "coinbase-ish"), confirmedTx},
+	}
+	require.NoError(t, (&opReturnProcessor{parser: parser}).ProcessBlock(ctx, 10, block))
+
+	timestamp := get(confirmed)
+	assert.Equal(t, timestamps.StatusConfirmed, timestamp.Status)
+	assert.Equal(t, lo.ToPtr(int64(10)), timestamp.BlockHeight)
+	assert.Equal(t, lo.ToPtr(block.BlockHash().String()), timestamp.BlockHash)
+	require.NotNil(t, timestamp.ConfirmedAt)
+	assert.True(t, blockTime.Equal(*timestamp.ConfirmedAt))
+
+	timestamp = get(dropped)
+	assert.Equal(t, timestamps.StatusFailed, timestamp.Status)
+	assert.Equal(t, lo.ToPtr("dropped from the mempool"), timestamp.FailureReason)
+
+	timestamp = get(replaced)
+	assert.Equal(t, timestamps.StatusFailed, timestamp.Status)
+	assert.Equal(t, lo.ToPtr("replaced by "+replacement.TxID()), timestamp.FailureReason)
+
+	// A reorg takes the confirmation away again
+	require.NoError(t, timestamps.UnconfirmAboveHeight(ctx, db, 9))
+	timestamp = get(confirmed)
+	assert.Equal(t, timestamps.StatusConfirming, timestamp.Status)
+	assert.Nil(t, timestamp.BlockHash)
+
+	t.Run("rebroadcast", func(t *testing.T) {
+		rebroadcast, err := engine.RebroadcastTimestamp(ctx, dropped)
+		require.NoError(t, err)
+		assert.Equal(t, timestamps.StatusConfirming, rebroadcast.Status)
+		assert.Nil(t, rebroadcast.FailureReason)
+		assert.NotEqual(t, droppedTx.TxID(), *rebroadcast.TxID)
+
+		require.Len(t, wallet.sent, 1)
+		assert.Equal(t, rebroadcast.FileHash, hex.EncodeToString(wallet.sent[0]))
+
+		_, err = engine.RebroadcastTimestamp(ctx, confirmed)
+		assert.ErrorIs(t, err, timestamps.ErrNotFailed)
+		_, err = engine.RebroadcastTimestamp(ctx, 1234)
+		assert.ErrorIs(t, err, ErrTimestampNotFound)
+	})
+}
+
+func TestUpgradeTimestamp(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := database.Test(t)
+	core := mocks.NewMockBitcoinServiceClient(gomock.NewController(t))
+	engine := NewTimestampEngine(db, zerolog.Nop(), &fakeTimestampWallet{}, service.New("bitcoind", func(ctx context.Context) (corerpc.BitcoinServiceClient, error) {
+		return core, nil
+	}), 0)
+
+	txid := func(b byte) string { return chainhash.Hash{b}.String() }
+
+	// Seen by the parser
+	walked := createTimestamp(t, db, "walked", txid(1))
+	blockTime := time.Unix(1_700_000_000, 0)
+	require.NoError(t, blocks.MarkBlocksProcessed(ctx, db, []blocks.ProcessedBlock{{
+		Height: 7, Hash: chainhash.Hash{7}, BlockTime: blockTime,
+	}}))
+	require.NoError(t, opreturns.Persist(ctx, db, []opreturns.OPReturn{
+		{TxID: txid(1), Data: []byte("walked"), Height: lo.ToPtr(uint32(7))},
+		{TxID: txid(5), Data: []byte("dropped")},
+	}))
+
+	// Only Core knows
+	inCore := createTimestamp(t, db, "core", txid(2))
+	core.EXPECT().
+		GetRawTransaction(gomock.Any(), tests.Connect(&corepb.GetRawTransactionRequest{
+			Txid: txid(2), Verbosity: corepb.GetRawTransactionRequest_VERBOSITY_TX_INFO,
+		})).
+		Return(connect.NewResponse(&corepb.GetRawTransactionResponse{Blockhash: "blockhash"}), nil)
+	core.EXPECT().
+		GetBlock(gomock.Any(), tests.Connect(&corepb.GetBlockRequest{
+			Hash: "blockhash", Verbosity: corepb.GetBlockRequest_VERBOSITY_BLOCK_INFO,
+		})).
+		Return(connect.NewResponse(&corepb.GetBlockResponse{
+			Hash: "blockhash", Height: 8, Time: timestamppb.New(blockTime.Add(time.Minute)),
+		}), nil)
+
+	mempool := createTimestamp(t, db, "mempool", txid(3))
+	core.EXPECT().
+		GetRawTransaction(gomock.Any(), tests.Connect(&corepb.GetRawTransactionRequest{
+			Txid: txid(3), Verbosity: corepb.GetRawTransactionRequest_VERBOSITY_TX_INFO,
+		})).
+		Return(connect.NewResponse(&corepb.GetRawTransactionResponse{}), nil).
+		Times(2)
+
+	unknown := createTimestamp(t, db, "unknown", txid(4))
+	core.EXPECT().
+		GetRawTransaction(gomock.Any(), tests.Connect(&corepb.GetRawTransactionRequest{
+			Txid: txid(4), Verbosity: corepb.GetRawTransactionRequest_VERBOSITY_TX_INFO,
+		})).
+		Return(nil, connect.NewError(connect.CodeNotFound, errors.New("no such mempool or blockchain transaction"))).
+		Times(2)
+
+	dropped := createTimestamp(t, db, "dropped", txid(5))
+	_, err := opreturns.MarkDropped(ctx, db, txid(5))
+	require.NoError(t, err)
+
+	upgraded, height, _, err := engine.UpgradeTimestamp(ctx, walked)
+	require.NoError(t, err)
+	assert.True(t, upgraded)
+	assert.Equal(t, lo.ToPtr(int64(7)), height)
+
+	upgraded, height, _, err = engine.UpgradeTimestamp(ctx, inCore)
+	require.NoError(t, err)
+	assert.True(t, upgraded)
+	assert.Equal(t, lo.ToPtr(int64(8)), height)
+
+	for _, id := range []int64{mempool, unknown} {
+		upgraded, height, msg, err := engine.UpgradeTimestamp(ctx, id)
+		require.NoError(t, err)
+		assert.False(t, upgraded)
+		assert.Nil(t, height)
+		assert.Equal(t, "waiting for confirmation", msg)
+	}
+
+	upgraded, _, msg, err := engine.UpgradeTimestamp(ctx, dropped)
+	require.NoError(t, err)
+	assert.True(t, upgraded)
+	assert.Equal(t, "dropped from the mempool", msg)
+
+	// Only checks the ones that aren't done
+	require.NoError(t, engine.UpgradeAllPending(ctx))
+
+	all, err := engine.ListTimestamps(ctx)
+	require.NoError(t, err)
+	byID := lo.KeyBy(all, func(ts timestamps.FileTimestamp) int64 { return ts.ID })
+
+	assert.Equal(t, timestamps.StatusConfirmed, byID[walked].Status)
+	assert.Equal(t, lo.ToPtr(chainhash.Hash{7}.String()), byID[walked].BlockHash)
+	assert.True(t, blockTime.Equal(*byID[walked].ConfirmedAt))
+
+	assert.Equal(t, timestamps.StatusConfirmed, byID[inCore].Status)
+	assert.Equal(t, lo.ToPtr("blockhash"), byID[inCore].BlockHash)
+	assert.True(t, blockTime.Add(time.Minute).Equal(*byID[inCore].ConfirmedAt))
+
+	assert.Equal(t, timestamps.StatusConfirming, byID[mempool].Status)
+	assert.Equal(t, timestamps.StatusConfirming, byID[unknown].Status)
+	assert.Equal(t, timestamps.StatusFailed, byID[dropped].Status)
+}
+
+func TestTimestampBatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := database.Test(t)
+	parser := &Parser{db: db}
+	wallet := &fakeTimestampWallet{}
+	engine := NewTimestampEngine(db, zerolog.Nop(), wallet, nil, time.Hour)
+
+	files := []string{"first", "second", "third"}
+	for _, file := range files {
+		timestamp, err := engine.TimestampFile(ctx, file+".txt", []byte(file), SendOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, timestamps.StatusPending, timestamp.Status)
+		assert.Nil(t, timestamp.TxID)
+	}
+	assert.Empty(t, wallet.sent, "nothing is sent before the batch is committed")
+
+	_, err := engine.TimestampFile(ctx, "fourth.txt", []byte("fourth"), SendOptions{FeeSatPerVbyte: 5})
+	assert.ErrorIs(t, err, ErrBatchedSendOptions)
+	_, err = engine.TimestampFile(ctx, "fourth.txt", []byte("fourth"), SendOptions{WalletID: "other"})
+	assert.ErrorIs(t, err, ErrBatchedSendOptions)
+
+	queued, err := engine.VerifyTimestamp(ctx, []byte("first"))
+	require.NoError(t, err)
+	assert.Nil(t, queued.MerkleRoot)
+
+	txid, err := engine.CommitBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, wallet.sent, 1, "one transaction for the whole batch")
+
+	all, err := engine.ListTimestamps(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, len(files))
+	for _, timestamp := range all {
+		assert.Equal(t, timestamps.StatusConfirming, timestamp.Status)
+		assert.Equal(t, &txid, timestamp.TxID)
+		assert.Equal(t, lo.ToPtr(hex.EncodeToString(wallet.sent[0])), timestamp.MerkleRoot)
+	}
+
+	// Nothing left to commit
+	txid, err = engine.CommitBatch(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, txid)
+	assert.Len(t, wallet.sent, 1)
+
+	block := &wire.MsgBlock{
+		Header:       wire.BlockHeader{Timestamp: time.Unix(1_700_000_000, 0)},
+		Transactions: []*wire.MsgTx{{TxOut: []*wire.TxOut{{Value: 1}}}, wallet.txs[0]},
+	}
+	require.NoError(t, (&opReturnProcessor{parser: parser}).ProcessBlock(ctx, 10, block))
+
+	for _, file := range files {
+		timestamp, err := engine.VerifyTimestamp(ctx, []byte(file))
+		require.NoError(t, err, file)
+		assert.Equal(t, timestamps.StatusConfirmed, timestamp.Status)
+		assert.Equal(t, lo.ToPtr(int64(10)), timestamp.BlockHeight)
+
+		root, err := timestamp.MerklePath.Root(sha256.Sum256([]byte(file)))
+		require.NoError(t, err)
+		assert.Equal(t, wallet.sent[0], root[:])
+	}
+
+	t.Run("tampered path", func(t *testing.T) {
+		timestamp, err := timestamps.GetByHash(ctx, db, hex.EncodeToString(lo.ToPtr(sha256.Sum256([]byte("second")))[:]))
+		require.NoError(t, err)
+		timestamp.MerklePath[0].Left = !timestamp.MerklePath[0].Left
+
+		err = engine.verifyCommitment(ctx, sha256.Sum256([]byte("second")), timestamp)
+		assert.ErrorIs(t, err, ErrCommitmentMismatch)
+	})
+}
+
+func TestTimestampBatchRecovery(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := database.Test(t)
+	wallet := &fakeTimestampWallet{err: errors.New("wallet is locked")}
+	engine := NewTimestampEngine(db, zerolog.Nop(), wallet, nil, time.Hour)
+
+	queue := func(files ...string) (string, map[int64]timestamps.MerklePath) {
+		leaves := make([][32]byte, len(files))
+		ids := make([]int64, len(files))
+		for i, file := range files {
+			timestamp, err := engine.TimestampFile(ctx, file+".txt", []byte(file), SendOptions{})
+			require.NoError(t, err)
+			ids[i] = timestamp.ID
+			leaves[i] = sha256.Sum256([]byte(file))
+		}
+		root, paths := timestamps.BuildMerkleTree(leaves)
+		byID := make(map[int64]timestamps.MerklePath, len(files))
+		for i, id := range ids {
+			byID[id] = paths[i]
+		}
+		return hex.EncodeToString(root[:]), byID
+	}
+	statuses := func() map[string]timestamps.Status {
+		all, err := engine.ListTimestamps(ctx)
+		require.NoError(t, err)
+		return lo.SliceToMap(all, func(timestamp timestamps.FileTimestamp) (string, timestamps.Status) {
+			return timestamp.Filename, timestamp.Status
+		})
+	}
+
+	// Failing to send queues the files again
+	queue("first")
+	_, err := engine.CommitBatch(ctx)
+	require.ErrorContains(t, err, "wallet is locked")
+	assert.Equal(t, map[string]timestamps.Status{"first.txt": timestamps.StatusPending}, statuses())
+	wallet.err = nil
+
+	// Sent and seen, but never recorded as committed
+	seenRoot, seen := queue("second", "third")
+	require.NoError(t, timestamps.StartBatch(ctx, db, seenRoot, seen))
+	data, err := hex.DecodeString(seenRoot)
+	require.NoError(t, err)
+	require.NoError(t, opreturns.Persist(ctx, db, []opreturns.OPReturn{{TxID: "seen_txid", Data: data}}))
+
+	// Sent, but never seen
+	lostRoot, lost := queue("fourth")
+	require.NoError(t, timestamps.StartBatch(ctx, db, lostRoot, lost))
+
+	txid, err := engine.CommitBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, wallet.sent, 1, "recovered batches are not sent again")
+
+	assert.Equal(t, map[string]timestamps.Status{
+		"first.txt":  timestamps.StatusConfirming,
+		"second.txt": timestamps.StatusConfirming,
+		"third.txt":  timestamps.StatusConfirming,
+		"fourth.txt": timestamps.StatusFailed,
+	}, statuses())
+
+	first, err := engine.VerifyTimestamp(ctx, []byte("first"))
+	require.NoError(t, err)
+	assert.Equal(t, &txid, first.TxID)
+	second, err := engine.VerifyTimestamp(ctx, []byte("second"))
+	require.NoError(t, err)
+	assert.Equal(t, lo.ToPtr("seen_txid"), second.TxID)
+}
+
+func TestTimestampProof(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := database.Test(t)
+	parser := &Parser{db: db}
+	wallet := &fakeTimestampWallet{}
+	core := mocks.NewMockBitcoinServiceClient(gomock.NewController(t))
+	engine := NewTimestampEngine(db, zerolog.Nop(), wallet, service.New("bitcoind", func(ctx context.Context) (corerpc.BitcoinServiceClient, error) {
+		return core, nil
+	}), time.Hour)
+
+	files := []string{"first", "second", "third"}
+	ids := make([]int64, len(files))
+	for i, file := range files {
+		timestamp, err := engine.TimestampFile(ctx, file+".txt", []byte(file), SendOptions{})
+		require.NoError(t, err)
+		ids[i] = timestamp.ID
+	}
+
+	_, _, err := engine.ExportProof(ctx, ids[0])
+	require.ErrorIs(t, err, ErrTimestampNotConfirmed)
+
+	_, err = engine.CommitBatch(ctx)
+	require.NoError(t, err)
+
+	block := &wire.MsgBlock{
+		Header: wire.BlockHeader{Timestamp: time.Unix(1_700_000_000, 0)},
+		Transactions: []*wire.MsgTx{
+			{TxIn: []*wire.TxIn{{}}, TxOut: []*wire.TxOut{{Value: 1}}},
+			{TxIn: []*wire.TxIn{{}}, TxOut: []*wire.TxOut{{Value: 2}}},
+			wallet.txs[0],
+		},
+	}
+	block.Header.MerkleRoot = blockchain.CalcMerkleRoot(lo.Map(block.Transactions, func(tx *wire.MsgTx, _ int) *btcutil.Tx {
+		return btcutil.NewTx(tx)
+	}), false)
+	require.NoError(t, (&opReturnProcessor{parser: parser}).ProcessBlock(ctx, 10, block))
+
+	var raw bytes.Buffer
+	require.NoError(t, block.Serialize(&raw))
+	core.EXPECT().
+		GetBlock(gomock.Any(), tests.Connect(&corepb.GetBlockRequest{
+			Hash: block.BlockHash().String(), Verbosity: corepb.GetBlockRequest_VERBOSITY_RAW_DATA,
+		})).
+		Return(connect.NewResponse(&corepb.GetBlockResponse{Hex: hex.EncodeToString(raw.Bytes())}), nil).
+		Times(len(files))
+	core.EXPECT().
+		GetBlockHash(gomock.Any(), tests.Connect(&corepb.GetBlockHashRequest{Height: 10})).
+		Return(connect.NewResponse(&corepb.GetBlockHashResponse{Hash: block.BlockHash().String()}), nil).
+		AnyTimes()
+	core.EXPECT().
+		GetBlock(gomock.Any(), tests.Connect(&corepb.GetBlockRequest{
+			Hash: block.BlockHash().String(), Verbosity: corepb.GetBlockRequest_VERBOSITY_BLOCK_INFO,
+		})).
+		Return(connect.NewResponse(&corepb.GetBlockResponse{
+			Hash:       block.BlockHash().String(),
+			Height:     10,
+			MerkleRoot: block.Header.MerkleRoot.String(),
+			Time:       timestamppb.New(block.Header.Timestamp),
+		}), nil).
+		AnyTimes()
+
+	proofs := make([][]byte, len(files))
+	for i, file := range files {
+		timestamp, proof, err := engine.ExportProof(ctx, ids[i])
+		require.NoError(t, err, file)
+		assert.Equal(t, file+".txt", timestamp.Filename)
+		proofs[i] = proof
+
+		// Only the proof is needed, not our timestamps
+		verification, err := engine.VerifyProof(ctx, []byte(file), proof)
+		require.NoError(t, err, file)
+		assert.Equal(t, uint32(10), verification.BlockHeight)
+		assert.Equal(t, block.BlockHash().String(), verification.BlockHash)
+		assert.True(t, block.Header.Timestamp.Equal(verification.BlockTime))
+	}
+
+	_, err = engine.VerifyProof(ctx, []byte("second"), proofs[0])
+	assert.ErrorIs(t, err, ErrCommitmentMismatch, "proof of another file")
+
+	_, err = engine.VerifyProof(ctx, []byte("first"), []byte("not a proof"))
+	assert.ErrorIs(t, err, timestamps.ErrInvalidProof)
+
+	_, _, err = engine.ExportProof(ctx, 1234)
+	assert.ErrorIs(t, err, ErrTimestampNotFound)
+}
+
+func TestVerifyTimestampOnChain(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newEngine := func(t *testing.T) (*TimestampEngine, *sql.DB, *mocks.MockBitcoinServiceClient) {
+		db := database.Test(t)
+		core := mocks.NewMockBitcoinServiceClient(gomock.NewController(t))
+		engine := NewTimestampEngine(db, zerolog.Nop(), nil, service.New("bitcoind", func(ctx context.Context) (corerpc.BitcoinServiceClient, error) {
+			return core, nil
+		}), 0)
+		return engine, db, core
+	}
+	expectTip := func(core *mocks.MockBitcoinServiceClient, height uint32) {
+		core.EXPECT().
+			GetBlockchainInfo(gomock.Any(), gomock.Any()).
+			Return(connect.NewResponse(&corepb.GetBlockchainInfoResponse{Blocks: height}), nil).
+			AnyTimes()
+	}
+	expectBlock := func(core *mocks.MockBitcoinServiceClient, height uint32, block *wire.MsgBlock) {
+		var raw bytes.Buffer
+		require.NoError(t, block.Serialize(&raw))
+		core.EXPECT().
+			GetBlockHash(gomock.Any(), tests.Connect(&corepb.GetBlockHashRequest{Height: height})).
+			Return(connect.NewResponse(&corepb.GetBlockHashResponse{Hash: block.BlockHash().String()}), nil).
+			AnyTimes()
+		core.EXPECT().
+			GetBlock(gomock.Any(), tests.Connect(&corepb.GetBlockRequest{
+				Hash: block.BlockHash().String(), Verbosity: corepb.GetBlockRequest_VERBOSITY_RAW_DATA,
+			})).
+			Return(connect.NewResponse(&corepb.GetBlockResponse{Hex: hex.EncodeToString(raw.Bytes())}), nil).
+			AnyTimes()
+	}
+
+	t.Run("indexed and scanned", func(t *testing.T) {
+		t.Parallel()
+		engine, db, core := newEngine(t)
+
+		// Indexed up to block 5
+		indexedHash := sha256.Sum256([]byte("indexed"))
+		blockTime := time.Unix(1_700_000_000, 0)
+		require.NoError(t, blocks.MarkBlocksProcessed(ctx, db, []blocks.ProcessedBlock{{
+			Height: 5, Hash: chainhash.Hash{5}, BlockTime: blockTime,
+		}}))
+		require.NoError(t, opreturns.Persist(ctx, db, []opreturns.OPReturn{
+			{TxID: chainhash.Hash{1}.String(), Data: indexedHash[:], Height: lo.ToPtr(uint32(5))},
+		}))
+		require.NoError(t, blocks.SetProcessorCursor(ctx, db, OPReturnIndexName, 5, chainhash.Hash{5}))
+
+		// Blocks 6 and 7 aren't
+		scannedHash := sha256.Sum256([]byte("scanned"))
+		scannedTx := &wire.MsgTx{
+			TxIn:  []*wire.TxIn{{}},
+			TxOut: []*wire.TxOut{{PkScript: pkScript(t, scannedHash[:])}},
+		}
+		expectTip(core, 7)
+		expectBlock(core, 6, &wire.MsgBlock{Transactions: []*wire.MsgTx{{TxIn: []*wire.TxIn{{}}}}})
+		block7 := &wire.MsgBlock{
+			Header:       wire.BlockHeader{Timestamp: blockTime.Add(time.Hour)},
+			Transactions: []*wire.MsgTx{{TxIn: []*wire.TxIn{{}}}, scannedTx},
+		}
+		expectBlock(core, 7, block7)
+
+		found, err := engine.VerifyTimestampOnChain(ctx, []byte("indexed"), nil, nil)
+		require.NoError(t, err)
+		assert.True(t, blockTime.Equal(found.BlockTime))
+		found.BlockTime = time.Time{}
+		assert.Equal(t, &ChainTimestamp{
+			FileHash:  hex.EncodeToString(indexedHash[:]),
+			TxID:      chainhash.Hash{1}.String(),
+			Height:    5,
+			BlockHash: chainhash.Hash{5}.String(),
+		}, found)
+
+		found, err = engine.VerifyTimestampOnChain(ctx, []byte("scanned"), nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, scannedTx.TxID(), found.TxID)
+		assert.Equal(t, uint32(7), found.Height)
+		assert.Equal(t, block7.BlockHash().String(), found.BlockHash)
+		assert.True(t, block7.Header.Timestamp.Equal(found.BlockTime))
+
+		_, err = engine.VerifyTimestampOnChain(ctx, []byte("scanned"), nil, lo.ToPtr(uint32(6)))
+		assert.ErrorIs(t, err, ErrTimestampNotOnChain, "outside of the range")
+		_, err = engine.VerifyTimestampOnChain(ctx, []byte("indexed"), lo.ToPtr(uint32(6)), nil)
+		assert.ErrorIs(t, err, ErrTimestampNotOnChain, "outside of the range")
+		_, err = engine.VerifyTimestampOnChain(ctx, []byte("nowhere"), nil, nil)
+		assert.ErrorIs(t, err, ErrTimestampNotOnChain)
+	})
+
+	t.Run("fresh install", func(t *testing.T) {
+		t.Parallel()
+		engine, _, core := newEngine(t)
+		expectTip(core, 900_000)
+
+		_, err := engine.VerifyTimestampOnChain(ctx, []byte("file"), nil, nil)
+		assert.ErrorIs(t, err, ErrScanRangeTooLarge)
+
+		expectBlock(core, 899_999, &wire.MsgBlock{Transactions: []*wire.MsgTx{{TxIn: []*wire.TxIn{{}}}}})
+		expectBlock(core, 900_000, &wire.MsgBlock{Transactions: []*wire.MsgTx{{TxIn: []*wire.TxIn{{}}}}})
+		_, err = engine.VerifyTimestampOnChain(ctx, []byte("file"), lo.ToPtr(uint32(899_999)), nil)
+		assert.ErrorIs(t, err, ErrTimestampNotOnChain)
+	})
+}
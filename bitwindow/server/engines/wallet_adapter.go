@@ -1,34 +1,134 @@
 package engines
 
 import (
+	"bytes"
+	"cmp"
 	"context"
+	"database/sql"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
 
 	"connectrpc.com/connect"
 	commonv1 "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/cusf/common/v1"
 	validatorpb "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/cusf/mainchain/v1"
 	validatorrpc "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/cusf/mainchain/v1/mainchainv1connect"
 	service "github.com/LayerTwo-Labs/sidesail/bitwindow/server/service"
+	corepb "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha"
+	corerpc "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha/bitcoindv1alphaconnect"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/rs/zerolog"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
-// WalletAdapter adapts the wallet service for timestamp engine
+const (
+	// Confirmation target for fee estimates, when no fee rate is given
+	timestampConfTarget = 6
+	// How far we derive along a chain of a Bitcoin Core wallet, looking for
+	// the key of an output Core says is ours. Core extends its descriptors
+	// as they're used, so there's no fixed range to search.
+	maxCoreKeyIndex = 1 << 20
+	// Change of Bitcoin Core wallets starts at index 1, as some wallets have
+	// their change descriptor imported from there.
+	firstCoreChangeIndex = 1
+	dustLimit            = 546
+)
+
+// WalletAdapter adapts the wallet service for timestamp engine. Like
+// api/wallet.SendTransaction, it routes transactions to the backend of the
+// wallet they're sent from.
 type WalletAdapter struct {
-	wallet *service.Service[validatorrpc.WalletServiceClient]
+	db       *sql.DB
+	wallets  *WalletEngine
+	enforcer *service.Service[validatorrpc.WalletServiceClient]
+	bitcoind *service.Service[corerpc.BitcoinServiceClient]
+
+	// Transactions from Bitcoin Core wallets are funded from ListUnspent,
+	// so they're sent one at a time. Guards the fields below.
+	coreMu sync.Mutex
+	// Keys of Bitcoin Core wallets found so far, by wallet ID
+	coreKeyrings map[string]*coreKeyring
+	// Outputs spent by transactions we've broadcast, that Core might still
+	// list as unspent until its wallet has caught up with the mempool
+	coreSpent map[string]bool
+}
+
+func NewWalletAdapter(
+	db *sql.DB,
+	wallets *WalletEngine,
+	enforcer *service.Service[validatorrpc.WalletServiceClient],
+	bitcoind *service.Service[corerpc.BitcoinServiceClient],
+) *WalletAdapter {
+	return &WalletAdapter{
+		db:           db,
+		wallets:      wallets,
+		enforcer:     enforcer,
+		bitcoind:     bitcoind,
+		coreKeyrings: make(map[string]*coreKeyring),
+		coreSpent:    make(map[string]bool),
+	}
+}
+
+func (w *WalletAdapter) SendTransaction(ctx context.Context, opReturnData []byte, opts SendOptions) (string, error) {
+	walletType, err := w.walletType(ctx, &opts)
+	if err != nil {
+		return "", err
+	}
+
+	switch walletType {
+	case WalletTypeEnforcer:
+		return w.sendWithEnforcer(ctx, opReturnData, opts)
+	case WalletTypeBitcoinCore:
+		return w.sendWithBitcoinCore(ctx, opReturnData, opts)
+	default:
+		return "", fmt.Errorf("cannot send timestamps from %s wallet %s", walletType, opts.WalletID)
+	}
 }
 
-func NewWalletAdapter(wallet *service.Service[validatorrpc.WalletServiceClient]) *WalletAdapter {
-	return &WalletAdapter{wallet: wallet}
+// walletType resolves the wallet to send from. Without a wallet ID, that's
+// the active wallet, or the enforcer wallet if there's no wallet.json to
+// pick from.
+func (w *WalletAdapter) walletType(ctx context.Context, opts *SendOptions) (WalletType, error) {
+	if opts.WalletID != "" {
+		walletType, err := w.wallets.GetWalletBackendType(ctx, opts.WalletID)
+		if err != nil {
+			return "", fmt.Errorf("get wallet type: %w", err)
+		}
+		return walletType, nil
+	}
+
+	active, err := w.wallets.GetActiveWallet(ctx)
+	if err != nil {
+		zerolog.Ctx(ctx).Debug().Err(err).Msg("no active wallet, sending timestamp with the enforcer wallet")
+		return WalletTypeEnforcer, nil
+	}
+
+	opts.WalletID = active.ID
+	return active.WalletType, nil
 }
 
-func (w *WalletAdapter) SendTransaction(ctx context.Context, opReturnData []byte) (string, error) {
-	client, err := w.wallet.Get(ctx)
+func (w *WalletAdapter) sendWithEnforcer(ctx context.Context, opReturnData []byte, opts SendOptions) (string, error) {
+	client, err := w.enforcer.Get(ctx)
 	if err != nil {
 		return "", fmt.Errorf("get wallet client: %w", err)
 	}
 
+	var feeRate *validatorpb.SendTransactionRequest_FeeRate
+	if opts.FeeSatPerVbyte != 0 {
+		feeRate = &validatorpb.SendTransactionRequest_FeeRate{
+			Fee: &validatorpb.SendTransactionRequest_FeeRate_SatPerVbyte{SatPerVbyte: opts.FeeSatPerVbyte},
+		}
+	}
+
 	resp, err := client.SendTransaction(ctx, connect.NewRequest(&validatorpb.SendTransactionRequest{
+		FeeRate: feeRate,
 		OpReturnMessage: &commonv1.Hex{
 			Hex: &wrapperspb.StringValue{
 				Value: hex.EncodeToString(opReturnData),
@@ -41,3 +141,441 @@ func (w *WalletAdapter) SendTransaction(ctx context.Context, opReturnData []byte
 
 	return resp.Msg.Txid.Hex.Value, nil
 }
+
+// sendWithBitcoinCore sends an OP_RETURN transaction from a Bitcoin Core
+// wallet. Core's send RPC can't carry an OP_RETURN output, and there's no
+// RPC to fund or sign a raw transaction with the wallet, so the transaction
+// is built and signed here, with keys derived from the seed the wallet's
+// descriptors were imported from.
+func (w *WalletAdapter) sendWithBitcoinCore(ctx context.Context, opReturnData []byte, opts SendOptions) (string, error) {
+	log := zerolog.Ctx(ctx)
+
+	w.coreMu.Lock()
+	defer w.coreMu.Unlock()
+
+	walletName, err := w.wallets.GetBitcoinCoreWalletName(ctx, opts.WalletID)
+	if err != nil {
+		return "", fmt.Errorf("get Bitcoin Core wallet: %w", err)
+	}
+
+	bitcoind, err := w.bitcoind.Get(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	keyring, err := w.coreKeyring(ctx, opts.WalletID)
+	if err != nil {
+		return "", err
+	}
+
+	feeRate := opts.FeeSatPerVbyte
+	if feeRate == 0 {
+		feeRate = estimateFeeRate(ctx, bitcoind)
+	}
+
+	utxos, err := bitcoind.ListUnspent(ctx, connect.NewRequest(&corepb.ListUnspentRequest{
+		Wallet: walletName,
+	}))
+	if err != nil {
+		return "", fmt.Errorf("bitcoin Core list unspent: %w", err)
+	}
+
+	opReturn, err := txscript.NullDataScript(opReturnData)
+	if err != nil {
+		return "", fmt.Errorf("create OP_RETURN script: %w", err)
+	}
+
+	selected, change, err := selectUTXOs(opReturn, w.unspentOutputs(utxos.Msg.Unspent), feeRate)
+	if err != nil {
+		return "", err
+	}
+
+	keys, err := w.coreSigningKeys(ctx, bitcoind, walletName, keyring, selected)
+	if err != nil {
+		return "", err
+	}
+
+	changeIndex, err := w.nextCoreChangeIndex(ctx, opts.WalletID, keyring)
+	if err != nil {
+		return "", err
+	}
+	changeScript, err := keyring.script(coreChangeChain, changeIndex)
+	if err != nil {
+		return "", fmt.Errorf("derive change script: %w", err)
+	}
+
+	tx, err := buildOPReturnTx(opReturn, selected, change, changeScript)
+	if err != nil {
+		return "", err
+	}
+	if err := signP2WPKHInputs(tx, selected, keys); err != nil {
+		return "", err
+	}
+
+	var raw bytes.Buffer
+	if err := tx.Serialize(&raw); err != nil {
+		return "", fmt.Errorf("serialize transaction: %w", err)
+	}
+
+	res, err := bitcoind.SendRawTransaction(ctx, connect.NewRequest(&corepb.SendRawTransactionRequest{
+		HexString: hex.EncodeToString(raw.Bytes()),
+	}))
+	if err != nil {
+		return "", fmt.Errorf("broadcast transaction: %w", err)
+	}
+
+	for _, utxo := range selected {
+		w.coreSpent[outpointString(utxo)] = true
+	}
+	keyring.markUsed(coreKeyPath{chain: coreChangeChain, index: changeIndex}, hex.EncodeToString(changeScript))
+	if err := w.setNextCoreChangeIndex(ctx, opts.WalletID, changeIndex+1); err != nil {
+		// The transaction is out, and the keyring remembers the change
+		// until we restart.
+		log.Warn().Err(err).
+			Str("wallet", walletName).
+			Uint32("index", changeIndex).
+			Msg("could not persist Bitcoin Core change index")
+	}
+
+	log.Info().
+		Str("wallet", walletName).
+		Str("txid", res.Msg.Txid).
+		Uint64("fee_sat_per_vbyte", feeRate).
+		Int("inputs", len(selected)).
+		Uint32("change_index", changeIndex).
+		Msg("sent timestamp transaction from Bitcoin Core wallet")
+
+	return res.Msg.Txid, nil
+}
+
+// unspentOutputs filters out the outputs we've spent ourselves, from the
+// outputs Core lists as unspent. Forgets about spends Core has caught up
+// with.
+func (w *WalletAdapter) unspentOutputs(utxos []*corepb.UnspentOutput) []*corepb.UnspentOutput {
+	listed := make(map[string]bool, len(utxos))
+	for _, utxo := range utxos {
+		listed[outpointString(utxo)] = true
+	}
+	for outpoint := range w.coreSpent {
+		if !listed[outpoint] {
+			delete(w.coreSpent, outpoint)
+		}
+	}
+
+	return slices.DeleteFunc(slices.Clone(utxos), func(utxo *corepb.UnspentOutput) bool {
+		return w.coreSpent[outpointString(utxo)]
+	})
+}
+
+func outpointString(utxo *corepb.UnspentOutput) string {
+	return fmt.Sprintf("%s:%d", utxo.Txid, utxo.Vout)
+}
+
+// estimateFeeRate asks Core for a fee rate in sat/vB, falling back to the
+// minimum relay fee when Core has no estimate, like on regtest.
+func estimateFeeRate(ctx context.Context, bitcoind corerpc.BitcoinServiceClient) uint64 {
+	res, err := bitcoind.EstimateSmartFee(ctx, connect.NewRequest(&corepb.EstimateSmartFeeRequest{
+		ConfTarget: timestampConfTarget,
+	}))
+	if err != nil || res.Msg.FeeRate <= 0 {
+		zerolog.Ctx(ctx).Debug().Err(err).Msg("no fee estimate, using 1 sat/vB")
+		return 1
+	}
+
+	// BTC/kvB to sat/vB, rounding up
+	satPerKvB, err := btcutil.NewAmount(res.Msg.FeeRate)
+	if err != nil {
+		return 1
+	}
+	return max(1, (uint64(satPerKvB)+999)/1000)
+}
+
+// selectUTXOs picks the largest UTXOs until they cover the fee of a
+// transaction with the given OP_RETURN output and a P2WPKH change output.
+// Returns the UTXOs to spend, in input order, and the change left over.
+func selectUTXOs(
+	opReturn []byte, utxos []*corepb.UnspentOutput, feeSatPerVbyte uint64,
+) ([]*corepb.UnspentOutput, btcutil.Amount, error) {
+	// Only P2WPKH outputs can be signed, largest first to keep the
+	// transaction small
+	spendable := slices.DeleteFunc(slices.Clone(utxos), func(utxo *corepb.UnspentOutput) bool {
+		script, err := hex.DecodeString(utxo.ScriptPubKey)
+		return !utxo.Spendable || err != nil || !txscript.IsPayToWitnessPubKeyHash(script)
+	})
+	slices.SortFunc(spendable, func(a, b *corepb.UnspentOutput) int {
+		return cmp.Compare(b.Amount, a.Amount)
+	})
+
+	var (
+		selected []*corepb.UnspentOutput
+		total    btcutil.Amount
+		fee      btcutil.Amount
+	)
+	for _, utxo := range spendable {
+		amount, err := btcutil.NewAmount(utxo.Amount)
+		if err != nil {
+			return nil, 0, fmt.Errorf("parse amount of %s:%d: %w", utxo.Txid, utxo.Vout, err)
+		}
+		selected = append(selected, utxo)
+		total += amount
+
+		// Overhead, P2WPKH inputs, the OP_RETURN output and a P2WPKH change output
+		vbytes := 11 + 68*len(selected) + 9 + len(opReturn) + 31
+		fee = btcutil.Amount(uint64(vbytes) * feeSatPerVbyte)
+		if total >= fee+dustLimit {
+			break
+		}
+	}
+	if total < fee+dustLimit {
+		return nil, 0, fmt.Errorf("insufficient funds: %s available, need %s for fees", total, fee+dustLimit)
+	}
+
+	return selected, total - fee, nil
+}
+
+// buildOPReturnTx builds an unsigned transaction spending the given UTXOs,
+// with an OP_RETURN output and a change output.
+func buildOPReturnTx(
+	opReturn []byte, utxos []*corepb.UnspentOutput, change btcutil.Amount, changeScript []byte,
+) (*wire.MsgTx, error) {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	for _, utxo := range utxos {
+		txHash, err := chainhash.NewHashFromStr(utxo.Txid)
+		if err != nil {
+			return nil, fmt.Errorf("parse txid: %w", err)
+		}
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(txHash, utxo.Vout), nil, nil))
+	}
+	tx.AddTxOut(wire.NewTxOut(0, opReturn))
+	tx.AddTxOut(wire.NewTxOut(int64(change), changeScript))
+
+	return tx, nil
+}
+
+// Chains of the descriptors imported into Bitcoin Core wallets
+const (
+	coreReceiveChain uint32 = 0
+	coreChangeChain  uint32 = 1
+)
+
+// coreKeyPath is the path of a key below the account key of a Bitcoin Core
+// wallet.
+type coreKeyPath struct {
+	chain, index uint32
+}
+
+// coreKeyring finds the keys of a Bitcoin Core wallet by their script. Keys
+// are derived as they're looked for, and remembered.
+type coreKeyring struct {
+	account *hdkeychain.ExtendedKey
+	chains  [2]*hdkeychain.ExtendedKey
+	// Number of keys derived so far, by chain
+	derived [2]uint32
+	paths   map[string]coreKeyPath
+	// One past the highest change index we know is used
+	nextChange uint32
+}
+
+// coreKeyring returns the keyring of the given Bitcoin Core wallet.
+func (w *WalletAdapter) coreKeyring(ctx context.Context, walletID string) (*coreKeyring, error) {
+	if keyring, ok := w.coreKeyrings[walletID]; ok {
+		return keyring, nil
+	}
+
+	account, err := w.wallets.BitcoinCoreAccountKey(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	keyring := &coreKeyring{
+		account: account,
+		paths:   make(map[string]coreKeyPath),
+	}
+	for _, chain := range []uint32{coreReceiveChain, coreChangeChain} {
+		if keyring.chains[chain], err = account.Derive(chain); err != nil {
+			return nil, fmt.Errorf("derive chain %d: %w", chain, err)
+		}
+	}
+
+	w.coreKeyrings[walletID] = keyring
+	return keyring, nil
+}
+
+// script returns the P2WPKH script of the key at chain/index.
+func (k *coreKeyring) script(chain, index uint32) ([]byte, error) {
+	child, err := k.chains[chain].Derive(index)
+	if err != nil {
+		return nil, fmt.Errorf("derive key %d/%d: %w", chain, index, err)
+	}
+	pubKey, err := child.ECPubKey()
+	if err != nil {
+		return nil, fmt.Errorf("get public key: %w", err)
+	}
+
+	return txscript.NewScriptBuilder().
+		AddOp(txscript.OP_0).
+		AddData(btcutil.Hash160(pubKey.SerializeCompressed())).
+		Script()
+}
+
+// lookup returns the path of the key with the given script, if we've
+// derived it before.
+func (k *coreKeyring) lookup(scriptHex string) (coreKeyPath, bool) {
+	path, ok := k.paths[scriptHex]
+	if ok {
+		k.markUsed(path, scriptHex)
+	}
+	return path, ok
+}
+
+// find is like lookup, but derives further along the given chain until it
+// finds the key.
+func (k *coreKeyring) find(scriptHex string, chain uint32) (coreKeyPath, bool, error) {
+	for {
+		if path, ok := k.lookup(scriptHex); ok {
+			return path, true, nil
+		}
+		if k.derived[chain] >= maxCoreKeyIndex {
+			return coreKeyPath{}, false, nil
+		}
+
+		index := k.derived[chain]
+		script, err := k.script(chain, index)
+		if err != nil {
+			return coreKeyPath{}, false, err
+		}
+
+		k.paths[hex.EncodeToString(script)] = coreKeyPath{chain: chain, index: index}
+		k.derived[chain]++
+	}
+}
+
+// markUsed remembers that the key at the given path has received coins.
+func (k *coreKeyring) markUsed(path coreKeyPath, scriptHex string) {
+	k.paths[scriptHex] = path
+	if path.chain == coreChangeChain {
+		k.nextChange = max(k.nextChange, path.index+1)
+	}
+}
+
+// privKey returns the private key at the given path.
+func (k *coreKeyring) privKey(path coreKeyPath) (*btcec.PrivateKey, error) {
+	child, err := k.chains[path.chain].Derive(path.index)
+	if err != nil {
+		return nil, fmt.Errorf("derive key %d/%d: %w", path.chain, path.index, err)
+	}
+
+	return child.ECPrivKey()
+}
+
+// coreSigningKeys finds the private keys of the UTXOs, by their script.
+// Core tells us whether the address is on the receive or change chain, and
+// we derive along that chain until we find it.
+func (w *WalletAdapter) coreSigningKeys(
+	ctx context.Context, bitcoind corerpc.BitcoinServiceClient, walletName string,
+	keyring *coreKeyring, utxos []*corepb.UnspentOutput,
+) (map[string]*btcec.PrivateKey, error) {
+	keys := make(map[string]*btcec.PrivateKey, len(utxos))
+	for _, utxo := range utxos {
+		path, ok := keyring.lookup(utxo.ScriptPubKey)
+		if !ok {
+			info, err := bitcoind.GetAddressInfo(ctx, connect.NewRequest(&corepb.GetAddressInfoRequest{
+				Address: utxo.Address,
+				Wallet:  walletName,
+			}))
+			if err != nil {
+				return nil, fmt.Errorf("get address info of %s: %w", utxo.Address, err)
+			}
+			if !info.Msg.IsMine {
+				return nil, fmt.Errorf("%s is not in wallet %s", utxo.Address, walletName)
+			}
+
+			chain := coreReceiveChain
+			if info.Msg.IsChange {
+				chain = coreChangeChain
+			}
+
+			path, ok, err = keyring.find(utxo.ScriptPubKey, chain)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, fmt.Errorf("no key in wallet %s for %s (%s)", walletName, utxo.Address, utxo.ScriptPubKey)
+			}
+		}
+
+		key, err := keyring.privKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("get private key: %w", err)
+		}
+		keys[utxo.ScriptPubKey] = key
+	}
+
+	return keys, nil
+}
+
+// nextCoreChangeIndex returns the index of the change key of the next
+// transaction from the given wallet. It's not used up until
+// setNextCoreChangeIndex is called.
+func (w *WalletAdapter) nextCoreChangeIndex(ctx context.Context, walletID string, keyring *coreKeyring) (uint32, error) {
+	next := uint32(firstCoreChangeIndex)
+	err := w.db.QueryRowContext(ctx, `
+		SELECT next_index FROM bitcoin_core_change_indexes WHERE wallet_id = ?
+	`, walletID).Scan(&next)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("get change index: %w", err)
+	}
+
+	// Skip past change Core sent to itself, that we've come across
+	return max(next, keyring.nextChange), nil
+}
+
+func (w *WalletAdapter) setNextCoreChangeIndex(ctx context.Context, walletID string, next uint32) error {
+	_, err := w.db.ExecContext(ctx, `
+		INSERT INTO bitcoin_core_change_indexes (wallet_id, next_index)
+		VALUES (?, ?)
+		ON CONFLICT(wallet_id) DO UPDATE SET
+			next_index = MAX(next_index, excluded.next_index)
+	`, walletID, next)
+	if err != nil {
+		return fmt.Errorf("set change index: %w", err)
+	}
+
+	return nil
+}
+
+// signP2WPKHInputs signs the inputs of tx, spending utxos, with the keys of
+// their scripts.
+func signP2WPKHInputs(tx *wire.MsgTx, utxos []*corepb.UnspentOutput, keys map[string]*btcec.PrivateKey) error {
+	prevOuts := txscript.NewMultiPrevOutFetcher(nil)
+	for i, utxo := range utxos {
+		script, err := hex.DecodeString(utxo.ScriptPubKey)
+		if err != nil {
+			return fmt.Errorf("decode script of input %d: %w", i, err)
+		}
+		amount, err := btcutil.NewAmount(utxo.Amount)
+		if err != nil {
+			return fmt.Errorf("parse amount of input %d: %w", i, err)
+		}
+		prevOuts.AddPrevOut(tx.TxIn[i].PreviousOutPoint, wire.NewTxOut(int64(amount), script))
+	}
+
+	sigHashes := txscript.NewTxSigHashes(tx, prevOuts)
+	for i, txIn := range tx.TxIn {
+		prevOut := prevOuts.FetchPrevOutput(txIn.PreviousOutPoint)
+		if !txscript.IsPayToWitnessPubKeyHash(prevOut.PkScript) {
+			return errors.New("can only spend P2WPKH outputs")
+		}
+
+		witness, err := txscript.WitnessSignature(
+			tx, sigHashes, i, prevOut.Value, prevOut.PkScript,
+			txscript.SigHashAll, keys[utxos[i].ScriptPubKey], true,
+		)
+		if err != nil {
+			return fmt.Errorf("create witness signature for input %d: %w", i, err)
+		}
+		txIn.Witness = witness
+	}
+
+	return nil
+}
@@ -0,0 +1,326 @@
+package engines_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/engines"
+	commonv1 "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/cusf/common/v1"
+	validatorpb "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/cusf/mainchain/v1"
+	validatorrpc "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/cusf/mainchain/v1/mainchainv1connect"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/service"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests"
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/tests/mocks"
+	corepb "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha"
+	corerpc "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha/bitcoindv1alphaconnect"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	coreWalletID     = "11111111-core"
+	enforcerWalletID = "22222222-enforcer"
+)
+
+func newWalletAdapter(t *testing.T) (*engines.WalletAdapter, *engines.WalletEngine, *mocks.MockBitcoinServiceClient, *mocks.MockWalletServiceClient) {
+	t.Helper()
+
+	dir := t.TempDir()
+	walletJSON, err := json.Marshal(map[string]any{
+		"version":        1,
+		"activeWalletId": coreWalletID,
+		"wallets": []map[string]any{
+			{
+				"id":          coreWalletID,
+				"name":        "Core",
+				"wallet_type": engines.WalletTypeBitcoinCore,
+				"master":      map[string]any{"seed_hex": "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"},
+			},
+			{
+				"id":          enforcerWalletID,
+				"name":        "Enforcer",
+				"wallet_type": engines.WalletTypeEnforcer,
+				"master":      map[string]any{"seed_hex": "1f1e1d1c1b1a191817161514131211100f0e0d0c0b0a09080706050403020100"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "wallet.json"), walletJSON, 0o600))
+
+	ctrl := gomock.NewController(t)
+	core := mocks.NewMockBitcoinServiceClient(ctrl)
+	enforcer := mocks.NewMockWalletServiceClient(ctrl)
+	coreConnector := func(ctx context.Context) (corerpc.BitcoinServiceClient, error) { return core, nil }
+	enforcerConnector := func(ctx context.Context) (validatorrpc.WalletServiceClient, error) { return enforcer, nil }
+
+	walletEngine := engines.NewWalletEngine(coreConnector, enforcerConnector, dir, &chaincfg.RegressionNetParams)
+	adapter := engines.NewWalletAdapter(
+		database.Test(t),
+		walletEngine,
+		service.New("wallet", enforcerConnector),
+		service.New("bitcoind", coreConnector),
+	)
+	return adapter, walletEngine, core, enforcer
+}
+
+// coreAddress derives the address at chain/index of the Core wallet.
+func coreAddress(t *testing.T, walletEngine *engines.WalletEngine, chain, index uint32) btcutil.Address {
+	t.Helper()
+
+	account, err := walletEngine.BitcoinCoreAccountKey(context.Background(), coreWalletID)
+	require.NoError(t, err)
+	key, err := account.Derive(chain)
+	require.NoError(t, err)
+	key, err = key.Derive(index)
+	require.NoError(t, err)
+	pubKey, err := key.ECPubKey()
+	require.NoError(t, err)
+
+	address, err := btcutil.NewAddressWitnessPubKeyHash(
+		btcutil.Hash160(pubKey.SerializeCompressed()), &chaincfg.RegressionNetParams,
+	)
+	require.NoError(t, err)
+	return address
+}
+
+func utxo(t *testing.T, txid byte, address btcutil.Address, amount float64, spendable bool) *corepb.UnspentOutput {
+	t.Helper()
+
+	script, err := txscript.PayToAddrScript(address)
+	require.NoError(t, err)
+	return &corepb.UnspentOutput{
+		Txid:          chainhash.Hash{txid}.String(),
+		Vout:          1,
+		Address:       address.EncodeAddress(),
+		ScriptPubKey:  hex.EncodeToString(script),
+		Amount:        amount,
+		Confirmations: 6,
+		Spendable:     spendable,
+	}
+}
+
+func TestWalletAdapter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	data := bytes.Repeat([]byte{0xab}, 32)
+
+	t.Run("bitcoin core wallet", func(t *testing.T) {
+		t.Parallel()
+		adapter, walletEngine, core, _ := newWalletAdapter(t)
+
+		// Past the range the descriptors were imported with
+		funding := utxo(t, 2, coreAddress(t, walletEngine, 1, 1500), 0.001, true)
+		utxos := []*corepb.UnspentOutput{
+			utxo(t, 1, coreAddress(t, walletEngine, 0, 3), 0.00001, true),
+			funding,
+			utxo(t, 3, coreAddress(t, walletEngine, 0, 4), 1, false),
+		}
+
+		core.EXPECT().
+			ListWallets(gomock.Any(), gomock.Any()).
+			Return(connect.NewResponse(&corepb.ListWalletsResponse{Wallets: []string{"wallet_11111111"}}), nil)
+		core.EXPECT().
+			EstimateSmartFee(gomock.Any(), tests.Connect(&corepb.EstimateSmartFeeRequest{ConfTarget: 6})).
+			Return(connect.NewResponse(&corepb.EstimateSmartFeeResponse{FeeRate: 0.00002}), nil)
+		core.EXPECT().
+			ListUnspent(gomock.Any(), tests.Connect(&corepb.ListUnspentRequest{Wallet: "wallet_11111111"})).
+			Return(connect.NewResponse(&corepb.ListUnspentResponse{Unspent: utxos}), nil)
+		core.EXPECT().
+			GetAddressInfo(gomock.Any(), tests.Connect(&corepb.GetAddressInfoRequest{
+				Address: funding.Address,
+				Wallet:  "wallet_11111111",
+			})).
+			Return(connect.NewResponse(&corepb.GetAddressInfoResponse{
+				Address:  funding.Address,
+				IsMine:   true,
+				IsChange: true,
+			}), nil)
+
+		var sent wire.MsgTx
+		core.EXPECT().
+			SendRawTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *connect.Request[corepb.SendRawTransactionRequest]) (*connect.Response[corepb.SendRawTransactionResponse], error) {
+				raw, err := hex.DecodeString(req.Msg.HexString)
+				require.NoError(t, err)
+				require.NoError(t, sent.Deserialize(bytes.NewReader(raw)))
+				return connect.NewResponse(&corepb.SendRawTransactionResponse{Txid: sent.TxID()}), nil
+			})
+
+		txid, err := adapter.SendTransaction(ctx, data, engines.SendOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, sent.TxID(), txid)
+
+		// Funded by the largest spendable output alone
+		require.Len(t, sent.TxIn, 1)
+		assert.Equal(t, funding.Txid, sent.TxIn[0].PreviousOutPoint.Hash.String())
+
+		// Change goes to the change chain, past the change we're spending
+		opReturn, err := txscript.NullDataScript(data)
+		require.NoError(t, err)
+		changeScript, err := txscript.PayToAddrScript(coreAddress(t, walletEngine, 1, 1501))
+		require.NoError(t, err)
+		require.Len(t, sent.TxOut, 2)
+		assert.Equal(t, opReturn, sent.TxOut[0].PkScript)
+		assert.Equal(t, changeScript, sent.TxOut[1].PkScript)
+		// 2 sat/vB
+		assert.Equal(t, int64(100_000-2*(11+68+9+len(opReturn)+31)), sent.TxOut[1].Value)
+
+		fundingScript, err := hex.DecodeString(funding.ScriptPubKey)
+		require.NoError(t, err)
+		prevOuts := txscript.NewCannedPrevOutputFetcher(fundingScript, 100_000)
+		vm, err := txscript.NewEngine(
+			fundingScript, &sent, 0, txscript.StandardVerifyFlags, nil,
+			txscript.NewTxSigHashes(&sent, prevOuts), 100_000, prevOuts,
+		)
+		require.NoError(t, err)
+		assert.NoError(t, vm.Execute())
+
+		// The next transaction spends the change, and sends its own change
+		// to the next key. The key of the change is known, so Core isn't
+		// asked about it.
+		change := utxo(t, 0, coreAddress(t, walletEngine, 1, 1501), 0.0009, true)
+		change.Txid = sent.TxID()
+		core.EXPECT().
+			ListUnspent(gomock.Any(), gomock.Any()).
+			Return(connect.NewResponse(&corepb.ListUnspentResponse{Unspent: []*corepb.UnspentOutput{change}}), nil)
+		core.EXPECT().
+			SendRawTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *connect.Request[corepb.SendRawTransactionRequest]) (*connect.Response[corepb.SendRawTransactionResponse], error) {
+				raw, err := hex.DecodeString(req.Msg.HexString)
+				require.NoError(t, err)
+				require.NoError(t, sent.Deserialize(bytes.NewReader(raw)))
+				return connect.NewResponse(&corepb.SendRawTransactionResponse{Txid: sent.TxID()}), nil
+			})
+
+		_, err = adapter.SendTransaction(ctx, data, engines.SendOptions{FeeSatPerVbyte: 1})
+		require.NoError(t, err)
+		changeScript, err = txscript.PayToAddrScript(coreAddress(t, walletEngine, 1, 1502))
+		require.NoError(t, err)
+		assert.Equal(t, changeScript, sent.TxOut[1].PkScript)
+	})
+
+	t.Run("concurrent bitcoin core sends", func(t *testing.T) {
+		t.Parallel()
+		adapter, walletEngine, core, _ := newWalletAdapter(t)
+
+		// Core's wallet hasn't seen the first transaction yet when the
+		// second one is funded, and lists the same outputs both times.
+		utxos := []*corepb.UnspentOutput{
+			utxo(t, 1, coreAddress(t, walletEngine, 0, 1), 0.001, true),
+			utxo(t, 2, coreAddress(t, walletEngine, 0, 2), 0.001, true),
+		}
+
+		core.EXPECT().
+			ListWallets(gomock.Any(), gomock.Any()).
+			Return(connect.NewResponse(&corepb.ListWalletsResponse{Wallets: []string{"wallet_11111111"}}), nil)
+		core.EXPECT().
+			ListUnspent(gomock.Any(), gomock.Any()).
+			Return(connect.NewResponse(&corepb.ListUnspentResponse{Unspent: utxos}), nil).
+			Times(2)
+		core.EXPECT().
+			GetAddressInfo(gomock.Any(), gomock.Any()).
+			Return(connect.NewResponse(&corepb.GetAddressInfoResponse{IsMine: true}), nil).
+			Times(2)
+
+		var (
+			mu    sync.Mutex
+			spent []string
+		)
+		core.EXPECT().
+			SendRawTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *connect.Request[corepb.SendRawTransactionRequest]) (*connect.Response[corepb.SendRawTransactionResponse], error) {
+				raw, err := hex.DecodeString(req.Msg.HexString)
+				require.NoError(t, err)
+				var tx wire.MsgTx
+				require.NoError(t, tx.Deserialize(bytes.NewReader(raw)))
+
+				mu.Lock()
+				defer mu.Unlock()
+				for _, txIn := range tx.TxIn {
+					spent = append(spent, txIn.PreviousOutPoint.String())
+				}
+				return connect.NewResponse(&corepb.SendRawTransactionResponse{Txid: tx.TxID()}), nil
+			}).
+			Times(2)
+
+		var wg sync.WaitGroup
+		for range 2 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := adapter.SendTransaction(ctx, data, engines.SendOptions{FeeSatPerVbyte: 1})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.ElementsMatch(t, []string{
+			wire.NewOutPoint(&chainhash.Hash{1}, 1).String(),
+			wire.NewOutPoint(&chainhash.Hash{2}, 1).String(),
+		}, spent)
+	})
+
+	t.Run("bitcoin core wallet without funds", func(t *testing.T) {
+		t.Parallel()
+		adapter, walletEngine, core, _ := newWalletAdapter(t)
+
+		core.EXPECT().
+			ListWallets(gomock.Any(), gomock.Any()).
+			Return(connect.NewResponse(&corepb.ListWalletsResponse{Wallets: []string{"wallet_11111111"}}), nil)
+		core.EXPECT().
+			ListUnspent(gomock.Any(), gomock.Any()).
+			Return(connect.NewResponse(&corepb.ListUnspentResponse{Unspent: []*corepb.UnspentOutput{
+				utxo(t, 1, coreAddress(t, walletEngine, 0, 0), 0.00001, true),
+			}}), nil)
+
+		_, err := adapter.SendTransaction(ctx, data, engines.SendOptions{FeeSatPerVbyte: 10})
+		assert.ErrorContains(t, err, "insufficient funds")
+	})
+
+	t.Run("enforcer wallet", func(t *testing.T) {
+		t.Parallel()
+		adapter, _, _, enforcer := newWalletAdapter(t)
+
+		enforcer.EXPECT().
+			SendTransaction(gomock.Any(), tests.Connect(&validatorpb.SendTransactionRequest{
+				FeeRate: &validatorpb.SendTransactionRequest_FeeRate{
+					Fee: &validatorpb.SendTransactionRequest_FeeRate_SatPerVbyte{SatPerVbyte: 5},
+				},
+				OpReturnMessage: &commonv1.Hex{Hex: wrapperspb.String(hex.EncodeToString(data))},
+			})).
+			Return(connect.NewResponse(&validatorpb.SendTransactionResponse{
+				Txid: &commonv1.ReverseHex{Hex: wrapperspb.String("enforcer-txid")},
+			}), nil)
+
+		txid, err := adapter.SendTransaction(ctx, data, engines.SendOptions{
+			WalletID:       enforcerWalletID,
+			FeeSatPerVbyte: 5,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "enforcer-txid", txid)
+	})
+
+	t.Run("unknown wallet", func(t *testing.T) {
+		t.Parallel()
+		adapter, _, _, _ := newWalletAdapter(t)
+
+		_, err := adapter.SendTransaction(ctx, data, engines.SendOptions{WalletID: "missing"})
+		assert.ErrorContains(t, err, "wallet missing not found")
+	})
+}
@@ -542,6 +542,42 @@ func (e *WalletEngine) GetBitcoinCoreWalletName(ctx context.Context, walletId st
 	return e.EnsureBitcoinCoreWallet(ctx, walletId)
 }
 
+// BitcoinCoreAccountKey derives the BIP84 account key m/84'/coinType'/0'
+// of a Bitcoin Core wallet, which its descriptors are imported from.
+func (e *WalletEngine) BitcoinCoreAccountKey(ctx context.Context, walletId string) (*hdkeychain.ExtendedKey, error) {
+	wallet, err := e.GetWalletInfo(ctx, walletId)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.WalletType != WalletTypeBitcoinCore {
+		return nil, fmt.Errorf("wallet %s is not a Bitcoin Core wallet", walletId)
+	}
+
+	seed, err := hex.DecodeString(wallet.Master.SeedHex)
+	if err != nil || len(seed) == 0 {
+		return nil, fmt.Errorf("wallet %s has no valid seed", walletId)
+	}
+
+	masterKey, err := hdkeychain.NewMaster(seed, e.chainParams)
+	if err != nil {
+		return nil, fmt.Errorf("derive master key: %w", err)
+	}
+
+	// Coin type: 0' for mainnet, 1' for testnet/signet
+	coinType := uint32(0)
+	if e.chainParams.Name != "mainnet" {
+		coinType = 1
+	}
+
+	key := masterKey
+	for _, index := range []uint32{84, coinType, 0} {
+		if key, err = key.Derive(hdkeychain.HardenedKeyStart + index); err != nil {
+			return nil, fmt.Errorf("derive account key: %w", err)
+		}
+	}
+	return key, nil
+}
+
 // EnsureWatchOnlyWallet ensures a watch-only wallet exists in Bitcoin Core
 func (e *WalletEngine) EnsureWatchOnlyWallet(ctx context.Context, walletId string) (string, error) {
 	e.mu.Lock()
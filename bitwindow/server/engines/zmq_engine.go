@@ -13,32 +13,57 @@ import (
 	"github.com/rs/zerolog"
 )
 
-// ZMQEngine receives raw, unconfirmed transactions from bitcoind via ZMQ.
-// Processes them and broadcasts them to the rest of the system.
-type ZMQ struct {
-	txChan chan *wire.MsgTx
-
-	inner *zmqEngine
+// ZMQEndpoints holds the addresses Bitcoin Core publishes its ZMQ
+// notifications on. Empty values mean the notification is not enabled.
+type ZMQEndpoints struct {
+	RawTx     string
+	HashBlock string
+	Sequence  string
+}
 
-	mu          sync.RWMutex
-	subscribers []chan *wire.MsgTx
+// ZMQEngine receives raw, unconfirmed transactions and block notifications
+// from bitcoind via ZMQ. Processes them and broadcasts them to the rest of
+// the system.
+type ZMQ struct {
+	txChan      chan *wire.MsgTx
+	mempoolChan chan SequenceMsg
+	// Block events are queued apart from mempool events, so a burst of
+	// transactions can't crowd them out
+	blockChan chan SequenceMsg
+
+	endpoints ZMQEndpoints
+	inner     *zmqEngine
+
+	mu                 sync.RWMutex
+	subscribers        []chan *wire.MsgTx
+	mempoolSubscribers []chan SequenceMsg
+	blockSubscribers   []chan SequenceMsg
 }
 
-// NewZMQ creates a new ZMQ engine for receiving Bitcoin Core raw transaction notifications
-func NewZMQ(endpoint string) (*ZMQ, error) {
-	if endpoint == "" {
-		return nil, errors.New("engines/zmq: endpoint is empty")
+// NewZMQ creates a new ZMQ engine for receiving Bitcoin Core notifications
+func NewZMQ(endpoints ZMQEndpoints) (*ZMQ, error) {
+	if endpoints == (ZMQEndpoints{}) {
+		return nil, errors.New("engines/zmq: no endpoints configured")
 	}
 
-	inner := NewZmqEngine(endpoint)
-
 	return &ZMQ{
-		inner:       inner,
-		txChan:      make(chan *wire.MsgTx, 1000), // Buffer for high transaction volumes
-		subscribers: make([]chan *wire.MsgTx, 0),
+		endpoints:          endpoints,
+		inner:              NewZmqEngine(),
+		txChan:             make(chan *wire.MsgTx, 1000), // Buffer for high transaction volumes
+		mempoolChan:        make(chan SequenceMsg, 1000),
+		blockChan:          make(chan SequenceMsg, 100),
+		subscribers:        make([]chan *wire.MsgTx, 0),
+		mempoolSubscribers: make([]chan SequenceMsg, 0),
+		blockSubscribers:   make([]chan SequenceMsg, 0),
 	}, nil
 }
 
+// HasBlockNotifications returns true if the engine is able to notify
+// subscribers about connected and disconnected blocks.
+func (e *ZMQ) HasBlockNotifications() bool {
+	return e.endpoints.Sequence != "" || e.endpoints.HashBlock != ""
+}
+
 // Subscribe returns a channel that will receive new transactions
 func (e *ZMQ) Subscribe() <-chan *wire.MsgTx {
 	e.mu.Lock()
@@ -49,32 +74,90 @@ func (e *ZMQ) Subscribe() <-chan *wire.MsgTx {
 	return subscriber
 }
 
-// Run starts the ZMQ engine and begins listening for raw transaction notifications
+// SubscribeBlocks returns a channel that will receive BlockConnected and
+// BlockDisconnected events. If Bitcoin Core only publishes hashblock
+// notifications, these are passed on as BlockConnected events.
+func (e *ZMQ) SubscribeBlocks() <-chan SequenceMsg {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	subscriber := make(chan SequenceMsg, 100)
+	e.blockSubscribers = append(e.blockSubscribers, subscriber)
+	return subscriber
+}
+
+// SubscribeMempool returns a channel that will receive TransactionAdded and
+// TransactionRemoved events. Only available through the sequence
+// notification.
+func (e *ZMQ) SubscribeMempool() <-chan SequenceMsg {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	subscriber := make(chan SequenceMsg, 100)
+	e.mempoolSubscribers = append(e.mempoolSubscribers, subscriber)
+	return subscriber
+}
+
+// Run starts the ZMQ engine and begins listening for notifications
 func (e *ZMQ) Run(ctx context.Context) error {
-	subCh, cancel, err := e.inner.SubscribeRawTx(ctx)
-	if err != nil {
-		return err
+	errChan := make(chan error, 1)
+
+	if e.endpoints.RawTx != "" {
+		subCh, cancel, err := e.inner.SubscribeRawTx(ctx, e.endpoints.RawTx)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		go func() {
+			for tx := range subCh {
+				tx, err := decodeTransaction(tx.Serialized)
+				if err != nil {
+					errChan <- fmt.Errorf("error decoding raw transaction: %w", err)
+					return
+				}
+
+				zerolog.Ctx(ctx).Trace().
+					Msgf("received raw transaction: %s", tx.TxHash())
+				e.txChan <- tx
+			}
+		}()
 	}
-	defer cancel()
 
-	errChan := make(chan error, 1)
+	// The sequence notification is a superset of hashblock, so only fall
+	// back to hashblock if sequence isn't available.
+	switch {
+	case e.endpoints.Sequence != "":
+		subCh, cancel, err := e.inner.SubscribeSequence(ctx, e.endpoints.Sequence)
+		if err != nil {
+			return err
+		}
+		defer cancel()
 
-	go func() {
-		for tx := range subCh {
-			tx, err := decodeTransaction(tx.Serialized)
-			if err != nil {
-				errChan <- fmt.Errorf("error decoding raw transaction: %w", err)
-				return
+		go func() {
+			for msg := range subCh {
+				e.queueSequence(msg)
 			}
+		}()
 
-			zerolog.Ctx(ctx).Trace().
-				Msgf("received raw transaction: %s", tx.TxHash())
-			e.txChan <- tx
+	case e.endpoints.HashBlock != "":
+		subCh, cancel, err := e.inner.SubscribeHashBlock(ctx, e.endpoints.HashBlock)
+		if err != nil {
+			return err
 		}
-	}()
+		defer cancel()
 
-	// Start broadcaster for transaction subscribers
-	go e.broadcastTransactions(ctx)
+		go func() {
+			for msg := range subCh {
+				e.blockChan <- SequenceMsg{
+					Hash:  msg.Hash,
+					Event: BlockConnected,
+				}
+			}
+		}()
+	}
+
+	e.startBroadcasters(ctx)
 
 	// Wait for context cancellation or error
 	select {
@@ -92,37 +175,54 @@ func (e *ZMQ) Run(ctx context.Context) error {
 	}
 }
 
-// broadcastTransactions sends transactions to all subscribers
-func (e *ZMQ) broadcastTransactions(ctx context.Context) {
-	log := zerolog.Ctx(ctx).With().Str("component", "tx-broadcaster").Logger()
-	log.Info().Msg("engines/zmq: starting transaction broadcaster")
+// queueSequence passes a sequence message on to the block or the mempool
+// subscribers.
+func (e *ZMQ) queueSequence(msg SequenceMsg) {
+	switch msg.Event {
+	case BlockConnected, BlockDisconnected:
+		e.blockChan <- msg
+	default:
+		e.mempoolChan <- msg
+	}
+}
+
+// startBroadcasters starts passing queued messages on to subscribers, until
+// the context is cancelled.
+func (e *ZMQ) startBroadcasters(ctx context.Context) {
+	go broadcast(ctx, "tx-broadcaster", &e.mu, e.txChan, &e.subscribers)
+	go broadcast(ctx, "mempool-broadcaster", &e.mu, e.mempoolChan, &e.mempoolSubscribers)
+	go broadcast(ctx, "block-broadcaster", &e.mu, e.blockChan, &e.blockSubscribers)
+}
+
+// broadcast sends messages from the given channel to all subscribers
+func broadcast[T any](ctx context.Context, component string, mu *sync.RWMutex, in <-chan T, subscribers *[]chan T) {
+	log := zerolog.Ctx(ctx).With().Str("component", component).Logger()
+	log.Info().Msg("engines/zmq: starting broadcaster")
 
 	for {
 		select {
 		case <-ctx.Done():
 			log.Info().Err(ctx.Err()).
-				Msg("engines/zmq: stopping transaction broadcaster")
+				Msg("engines/zmq: stopping broadcaster")
 
 			// Close all subscriber channels
-			e.mu.RLock()
-			for _, subscriber := range e.subscribers {
+			mu.RLock()
+			for _, subscriber := range *subscribers {
 				close(subscriber)
 			}
-			e.mu.RUnlock()
+			mu.RUnlock()
 			return
 
-		case tx := <-e.txChan:
-			e.mu.RLock()
-			for _, subscriber := range e.subscribers {
+		case msg := <-in:
+			mu.RLock()
+			for _, subscriber := range *subscribers {
 				select {
-				case subscriber <- tx:
+				case subscriber <- msg:
 				default:
-					log.Trace().
-						Msgf("engines/zmq: subscriber channel full, dropping transaction for subscriber: %s",
-							tx.TxHash())
+					log.Trace().Msg("engines/zmq: subscriber channel full, dropping message")
 				}
 			}
-			e.mu.RUnlock()
+			mu.RUnlock()
 		}
 	}
 }
@@ -166,17 +266,27 @@ const (
 	TransactionAdded                 // Transactionhash added mempool
 )
 
-// This is cribbed from https://pkg.go.dev/github.com/satshub/go-bitcoind/zmq
-type zmqEngine struct {
-	endpoint string
+// String returns the human-readable name of the event.
+func (e SequenceEvent) String() string {
+	switch e {
+	case BlockConnected:
+		return "block-connected"
+	case BlockDisconnected:
+		return "block-disconnected"
+	case TransactionRemoved:
+		return "transaction-removed"
+	case TransactionAdded:
+		return "transaction-added"
+	default:
+		return "invalid"
+	}
 }
 
-func NewZmqEngine(endpoint string) *zmqEngine {
-	bc := &zmqEngine{
-		endpoint: endpoint,
-	}
+// This is cribbed from https://pkg.go.dev/github.com/satshub/go-bitcoind/zmq
+type zmqEngine struct{}
 
-	return bc
+func NewZmqEngine() *zmqEngine {
+	return &zmqEngine{}
 }
 
 const channelSize = 100
@@ -185,17 +295,89 @@ const channelSize = 100
 //
 // Call cancel to cancel the subscription and let the client release the resources. The channel is closed
 // when the subscription is canceled or when the client is closed.
-func (bc *zmqEngine) SubscribeRawTx(ctx context.Context) (chan RawMsg, func(), error) {
+func (bc *zmqEngine) SubscribeRawTx(ctx context.Context, endpoint string) (chan RawMsg, func(), error) {
+	return subscribe(ctx, endpoint, "rawtx", func(frames [][]byte) (RawMsg, error) {
+		return RawMsg{
+			Serialized: frames[1],
+			Seq:        binary.LittleEndian.Uint32(frames[2]),
+		}, nil
+	})
+}
+
+// SubscribeHashBlock subscribes to the ZMQ "hashblock" messages as HashMsg items pushed onto the channel.
+//
+// Call cancel to cancel the subscription and let the client release the resources. The channel is closed
+// when the subscription is canceled or when the client is closed.
+func (bc *zmqEngine) SubscribeHashBlock(ctx context.Context, endpoint string) (chan HashMsg, func(), error) {
+	return subscribe(ctx, endpoint, "hashblock", func(frames [][]byte) (HashMsg, error) {
+		if len(frames[1]) != 32 {
+			return HashMsg{}, fmt.Errorf("expected 32 byte hash, got %d", len(frames[1]))
+		}
+
+		msg := HashMsg{Seq: binary.LittleEndian.Uint32(frames[2])}
+		copy(msg.Hash[:], frames[1])
+		return msg, nil
+	})
+}
+
+// SubscribeSequence subscribes to the ZMQ "sequence" messages as SequenceMsg items pushed onto the channel.
+//
+// Call cancel to cancel the subscription and let the client release the resources. The channel is closed
+// when the subscription is canceled or when the client is closed.
+func (bc *zmqEngine) SubscribeSequence(ctx context.Context, endpoint string) (chan SequenceMsg, func(), error) {
+	return subscribe(ctx, endpoint, "sequence", parseSequenceMsg)
+}
+
+// parseSequenceMsg parses the frames of a "sequence" message. The body is
+// <32-byte hash><1-byte label>, followed by an 8-byte little endian mempool
+// sequence number for mempool events.
+func parseSequenceMsg(frames [][]byte) (SequenceMsg, error) {
+	body := frames[1]
+	if len(body) < 33 {
+		return SequenceMsg{}, fmt.Errorf("expected at least 33 bytes, got %d", len(body))
+	}
+
+	var msg SequenceMsg
+	copy(msg.Hash[:], body[:32])
+
+	switch body[32] {
+	case 'C':
+		msg.Event = BlockConnected
+	case 'D':
+		msg.Event = BlockDisconnected
+	case 'R':
+		msg.Event = TransactionRemoved
+	case 'A':
+		msg.Event = TransactionAdded
+	default:
+		return SequenceMsg{}, fmt.Errorf("unknown sequence label: %q", body[32])
+	}
+
+	if msg.Event == TransactionRemoved || msg.Event == TransactionAdded {
+		if len(body) != 41 {
+			return SequenceMsg{}, fmt.Errorf("expected 41 bytes for mempool event, got %d", len(body))
+		}
+		msg.MempoolSeq = binary.LittleEndian.Uint64(body[33:])
+	}
+
+	return msg, nil
+}
+
+// subscribe dials the endpoint and subscribes to the given topic, parsing
+// each three-frame message (topic, body, sequence) with the given function.
+func subscribe[T any](
+	ctx context.Context, endpoint, topic string, parse func(frames [][]byte) (T, error),
+) (chan T, func(), error) {
 	sub := zmq.NewSub(ctx)
-	if err := sub.Dial(bc.endpoint); err != nil {
-		return nil, nil, fmt.Errorf("dial %q: %w", bc.endpoint, err)
+	if err := sub.Dial(endpoint); err != nil {
+		return nil, nil, fmt.Errorf("dial %q: %w", endpoint, err)
 	}
 
-	if err := sub.SetOption(zmq.OptionSubscribe, "rawtx"); err != nil {
-		return nil, nil, fmt.Errorf("subscribe to rawtx: %w", err)
+	if err := sub.SetOption(zmq.OptionSubscribe, topic); err != nil {
+		return nil, nil, fmt.Errorf("subscribe to %s: %w", topic, err)
 	}
 
-	subCh := make(chan RawMsg, channelSize)
+	subCh := make(chan T, channelSize)
 	go func() {
 		for {
 			msg, err := sub.Recv()
@@ -204,36 +386,37 @@ func (bc *zmqEngine) SubscribeRawTx(ctx context.Context) (chan RawMsg, func(), e
 			}
 
 			if len(msg.Frames) != 3 {
-				zerolog.Ctx(ctx).Err(err).Msgf("engines/zmq: expected 2 frames, got %d", len(msg.Frames))
+				zerolog.Ctx(ctx).Error().Msgf("engines/zmq: expected 3 frames, got %d", len(msg.Frames))
 				continue
 			}
 
-			if topic := string(msg.Frames[0]); topic != "rawtx" {
-				zerolog.Ctx(ctx).Err(err).Msgf("engines/zmq: expected rawtx topic, got %s", topic)
+			if got := string(msg.Frames[0]); got != topic {
+				zerolog.Ctx(ctx).Error().Msgf("engines/zmq: expected %s topic, got %s", topic, got)
 				continue
 			}
 
-			parsedRawMsg := RawMsg{
-				Serialized: msg.Frames[1],
-				Seq:        binary.LittleEndian.Uint32(msg.Frames[2]),
+			parsed, err := parse(msg.Frames)
+			if err != nil {
+				zerolog.Ctx(ctx).Err(err).Msgf("engines/zmq: unable to parse %s message", topic)
+				continue
 			}
 
 			select {
 			case <-ctx.Done():
 				zerolog.Ctx(ctx).Info().Err(ctx.Err()).
-					Msg("engines/zmq: rawtx subscription cancelled")
+					Msgf("engines/zmq: %s subscription cancelled", topic)
 				return
 
-			case subCh <- parsedRawMsg:
+			case subCh <- parsed:
 				zerolog.Ctx(ctx).Trace().
-					Msgf("engines/zmq: received rawtx message")
+					Msgf("engines/zmq: received %s message", topic)
 			}
 		}
 	}()
 
 	cancel := func() {
 		if err := sub.Close(); err != nil {
-			zerolog.Ctx(ctx).Err(err).Msg("engines/zmq: unable to close rawtx subscription")
+			zerolog.Ctx(ctx).Err(err).Msgf("engines/zmq: unable to close %s subscription", topic)
 		}
 	}
 	return subCh, cancel, nil
@@ -0,0 +1,77 @@
+package engines
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSequenceMsg(t *testing.T) {
+	t.Parallel()
+
+	hash := bytes.Repeat([]byte{0xab}, 32)
+	frames := func(body ...[]byte) [][]byte {
+		return [][]byte{[]byte("sequence"), bytes.Join(body, nil), {0, 0, 0, 0}}
+	}
+
+	msg, err := parseSequenceMsg(frames(hash, []byte("C")))
+	require.NoError(t, err)
+	assert.Equal(t, BlockConnected, msg.Event)
+	assert.Equal(t, hash, msg.Hash[:])
+
+	msg, err = parseSequenceMsg(frames(hash, []byte("D")))
+	require.NoError(t, err)
+	assert.Equal(t, BlockDisconnected, msg.Event)
+
+	mempoolSeq := binary.LittleEndian.AppendUint64(nil, 1337)
+	msg, err = parseSequenceMsg(frames(hash, []byte("R"), mempoolSeq))
+	require.NoError(t, err)
+	assert.Equal(t, TransactionRemoved, msg.Event)
+	assert.Equal(t, uint64(1337), msg.MempoolSeq)
+
+	_, err = parseSequenceMsg(frames(hash, []byte("R")))
+	assert.Error(t, err, "mempool event without sequence number")
+
+	_, err = parseSequenceMsg(frames(hash, []byte("X")))
+	assert.Error(t, err, "unknown label")
+
+	_, err = parseSequenceMsg(frames(hash[:10]))
+	assert.Error(t, err, "short body")
+}
+
+func TestZMQ_BlocksAfterMempoolBurst(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	e, err := NewZMQ(ZMQEndpoints{Sequence: "tcp://localhost:28332"})
+	require.NoError(t, err)
+	blocks := e.SubscribeBlocks()
+	mempool := e.SubscribeMempool()
+	e.startBroadcasters(ctx)
+
+	// Far more than the mempool subscriber buffers, which nobody reads
+	for i := range 500 {
+		e.queueSequence(SequenceMsg{Event: TransactionAdded, MempoolSeq: uint64(i)})
+	}
+	e.queueSequence(SequenceMsg{Hash: [32]byte{1}, Event: BlockConnected})
+	e.queueSequence(SequenceMsg{Hash: [32]byte{1}, Event: BlockDisconnected})
+
+	for _, event := range []SequenceEvent{BlockConnected, BlockDisconnected} {
+		select {
+		case msg := <-blocks:
+			assert.Equal(t, event, msg.Event)
+		case <-time.After(time.Second):
+			t.Fatalf("no %s event", event)
+		}
+	}
+
+	msg := <-mempool
+	assert.Equal(t, TransactionAdded, msg.Event)
+}
@@ -9,6 +9,7 @@ package bitwindowdv1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
@@ -72,6 +73,114 @@ func (Direction) EnumDescriptor() ([]byte, []int) {
 	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{0}
 }
 
+type WatchSyncInfoResponse_State int32
+
+const (
+	WatchSyncInfoResponse_STATE_UNSPECIFIED WatchSyncInfoResponse_State = 0
+	// Processing blocks
+	WatchSyncInfoResponse_STATE_SYNCING WatchSyncInfoResponse_State = 1
+	// Processed all blocks Bitcoin Core has
+	WatchSyncInfoResponse_STATE_SYNCED WatchSyncInfoResponse_State = 2
+	// Bitcoin Core is still downloading headers
+	WatchSyncInfoResponse_STATE_WAITING_FOR_IBD WatchSyncInfoResponse_State = 3
+	// Bitcoin Core can't be reached
+	WatchSyncInfoResponse_STATE_CORE_UNAVAILABLE WatchSyncInfoResponse_State = 4
+)
+
+// Enum value maps for WatchSyncInfoResponse_State.
+var (
+	WatchSyncInfoResponse_State_name = map[int32]string{
+		0: "STATE_UNSPECIFIED",
+		1: "STATE_SYNCING",
+		2: "STATE_SYNCED",
+		3: "STATE_WAITING_FOR_IBD",
+		4: "STATE_CORE_UNAVAILABLE",
+	}
+	WatchSyncInfoResponse_State_value = map[string]int32{
+		"STATE_UNSPECIFIED":      0,
+		"STATE_SYNCING":          1,
+		"STATE_SYNCED":           2,
+		"STATE_WAITING_FOR_IBD":  3,
+		"STATE_CORE_UNAVAILABLE": 4,
+	}
+)
+
+func (x WatchSyncInfoResponse_State) Enum() *WatchSyncInfoResponse_State {
+	p := new(WatchSyncInfoResponse_State)
+	*p = x
+	return p
+}
+
+func (x WatchSyncInfoResponse_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchSyncInfoResponse_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_bitwindowd_v1_bitwindowd_proto_enumTypes[1].Descriptor()
+}
+
+func (WatchSyncInfoResponse_State) Type() protoreflect.EnumType {
+	return &file_bitwindowd_v1_bitwindowd_proto_enumTypes[1]
+}
+
+func (x WatchSyncInfoResponse_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchSyncInfoResponse_State.Descriptor instead.
+func (WatchSyncInfoResponse_State) EnumDescriptor() ([]byte, []int) {
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{11, 0}
+}
+
+type AddressHistoryEntry_Type int32
+
+const (
+	AddressHistoryEntry_TYPE_UNSPECIFIED AddressHistoryEntry_Type = 0
+	AddressHistoryEntry_TYPE_FUNDING     AddressHistoryEntry_Type = 1
+	AddressHistoryEntry_TYPE_SPENDING    AddressHistoryEntry_Type = 2
+)
+
+// Enum value maps for AddressHistoryEntry_Type.
+var (
+	AddressHistoryEntry_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_FUNDING",
+		2: "TYPE_SPENDING",
+	}
+	AddressHistoryEntry_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_FUNDING":     1,
+		"TYPE_SPENDING":    2,
+	}
+)
+
+func (x AddressHistoryEntry_Type) Enum() *AddressHistoryEntry_Type {
+	p := new(AddressHistoryEntry_Type)
+	*p = x
+	return p
+}
+
+func (x AddressHistoryEntry_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AddressHistoryEntry_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_bitwindowd_v1_bitwindowd_proto_enumTypes[2].Descriptor()
+}
+
+func (AddressHistoryEntry_Type) Type() protoreflect.EnumType {
+	return &file_bitwindowd_v1_bitwindowd_proto_enumTypes[2]
+}
+
+func (x AddressHistoryEntry_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AddressHistoryEntry_Type.Descriptor instead.
+func (AddressHistoryEntry_Type) EnumDescriptor() ([]byte, []int) {
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{24, 0}
+}
+
 type CreateDenialRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
@@ -805,6 +914,102 @@ func (x *GetSyncInfoResponse) GetSyncProgress() float64 {
 	return 0
 }
 
+type WatchSyncInfoResponse struct {
+	state protoimpl.MessageState      `protogen:"open.v1"`
+	State WatchSyncInfoResponse_State `protobuf:"varint,1,opt,name=state,proto3,enum=bitwindowd.v1.WatchSyncInfoResponse_State" json:"state,omitempty"`
+	Info  *GetSyncInfoResponse        `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	// Zero if no blocks are being processed
+	BlocksPerSecond float64 `protobuf:"fixed64,3,opt,name=blocks_per_second,json=blocksPerSecond,proto3" json:"blocks_per_second,omitempty"`
+	// Estimated time until all blocks are processed. Unset if unknown.
+	Eta *durationpb.Duration `protobuf:"bytes,4,opt,name=eta,proto3,oneof" json:"eta,omitempty"`
+	// Set if this update was caused by a reorg
+	Reorg *WatchSyncInfoResponse_Reorg `protobuf:"bytes,5,opt,name=reorg,proto3,oneof" json:"reorg,omitempty"`
+	// Set if Bitcoin Core can't be reached
+	Error         *string                `protobuf:"bytes,6,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchSyncInfoResponse) Reset() {
+	*x = WatchSyncInfoResponse{}
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchSyncInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSyncInfoResponse) ProtoMessage() {}
+
+func (x *WatchSyncInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSyncInfoResponse.ProtoReflect.Descriptor instead.
+func (*WatchSyncInfoResponse) Descriptor() ([]byte, []int) {
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WatchSyncInfoResponse) GetState() WatchSyncInfoResponse_State {
+	if x != nil {
+		return x.State
+	}
+	return WatchSyncInfoResponse_STATE_UNSPECIFIED
+}
+
+func (x *WatchSyncInfoResponse) GetInfo() *GetSyncInfoResponse {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *WatchSyncInfoResponse) GetBlocksPerSecond() float64 {
+	if x != nil {
+		return x.BlocksPerSecond
+	}
+	return 0
+}
+
+func (x *WatchSyncInfoResponse) GetEta() *durationpb.Duration {
+	if x != nil {
+		return x.Eta
+	}
+	return nil
+}
+
+func (x *WatchSyncInfoResponse) GetReorg() *WatchSyncInfoResponse_Reorg {
+	if x != nil {
+		return x.Reorg
+	}
+	return nil
+}
+
+func (x *WatchSyncInfoResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *WatchSyncInfoResponse) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
 // Request to set a transaction note
 type SetTransactionNoteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -816,7 +1021,7 @@ type SetTransactionNoteRequest struct {
 
 func (x *SetTransactionNoteRequest) Reset() {
 	*x = SetTransactionNoteRequest{}
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[11]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -828,7 +1033,7 @@ func (x *SetTransactionNoteRequest) String() string {
 func (*SetTransactionNoteRequest) ProtoMessage() {}
 
 func (x *SetTransactionNoteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[11]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -841,7 +1046,7 @@ func (x *SetTransactionNoteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetTransactionNoteRequest.ProtoReflect.Descriptor instead.
 func (*SetTransactionNoteRequest) Descriptor() ([]byte, []int) {
-	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{11}
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SetTransactionNoteRequest) GetTxid() string {
@@ -863,13 +1068,21 @@ type GetFireplaceStatsResponse struct {
 	TransactionCount_24H int64                  `protobuf:"varint,1,opt,name=transaction_count_24h,json=transactionCount24h,proto3" json:"transaction_count_24h,omitempty"`
 	CoinnewsCount_7D     int64                  `protobuf:"varint,2,opt,name=coinnews_count_7d,json=coinnewsCount7d,proto3" json:"coinnews_count_7d,omitempty"`
 	BlockCount_24H       int64                  `protobuf:"varint,3,opt,name=block_count_24h,json=blockCount24h,proto3" json:"block_count_24h,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+	// Summed over the blocks of the last 24 hours, from the block stats
+	// index. Feerates are left out, GetBlockStats has those per block.
+	TotalFeeSats_24H int64 `protobuf:"varint,4,opt,name=total_fee_sats_24h,json=totalFeeSats24h,proto3" json:"total_fee_sats_24h,omitempty"`
+	Vsize_24H        int64 `protobuf:"varint,5,opt,name=vsize_24h,json=vsize24h,proto3" json:"vsize_24h,omitempty"`
+	// Of all non-coinbase transactions
+	SegwitShare_24H   float64 `protobuf:"fixed64,6,opt,name=segwit_share_24h,json=segwitShare24h,proto3" json:"segwit_share_24h,omitempty"`
+	TaprootShare_24H  float64 `protobuf:"fixed64,7,opt,name=taproot_share_24h,json=taprootShare24h,proto3" json:"taproot_share_24h,omitempty"`
+	OpReturnBytes_24H int64   `protobuf:"varint,8,opt,name=op_return_bytes_24h,json=opReturnBytes24h,proto3" json:"op_return_bytes_24h,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GetFireplaceStatsResponse) Reset() {
 	*x = GetFireplaceStatsResponse{}
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[12]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -881,7 +1094,7 @@ func (x *GetFireplaceStatsResponse) String() string {
 func (*GetFireplaceStatsResponse) ProtoMessage() {}
 
 func (x *GetFireplaceStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[12]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -894,7 +1107,7 @@ func (x *GetFireplaceStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFireplaceStatsResponse.ProtoReflect.Descriptor instead.
 func (*GetFireplaceStatsResponse) Descriptor() ([]byte, []int) {
-	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{12}
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetFireplaceStatsResponse) GetTransactionCount_24H() int64 {
@@ -918,6 +1131,41 @@ func (x *GetFireplaceStatsResponse) GetBlockCount_24H() int64 {
 	return 0
 }
 
+func (x *GetFireplaceStatsResponse) GetTotalFeeSats_24H() int64 {
+	if x != nil {
+		return x.TotalFeeSats_24H
+	}
+	return 0
+}
+
+func (x *GetFireplaceStatsResponse) GetVsize_24H() int64 {
+	if x != nil {
+		return x.Vsize_24H
+	}
+	return 0
+}
+
+func (x *GetFireplaceStatsResponse) GetSegwitShare_24H() float64 {
+	if x != nil {
+		return x.SegwitShare_24H
+	}
+	return 0
+}
+
+func (x *GetFireplaceStatsResponse) GetTaprootShare_24H() float64 {
+	if x != nil {
+		return x.TaprootShare_24H
+	}
+	return 0
+}
+
+func (x *GetFireplaceStatsResponse) GetOpReturnBytes_24H() int64 {
+	if x != nil {
+		return x.OpReturnBytes_24H
+	}
+	return 0
+}
+
 type ListRecentTransactionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
@@ -927,7 +1175,7 @@ type ListRecentTransactionsRequest struct {
 
 func (x *ListRecentTransactionsRequest) Reset() {
 	*x = ListRecentTransactionsRequest{}
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[13]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -939,7 +1187,7 @@ func (x *ListRecentTransactionsRequest) String() string {
 func (*ListRecentTransactionsRequest) ProtoMessage() {}
 
 func (x *ListRecentTransactionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[13]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -952,7 +1200,7 @@ func (x *ListRecentTransactionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRecentTransactionsRequest.ProtoReflect.Descriptor instead.
 func (*ListRecentTransactionsRequest) Descriptor() ([]byte, []int) {
-	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{13}
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListRecentTransactionsRequest) GetCount() int64 {
@@ -971,7 +1219,7 @@ type ListRecentTransactionsResponse struct {
 
 func (x *ListRecentTransactionsResponse) Reset() {
 	*x = ListRecentTransactionsResponse{}
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[14]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -983,7 +1231,7 @@ func (x *ListRecentTransactionsResponse) String() string {
 func (*ListRecentTransactionsResponse) ProtoMessage() {}
 
 func (x *ListRecentTransactionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[14]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -996,7 +1244,7 @@ func (x *ListRecentTransactionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRecentTransactionsResponse.ProtoReflect.Descriptor instead.
 func (*ListRecentTransactionsResponse) Descriptor() ([]byte, []int) {
-	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{14}
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ListRecentTransactionsResponse) GetTransactions() []*RecentTransaction {
@@ -1020,7 +1268,7 @@ type RecentTransaction struct {
 
 func (x *RecentTransaction) Reset() {
 	*x = RecentTransaction{}
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[15]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1032,7 +1280,7 @@ func (x *RecentTransaction) String() string {
 func (*RecentTransaction) ProtoMessage() {}
 
 func (x *RecentTransaction) ProtoReflect() protoreflect.Message {
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[15]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1045,7 +1293,7 @@ func (x *RecentTransaction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecentTransaction.ProtoReflect.Descriptor instead.
 func (*RecentTransaction) Descriptor() ([]byte, []int) {
-	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{15}
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *RecentTransaction) GetVirtualSize() uint32 {
@@ -1093,7 +1341,7 @@ type ListBlocksRequest struct {
 
 func (x *ListBlocksRequest) Reset() {
 	*x = ListBlocksRequest{}
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[16]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1105,7 +1353,7 @@ func (x *ListBlocksRequest) String() string {
 func (*ListBlocksRequest) ProtoMessage() {}
 
 func (x *ListBlocksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[16]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1118,7 +1366,7 @@ func (x *ListBlocksRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBlocksRequest.ProtoReflect.Descriptor instead.
 func (*ListBlocksRequest) Descriptor() ([]byte, []int) {
-	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{16}
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ListBlocksRequest) GetStartHeight() uint32 {
@@ -1159,7 +1407,7 @@ type Block struct {
 
 func (x *Block) Reset() {
 	*x = Block{}
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[17]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1171,7 +1419,7 @@ func (x *Block) String() string {
 func (*Block) ProtoMessage() {}
 
 func (x *Block) ProtoReflect() protoreflect.Message {
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[17]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1184,7 +1432,7 @@ func (x *Block) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Block.ProtoReflect.Descriptor instead.
 func (*Block) Descriptor() ([]byte, []int) {
-	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{17}
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *Block) GetBlockTime() *timestamppb.Timestamp {
@@ -1309,7 +1557,7 @@ type ListBlocksResponse struct {
 
 func (x *ListBlocksResponse) Reset() {
 	*x = ListBlocksResponse{}
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[18]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1321,7 +1569,7 @@ func (x *ListBlocksResponse) String() string {
 func (*ListBlocksResponse) ProtoMessage() {}
 
 func (x *ListBlocksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[18]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1334,7 +1582,7 @@ func (x *ListBlocksResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBlocksResponse.ProtoReflect.Descriptor instead.
 func (*ListBlocksResponse) Descriptor() ([]byte, []int) {
-	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{18}
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ListBlocksResponse) GetRecentBlocks() []*Block {
@@ -1364,7 +1612,7 @@ type MineBlocksResponse struct {
 
 func (x *MineBlocksResponse) Reset() {
 	*x = MineBlocksResponse{}
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[19]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1376,7 +1624,7 @@ func (x *MineBlocksResponse) String() string {
 func (*MineBlocksResponse) ProtoMessage() {}
 
 func (x *MineBlocksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[19]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1389,7 +1637,7 @@ func (x *MineBlocksResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MineBlocksResponse.ProtoReflect.Descriptor instead.
 func (*MineBlocksResponse) Descriptor() ([]byte, []int) {
-	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{19}
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *MineBlocksResponse) GetEvent() isMineBlocksResponse_Event {
@@ -1465,7 +1713,7 @@ type GetNetworkStatsResponse struct {
 
 func (x *GetNetworkStatsResponse) Reset() {
 	*x = GetNetworkStatsResponse{}
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[20]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1477,7 +1725,7 @@ func (x *GetNetworkStatsResponse) String() string {
 func (*GetNetworkStatsResponse) ProtoMessage() {}
 
 func (x *GetNetworkStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[20]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1490,7 +1738,7 @@ func (x *GetNetworkStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNetworkStatsResponse.ProtoReflect.Descriptor instead.
 func (*GetNetworkStatsResponse) Descriptor() ([]byte, []int) {
-	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{20}
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GetNetworkStatsResponse) GetNetworkHashrate() float64 {
@@ -1606,7 +1854,7 @@ type ProcessBandwidth struct {
 
 func (x *ProcessBandwidth) Reset() {
 	*x = ProcessBandwidth{}
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[21]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1618,7 +1866,7 @@ func (x *ProcessBandwidth) String() string {
 func (*ProcessBandwidth) ProtoMessage() {}
 
 func (x *ProcessBandwidth) ProtoReflect() protoreflect.Message {
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[21]
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1631,7 +1879,7 @@ func (x *ProcessBandwidth) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessBandwidth.ProtoReflect.Descriptor instead.
 func (*ProcessBandwidth) Descriptor() ([]byte, []int) {
-	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{21}
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ProcessBandwidth) GetProcessName() string {
@@ -1683,29 +1931,33 @@ func (x *ProcessBandwidth) GetConnectionCount() int32 {
 	return 0
 }
 
-type MineBlocksResponse_HashRate struct {
+type ListAddressHistoryRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Hashes per second
-	HashRate      float64 `protobuf:"fixed64,1,opt,name=hash_rate,json=hashRate,proto3" json:"hash_rate,omitempty"`
+	// Either address or script_pubkey must be set.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Hex-encoded
+	ScriptPubkey  string `protobuf:"bytes,2,opt,name=script_pubkey,json=scriptPubkey,proto3" json:"script_pubkey,omitempty"`
+	Offset        uint32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	PageSize      uint32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // How many entries to return (0 means default 50)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MineBlocksResponse_HashRate) Reset() {
-	*x = MineBlocksResponse_HashRate{}
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[22]
+func (x *ListAddressHistoryRequest) Reset() {
+	*x = ListAddressHistoryRequest{}
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MineBlocksResponse_HashRate) String() string {
+func (x *ListAddressHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MineBlocksResponse_HashRate) ProtoMessage() {}
+func (*ListAddressHistoryRequest) ProtoMessage() {}
 
-func (x *MineBlocksResponse_HashRate) ProtoReflect() protoreflect.Message {
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[22]
+func (x *ListAddressHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1716,40 +1968,70 @@ func (x *MineBlocksResponse_HashRate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MineBlocksResponse_HashRate.ProtoReflect.Descriptor instead.
-func (*MineBlocksResponse_HashRate) Descriptor() ([]byte, []int) {
-	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{19, 0}
+// Deprecated: Use ListAddressHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListAddressHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *MineBlocksResponse_HashRate) GetHashRate() float64 {
+func (x *ListAddressHistoryRequest) GetAddress() string {
 	if x != nil {
-		return x.HashRate
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ListAddressHistoryRequest) GetScriptPubkey() string {
+	if x != nil {
+		return x.ScriptPubkey
+	}
+	return ""
+}
+
+func (x *ListAddressHistoryRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
 	}
 	return 0
 }
 
-type MineBlocksResponse_BlockFound struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	BlockHash     string                 `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+func (x *ListAddressHistoryRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type AddressHistoryEntry struct {
+	state protoimpl.MessageState   `protogen:"open.v1"`
+	Type  AddressHistoryEntry_Type `protobuf:"varint,1,opt,name=type,proto3,enum=bitwindowd.v1.AddressHistoryEntry_Type" json:"type,omitempty"`
+	// The transaction funding or spending the output
+	Txid      string `protobuf:"bytes,2,opt,name=txid,proto3" json:"txid,omitempty"`
+	Height    uint32 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	ValueSats int64  `protobuf:"varint,4,opt,name=value_sats,json=valueSats,proto3" json:"value_sats,omitempty"`
+	// The output that was funded or spent
+	OutputTxid string `protobuf:"bytes,5,opt,name=output_txid,json=outputTxid,proto3" json:"output_txid,omitempty"`
+	OutputVout uint32 `protobuf:"varint,6,opt,name=output_vout,json=outputVout,proto3" json:"output_vout,omitempty"`
+	// The input spending the output. Only set for spends.
+	InputIndex    *uint32 `protobuf:"varint,7,opt,name=input_index,json=inputIndex,proto3,oneof" json:"input_index,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MineBlocksResponse_BlockFound) Reset() {
-	*x = MineBlocksResponse_BlockFound{}
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[23]
+func (x *AddressHistoryEntry) Reset() {
+	*x = AddressHistoryEntry{}
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MineBlocksResponse_BlockFound) String() string {
+func (x *AddressHistoryEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MineBlocksResponse_BlockFound) ProtoMessage() {}
+func (*AddressHistoryEntry) ProtoMessage() {}
 
-func (x *MineBlocksResponse_BlockFound) ProtoReflect() protoreflect.Message {
-	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[23]
+func (x *AddressHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1760,23 +2042,758 @@ func (x *MineBlocksResponse_BlockFound) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MineBlocksResponse_BlockFound.ProtoReflect.Descriptor instead.
-func (*MineBlocksResponse_BlockFound) Descriptor() ([]byte, []int) {
-	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{19, 1}
+// Deprecated: Use AddressHistoryEntry.ProtoReflect.Descriptor instead.
+func (*AddressHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *MineBlocksResponse_BlockFound) GetBlockHash() string {
+func (x *AddressHistoryEntry) GetType() AddressHistoryEntry_Type {
 	if x != nil {
-		return x.BlockHash
+		return x.Type
 	}
-	return ""
+	return AddressHistoryEntry_TYPE_UNSPECIFIED
 }
 
-var File_bitwindowd_v1_bitwindowd_proto protoreflect.FileDescriptor
+func (x *AddressHistoryEntry) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
 
-const file_bitwindowd_v1_bitwindowd_proto_rawDesc = "" +
+func (x *AddressHistoryEntry) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *AddressHistoryEntry) GetValueSats() int64 {
+	if x != nil {
+		return x.ValueSats
+	}
+	return 0
+}
+
+func (x *AddressHistoryEntry) GetOutputTxid() string {
+	if x != nil {
+		return x.OutputTxid
+	}
+	return ""
+}
+
+func (x *AddressHistoryEntry) GetOutputVout() uint32 {
+	if x != nil {
+		return x.OutputVout
+	}
+	return 0
+}
+
+func (x *AddressHistoryEntry) GetInputIndex() uint32 {
+	if x != nil && x.InputIndex != nil {
+		return *x.InputIndex
+	}
+	return 0
+}
+
+type ListAddressHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first
+	Entries []*AddressHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	HasMore bool                   `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	// The last block included in the index
+	IndexedHeight uint32 `protobuf:"varint,3,opt,name=indexed_height,json=indexedHeight,proto3" json:"indexed_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAddressHistoryResponse) Reset() {
+	*x = ListAddressHistoryResponse{}
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAddressHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAddressHistoryResponse) ProtoMessage() {}
+
+func (x *ListAddressHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAddressHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListAddressHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListAddressHistoryResponse) GetEntries() []*AddressHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListAddressHistoryResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *ListAddressHistoryResponse) GetIndexedHeight() uint32 {
+	if x != nil {
+		return x.IndexedHeight
+	}
+	return 0
+}
+
+type GetAddressBalanceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Either address or script_pubkey must be set.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Hex-encoded
+	ScriptPubkey  string `protobuf:"bytes,2,opt,name=script_pubkey,json=scriptPubkey,proto3" json:"script_pubkey,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAddressBalanceRequest) Reset() {
+	*x = GetAddressBalanceRequest{}
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAddressBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressBalanceRequest) ProtoMessage() {}
+
+func (x *GetAddressBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetAddressBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetAddressBalanceRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetAddressBalanceRequest) GetScriptPubkey() string {
+	if x != nil {
+		return x.ScriptPubkey
+	}
+	return ""
+}
+
+type GetAddressBalanceResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConfirmedSats    int64                  `protobuf:"varint,1,opt,name=confirmed_sats,json=confirmedSats,proto3" json:"confirmed_sats,omitempty"`
+	FundedTxoCount   int64                  `protobuf:"varint,2,opt,name=funded_txo_count,json=fundedTxoCount,proto3" json:"funded_txo_count,omitempty"`
+	FundedTxoSumSats int64                  `protobuf:"varint,3,opt,name=funded_txo_sum_sats,json=fundedTxoSumSats,proto3" json:"funded_txo_sum_sats,omitempty"`
+	SpentTxoCount    int64                  `protobuf:"varint,4,opt,name=spent_txo_count,json=spentTxoCount,proto3" json:"spent_txo_count,omitempty"`
+	SpentTxoSumSats  int64                  `protobuf:"varint,5,opt,name=spent_txo_sum_sats,json=spentTxoSumSats,proto3" json:"spent_txo_sum_sats,omitempty"`
+	// The last block included in the index
+	IndexedHeight uint32 `protobuf:"varint,6,opt,name=indexed_height,json=indexedHeight,proto3" json:"indexed_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAddressBalanceResponse) Reset() {
+	*x = GetAddressBalanceResponse{}
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAddressBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressBalanceResponse) ProtoMessage() {}
+
+func (x *GetAddressBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetAddressBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetAddressBalanceResponse) GetConfirmedSats() int64 {
+	if x != nil {
+		return x.ConfirmedSats
+	}
+	return 0
+}
+
+func (x *GetAddressBalanceResponse) GetFundedTxoCount() int64 {
+	if x != nil {
+		return x.FundedTxoCount
+	}
+	return 0
+}
+
+func (x *GetAddressBalanceResponse) GetFundedTxoSumSats() int64 {
+	if x != nil {
+		return x.FundedTxoSumSats
+	}
+	return 0
+}
+
+func (x *GetAddressBalanceResponse) GetSpentTxoCount() int64 {
+	if x != nil {
+		return x.SpentTxoCount
+	}
+	return 0
+}
+
+func (x *GetAddressBalanceResponse) GetSpentTxoSumSats() int64 {
+	if x != nil {
+		return x.SpentTxoSumSats
+	}
+	return 0
+}
+
+func (x *GetAddressBalanceResponse) GetIndexedHeight() uint32 {
+	if x != nil {
+		return x.IndexedHeight
+	}
+	return 0
+}
+
+type GetBlockStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Inclusive. 0 means the genesis block.
+	StartHeight uint32 `protobuf:"varint,1,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	// Inclusive. 0 means the last indexed block. At most 10000 blocks are
+	// returned per request.
+	EndHeight     uint32 `protobuf:"varint,2,opt,name=end_height,json=endHeight,proto3" json:"end_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockStatsRequest) Reset() {
+	*x = GetBlockStatsRequest{}
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockStatsRequest) ProtoMessage() {}
+
+func (x *GetBlockStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockStatsRequest) Descriptor() ([]byte, []int) {
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetBlockStatsRequest) GetStartHeight() uint32 {
+	if x != nil {
+		return x.StartHeight
+	}
+	return 0
+}
+
+func (x *GetBlockStatsRequest) GetEndHeight() uint32 {
+	if x != nil {
+		return x.EndHeight
+	}
+	return 0
+}
+
+type BlockStats struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Height     uint32                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Hash       string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	BlockTime  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"`
+	Difficulty float64                `protobuf:"fixed64,4,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	// Including the coinbase
+	TxCount      int64 `protobuf:"varint,5,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`
+	Weight       int64 `protobuf:"varint,6,opt,name=weight,proto3" json:"weight,omitempty"`
+	Vsize        int64 `protobuf:"varint,7,opt,name=vsize,proto3" json:"vsize,omitempty"`
+	TotalFeeSats int64 `protobuf:"varint,8,opt,name=total_fee_sats,json=totalFeeSats,proto3" json:"total_fee_sats,omitempty"`
+	// Non-coinbase transactions with witness data, and the share of all
+	// non-coinbase transactions they make up.
+	SegwitTxCount int64   `protobuf:"varint,9,opt,name=segwit_tx_count,json=segwitTxCount,proto3" json:"segwit_tx_count,omitempty"`
+	SegwitShare   float64 `protobuf:"fixed64,10,opt,name=segwit_share,json=segwitShare,proto3" json:"segwit_share,omitempty"`
+	// Non-coinbase transactions spending taproot outputs, and the share of
+	// all non-coinbase transactions they make up.
+	TaprootTxCount int64   `protobuf:"varint,11,opt,name=taproot_tx_count,json=taprootTxCount,proto3" json:"taproot_tx_count,omitempty"`
+	TaprootShare   float64 `protobuf:"fixed64,12,opt,name=taproot_share,json=taprootShare,proto3" json:"taproot_share,omitempty"`
+	OpReturnCount  int64   `protobuf:"varint,13,opt,name=op_return_count,json=opReturnCount,proto3" json:"op_return_count,omitempty"`
+	// Total size of all OP_RETURN output scripts
+	OpReturnBytes int64 `protobuf:"varint,14,opt,name=op_return_bytes,json=opReturnBytes,proto3" json:"op_return_bytes,omitempty"`
+	// In sat/vB, weighted by size. Only set if the fees of the individual
+	// transactions are known, which requires --index.addresses.
+	FeeratePercentiles *BlockStats_FeeratePercentiles `protobuf:"bytes,15,opt,name=feerate_percentiles,json=feeratePercentiles,proto3,oneof" json:"feerate_percentiles,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BlockStats) Reset() {
+	*x = BlockStats{}
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockStats) ProtoMessage() {}
+
+func (x *BlockStats) ProtoReflect() protoreflect.Message {
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockStats.ProtoReflect.Descriptor instead.
+func (*BlockStats) Descriptor() ([]byte, []int) {
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *BlockStats) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *BlockStats) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *BlockStats) GetBlockTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BlockTime
+	}
+	return nil
+}
+
+func (x *BlockStats) GetDifficulty() float64 {
+	if x != nil {
+		return x.Difficulty
+	}
+	return 0
+}
+
+func (x *BlockStats) GetTxCount() int64 {
+	if x != nil {
+		return x.TxCount
+	}
+	return 0
+}
+
+func (x *BlockStats) GetWeight() int64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *BlockStats) GetVsize() int64 {
+	if x != nil {
+		return x.Vsize
+	}
+	return 0
+}
+
+func (x *BlockStats) GetTotalFeeSats() int64 {
+	if x != nil {
+		return x.TotalFeeSats
+	}
+	return 0
+}
+
+func (x *BlockStats) GetSegwitTxCount() int64 {
+	if x != nil {
+		return x.SegwitTxCount
+	}
+	return 0
+}
+
+func (x *BlockStats) GetSegwitShare() float64 {
+	if x != nil {
+		return x.SegwitShare
+	}
+	return 0
+}
+
+func (x *BlockStats) GetTaprootTxCount() int64 {
+	if x != nil {
+		return x.TaprootTxCount
+	}
+	return 0
+}
+
+func (x *BlockStats) GetTaprootShare() float64 {
+	if x != nil {
+		return x.TaprootShare
+	}
+	return 0
+}
+
+func (x *BlockStats) GetOpReturnCount() int64 {
+	if x != nil {
+		return x.OpReturnCount
+	}
+	return 0
+}
+
+func (x *BlockStats) GetOpReturnBytes() int64 {
+	if x != nil {
+		return x.OpReturnBytes
+	}
+	return 0
+}
+
+func (x *BlockStats) GetFeeratePercentiles() *BlockStats_FeeratePercentiles {
+	if x != nil {
+		return x.FeeratePercentiles
+	}
+	return nil
+}
+
+type GetBlockStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ordered by height
+	Stats []*BlockStats `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	// The last block included in the index
+	IndexedHeight uint32 `protobuf:"varint,2,opt,name=indexed_height,json=indexedHeight,proto3" json:"indexed_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockStatsResponse) Reset() {
+	*x = GetBlockStatsResponse{}
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockStatsResponse) ProtoMessage() {}
+
+func (x *GetBlockStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetBlockStatsResponse) Descriptor() ([]byte, []int) {
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetBlockStatsResponse) GetStats() []*BlockStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *GetBlockStatsResponse) GetIndexedHeight() uint32 {
+	if x != nil {
+		return x.IndexedHeight
+	}
+	return 0
+}
+
+type WatchSyncInfoResponse_Reorg struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The processed tip before the reorg
+	FromHeight int64 `protobuf:"varint,1,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+	// The last processed block that's still in the best chain
+	ForkHeight    int64 `protobuf:"varint,2,opt,name=fork_height,json=forkHeight,proto3" json:"fork_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchSyncInfoResponse_Reorg) Reset() {
+	*x = WatchSyncInfoResponse_Reorg{}
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchSyncInfoResponse_Reorg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSyncInfoResponse_Reorg) ProtoMessage() {}
+
+func (x *WatchSyncInfoResponse_Reorg) ProtoReflect() protoreflect.Message {
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSyncInfoResponse_Reorg.ProtoReflect.Descriptor instead.
+func (*WatchSyncInfoResponse_Reorg) Descriptor() ([]byte, []int) {
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{11, 0}
+}
+
+func (x *WatchSyncInfoResponse_Reorg) GetFromHeight() int64 {
+	if x != nil {
+		return x.FromHeight
+	}
+	return 0
+}
+
+func (x *WatchSyncInfoResponse_Reorg) GetForkHeight() int64 {
+	if x != nil {
+		return x.ForkHeight
+	}
+	return 0
+}
+
+type MineBlocksResponse_HashRate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Hashes per second
+	HashRate      float64 `protobuf:"fixed64,1,opt,name=hash_rate,json=hashRate,proto3" json:"hash_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MineBlocksResponse_HashRate) Reset() {
+	*x = MineBlocksResponse_HashRate{}
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MineBlocksResponse_HashRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MineBlocksResponse_HashRate) ProtoMessage() {}
+
+func (x *MineBlocksResponse_HashRate) ProtoReflect() protoreflect.Message {
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MineBlocksResponse_HashRate.ProtoReflect.Descriptor instead.
+func (*MineBlocksResponse_HashRate) Descriptor() ([]byte, []int) {
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{20, 0}
+}
+
+func (x *MineBlocksResponse_HashRate) GetHashRate() float64 {
+	if x != nil {
+		return x.HashRate
+	}
+	return 0
+}
+
+type MineBlocksResponse_BlockFound struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockHash     string                 `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MineBlocksResponse_BlockFound) Reset() {
+	*x = MineBlocksResponse_BlockFound{}
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MineBlocksResponse_BlockFound) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MineBlocksResponse_BlockFound) ProtoMessage() {}
+
+func (x *MineBlocksResponse_BlockFound) ProtoReflect() protoreflect.Message {
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MineBlocksResponse_BlockFound.ProtoReflect.Descriptor instead.
+func (*MineBlocksResponse_BlockFound) Descriptor() ([]byte, []int) {
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{20, 1}
+}
+
+func (x *MineBlocksResponse_BlockFound) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+type BlockStats_FeeratePercentiles struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	P10           float64                `protobuf:"fixed64,1,opt,name=p10,proto3" json:"p10,omitempty"`
+	P25           float64                `protobuf:"fixed64,2,opt,name=p25,proto3" json:"p25,omitempty"`
+	P50           float64                `protobuf:"fixed64,3,opt,name=p50,proto3" json:"p50,omitempty"`
+	P75           float64                `protobuf:"fixed64,4,opt,name=p75,proto3" json:"p75,omitempty"`
+	P90           float64                `protobuf:"fixed64,5,opt,name=p90,proto3" json:"p90,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockStats_FeeratePercentiles) Reset() {
+	*x = BlockStats_FeeratePercentiles{}
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockStats_FeeratePercentiles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockStats_FeeratePercentiles) ProtoMessage() {}
+
+func (x *BlockStats_FeeratePercentiles) ProtoReflect() protoreflect.Message {
+	mi := &file_bitwindowd_v1_bitwindowd_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockStats_FeeratePercentiles.ProtoReflect.Descriptor instead.
+func (*BlockStats_FeeratePercentiles) Descriptor() ([]byte, []int) {
+	return file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP(), []int{29, 0}
+}
+
+func (x *BlockStats_FeeratePercentiles) GetP10() float64 {
+	if x != nil {
+		return x.P10
+	}
+	return 0
+}
+
+func (x *BlockStats_FeeratePercentiles) GetP25() float64 {
+	if x != nil {
+		return x.P25
+	}
+	return 0
+}
+
+func (x *BlockStats_FeeratePercentiles) GetP50() float64 {
+	if x != nil {
+		return x.P50
+	}
+	return 0
+}
+
+func (x *BlockStats_FeeratePercentiles) GetP75() float64 {
+	if x != nil {
+		return x.P75
+	}
+	return 0
+}
+
+func (x *BlockStats_FeeratePercentiles) GetP90() float64 {
+	if x != nil {
+		return x.P90
+	}
+	return 0
+}
+
+var File_bitwindowd_v1_bitwindowd_proto protoreflect.FileDescriptor
+
+const file_bitwindowd_v1_bitwindowd_proto_rawDesc = "" +
 	"\n" +
-	"\x1ebitwindowd/v1/bitwindowd.proto\x12\rbitwindowd.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"}\n" +
+	"\x1ebitwindowd/v1/bitwindowd.proto\x12\rbitwindowd.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"}\n" +
 	"\x13CreateDenialRequest\x12\x12\n" +
 	"\x04txid\x18\x01 \x01(\tR\x04txid\x12\x12\n" +
 	"\x04vout\x18\x02 \x01(\rR\x04vout\x12#\n" +
@@ -1840,14 +2857,42 @@ const file_bitwindowd_v1_bitwindowd_proto_rawDesc = "" +
 	"\x0etip_block_hash\x18\x03 \x01(\tR\ftipBlockHash\x12O\n" +
 	"\x16tip_block_processed_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x13tipBlockProcessedAt\x12#\n" +
 	"\rheader_height\x18\x05 \x01(\x03R\fheaderHeight\x12#\n" +
-	"\rsync_progress\x18\x06 \x01(\x01R\fsyncProgress\"C\n" +
+	"\rsync_progress\x18\x06 \x01(\x01R\fsyncProgress\"\xf1\x04\n" +
+	"\x15WatchSyncInfoResponse\x12@\n" +
+	"\x05state\x18\x01 \x01(\x0e2*.bitwindowd.v1.WatchSyncInfoResponse.StateR\x05state\x126\n" +
+	"\x04info\x18\x02 \x01(\v2\".bitwindowd.v1.GetSyncInfoResponseR\x04info\x12*\n" +
+	"\x11blocks_per_second\x18\x03 \x01(\x01R\x0fblocksPerSecond\x120\n" +
+	"\x03eta\x18\x04 \x01(\v2\x19.google.protobuf.DurationH\x00R\x03eta\x88\x01\x01\x12E\n" +
+	"\x05reorg\x18\x05 \x01(\v2*.bitwindowd.v1.WatchSyncInfoResponse.ReorgH\x01R\x05reorg\x88\x01\x01\x12\x19\n" +
+	"\x05error\x18\x06 \x01(\tH\x02R\x05error\x88\x01\x01\x12;\n" +
+	"\vupdate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x1aI\n" +
+	"\x05Reorg\x12\x1f\n" +
+	"\vfrom_height\x18\x01 \x01(\x03R\n" +
+	"fromHeight\x12\x1f\n" +
+	"\vfork_height\x18\x02 \x01(\x03R\n" +
+	"forkHeight\"z\n" +
+	"\x05State\x12\x15\n" +
+	"\x11STATE_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rSTATE_SYNCING\x10\x01\x12\x10\n" +
+	"\fSTATE_SYNCED\x10\x02\x12\x19\n" +
+	"\x15STATE_WAITING_FOR_IBD\x10\x03\x12\x1a\n" +
+	"\x16STATE_CORE_UNAVAILABLE\x10\x04B\x06\n" +
+	"\x04_etaB\b\n" +
+	"\x06_reorgB\b\n" +
+	"\x06_error\"C\n" +
 	"\x19SetTransactionNoteRequest\x12\x12\n" +
 	"\x04txid\x18\x01 \x01(\tR\x04txid\x12\x12\n" +
-	"\x04note\x18\x02 \x01(\tR\x04note\"\xa3\x01\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\"\xf2\x02\n" +
 	"\x19GetFireplaceStatsResponse\x122\n" +
 	"\x15transaction_count_24h\x18\x01 \x01(\x03R\x13transactionCount24h\x12*\n" +
 	"\x11coinnews_count_7d\x18\x02 \x01(\x03R\x0fcoinnewsCount7d\x12&\n" +
-	"\x0fblock_count_24h\x18\x03 \x01(\x03R\rblockCount24h\"5\n" +
+	"\x0fblock_count_24h\x18\x03 \x01(\x03R\rblockCount24h\x12+\n" +
+	"\x12total_fee_sats_24h\x18\x04 \x01(\x03R\x0ftotalFeeSats24h\x12\x1b\n" +
+	"\tvsize_24h\x18\x05 \x01(\x03R\bvsize24h\x12(\n" +
+	"\x10segwit_share_24h\x18\x06 \x01(\x01R\x0esegwitShare24h\x12*\n" +
+	"\x11taproot_share_24h\x18\a \x01(\x01R\x0ftaprootShare24h\x12-\n" +
+	"\x13op_return_bytes_24h\x18\b \x01(\x03R\x10opReturnBytes24h\"5\n" +
 	"\x1dListRecentTransactionsRequest\x12\x14\n" +
 	"\x05count\x18\x01 \x01(\x03R\x05count\"f\n" +
 	"\x1eListRecentTransactionsResponse\x12D\n" +
@@ -1926,11 +2971,82 @@ const file_bitwindowd_v1_bitwindowd_proto_rawDesc = "" +
 	"\x10tx_bytes_per_sec\x18\x04 \x01(\x01R\rtxBytesPerSec\x12$\n" +
 	"\x0etotal_rx_bytes\x18\x05 \x01(\x04R\ftotalRxBytes\x12$\n" +
 	"\x0etotal_tx_bytes\x18\x06 \x01(\x04R\ftotalTxBytes\x12)\n" +
-	"\x10connection_count\x18\a \x01(\x05R\x0fconnectionCount*Q\n" +
+	"\x10connection_count\x18\a \x01(\x05R\x0fconnectionCount\"\x8f\x01\n" +
+	"\x19ListAddressHistoryRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12#\n" +
+	"\rscript_pubkey\x18\x02 \x01(\tR\fscriptPubkey\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\rR\x06offset\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\rR\bpageSize\"\xd8\x02\n" +
+	"\x13AddressHistoryEntry\x12;\n" +
+	"\x04type\x18\x01 \x01(\x0e2'.bitwindowd.v1.AddressHistoryEntry.TypeR\x04type\x12\x12\n" +
+	"\x04txid\x18\x02 \x01(\tR\x04txid\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\rR\x06height\x12\x1d\n" +
+	"\n" +
+	"value_sats\x18\x04 \x01(\x03R\tvalueSats\x12\x1f\n" +
+	"\voutput_txid\x18\x05 \x01(\tR\n" +
+	"outputTxid\x12\x1f\n" +
+	"\voutput_vout\x18\x06 \x01(\rR\n" +
+	"outputVout\x12$\n" +
+	"\vinput_index\x18\a \x01(\rH\x00R\n" +
+	"inputIndex\x88\x01\x01\"A\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fTYPE_FUNDING\x10\x01\x12\x11\n" +
+	"\rTYPE_SPENDING\x10\x02B\x0e\n" +
+	"\f_input_index\"\x9c\x01\n" +
+	"\x1aListAddressHistoryResponse\x12<\n" +
+	"\aentries\x18\x01 \x03(\v2\".bitwindowd.v1.AddressHistoryEntryR\aentries\x12\x19\n" +
+	"\bhas_more\x18\x02 \x01(\bR\ahasMore\x12%\n" +
+	"\x0eindexed_height\x18\x03 \x01(\rR\rindexedHeight\"Y\n" +
+	"\x18GetAddressBalanceRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12#\n" +
+	"\rscript_pubkey\x18\x02 \x01(\tR\fscriptPubkey\"\x97\x02\n" +
+	"\x19GetAddressBalanceResponse\x12%\n" +
+	"\x0econfirmed_sats\x18\x01 \x01(\x03R\rconfirmedSats\x12(\n" +
+	"\x10funded_txo_count\x18\x02 \x01(\x03R\x0efundedTxoCount\x12-\n" +
+	"\x13funded_txo_sum_sats\x18\x03 \x01(\x03R\x10fundedTxoSumSats\x12&\n" +
+	"\x0fspent_txo_count\x18\x04 \x01(\x03R\rspentTxoCount\x12+\n" +
+	"\x12spent_txo_sum_sats\x18\x05 \x01(\x03R\x0fspentTxoSumSats\x12%\n" +
+	"\x0eindexed_height\x18\x06 \x01(\rR\rindexedHeight\"X\n" +
+	"\x14GetBlockStatsRequest\x12!\n" +
+	"\fstart_height\x18\x01 \x01(\rR\vstartHeight\x12\x1d\n" +
+	"\n" +
+	"end_height\x18\x02 \x01(\rR\tendHeight\"\xd8\x05\n" +
+	"\n" +
+	"BlockStats\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\rR\x06height\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\x129\n" +
+	"\n" +
+	"block_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tblockTime\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x04 \x01(\x01R\n" +
+	"difficulty\x12\x19\n" +
+	"\btx_count\x18\x05 \x01(\x03R\atxCount\x12\x16\n" +
+	"\x06weight\x18\x06 \x01(\x03R\x06weight\x12\x14\n" +
+	"\x05vsize\x18\a \x01(\x03R\x05vsize\x12$\n" +
+	"\x0etotal_fee_sats\x18\b \x01(\x03R\ftotalFeeSats\x12&\n" +
+	"\x0fsegwit_tx_count\x18\t \x01(\x03R\rsegwitTxCount\x12!\n" +
+	"\fsegwit_share\x18\n" +
+	" \x01(\x01R\vsegwitShare\x12(\n" +
+	"\x10taproot_tx_count\x18\v \x01(\x03R\x0etaprootTxCount\x12#\n" +
+	"\rtaproot_share\x18\f \x01(\x01R\ftaprootShare\x12&\n" +
+	"\x0fop_return_count\x18\r \x01(\x03R\ropReturnCount\x12&\n" +
+	"\x0fop_return_bytes\x18\x0e \x01(\x03R\ropReturnBytes\x12b\n" +
+	"\x13feerate_percentiles\x18\x0f \x01(\v2,.bitwindowd.v1.BlockStats.FeeratePercentilesH\x00R\x12feeratePercentiles\x88\x01\x01\x1an\n" +
+	"\x12FeeratePercentiles\x12\x10\n" +
+	"\x03p10\x18\x01 \x01(\x01R\x03p10\x12\x10\n" +
+	"\x03p25\x18\x02 \x01(\x01R\x03p25\x12\x10\n" +
+	"\x03p50\x18\x03 \x01(\x01R\x03p50\x12\x10\n" +
+	"\x03p75\x18\x04 \x01(\x01R\x03p75\x12\x10\n" +
+	"\x03p90\x18\x05 \x01(\x01R\x03p90B\x16\n" +
+	"\x14_feerate_percentiles\"o\n" +
+	"\x15GetBlockStatsResponse\x12/\n" +
+	"\x05stats\x18\x01 \x03(\v2\x19.bitwindowd.v1.BlockStatsR\x05stats\x12%\n" +
+	"\x0eindexed_height\x18\x02 \x01(\rR\rindexedHeight*Q\n" +
 	"\tDirection\x12\x19\n" +
 	"\x15DIRECTION_UNSPECIFIED\x10\x00\x12\x12\n" +
 	"\x0eDIRECTION_SEND\x10\x01\x12\x15\n" +
-	"\x11DIRECTION_RECEIVE\x10\x022\xcf\t\n" +
+	"\x11DIRECTION_RECEIVE\x10\x022\xcf\f\n" +
 	"\x11BitwindowdService\x126\n" +
 	"\x04Stop\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12I\n" +
 	"\n" +
@@ -1941,13 +3057,17 @@ const file_bitwindowd_v1_bitwindowd_proto_rawDesc = "" +
 	"\x0fListAddressBook\x12\x16.google.protobuf.Empty\x1a&.bitwindowd.v1.ListAddressBookResponse\x12^\n" +
 	"\x16UpdateAddressBookEntry\x12,.bitwindowd.v1.UpdateAddressBookEntryRequest\x1a\x16.google.protobuf.Empty\x12^\n" +
 	"\x16DeleteAddressBookEntry\x12,.bitwindowd.v1.DeleteAddressBookEntryRequest\x1a\x16.google.protobuf.Empty\x12I\n" +
-	"\vGetSyncInfo\x12\x16.google.protobuf.Empty\x1a\".bitwindowd.v1.GetSyncInfoResponse\x12V\n" +
+	"\vGetSyncInfo\x12\x16.google.protobuf.Empty\x1a\".bitwindowd.v1.GetSyncInfoResponse\x12O\n" +
+	"\rWatchSyncInfo\x12\x16.google.protobuf.Empty\x1a$.bitwindowd.v1.WatchSyncInfoResponse0\x01\x12V\n" +
 	"\x12SetTransactionNote\x12(.bitwindowd.v1.SetTransactionNoteRequest\x1a\x16.google.protobuf.Empty\x12U\n" +
 	"\x11GetFireplaceStats\x12\x16.google.protobuf.Empty\x1a(.bitwindowd.v1.GetFireplaceStatsResponse\x12u\n" +
 	"\x16ListRecentTransactions\x12,.bitwindowd.v1.ListRecentTransactionsRequest\x1a-.bitwindowd.v1.ListRecentTransactionsResponse\x12Q\n" +
 	"\n" +
 	"ListBlocks\x12 .bitwindowd.v1.ListBlocksRequest\x1a!.bitwindowd.v1.ListBlocksResponse\x12Q\n" +
-	"\x0fGetNetworkStats\x12\x16.google.protobuf.Empty\x1a&.bitwindowd.v1.GetNetworkStatsResponseB\xcc\x01\n" +
+	"\x0fGetNetworkStats\x12\x16.google.protobuf.Empty\x1a&.bitwindowd.v1.GetNetworkStatsResponse\x12i\n" +
+	"\x12ListAddressHistory\x12(.bitwindowd.v1.ListAddressHistoryRequest\x1a).bitwindowd.v1.ListAddressHistoryResponse\x12f\n" +
+	"\x11GetAddressBalance\x12'.bitwindowd.v1.GetAddressBalanceRequest\x1a(.bitwindowd.v1.GetAddressBalanceResponse\x12Z\n" +
+	"\rGetBlockStats\x12#.bitwindowd.v1.GetBlockStatsRequest\x1a$.bitwindowd.v1.GetBlockStatsResponseB\xcc\x01\n" +
 	"\x11com.bitwindowd.v1B\x0fBitwindowdProtoP\x01ZQgithub.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/bitwindowd/v1;bitwindowdv1\xa2\x02\x03BXX\xaa\x02\rBitwindowd.V1\xca\x02\rBitwindowd\\V1\xe2\x02\x19Bitwindowd\\V1\\GPBMetadata\xea\x02\x0eBitwindowd::V1b\x06proto3"
 
 var (
@@ -1962,90 +3082,122 @@ func file_bitwindowd_v1_bitwindowd_proto_rawDescGZIP() []byte {
 	return file_bitwindowd_v1_bitwindowd_proto_rawDescData
 }
 
-var file_bitwindowd_v1_bitwindowd_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_bitwindowd_v1_bitwindowd_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_bitwindowd_v1_bitwindowd_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_bitwindowd_v1_bitwindowd_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_bitwindowd_v1_bitwindowd_proto_goTypes = []any{
 	(Direction)(0),                         // 0: bitwindowd.v1.Direction
-	(*CreateDenialRequest)(nil),            // 1: bitwindowd.v1.CreateDenialRequest
-	(*DenialInfo)(nil),                     // 2: bitwindowd.v1.DenialInfo
-	(*ExecutedDenial)(nil),                 // 3: bitwindowd.v1.ExecutedDenial
-	(*CancelDenialRequest)(nil),            // 4: bitwindowd.v1.CancelDenialRequest
-	(*CreateAddressBookEntryRequest)(nil),  // 5: bitwindowd.v1.CreateAddressBookEntryRequest
-	(*CreateAddressBookEntryResponse)(nil), // 6: bitwindowd.v1.CreateAddressBookEntryResponse
-	(*AddressBookEntry)(nil),               // 7: bitwindowd.v1.AddressBookEntry
-	(*ListAddressBookResponse)(nil),        // 8: bitwindowd.v1.ListAddressBookResponse
-	(*UpdateAddressBookEntryRequest)(nil),  // 9: bitwindowd.v1.UpdateAddressBookEntryRequest
-	(*DeleteAddressBookEntryRequest)(nil),  // 10: bitwindowd.v1.DeleteAddressBookEntryRequest
-	(*GetSyncInfoResponse)(nil),            // 11: bitwindowd.v1.GetSyncInfoResponse
-	(*SetTransactionNoteRequest)(nil),      // 12: bitwindowd.v1.SetTransactionNoteRequest
-	(*GetFireplaceStatsResponse)(nil),      // 13: bitwindowd.v1.GetFireplaceStatsResponse
-	(*ListRecentTransactionsRequest)(nil),  // 14: bitwindowd.v1.ListRecentTransactionsRequest
-	(*ListRecentTransactionsResponse)(nil), // 15: bitwindowd.v1.ListRecentTransactionsResponse
-	(*RecentTransaction)(nil),              // 16: bitwindowd.v1.RecentTransaction
-	(*ListBlocksRequest)(nil),              // 17: bitwindowd.v1.ListBlocksRequest
-	(*Block)(nil),                          // 18: bitwindowd.v1.Block
-	(*ListBlocksResponse)(nil),             // 19: bitwindowd.v1.ListBlocksResponse
-	(*MineBlocksResponse)(nil),             // 20: bitwindowd.v1.MineBlocksResponse
-	(*GetNetworkStatsResponse)(nil),        // 21: bitwindowd.v1.GetNetworkStatsResponse
-	(*ProcessBandwidth)(nil),               // 22: bitwindowd.v1.ProcessBandwidth
-	(*MineBlocksResponse_HashRate)(nil),    // 23: bitwindowd.v1.MineBlocksResponse.HashRate
-	(*MineBlocksResponse_BlockFound)(nil),  // 24: bitwindowd.v1.MineBlocksResponse.BlockFound
-	(*timestamppb.Timestamp)(nil),          // 25: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                  // 26: google.protobuf.Empty
+	(WatchSyncInfoResponse_State)(0),       // 1: bitwindowd.v1.WatchSyncInfoResponse.State
+	(AddressHistoryEntry_Type)(0),          // 2: bitwindowd.v1.AddressHistoryEntry.Type
+	(*CreateDenialRequest)(nil),            // 3: bitwindowd.v1.CreateDenialRequest
+	(*DenialInfo)(nil),                     // 4: bitwindowd.v1.DenialInfo
+	(*ExecutedDenial)(nil),                 // 5: bitwindowd.v1.ExecutedDenial
+	(*CancelDenialRequest)(nil),            // 6: bitwindowd.v1.CancelDenialRequest
+	(*CreateAddressBookEntryRequest)(nil),  // 7: bitwindowd.v1.CreateAddressBookEntryRequest
+	(*CreateAddressBookEntryResponse)(nil), // 8: bitwindowd.v1.CreateAddressBookEntryResponse
+	(*AddressBookEntry)(nil),               // 9: bitwindowd.v1.AddressBookEntry
+	(*ListAddressBookResponse)(nil),        // 10: bitwindowd.v1.ListAddressBookResponse
+	(*UpdateAddressBookEntryRequest)(nil),  // 11: bitwindowd.v1.UpdateAddressBookEntryRequest
+	(*DeleteAddressBookEntryRequest)(nil),  // 12: bitwindowd.v1.DeleteAddressBookEntryRequest
+	(*GetSyncInfoResponse)(nil),            // 13: bitwindowd.v1.GetSyncInfoResponse
+	(*WatchSyncInfoResponse)(nil),          // 14: bitwindowd.v1.WatchSyncInfoResponse
+	(*SetTransactionNoteRequest)(nil),      // 15: bitwindowd.v1.SetTransactionNoteRequest
+	(*GetFireplaceStatsResponse)(nil),      // 16: bitwindowd.v1.GetFireplaceStatsResponse
+	(*ListRecentTransactionsRequest)(nil),  // 17: bitwindowd.v1.ListRecentTransactionsRequest
+	(*ListRecentTransactionsResponse)(nil), // 18: bitwindowd.v1.ListRecentTransactionsResponse
+	(*RecentTransaction)(nil),              // 19: bitwindowd.v1.RecentTransaction
+	(*ListBlocksRequest)(nil),              // 20: bitwindowd.v1.ListBlocksRequest
+	(*Block)(nil),                          // 21: bitwindowd.v1.Block
+	(*ListBlocksResponse)(nil),             // 22: bitwindowd.v1.ListBlocksResponse
+	(*MineBlocksResponse)(nil),             // 23: bitwindowd.v1.MineBlocksResponse
+	(*GetNetworkStatsResponse)(nil),        // 24: bitwindowd.v1.GetNetworkStatsResponse
+	(*ProcessBandwidth)(nil),               // 25: bitwindowd.v1.ProcessBandwidth
+	(*ListAddressHistoryRequest)(nil),      // 26: bitwindowd.v1.ListAddressHistoryRequest
+	(*AddressHistoryEntry)(nil),            // 27: bitwindowd.v1.AddressHistoryEntry
+	(*ListAddressHistoryResponse)(nil),     // 28: bitwindowd.v1.ListAddressHistoryResponse
+	(*GetAddressBalanceRequest)(nil),       // 29: bitwindowd.v1.GetAddressBalanceRequest
+	(*GetAddressBalanceResponse)(nil),      // 30: bitwindowd.v1.GetAddressBalanceResponse
+	(*GetBlockStatsRequest)(nil),           // 31: bitwindowd.v1.GetBlockStatsRequest
+	(*BlockStats)(nil),                     // 32: bitwindowd.v1.BlockStats
+	(*GetBlockStatsResponse)(nil),          // 33: bitwindowd.v1.GetBlockStatsResponse
+	(*WatchSyncInfoResponse_Reorg)(nil),    // 34: bitwindowd.v1.WatchSyncInfoResponse.Reorg
+	(*MineBlocksResponse_HashRate)(nil),    // 35: bitwindowd.v1.MineBlocksResponse.HashRate
+	(*MineBlocksResponse_BlockFound)(nil),  // 36: bitwindowd.v1.MineBlocksResponse.BlockFound
+	(*BlockStats_FeeratePercentiles)(nil),  // 37: bitwindowd.v1.BlockStats.FeeratePercentiles
+	(*timestamppb.Timestamp)(nil),          // 38: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),            // 39: google.protobuf.Duration
+	(*emptypb.Empty)(nil),                  // 40: google.protobuf.Empty
 }
 var file_bitwindowd_v1_bitwindowd_proto_depIdxs = []int32{
-	25, // 0: bitwindowd.v1.DenialInfo.create_time:type_name -> google.protobuf.Timestamp
-	25, // 1: bitwindowd.v1.DenialInfo.cancel_time:type_name -> google.protobuf.Timestamp
-	25, // 2: bitwindowd.v1.DenialInfo.next_execution_time:type_name -> google.protobuf.Timestamp
-	3,  // 3: bitwindowd.v1.DenialInfo.executions:type_name -> bitwindowd.v1.ExecutedDenial
-	25, // 4: bitwindowd.v1.ExecutedDenial.create_time:type_name -> google.protobuf.Timestamp
+	38, // 0: bitwindowd.v1.DenialInfo.create_time:type_name -> google.protobuf.Timestamp
+	38, // 1: bitwindowd.v1.DenialInfo.cancel_time:type_name -> google.protobuf.Timestamp
+	38, // 2: bitwindowd.v1.DenialInfo.next_execution_time:type_name -> google.protobuf.Timestamp
+	5,  // 3: bitwindowd.v1.DenialInfo.executions:type_name -> bitwindowd.v1.ExecutedDenial
+	38, // 4: bitwindowd.v1.ExecutedDenial.create_time:type_name -> google.protobuf.Timestamp
 	0,  // 5: bitwindowd.v1.CreateAddressBookEntryRequest.direction:type_name -> bitwindowd.v1.Direction
-	7,  // 6: bitwindowd.v1.CreateAddressBookEntryResponse.entry:type_name -> bitwindowd.v1.AddressBookEntry
+	9,  // 6: bitwindowd.v1.CreateAddressBookEntryResponse.entry:type_name -> bitwindowd.v1.AddressBookEntry
 	0,  // 7: bitwindowd.v1.AddressBookEntry.direction:type_name -> bitwindowd.v1.Direction
-	25, // 8: bitwindowd.v1.AddressBookEntry.create_time:type_name -> google.protobuf.Timestamp
-	7,  // 9: bitwindowd.v1.ListAddressBookResponse.entries:type_name -> bitwindowd.v1.AddressBookEntry
-	25, // 10: bitwindowd.v1.GetSyncInfoResponse.tip_block_processed_at:type_name -> google.protobuf.Timestamp
-	16, // 11: bitwindowd.v1.ListRecentTransactionsResponse.transactions:type_name -> bitwindowd.v1.RecentTransaction
-	25, // 12: bitwindowd.v1.RecentTransaction.time:type_name -> google.protobuf.Timestamp
-	25, // 13: bitwindowd.v1.Block.block_time:type_name -> google.protobuf.Timestamp
-	18, // 14: bitwindowd.v1.ListBlocksResponse.recent_blocks:type_name -> bitwindowd.v1.Block
-	24, // 15: bitwindowd.v1.MineBlocksResponse.block_found:type_name -> bitwindowd.v1.MineBlocksResponse.BlockFound
-	23, // 16: bitwindowd.v1.MineBlocksResponse.hash_rate:type_name -> bitwindowd.v1.MineBlocksResponse.HashRate
-	22, // 17: bitwindowd.v1.GetNetworkStatsResponse.bitcoind_bandwidth:type_name -> bitwindowd.v1.ProcessBandwidth
-	22, // 18: bitwindowd.v1.GetNetworkStatsResponse.enforcer_bandwidth:type_name -> bitwindowd.v1.ProcessBandwidth
-	26, // 19: bitwindowd.v1.BitwindowdService.Stop:input_type -> google.protobuf.Empty
-	26, // 20: bitwindowd.v1.BitwindowdService.MineBlocks:input_type -> google.protobuf.Empty
-	1,  // 21: bitwindowd.v1.BitwindowdService.CreateDenial:input_type -> bitwindowd.v1.CreateDenialRequest
-	4,  // 22: bitwindowd.v1.BitwindowdService.CancelDenial:input_type -> bitwindowd.v1.CancelDenialRequest
-	5,  // 23: bitwindowd.v1.BitwindowdService.CreateAddressBookEntry:input_type -> bitwindowd.v1.CreateAddressBookEntryRequest
-	26, // 24: bitwindowd.v1.BitwindowdService.ListAddressBook:input_type -> google.protobuf.Empty
-	9,  // 25: bitwindowd.v1.BitwindowdService.UpdateAddressBookEntry:input_type -> bitwindowd.v1.UpdateAddressBookEntryRequest
-	10, // 26: bitwindowd.v1.BitwindowdService.DeleteAddressBookEntry:input_type -> bitwindowd.v1.DeleteAddressBookEntryRequest
-	26, // 27: bitwindowd.v1.BitwindowdService.GetSyncInfo:input_type -> google.protobuf.Empty
-	12, // 28: bitwindowd.v1.BitwindowdService.SetTransactionNote:input_type -> bitwindowd.v1.SetTransactionNoteRequest
-	26, // 29: bitwindowd.v1.BitwindowdService.GetFireplaceStats:input_type -> google.protobuf.Empty
-	14, // 30: bitwindowd.v1.BitwindowdService.ListRecentTransactions:input_type -> bitwindowd.v1.ListRecentTransactionsRequest
-	17, // 31: bitwindowd.v1.BitwindowdService.ListBlocks:input_type -> bitwindowd.v1.ListBlocksRequest
-	26, // 32: bitwindowd.v1.BitwindowdService.GetNetworkStats:input_type -> google.protobuf.Empty
-	26, // 33: bitwindowd.v1.BitwindowdService.Stop:output_type -> google.protobuf.Empty
-	20, // 34: bitwindowd.v1.BitwindowdService.MineBlocks:output_type -> bitwindowd.v1.MineBlocksResponse
-	26, // 35: bitwindowd.v1.BitwindowdService.CreateDenial:output_type -> google.protobuf.Empty
-	26, // 36: bitwindowd.v1.BitwindowdService.CancelDenial:output_type -> google.protobuf.Empty
-	6,  // 37: bitwindowd.v1.BitwindowdService.CreateAddressBookEntry:output_type -> bitwindowd.v1.CreateAddressBookEntryResponse
-	8,  // 38: bitwindowd.v1.BitwindowdService.ListAddressBook:output_type -> bitwindowd.v1.ListAddressBookResponse
-	26, // 39: bitwindowd.v1.BitwindowdService.UpdateAddressBookEntry:output_type -> google.protobuf.Empty
-	26, // 40: bitwindowd.v1.BitwindowdService.DeleteAddressBookEntry:output_type -> google.protobuf.Empty
-	11, // 41: bitwindowd.v1.BitwindowdService.GetSyncInfo:output_type -> bitwindowd.v1.GetSyncInfoResponse
-	26, // 42: bitwindowd.v1.BitwindowdService.SetTransactionNote:output_type -> google.protobuf.Empty
-	13, // 43: bitwindowd.v1.BitwindowdService.GetFireplaceStats:output_type -> bitwindowd.v1.GetFireplaceStatsResponse
-	15, // 44: bitwindowd.v1.BitwindowdService.ListRecentTransactions:output_type -> bitwindowd.v1.ListRecentTransactionsResponse
-	19, // 45: bitwindowd.v1.BitwindowdService.ListBlocks:output_type -> bitwindowd.v1.ListBlocksResponse
-	21, // 46: bitwindowd.v1.BitwindowdService.GetNetworkStats:output_type -> bitwindowd.v1.GetNetworkStatsResponse
-	33, // [33:47] is the sub-list for method output_type
-	19, // [19:33] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	38, // 8: bitwindowd.v1.AddressBookEntry.create_time:type_name -> google.protobuf.Timestamp
+	9,  // 9: bitwindowd.v1.ListAddressBookResponse.entries:type_name -> bitwindowd.v1.AddressBookEntry
+	38, // 10: bitwindowd.v1.GetSyncInfoResponse.tip_block_processed_at:type_name -> google.protobuf.Timestamp
+	1,  // 11: bitwindowd.v1.WatchSyncInfoResponse.state:type_name -> bitwindowd.v1.WatchSyncInfoResponse.State
+	13, // 12: bitwindowd.v1.WatchSyncInfoResponse.info:type_name -> bitwindowd.v1.GetSyncInfoResponse
+	39, // 13: bitwindowd.v1.WatchSyncInfoResponse.eta:type_name -> google.protobuf.Duration
+	34, // 14: bitwindowd.v1.WatchSyncInfoResponse.reorg:type_name -> bitwindowd.v1.WatchSyncInfoResponse.Reorg
+	38, // 15: bitwindowd.v1.WatchSyncInfoResponse.update_time:type_name -> google.protobuf.Timestamp
+	19, // 16: bitwindowd.v1.ListRecentTransactionsResponse.transactions:type_name -> bitwindowd.v1.RecentTransaction
+	38, // 17: bitwindowd.v1.RecentTransaction.time:type_name -> google.protobuf.Timestamp
+	38, // 18: bitwindowd.v1.Block.block_time:type_name -> google.protobuf.Timestamp
+	21, // 19: bitwindowd.v1.ListBlocksResponse.recent_blocks:type_name -> bitwindowd.v1.Block
+	36, // 20: bitwindowd.v1.MineBlocksResponse.block_found:type_name -> bitwindowd.v1.MineBlocksResponse.BlockFound
+	35, // 21: bitwindowd.v1.MineBlocksResponse.hash_rate:type_name -> bitwindowd.v1.MineBlocksResponse.HashRate
+	25, // 22: bitwindowd.v1.GetNetworkStatsResponse.bitcoind_bandwidth:type_name -> bitwindowd.v1.ProcessBandwidth
+	25, // 23: bitwindowd.v1.GetNetworkStatsResponse.enforcer_bandwidth:type_name -> bitwindowd.v1.ProcessBandwidth
+	2,  // 24: bitwindowd.v1.AddressHistoryEntry.type:type_name -> bitwindowd.v1.AddressHistoryEntry.Type
+	27, // 25: bitwindowd.v1.ListAddressHistoryResponse.entries:type_name -> bitwindowd.v1.AddressHistoryEntry
+	38, // 26: bitwindowd.v1.BlockStats.block_time:type_name -> google.protobuf.Timestamp
+	37, // 27: bitwindowd.v1.BlockStats.feerate_percentiles:type_name -> bitwindowd.v1.BlockStats.FeeratePercentiles
+	32, // 28: bitwindowd.v1.GetBlockStatsResponse.stats:type_name -> bitwindowd.v1.BlockStats
+	40, // 29: bitwindowd.v1.BitwindowdService.Stop:input_type -> google.protobuf.Empty
+	40, // 30: bitwindowd.v1.BitwindowdService.MineBlocks:input_type -> google.protobuf.Empty
+	3,  // 31: bitwindowd.v1.BitwindowdService.CreateDenial:input_type -> bitwindowd.v1.CreateDenialRequest
+	6,  // 32: bitwindowd.v1.BitwindowdService.CancelDenial:input_type -> bitwindowd.v1.CancelDenialRequest
+	7,  // 33: bitwindowd.v1.BitwindowdService.CreateAddressBookEntry:input_type -> bitwindowd.v1.CreateAddressBookEntryRequest
+	40, // 34: bitwindowd.v1.BitwindowdService.ListAddressBook:input_type -> google.protobuf.Empty
+	11, // 35: bitwindowd.v1.BitwindowdService.UpdateAddressBookEntry:input_type -> bitwindowd.v1.UpdateAddressBookEntryRequest
+	12, // 36: bitwindowd.v1.BitwindowdService.DeleteAddressBookEntry:input_type -> bitwindowd.v1.DeleteAddressBookEntryRequest
+	40, // 37: bitwindowd.v1.BitwindowdService.GetSyncInfo:input_type -> google.protobuf.Empty
+	40, // 38: bitwindowd.v1.BitwindowdService.WatchSyncInfo:input_type -> google.protobuf.Empty
+	15, // 39: bitwindowd.v1.BitwindowdService.SetTransactionNote:input_type -> bitwindowd.v1.SetTransactionNoteRequest
+	40, // 40: bitwindowd.v1.BitwindowdService.GetFireplaceStats:input_type -> google.protobuf.Empty
+	17, // 41: bitwindowd.v1.BitwindowdService.ListRecentTransactions:input_type -> bitwindowd.v1.ListRecentTransactionsRequest
+	20, // 42: bitwindowd.v1.BitwindowdService.ListBlocks:input_type -> bitwindowd.v1.ListBlocksRequest
+	40, // 43: bitwindowd.v1.BitwindowdService.GetNetworkStats:input_type -> google.protobuf.Empty
+	26, // 44: bitwindowd.v1.BitwindowdService.ListAddressHistory:input_type -> bitwindowd.v1.ListAddressHistoryRequest
+	29, // 45: bitwindowd.v1.BitwindowdService.GetAddressBalance:input_type -> bitwindowd.v1.GetAddressBalanceRequest
+	31, // 46: bitwindowd.v1.BitwindowdService.GetBlockStats:input_type -> bitwindowd.v1.GetBlockStatsRequest
+	40, // 47: bitwindowd.v1.BitwindowdService.Stop:output_type -> google.protobuf.Empty
+	23, // 48: bitwindowd.v1.BitwindowdService.MineBlocks:output_type -> bitwindowd.v1.MineBlocksResponse
+	40, // 49: bitwindowd.v1.BitwindowdService.CreateDenial:output_type -> google.protobuf.Empty
+	40, // 50: bitwindowd.v1.BitwindowdService.CancelDenial:output_type -> google.protobuf.Empty
+	8,  // 51: bitwindowd.v1.BitwindowdService.CreateAddressBookEntry:output_type -> bitwindowd.v1.CreateAddressBookEntryResponse
+	10, // 52: bitwindowd.v1.BitwindowdService.ListAddressBook:output_type -> bitwindowd.v1.ListAddressBookResponse
+	40, // 53: bitwindowd.v1.BitwindowdService.UpdateAddressBookEntry:output_type -> google.protobuf.Empty
+	40, // 54: bitwindowd.v1.BitwindowdService.DeleteAddressBookEntry:output_type -> google.protobuf.Empty
+	13, // 55: bitwindowd.v1.BitwindowdService.GetSyncInfo:output_type -> bitwindowd.v1.GetSyncInfoResponse
+	14, // 56: bitwindowd.v1.BitwindowdService.WatchSyncInfo:output_type -> bitwindowd.v1.WatchSyncInfoResponse
+	40, // 57: bitwindowd.v1.BitwindowdService.SetTransactionNote:output_type -> google.protobuf.Empty
+	16, // 58: bitwindowd.v1.BitwindowdService.GetFireplaceStats:output_type -> bitwindowd.v1.GetFireplaceStatsResponse
+	18, // 59: bitwindowd.v1.BitwindowdService.ListRecentTransactions:output_type -> bitwindowd.v1.ListRecentTransactionsResponse
+	22, // 60: bitwindowd.v1.BitwindowdService.ListBlocks:output_type -> bitwindowd.v1.ListBlocksResponse
+	24, // 61: bitwindowd.v1.BitwindowdService.GetNetworkStats:output_type -> bitwindowd.v1.GetNetworkStatsResponse
+	28, // 62: bitwindowd.v1.BitwindowdService.ListAddressHistory:output_type -> bitwindowd.v1.ListAddressHistoryResponse
+	30, // 63: bitwindowd.v1.BitwindowdService.GetAddressBalance:output_type -> bitwindowd.v1.GetAddressBalanceResponse
+	33, // 64: bitwindowd.v1.BitwindowdService.GetBlockStats:output_type -> bitwindowd.v1.GetBlockStatsResponse
+	47, // [47:65] is the sub-list for method output_type
+	29, // [29:47] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_bitwindowd_v1_bitwindowd_proto_init() }
@@ -2054,18 +3206,21 @@ func file_bitwindowd_v1_bitwindowd_proto_init() {
 		return
 	}
 	file_bitwindowd_v1_bitwindowd_proto_msgTypes[1].OneofWrappers = []any{}
-	file_bitwindowd_v1_bitwindowd_proto_msgTypes[15].OneofWrappers = []any{}
-	file_bitwindowd_v1_bitwindowd_proto_msgTypes[19].OneofWrappers = []any{
+	file_bitwindowd_v1_bitwindowd_proto_msgTypes[11].OneofWrappers = []any{}
+	file_bitwindowd_v1_bitwindowd_proto_msgTypes[16].OneofWrappers = []any{}
+	file_bitwindowd_v1_bitwindowd_proto_msgTypes[20].OneofWrappers = []any{
 		(*MineBlocksResponse_BlockFound_)(nil),
 		(*MineBlocksResponse_HashRate_)(nil),
 	}
+	file_bitwindowd_v1_bitwindowd_proto_msgTypes[24].OneofWrappers = []any{}
+	file_bitwindowd_v1_bitwindowd_proto_msgTypes[29].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bitwindowd_v1_bitwindowd_proto_rawDesc), len(file_bitwindowd_v1_bitwindowd_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   24,
+			NumEnums:      3,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -60,6 +60,9 @@ const (
 	// BitwindowdServiceGetSyncInfoProcedure is the fully-qualified name of the BitwindowdService's
 	// GetSyncInfo RPC.
 	BitwindowdServiceGetSyncInfoProcedure = "/bitwindowd.v1.BitwindowdService/GetSyncInfo"
+	// BitwindowdServiceWatchSyncInfoProcedure is the fully-qualified name of the BitwindowdService's
+	// WatchSyncInfo RPC.
+	BitwindowdServiceWatchSyncInfoProcedure = "/bitwindowd.v1.BitwindowdService/WatchSyncInfo"
 	// BitwindowdServiceSetTransactionNoteProcedure is the fully-qualified name of the
 	// BitwindowdService's SetTransactionNote RPC.
 	BitwindowdServiceSetTransactionNoteProcedure = "/bitwindowd.v1.BitwindowdService/SetTransactionNote"
@@ -75,6 +78,15 @@ const (
 	// BitwindowdServiceGetNetworkStatsProcedure is the fully-qualified name of the BitwindowdService's
 	// GetNetworkStats RPC.
 	BitwindowdServiceGetNetworkStatsProcedure = "/bitwindowd.v1.BitwindowdService/GetNetworkStats"
+	// BitwindowdServiceListAddressHistoryProcedure is the fully-qualified name of the
+	// BitwindowdService's ListAddressHistory RPC.
+	BitwindowdServiceListAddressHistoryProcedure = "/bitwindowd.v1.BitwindowdService/ListAddressHistory"
+	// BitwindowdServiceGetAddressBalanceProcedure is the fully-qualified name of the
+	// BitwindowdService's GetAddressBalance RPC.
+	BitwindowdServiceGetAddressBalanceProcedure = "/bitwindowd.v1.BitwindowdService/GetAddressBalance"
+	// BitwindowdServiceGetBlockStatsProcedure is the fully-qualified name of the BitwindowdService's
+	// GetBlockStats RPC.
+	BitwindowdServiceGetBlockStatsProcedure = "/bitwindowd.v1.BitwindowdService/GetBlockStats"
 )
 
 // BitwindowdServiceClient is a client for the bitwindowd.v1.BitwindowdService service.
@@ -90,6 +102,10 @@ type BitwindowdServiceClient interface {
 	UpdateAddressBookEntry(context.Context, *connect.Request[v1.UpdateAddressBookEntryRequest]) (*connect.Response[emptypb.Empty], error)
 	DeleteAddressBookEntry(context.Context, *connect.Request[v1.DeleteAddressBookEntryRequest]) (*connect.Response[emptypb.Empty], error)
 	GetSyncInfo(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetSyncInfoResponse], error)
+	// Sends the current sync info right away, and then again whenever it
+	// changes: when new blocks are processed, a reorg is detected, or we're
+	// waiting on Bitcoin Core.
+	WatchSyncInfo(context.Context, *connect.Request[emptypb.Empty]) (*connect.ServerStreamForClient[v1.WatchSyncInfoResponse], error)
 	SetTransactionNote(context.Context, *connect.Request[v1.SetTransactionNoteRequest]) (*connect.Response[emptypb.Empty], error)
 	GetFireplaceStats(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetFireplaceStatsResponse], error)
 	// Lists the most recent transactions, both confirmed and unconfirmed.
@@ -98,6 +114,13 @@ type BitwindowdServiceClient interface {
 	ListBlocks(context.Context, *connect.Request[v1.ListBlocksRequest]) (*connect.Response[v1.ListBlocksResponse], error)
 	// Get network statistics
 	GetNetworkStats(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetNetworkStatsResponse], error)
+	// Address history, backed by the local address index. Only available
+	// when bitwindowd is started with --index.addresses.
+	ListAddressHistory(context.Context, *connect.Request[v1.ListAddressHistoryRequest]) (*connect.Response[v1.ListAddressHistoryResponse], error)
+	GetAddressBalance(context.Context, *connect.Request[v1.GetAddressBalanceRequest]) (*connect.Response[v1.GetAddressBalanceResponse], error)
+	// Per-block fee and transaction statistics, for charting. Served from
+	// the local index, without asking Bitcoin Core.
+	GetBlockStats(context.Context, *connect.Request[v1.GetBlockStatsRequest]) (*connect.Response[v1.GetBlockStatsResponse], error)
 }
 
 // NewBitwindowdServiceClient constructs a client for the bitwindowd.v1.BitwindowdService service.
@@ -165,6 +188,12 @@ func NewBitwindowdServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(bitwindowdServiceMethods.ByName("GetSyncInfo")),
 			connect.WithClientOptions(opts...),
 		),
+		watchSyncInfo: connect.NewClient[emptypb.Empty, v1.WatchSyncInfoResponse](
+			httpClient,
+			baseURL+BitwindowdServiceWatchSyncInfoProcedure,
+			connect.WithSchema(bitwindowdServiceMethods.ByName("WatchSyncInfo")),
+			connect.WithClientOptions(opts...),
+		),
 		setTransactionNote: connect.NewClient[v1.SetTransactionNoteRequest, emptypb.Empty](
 			httpClient,
 			baseURL+BitwindowdServiceSetTransactionNoteProcedure,
@@ -195,6 +224,24 @@ func NewBitwindowdServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(bitwindowdServiceMethods.ByName("GetNetworkStats")),
 			connect.WithClientOptions(opts...),
 		),
+		listAddressHistory: connect.NewClient[v1.ListAddressHistoryRequest, v1.ListAddressHistoryResponse](
+			httpClient,
+			baseURL+BitwindowdServiceListAddressHistoryProcedure,
+			connect.WithSchema(bitwindowdServiceMethods.ByName("ListAddressHistory")),
+			connect.WithClientOptions(opts...),
+		),
+		getAddressBalance: connect.NewClient[v1.GetAddressBalanceRequest, v1.GetAddressBalanceResponse](
+			httpClient,
+			baseURL+BitwindowdServiceGetAddressBalanceProcedure,
+			connect.WithSchema(bitwindowdServiceMethods.ByName("GetAddressBalance")),
+			connect.WithClientOptions(opts...),
+		),
+		getBlockStats: connect.NewClient[v1.GetBlockStatsRequest, v1.GetBlockStatsResponse](
+			httpClient,
+			baseURL+BitwindowdServiceGetBlockStatsProcedure,
+			connect.WithSchema(bitwindowdServiceMethods.ByName("GetBlockStats")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -209,11 +256,15 @@ type bitwindowdServiceClient struct {
 	updateAddressBookEntry *connect.Client[v1.UpdateAddressBookEntryRequest, emptypb.Empty]
 	deleteAddressBookEntry *connect.Client[v1.DeleteAddressBookEntryRequest, emptypb.Empty]
 	getSyncInfo            *connect.Client[emptypb.Empty, v1.GetSyncInfoResponse]
+	watchSyncInfo          *connect.Client[emptypb.Empty, v1.WatchSyncInfoResponse]
 	setTransactionNote     *connect.Client[v1.SetTransactionNoteRequest, emptypb.Empty]
 	getFireplaceStats      *connect.Client[emptypb.Empty, v1.GetFireplaceStatsResponse]
 	listRecentTransactions *connect.Client[v1.ListRecentTransactionsRequest, v1.ListRecentTransactionsResponse]
 	listBlocks             *connect.Client[v1.ListBlocksRequest, v1.ListBlocksResponse]
 	getNetworkStats        *connect.Client[emptypb.Empty, v1.GetNetworkStatsResponse]
+	listAddressHistory     *connect.Client[v1.ListAddressHistoryRequest, v1.ListAddressHistoryResponse]
+	getAddressBalance      *connect.Client[v1.GetAddressBalanceRequest, v1.GetAddressBalanceResponse]
+	getBlockStats          *connect.Client[v1.GetBlockStatsRequest, v1.GetBlockStatsResponse]
 }
 
 // Stop calls bitwindowd.v1.BitwindowdService.Stop.
@@ -261,6 +312,11 @@ func (c *bitwindowdServiceClient) GetSyncInfo(ctx context.Context, req *connect.
 	return c.getSyncInfo.CallUnary(ctx, req)
 }
 
+// WatchSyncInfo calls bitwindowd.v1.BitwindowdService.WatchSyncInfo.
+func (c *bitwindowdServiceClient) WatchSyncInfo(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.ServerStreamForClient[v1.WatchSyncInfoResponse], error) {
+	return c.watchSyncInfo.CallServerStream(ctx, req)
+}
+
 // SetTransactionNote calls bitwindowd.v1.BitwindowdService.SetTransactionNote.
 func (c *bitwindowdServiceClient) SetTransactionNote(ctx context.Context, req *connect.Request[v1.SetTransactionNoteRequest]) (*connect.Response[emptypb.Empty], error) {
 	return c.setTransactionNote.CallUnary(ctx, req)
@@ -286,6 +342,21 @@ func (c *bitwindowdServiceClient) GetNetworkStats(ctx context.Context, req *conn
 	return c.getNetworkStats.CallUnary(ctx, req)
 }
 
+// ListAddressHistory calls bitwindowd.v1.BitwindowdService.ListAddressHistory.
+func (c *bitwindowdServiceClient) ListAddressHistory(ctx context.Context, req *connect.Request[v1.ListAddressHistoryRequest]) (*connect.Response[v1.ListAddressHistoryResponse], error) {
+	return c.listAddressHistory.CallUnary(ctx, req)
+}
+
+// GetAddressBalance calls bitwindowd.v1.BitwindowdService.GetAddressBalance.
+func (c *bitwindowdServiceClient) GetAddressBalance(ctx context.Context, req *connect.Request[v1.GetAddressBalanceRequest]) (*connect.Response[v1.GetAddressBalanceResponse], error) {
+	return c.getAddressBalance.CallUnary(ctx, req)
+}
+
+// GetBlockStats calls bitwindowd.v1.BitwindowdService.GetBlockStats.
+func (c *bitwindowdServiceClient) GetBlockStats(ctx context.Context, req *connect.Request[v1.GetBlockStatsRequest]) (*connect.Response[v1.GetBlockStatsResponse], error) {
+	return c.getBlockStats.CallUnary(ctx, req)
+}
+
 // BitwindowdServiceHandler is an implementation of the bitwindowd.v1.BitwindowdService service.
 type BitwindowdServiceHandler interface {
 	Stop(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error)
@@ -299,6 +370,10 @@ type BitwindowdServiceHandler interface {
 	UpdateAddressBookEntry(context.Context, *connect.Request[v1.UpdateAddressBookEntryRequest]) (*connect.Response[emptypb.Empty], error)
 	DeleteAddressBookEntry(context.Context, *connect.Request[v1.DeleteAddressBookEntryRequest]) (*connect.Response[emptypb.Empty], error)
 	GetSyncInfo(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetSyncInfoResponse], error)
+	// Sends the current sync info right away, and then again whenever it
+	// changes: when new blocks are processed, a reorg is detected, or we're
+	// waiting on Bitcoin Core.
+	WatchSyncInfo(context.Context, *connect.Request[emptypb.Empty], *connect.ServerStream[v1.WatchSyncInfoResponse]) error
 	SetTransactionNote(context.Context, *connect.Request[v1.SetTransactionNoteRequest]) (*connect.Response[emptypb.Empty], error)
 	GetFireplaceStats(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetFireplaceStatsResponse], error)
 	// Lists the most recent transactions, both confirmed and unconfirmed.
@@ -307,6 +382,13 @@ type BitwindowdServiceHandler interface {
 	ListBlocks(context.Context, *connect.Request[v1.ListBlocksRequest]) (*connect.Response[v1.ListBlocksResponse], error)
 	// Get network statistics
 	GetNetworkStats(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetNetworkStatsResponse], error)
+	// Address history, backed by the local address index. Only available
+	// when bitwindowd is started with --index.addresses.
+	ListAddressHistory(context.Context, *connect.Request[v1.ListAddressHistoryRequest]) (*connect.Response[v1.ListAddressHistoryResponse], error)
+	GetAddressBalance(context.Context, *connect.Request[v1.GetAddressBalanceRequest]) (*connect.Response[v1.GetAddressBalanceResponse], error)
+	// Per-block fee and transaction statistics, for charting. Served from
+	// the local index, without asking Bitcoin Core.
+	GetBlockStats(context.Context, *connect.Request[v1.GetBlockStatsRequest]) (*connect.Response[v1.GetBlockStatsResponse], error)
 }
 
 // NewBitwindowdServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -370,6 +452,12 @@ func NewBitwindowdServiceHandler(svc BitwindowdServiceHandler, opts ...connect.H
 		connect.WithSchema(bitwindowdServiceMethods.ByName("GetSyncInfo")),
 		connect.WithHandlerOptions(opts...),
 	)
+	bitwindowdServiceWatchSyncInfoHandler := connect.NewServerStreamHandler(
+		BitwindowdServiceWatchSyncInfoProcedure,
+		svc.WatchSyncInfo,
+		connect.WithSchema(bitwindowdServiceMethods.ByName("WatchSyncInfo")),
+		connect.WithHandlerOptions(opts...),
+	)
 	bitwindowdServiceSetTransactionNoteHandler := connect.NewUnaryHandler(
 		BitwindowdServiceSetTransactionNoteProcedure,
 		svc.SetTransactionNote,
@@ -400,6 +488,24 @@ func NewBitwindowdServiceHandler(svc BitwindowdServiceHandler, opts ...connect.H
 		connect.WithSchema(bitwindowdServiceMethods.ByName("GetNetworkStats")),
 		connect.WithHandlerOptions(opts...),
 	)
+	bitwindowdServiceListAddressHistoryHandler := connect.NewUnaryHandler(
+		BitwindowdServiceListAddressHistoryProcedure,
+		svc.ListAddressHistory,
+		connect.WithSchema(bitwindowdServiceMethods.ByName("ListAddressHistory")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bitwindowdServiceGetAddressBalanceHandler := connect.NewUnaryHandler(
+		BitwindowdServiceGetAddressBalanceProcedure,
+		svc.GetAddressBalance,
+		connect.WithSchema(bitwindowdServiceMethods.ByName("GetAddressBalance")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bitwindowdServiceGetBlockStatsHandler := connect.NewUnaryHandler(
+		BitwindowdServiceGetBlockStatsProcedure,
+		svc.GetBlockStats,
+		connect.WithSchema(bitwindowdServiceMethods.ByName("GetBlockStats")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/bitwindowd.v1.BitwindowdService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case BitwindowdServiceStopProcedure:
@@ -420,6 +526,8 @@ func NewBitwindowdServiceHandler(svc BitwindowdServiceHandler, opts ...connect.H
 			bitwindowdServiceDeleteAddressBookEntryHandler.ServeHTTP(w, r)
 		case BitwindowdServiceGetSyncInfoProcedure:
 			bitwindowdServiceGetSyncInfoHandler.ServeHTTP(w, r)
+		case BitwindowdServiceWatchSyncInfoProcedure:
+			bitwindowdServiceWatchSyncInfoHandler.ServeHTTP(w, r)
 		case BitwindowdServiceSetTransactionNoteProcedure:
 			bitwindowdServiceSetTransactionNoteHandler.ServeHTTP(w, r)
 		case BitwindowdServiceGetFireplaceStatsProcedure:
@@ -430,6 +538,12 @@ func NewBitwindowdServiceHandler(svc BitwindowdServiceHandler, opts ...connect.H
 			bitwindowdServiceListBlocksHandler.ServeHTTP(w, r)
 		case BitwindowdServiceGetNetworkStatsProcedure:
 			bitwindowdServiceGetNetworkStatsHandler.ServeHTTP(w, r)
+		case BitwindowdServiceListAddressHistoryProcedure:
+			bitwindowdServiceListAddressHistoryHandler.ServeHTTP(w, r)
+		case BitwindowdServiceGetAddressBalanceProcedure:
+			bitwindowdServiceGetAddressBalanceHandler.ServeHTTP(w, r)
+		case BitwindowdServiceGetBlockStatsProcedure:
+			bitwindowdServiceGetBlockStatsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -475,6 +589,10 @@ func (UnimplementedBitwindowdServiceHandler) GetSyncInfo(context.Context, *conne
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bitwindowd.v1.BitwindowdService.GetSyncInfo is not implemented"))
 }
 
+func (UnimplementedBitwindowdServiceHandler) WatchSyncInfo(context.Context, *connect.Request[emptypb.Empty], *connect.ServerStream[v1.WatchSyncInfoResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("bitwindowd.v1.BitwindowdService.WatchSyncInfo is not implemented"))
+}
+
 func (UnimplementedBitwindowdServiceHandler) SetTransactionNote(context.Context, *connect.Request[v1.SetTransactionNoteRequest]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bitwindowd.v1.BitwindowdService.SetTransactionNote is not implemented"))
 }
@@ -494,3 +612,15 @@ func (UnimplementedBitwindowdServiceHandler) ListBlocks(context.Context, *connec
 func (UnimplementedBitwindowdServiceHandler) GetNetworkStats(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetNetworkStatsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bitwindowd.v1.BitwindowdService.GetNetworkStats is not implemented"))
 }
+
+func (UnimplementedBitwindowdServiceHandler) ListAddressHistory(context.Context, *connect.Request[v1.ListAddressHistoryRequest]) (*connect.Response[v1.ListAddressHistoryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bitwindowd.v1.BitwindowdService.ListAddressHistory is not implemented"))
+}
+
+func (UnimplementedBitwindowdServiceHandler) GetAddressBalance(context.Context, *connect.Request[v1.GetAddressBalanceRequest]) (*connect.Response[v1.GetAddressBalanceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bitwindowd.v1.BitwindowdService.GetAddressBalance is not implemented"))
+}
+
+func (UnimplementedBitwindowdServiceHandler) GetBlockStats(context.Context, *connect.Request[v1.GetBlockStatsRequest]) (*connect.Response[v1.GetBlockStatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bitwindowd.v1.BitwindowdService.GetBlockStats is not implemented"))
+}
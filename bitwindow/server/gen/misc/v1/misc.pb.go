@@ -23,16 +23,444 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Protocol int32
+
+const (
+	Protocol_PROTOCOL_UNSPECIFIED Protocol = 0
+	// Not recognised, and not text
+	Protocol_PROTOCOL_UNKNOWN              Protocol = 1
+	Protocol_PROTOCOL_COIN_NEWS            Protocol = 2
+	Protocol_PROTOCOL_COIN_NEWS_TOPIC      Protocol = 3
+	Protocol_PROTOCOL_COIN_NEWS_MODERATION Protocol = 4
+	// Part of a payload split across several transactions
+	Protocol_PROTOCOL_CHUNK Protocol = 5
+	// BIP300 and BIP301 messages, M1-M4, M7 and M8
+	Protocol_PROTOCOL_BIP300 Protocol = 6
+	Protocol_PROTOCOL_RUNES  Protocol = 7
+	Protocol_PROTOCOL_OMNI   Protocol = 8
+	// A bare SHA256 hash, like our own and OpenTimestamps timestamps
+	Protocol_PROTOCOL_TIMESTAMP Protocol = 9
+	Protocol_PROTOCOL_TEXT      Protocol = 10
+)
+
+// Enum value maps for Protocol.
+var (
+	Protocol_name = map[int32]string{
+		0:  "PROTOCOL_UNSPECIFIED",
+		1:  "PROTOCOL_UNKNOWN",
+		2:  "PROTOCOL_COIN_NEWS",
+		3:  "PROTOCOL_COIN_NEWS_TOPIC",
+		4:  "PROTOCOL_COIN_NEWS_MODERATION",
+		5:  "PROTOCOL_CHUNK",
+		6:  "PROTOCOL_BIP300",
+		7:  "PROTOCOL_RUNES",
+		8:  "PROTOCOL_OMNI",
+		9:  "PROTOCOL_TIMESTAMP",
+		10: "PROTOCOL_TEXT",
+	}
+	Protocol_value = map[string]int32{
+		"PROTOCOL_UNSPECIFIED":          0,
+		"PROTOCOL_UNKNOWN":              1,
+		"PROTOCOL_COIN_NEWS":            2,
+		"PROTOCOL_COIN_NEWS_TOPIC":      3,
+		"PROTOCOL_COIN_NEWS_MODERATION": 4,
+		"PROTOCOL_CHUNK":                5,
+		"PROTOCOL_BIP300":               6,
+		"PROTOCOL_RUNES":                7,
+		"PROTOCOL_OMNI":                 8,
+		"PROTOCOL_TIMESTAMP":            9,
+		"PROTOCOL_TEXT":                 10,
+	}
+)
+
+func (x Protocol) Enum() *Protocol {
+	p := new(Protocol)
+	*p = x
+	return p
+}
+
+func (x Protocol) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Protocol) Descriptor() protoreflect.EnumDescriptor {
+	return file_misc_v1_misc_proto_enumTypes[0].Descriptor()
+}
+
+func (Protocol) Type() protoreflect.EnumType {
+	return &file_misc_v1_misc_proto_enumTypes[0]
+}
+
+func (x Protocol) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Protocol.Descriptor instead.
+func (Protocol) EnumDescriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{0}
+}
+
+type CoinNewsSort int32
+
+const (
+	// Same as COIN_NEWS_SORT_NEWEST
+	CoinNewsSort_COIN_NEWS_SORT_UNSPECIFIED CoinNewsSort = 0
+	// When the post was first seen, newest first
+	CoinNewsSort_COIN_NEWS_SORT_NEWEST CoinNewsSort = 1
+	// Highest fee rate first, of the posts seen in the last 24 hours
+	CoinNewsSort_COIN_NEWS_SORT_TOP_DAY CoinNewsSort = 2
+	// Highest fee rate first, of the posts seen in the last 7 days
+	CoinNewsSort_COIN_NEWS_SORT_TOP_WEEK CoinNewsSort = 3
+)
+
+// Enum value maps for CoinNewsSort.
+var (
+	CoinNewsSort_name = map[int32]string{
+		0: "COIN_NEWS_SORT_UNSPECIFIED",
+		1: "COIN_NEWS_SORT_NEWEST",
+		2: "COIN_NEWS_SORT_TOP_DAY",
+		3: "COIN_NEWS_SORT_TOP_WEEK",
+	}
+	CoinNewsSort_value = map[string]int32{
+		"COIN_NEWS_SORT_UNSPECIFIED": 0,
+		"COIN_NEWS_SORT_NEWEST":      1,
+		"COIN_NEWS_SORT_TOP_DAY":     2,
+		"COIN_NEWS_SORT_TOP_WEEK":    3,
+	}
+)
+
+func (x CoinNewsSort) Enum() *CoinNewsSort {
+	p := new(CoinNewsSort)
+	*p = x
+	return p
+}
+
+func (x CoinNewsSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CoinNewsSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_misc_v1_misc_proto_enumTypes[1].Descriptor()
+}
+
+func (CoinNewsSort) Type() protoreflect.EnumType {
+	return &file_misc_v1_misc_proto_enumTypes[1]
+}
+
+func (x CoinNewsSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CoinNewsSort.Descriptor instead.
+func (CoinNewsSort) EnumDescriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{1}
+}
+
+type WatchEvent int32
+
+const (
+	WatchEvent_WATCH_EVENT_UNSPECIFIED WatchEvent = 0
+	// Seen in the mempool
+	WatchEvent_WATCH_EVENT_UNCONFIRMED WatchEvent = 1
+	// Seen in a block. Also sent for ones that were never seen unconfirmed.
+	WatchEvent_WATCH_EVENT_CONFIRMED WatchEvent = 2
+)
+
+// Enum value maps for WatchEvent.
+var (
+	WatchEvent_name = map[int32]string{
+		0: "WATCH_EVENT_UNSPECIFIED",
+		1: "WATCH_EVENT_UNCONFIRMED",
+		2: "WATCH_EVENT_CONFIRMED",
+	}
+	WatchEvent_value = map[string]int32{
+		"WATCH_EVENT_UNSPECIFIED": 0,
+		"WATCH_EVENT_UNCONFIRMED": 1,
+		"WATCH_EVENT_CONFIRMED":   2,
+	}
+)
+
+func (x WatchEvent) Enum() *WatchEvent {
+	p := new(WatchEvent)
+	*p = x
+	return p
+}
+
+func (x WatchEvent) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchEvent) Descriptor() protoreflect.EnumDescriptor {
+	return file_misc_v1_misc_proto_enumTypes[2].Descriptor()
+}
+
+func (WatchEvent) Type() protoreflect.EnumType {
+	return &file_misc_v1_misc_proto_enumTypes[2]
+}
+
+func (x WatchEvent) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchEvent.Descriptor instead.
+func (WatchEvent) EnumDescriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{2}
+}
+
+type OPReturn_Status int32
+
+const (
+	OPReturn_STATUS_UNSPECIFIED OPReturn_Status = 0
+	// Confirmed, or in the mempool.
+	OPReturn_STATUS_ACTIVE OPReturn_Status = 1
+	// Removed from the mempool without being confirmed.
+	OPReturn_STATUS_DROPPED OPReturn_Status = 2
+	// Another transaction spent the same inputs.
+	OPReturn_STATUS_REPLACED OPReturn_Status = 3
+)
+
+// Enum value maps for OPReturn_Status.
+var (
+	OPReturn_Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_ACTIVE",
+		2: "STATUS_DROPPED",
+		3: "STATUS_REPLACED",
+	}
+	OPReturn_Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_ACTIVE":      1,
+		"STATUS_DROPPED":     2,
+		"STATUS_REPLACED":    3,
+	}
+)
+
+func (x OPReturn_Status) Enum() *OPReturn_Status {
+	p := new(OPReturn_Status)
+	*p = x
+	return p
+}
+
+func (x OPReturn_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OPReturn_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_misc_v1_misc_proto_enumTypes[3].Descriptor()
+}
+
+func (OPReturn_Status) Type() protoreflect.EnumType {
+	return &file_misc_v1_misc_proto_enumTypes[3]
+}
+
+func (x OPReturn_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OPReturn_Status.Descriptor instead.
+func (OPReturn_Status) EnumDescriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{3, 0}
+}
+
+// Unset fields match everything
+type SearchFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Full-text search over decoded messages and headlines, in SQLite FTS
+	// syntax. For example "bitcoin AND news" or "sat*".
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Unconfirmed OP_RETURNs never match a height range
+	MinHeight *uint32 `protobuf:"varint,2,opt,name=min_height,json=minHeight,proto3,oneof" json:"min_height,omitempty"`
+	MaxHeight *uint32 `protobuf:"varint,3,opt,name=max_height,json=maxHeight,proto3,oneof" json:"max_height,omitempty"`
+	// When the OP_RETURN was first seen, inclusive
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Hex encoded
+	TxidPrefix string  `protobuf:"bytes,6,opt,name=txid_prefix,json=txidPrefix,proto3" json:"txid_prefix,omitempty"`
+	Topic      *string `protobuf:"bytes,7,opt,name=topic,proto3,oneof" json:"topic,omitempty"`
+	MinFeeSats int64   `protobuf:"varint,8,opt,name=min_fee_sats,json=minFeeSats,proto3" json:"min_fee_sats,omitempty"`
+	// In sat/vB. OP_RETURNs with an unknown fee rate never match.
+	MinFeeRate    float64 `protobuf:"fixed64,9,opt,name=min_fee_rate,json=minFeeRate,proto3" json:"min_fee_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFilter) Reset() {
+	*x = SearchFilter{}
+	mi := &file_misc_v1_misc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFilter) ProtoMessage() {}
+
+func (x *SearchFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFilter.ProtoReflect.Descriptor instead.
+func (*SearchFilter) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SearchFilter) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchFilter) GetMinHeight() uint32 {
+	if x != nil && x.MinHeight != nil {
+		return *x.MinHeight
+	}
+	return 0
+}
+
+func (x *SearchFilter) GetMaxHeight() uint32 {
+	if x != nil && x.MaxHeight != nil {
+		return *x.MaxHeight
+	}
+	return 0
+}
+
+func (x *SearchFilter) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *SearchFilter) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *SearchFilter) GetTxidPrefix() string {
+	if x != nil {
+		return x.TxidPrefix
+	}
+	return ""
+}
+
+func (x *SearchFilter) GetTopic() string {
+	if x != nil && x.Topic != nil {
+		return *x.Topic
+	}
+	return ""
+}
+
+func (x *SearchFilter) GetMinFeeSats() int64 {
+	if x != nil {
+		return x.MinFeeSats
+	}
+	return 0
+}
+
+func (x *SearchFilter) GetMinFeeRate() float64 {
+	if x != nil {
+		return x.MinFeeRate
+	}
+	return 0
+}
+
+type ListOPReturnRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// If set, only return OP_RETURNs of these protocols
+	Protocols []Protocol    `protobuf:"varint,1,rep,packed,name=protocols,proto3,enum=misc.v1.Protocol" json:"protocols,omitempty"`
+	Filter    *SearchFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Defaults to 100, at most 1000
+	PageSize uint32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// From a previous response, to get the next page
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOPReturnRequest) Reset() {
+	*x = ListOPReturnRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOPReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOPReturnRequest) ProtoMessage() {}
+
+func (x *ListOPReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOPReturnRequest.ProtoReflect.Descriptor instead.
+func (*ListOPReturnRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListOPReturnRequest) GetProtocols() []Protocol {
+	if x != nil {
+		return x.Protocols
+	}
+	return nil
+}
+
+func (x *ListOPReturnRequest) GetFilter() *SearchFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListOPReturnRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOPReturnRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 type ListOPReturnResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OpReturns     []*OPReturn            `protobuf:"bytes,1,rep,name=op_returns,json=opReturns,proto3" json:"op_returns,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first
+	OpReturns []*OPReturn `protobuf:"bytes,1,rep,name=op_returns,json=opReturns,proto3" json:"op_returns,omitempty"`
+	// Empty if there are no more pages
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListOPReturnResponse) Reset() {
 	*x = ListOPReturnResponse{}
-	mi := &file_misc_v1_misc_proto_msgTypes[0]
+	mi := &file_misc_v1_misc_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -44,7 +472,7 @@ func (x *ListOPReturnResponse) String() string {
 func (*ListOPReturnResponse) ProtoMessage() {}
 
 func (x *ListOPReturnResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[0]
+	mi := &file_misc_v1_misc_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,7 +485,7 @@ func (x *ListOPReturnResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOPReturnResponse.ProtoReflect.Descriptor instead.
 func (*ListOPReturnResponse) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{0}
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ListOPReturnResponse) GetOpReturns() []*OPReturn {
@@ -67,21 +495,35 @@ func (x *ListOPReturnResponse) GetOpReturns() []*OPReturn {
 	return nil
 }
 
+func (x *ListOPReturnResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type OPReturn struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Txid          string                 `protobuf:"bytes,3,opt,name=txid,proto3" json:"txid,omitempty"`
-	Vout          int32                  `protobuf:"varint,4,opt,name=vout,proto3" json:"vout,omitempty"`
-	Height        *int32                 `protobuf:"varint,5,opt,name=height,proto3,oneof" json:"height,omitempty"`
-	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Message    string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Txid       string                 `protobuf:"bytes,3,opt,name=txid,proto3" json:"txid,omitempty"`
+	Vout       int32                  `protobuf:"varint,4,opt,name=vout,proto3" json:"vout,omitempty"`
+	Height     *int32                 `protobuf:"varint,5,opt,name=height,proto3,oneof" json:"height,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	Status     OPReturn_Status        `protobuf:"varint,8,opt,name=status,proto3,enum=misc.v1.OPReturn_Status" json:"status,omitempty"`
+	// Set if status is STATUS_REPLACED.
+	ReplacedByTxid *string `protobuf:"bytes,9,opt,name=replaced_by_txid,json=replacedByTxid,proto3,oneof" json:"replaced_by_txid,omitempty"`
+	// Unspecified if not classified yet
+	Protocol Protocol `protobuf:"varint,10,opt,name=protocol,proto3,enum=misc.v1.Protocol" json:"protocol,omitempty"`
+	// What we could decode, depends on the protocol
+	Fields        map[string]string `protobuf:"bytes,11,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OPReturn) Reset() {
 	*x = OPReturn{}
-	mi := &file_misc_v1_misc_proto_msgTypes[1]
+	mi := &file_misc_v1_misc_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -93,7 +535,7 @@ func (x *OPReturn) String() string {
 func (*OPReturn) ProtoMessage() {}
 
 func (x *OPReturn) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[1]
+	mi := &file_misc_v1_misc_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -106,7 +548,7 @@ func (x *OPReturn) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OPReturn.ProtoReflect.Descriptor instead.
 func (*OPReturn) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{1}
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *OPReturn) GetId() int64 {
@@ -151,30 +593,2227 @@ func (x *OPReturn) GetCreateTime() *timestamppb.Timestamp {
 	return nil
 }
 
-type BroadcastNewsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Topic         string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
-	Headline      string                 `protobuf:"bytes,2,opt,name=headline,proto3" json:"headline,omitempty"`
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+func (x *OPReturn) GetStatus() OPReturn_Status {
+	if x != nil {
+		return x.Status
+	}
+	return OPReturn_STATUS_UNSPECIFIED
+}
+
+func (x *OPReturn) GetReplacedByTxid() string {
+	if x != nil && x.ReplacedByTxid != nil {
+		return *x.ReplacedByTxid
+	}
+	return ""
+}
+
+func (x *OPReturn) GetProtocol() Protocol {
+	if x != nil {
+		return x.Protocol
+	}
+	return Protocol_PROTOCOL_UNSPECIFIED
+}
+
+func (x *OPReturn) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type BroadcastNewsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Topic    string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Headline string                 `protobuf:"bytes,2,opt,name=headline,proto3" json:"headline,omitempty"`
+	Content  string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// Sign the post with this wallet's news key, so readers can verify who
+	// wrote it. Costs 86 bytes extra.
+	Sign bool `protobuf:"varint,4,opt,name=sign,proto3" json:"sign,omitempty"`
+	// Split the post across several transactions if it's larger than 80
+	// bytes, the most nodes relay in one OP_RETURN by default.
+	Chunked bool `protobuf:"varint,5,opt,name=chunked,proto3" json:"chunked,omitempty"`
+	// Reply to the post or reply with this txid. Replies have no headline,
+	// and are in the topic of what they reply to, so topic can be left out.
+	ReplyTo       *string `protobuf:"bytes,6,opt,name=reply_to,json=replyTo,proto3,oneof" json:"reply_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastNewsRequest) Reset() {
+	*x = BroadcastNewsRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastNewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastNewsRequest) ProtoMessage() {}
+
+func (x *BroadcastNewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastNewsRequest.ProtoReflect.Descriptor instead.
+func (*BroadcastNewsRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BroadcastNewsRequest) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *BroadcastNewsRequest) GetHeadline() string {
+	if x != nil {
+		return x.Headline
+	}
+	return ""
+}
+
+func (x *BroadcastNewsRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *BroadcastNewsRequest) GetSign() bool {
+	if x != nil {
+		return x.Sign
+	}
+	return false
+}
+
+func (x *BroadcastNewsRequest) GetChunked() bool {
+	if x != nil {
+		return x.Chunked
+	}
+	return false
+}
+
+func (x *BroadcastNewsRequest) GetReplyTo() string {
+	if x != nil && x.ReplyTo != nil {
+		return *x.ReplyTo
+	}
+	return ""
+}
+
+type BroadcastNewsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// For chunked posts, the txid of the first chunk
+	Txid string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	// Set if the post was signed
+	Author string `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	// Set if the post was chunked, in order
+	ChunkTxids    []string `protobuf:"bytes,3,rep,name=chunk_txids,json=chunkTxids,proto3" json:"chunk_txids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastNewsResponse) Reset() {
+	*x = BroadcastNewsResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastNewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastNewsResponse) ProtoMessage() {}
+
+func (x *BroadcastNewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastNewsResponse.ProtoReflect.Descriptor instead.
+func (*BroadcastNewsResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BroadcastNewsResponse) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *BroadcastNewsResponse) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *BroadcastNewsResponse) GetChunkTxids() []string {
+	if x != nil {
+		return x.ChunkTxids
+	}
+	return nil
+}
+
+type CreateTopicRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Topic string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Make this wallet's news key the owner of the topic, so it can
+	// moderate it.
+	Owned         bool `protobuf:"varint,3,opt,name=owned,proto3" json:"owned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTopicRequest) Reset() {
+	*x = CreateTopicRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTopicRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTopicRequest) ProtoMessage() {}
+
+func (x *CreateTopicRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTopicRequest.ProtoReflect.Descriptor instead.
+func (*CreateTopicRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateTopicRequest) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *CreateTopicRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTopicRequest) GetOwned() bool {
+	if x != nil {
+		return x.Owned
+	}
+	return false
+}
+
+type CreateTopicResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Txid  string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	// Set if the topic is owned
+	Owner         string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTopicResponse) Reset() {
+	*x = CreateTopicResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTopicResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTopicResponse) ProtoMessage() {}
+
+func (x *CreateTopicResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTopicResponse.ProtoReflect.Descriptor instead.
+func (*CreateTopicResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateTopicResponse) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *CreateTopicResponse) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+type Topic struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Topic      string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Name       string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// hash160 of the news key that owns the topic, hex encoded. Empty for
+	// topics that can't be moderated.
+	Owner string `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Authors allowed to post besides the owner. If empty, anyone can.
+	Posters      []string           `protobuf:"bytes,6,rep,name=posters,proto3" json:"posters,omitempty"`
+	Subscription *TopicSubscription `protobuf:"bytes,7,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	// Empty for the default topics
+	Txid string `protobuf:"bytes,8,opt,name=txid,proto3" json:"txid,omitempty"`
+	// Imported from a topic set, and not seen on chain yet
+	Imported      bool `protobuf:"varint,9,opt,name=imported,proto3" json:"imported,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Topic) Reset() {
+	*x = Topic{}
+	mi := &file_misc_v1_misc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Topic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Topic) ProtoMessage() {}
+
+func (x *Topic) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Topic.ProtoReflect.Descriptor instead.
+func (*Topic) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Topic) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Topic) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Topic) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Topic) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Topic) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Topic) GetPosters() []string {
+	if x != nil {
+		return x.Posters
+	}
+	return nil
+}
+
+func (x *Topic) GetSubscription() *TopicSubscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+func (x *Topic) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *Topic) GetImported() bool {
+	if x != nil {
+		return x.Imported
+	}
+	return false
+}
+
+type TopicSubscription struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unsubscribed topics are left out of the feed, but can still be listed
+	// by asking for the topic
+	Subscribed bool `protobuf:"varint,1,opt,name=subscribed,proto3" json:"subscribed,omitempty"`
+	// Muted topics stay subscribed, but none of their posts are listed
+	Muted bool `protobuf:"varint,2,opt,name=muted,proto3" json:"muted,omitempty"`
+	// Posts paying a lower fee are left out
+	MinFeeSats int64 `protobuf:"varint,3,opt,name=min_fee_sats,json=minFeeSats,proto3" json:"min_fee_sats,omitempty"`
+	// Posts hidden by the topic owner are listed anyway
+	IgnoreModeration bool `protobuf:"varint,4,opt,name=ignore_moderation,json=ignoreModeration,proto3" json:"ignore_moderation,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TopicSubscription) Reset() {
+	*x = TopicSubscription{}
+	mi := &file_misc_v1_misc_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopicSubscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicSubscription) ProtoMessage() {}
+
+func (x *TopicSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicSubscription.ProtoReflect.Descriptor instead.
+func (*TopicSubscription) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TopicSubscription) GetSubscribed() bool {
+	if x != nil {
+		return x.Subscribed
+	}
+	return false
+}
+
+func (x *TopicSubscription) GetMuted() bool {
+	if x != nil {
+		return x.Muted
+	}
+	return false
+}
+
+func (x *TopicSubscription) GetMinFeeSats() int64 {
+	if x != nil {
+		return x.MinFeeSats
+	}
+	return 0
+}
+
+func (x *TopicSubscription) GetIgnoreModeration() bool {
+	if x != nil {
+		return x.IgnoreModeration
+	}
+	return false
+}
+
+type SetTopicSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topic         string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Subscription  *TopicSubscription     `protobuf:"bytes,2,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTopicSubscriptionRequest) Reset() {
+	*x = SetTopicSubscriptionRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTopicSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTopicSubscriptionRequest) ProtoMessage() {}
+
+func (x *SetTopicSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTopicSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*SetTopicSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetTopicSubscriptionRequest) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *SetTopicSubscriptionRequest) GetSubscription() *TopicSubscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type SetTopicSubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topic         *Topic                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTopicSubscriptionResponse) Reset() {
+	*x = SetTopicSubscriptionResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTopicSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTopicSubscriptionResponse) ProtoMessage() {}
+
+func (x *SetTopicSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTopicSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*SetTopicSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetTopicSubscriptionResponse) GetTopic() *Topic {
+	if x != nil {
+		return x.Topic
+	}
+	return nil
+}
+
+type ExportTopicsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// If empty, all subscribed topics are exported
+	Topics        []string `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTopicsRequest) Reset() {
+	*x = ExportTopicsRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTopicsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTopicsRequest) ProtoMessage() {}
+
+func (x *ExportTopicsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTopicsRequest.ProtoReflect.Descriptor instead.
+func (*ExportTopicsRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExportTopicsRequest) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+type ExportTopicsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TopicSet      string                 `protobuf:"bytes,1,opt,name=topic_set,json=topicSet,proto3" json:"topic_set,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTopicsResponse) Reset() {
+	*x = ExportTopicsResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTopicsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTopicsResponse) ProtoMessage() {}
+
+func (x *ExportTopicsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTopicsResponse.ProtoReflect.Descriptor instead.
+func (*ExportTopicsResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExportTopicsResponse) GetTopicSet() string {
+	if x != nil {
+		return x.TopicSet
+	}
+	return ""
+}
+
+type ImportTopicsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TopicSet      string                 `protobuf:"bytes,1,opt,name=topic_set,json=topicSet,proto3" json:"topic_set,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTopicsRequest) Reset() {
+	*x = ImportTopicsRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTopicsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTopicsRequest) ProtoMessage() {}
+
+func (x *ImportTopicsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTopicsRequest.ProtoReflect.Descriptor instead.
+func (*ImportTopicsRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ImportTopicsRequest) GetTopicSet() string {
+	if x != nil {
+		return x.TopicSet
+	}
+	return ""
+}
+
+type ImportTopicsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Topics that weren't known before
+	Imported uint32 `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	// Topics that were already known, now subscribed to
+	AlreadyKnown  uint32 `protobuf:"varint,2,opt,name=already_known,json=alreadyKnown,proto3" json:"already_known,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTopicsResponse) Reset() {
+	*x = ImportTopicsResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTopicsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTopicsResponse) ProtoMessage() {}
+
+func (x *ImportTopicsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTopicsResponse.ProtoReflect.Descriptor instead.
+func (*ImportTopicsResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ImportTopicsResponse) GetImported() uint32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportTopicsResponse) GetAlreadyKnown() uint32 {
+	if x != nil {
+		return x.AlreadyKnown
+	}
+	return 0
+}
+
+type ModerateTopicRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Topic string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// Types that are valid to be assigned to Action:
+	//
+	//	*ModerateTopicRequest_Rename
+	//	*ModerateTopicRequest_AddPoster
+	//	*ModerateTopicRequest_RemovePoster
+	//	*ModerateTopicRequest_HideTxid
+	Action        isModerateTopicRequest_Action `protobuf_oneof:"action"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerateTopicRequest) Reset() {
+	*x = ModerateTopicRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerateTopicRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateTopicRequest) ProtoMessage() {}
+
+func (x *ModerateTopicRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateTopicRequest.ProtoReflect.Descriptor instead.
+func (*ModerateTopicRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ModerateTopicRequest) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *ModerateTopicRequest) GetAction() isModerateTopicRequest_Action {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *ModerateTopicRequest) GetRename() string {
+	if x != nil {
+		if x, ok := x.Action.(*ModerateTopicRequest_Rename); ok {
+			return x.Rename
+		}
+	}
+	return ""
+}
+
+func (x *ModerateTopicRequest) GetAddPoster() string {
+	if x != nil {
+		if x, ok := x.Action.(*ModerateTopicRequest_AddPoster); ok {
+			return x.AddPoster
+		}
+	}
+	return ""
+}
+
+func (x *ModerateTopicRequest) GetRemovePoster() string {
+	if x != nil {
+		if x, ok := x.Action.(*ModerateTopicRequest_RemovePoster); ok {
+			return x.RemovePoster
+		}
+	}
+	return ""
+}
+
+func (x *ModerateTopicRequest) GetHideTxid() string {
+	if x != nil {
+		if x, ok := x.Action.(*ModerateTopicRequest_HideTxid); ok {
+			return x.HideTxid
+		}
+	}
+	return ""
+}
+
+type isModerateTopicRequest_Action interface {
+	isModerateTopicRequest_Action()
+}
+
+type ModerateTopicRequest_Rename struct {
+	Rename string `protobuf:"bytes,2,opt,name=rename,proto3,oneof"`
+}
+
+type ModerateTopicRequest_AddPoster struct {
+	// hash160 of the poster's news key, hex encoded
+	AddPoster string `protobuf:"bytes,3,opt,name=add_poster,json=addPoster,proto3,oneof"`
+}
+
+type ModerateTopicRequest_RemovePoster struct {
+	RemovePoster string `protobuf:"bytes,4,opt,name=remove_poster,json=removePoster,proto3,oneof"`
+}
+
+type ModerateTopicRequest_HideTxid struct {
+	HideTxid string `protobuf:"bytes,5,opt,name=hide_txid,json=hideTxid,proto3,oneof"`
+}
+
+func (*ModerateTopicRequest_Rename) isModerateTopicRequest_Action() {}
+
+func (*ModerateTopicRequest_AddPoster) isModerateTopicRequest_Action() {}
+
+func (*ModerateTopicRequest_RemovePoster) isModerateTopicRequest_Action() {}
+
+func (*ModerateTopicRequest_HideTxid) isModerateTopicRequest_Action() {}
+
+type ModerateTopicResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerateTopicResponse) Reset() {
+	*x = ModerateTopicResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerateTopicResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateTopicResponse) ProtoMessage() {}
+
+func (x *ModerateTopicResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateTopicResponse.ProtoReflect.Descriptor instead.
+func (*ModerateTopicResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ModerateTopicResponse) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+type BroadcastChunkedRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Data  []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Maximum size of each OP_RETURN, including the 19 byte chunk header.
+	// Defaults to 80.
+	ChunkSize     *uint32 `protobuf:"varint,2,opt,name=chunk_size,json=chunkSize,proto3,oneof" json:"chunk_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastChunkedRequest) Reset() {
+	*x = BroadcastChunkedRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastChunkedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastChunkedRequest) ProtoMessage() {}
+
+func (x *BroadcastChunkedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastChunkedRequest.ProtoReflect.Descriptor instead.
+func (*BroadcastChunkedRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BroadcastChunkedRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *BroadcastChunkedRequest) GetChunkSize() uint32 {
+	if x != nil && x.ChunkSize != nil {
+		return *x.ChunkSize
+	}
+	return 0
+}
+
+type BroadcastChunkedResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// First 8 bytes of the SHA256 hash of the data, hex encoded
+	MessageId string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// One per chunk, in order
+	Txids         []string `protobuf:"bytes,2,rep,name=txids,proto3" json:"txids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastChunkedResponse) Reset() {
+	*x = BroadcastChunkedResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastChunkedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastChunkedResponse) ProtoMessage() {}
+
+func (x *BroadcastChunkedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastChunkedResponse.ProtoReflect.Descriptor instead.
+func (*BroadcastChunkedResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BroadcastChunkedResponse) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *BroadcastChunkedResponse) GetTxids() []string {
+	if x != nil {
+		return x.Txids
+	}
+	return nil
+}
+
+type ChunkedMessage struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MessageId      string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	TotalChunks    uint32                 `protobuf:"varint,2,opt,name=total_chunks,json=totalChunks,proto3" json:"total_chunks,omitempty"`
+	ReceivedChunks uint32                 `protobuf:"varint,3,opt,name=received_chunks,json=receivedChunks,proto3" json:"received_chunks,omitempty"`
+	// One per chunk, in order. Empty for chunks that haven't been seen.
+	Txids []string `protobuf:"bytes,4,rep,name=txids,proto3" json:"txids,omitempty"`
+	// Whether all chunks are seen, and they hash to the message ID
+	Complete bool `protobuf:"varint,5,opt,name=complete,proto3" json:"complete,omitempty"`
+	// Only set if complete
+	Data    []byte `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	FeeSats int64  `protobuf:"varint,7,opt,name=fee_sats,json=feeSats,proto3" json:"fee_sats,omitempty"`
+	// Set once all chunks are confirmed
+	Height        *uint32                `protobuf:"varint,8,opt,name=height,proto3,oneof" json:"height,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChunkedMessage) Reset() {
+	*x = ChunkedMessage{}
+	mi := &file_misc_v1_misc_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChunkedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChunkedMessage) ProtoMessage() {}
+
+func (x *ChunkedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChunkedMessage.ProtoReflect.Descriptor instead.
+func (*ChunkedMessage) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ChunkedMessage) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ChunkedMessage) GetTotalChunks() uint32 {
+	if x != nil {
+		return x.TotalChunks
+	}
+	return 0
+}
+
+func (x *ChunkedMessage) GetReceivedChunks() uint32 {
+	if x != nil {
+		return x.ReceivedChunks
+	}
+	return 0
+}
+
+func (x *ChunkedMessage) GetTxids() []string {
+	if x != nil {
+		return x.Txids
+	}
+	return nil
+}
+
+func (x *ChunkedMessage) GetComplete() bool {
+	if x != nil {
+		return x.Complete
+	}
+	return false
+}
+
+func (x *ChunkedMessage) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ChunkedMessage) GetFeeSats() int64 {
+	if x != nil {
+		return x.FeeSats
+	}
+	return 0
+}
+
+func (x *ChunkedMessage) GetHeight() uint32 {
+	if x != nil && x.Height != nil {
+		return *x.Height
+	}
+	return 0
+}
+
+func (x *ChunkedMessage) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+type ListChunkedMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*ChunkedMessage      `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChunkedMessagesResponse) Reset() {
+	*x = ListChunkedMessagesResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChunkedMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChunkedMessagesResponse) ProtoMessage() {}
+
+func (x *ListChunkedMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChunkedMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListChunkedMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListChunkedMessagesResponse) GetMessages() []*ChunkedMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type ListTopicsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topics        []*Topic               `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopicsResponse) Reset() {
+	*x = ListTopicsResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopicsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopicsResponse) ProtoMessage() {}
+
+func (x *ListTopicsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopicsResponse.ProtoReflect.Descriptor instead.
+func (*ListTopicsResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListTopicsResponse) GetTopics() []*Topic {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+type ListCoinNewsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// if set, only return news for this topic
+	Topic *string `protobuf:"bytes,1,opt,name=topic,proto3,oneof" json:"topic,omitempty"`
+	// if set, only return news with a valid signature by this author
+	Author *string `protobuf:"bytes,2,opt,name=author,proto3,oneof" json:"author,omitempty"`
+	// Also return posts hidden by the topic owner, marked as moderated
+	IgnoreModeration bool          `protobuf:"varint,3,opt,name=ignore_moderation,json=ignoreModeration,proto3" json:"ignore_moderation,omitempty"`
+	Filter           *SearchFilter `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Defaults to 100, at most 1000
+	PageSize uint32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// From a previous response, to get the next page
+	PageToken string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Also return posts left out by topic subscriptions
+	IgnoreSubscriptions bool         `protobuf:"varint,7,opt,name=ignore_subscriptions,json=ignoreSubscriptions,proto3" json:"ignore_subscriptions,omitempty"`
+	Sort                CoinNewsSort `protobuf:"varint,8,opt,name=sort,proto3,enum=misc.v1.CoinNewsSort" json:"sort,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListCoinNewsRequest) Reset() {
+	*x = ListCoinNewsRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCoinNewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCoinNewsRequest) ProtoMessage() {}
+
+func (x *ListCoinNewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCoinNewsRequest.ProtoReflect.Descriptor instead.
+func (*ListCoinNewsRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListCoinNewsRequest) GetTopic() string {
+	if x != nil && x.Topic != nil {
+		return *x.Topic
+	}
+	return ""
+}
+
+func (x *ListCoinNewsRequest) GetAuthor() string {
+	if x != nil && x.Author != nil {
+		return *x.Author
+	}
+	return ""
+}
+
+func (x *ListCoinNewsRequest) GetIgnoreModeration() bool {
+	if x != nil {
+		return x.IgnoreModeration
+	}
+	return false
+}
+
+func (x *ListCoinNewsRequest) GetFilter() *SearchFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListCoinNewsRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCoinNewsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListCoinNewsRequest) GetIgnoreSubscriptions() bool {
+	if x != nil {
+		return x.IgnoreSubscriptions
+	}
+	return false
+}
+
+func (x *ListCoinNewsRequest) GetSort() CoinNewsSort {
+	if x != nil {
+		return x.Sort
+	}
+	return CoinNewsSort_COIN_NEWS_SORT_UNSPECIFIED
+}
+
+type CoinNews struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Topic      string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Headline   string                 `protobuf:"bytes,3,opt,name=headline,proto3" json:"headline,omitempty"`
+	Content    string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	FeeSats    int64                  `protobuf:"varint,5,opt,name=fee_sats,json=feeSats,proto3" json:"fee_sats,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// hash160 of the pubkey that signed the post, hex encoded. Empty
+	// if the post is not signed.
+	Author string `protobuf:"bytes,7,opt,name=author,proto3" json:"author,omitempty"`
+	// Whether the signature is valid for the author. Anyone can put
+	// any author in a post, only trust verified ones.
+	Verified bool `protobuf:"varint,8,opt,name=verified,proto3" json:"verified,omitempty"`
+	// Hidden by the topic owner, or not by one of its posters. Only
+	// returned with ignore_moderation.
+	Moderated bool `protobuf:"varint,9,opt,name=moderated,proto3" json:"moderated,omitempty"`
+	// For posts split across several transactions, the first one
+	Txid string `protobuf:"bytes,10,opt,name=txid,proto3" json:"txid,omitempty"`
+	// Not set if unconfirmed
+	Height *uint32 `protobuf:"varint,11,opt,name=height,proto3,oneof" json:"height,omitempty"`
+	// Set for replies and reactions, the txid of the post or reply they're
+	// to. Only pushed by WatchCoinNews and listed in threads.
+	ReplyTo string `protobuf:"bytes,12,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	// Set for reactions, which have no headline or content
+	Reaction string `protobuf:"bytes,13,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	// Replies anywhere in the thread below the post
+	ReplyCount uint32 `protobuf:"varint,14,opt,name=reply_count,json=replyCount,proto3" json:"reply_count,omitempty"`
+	// How many times each reaction was given
+	Reactions map[string]uint32 `protobuf:"bytes,15,rep,name=reactions,proto3" json:"reactions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// Of the transactions carrying the post, 0 if unknown
+	Vsize int64 `protobuf:"varint,16,opt,name=vsize,proto3" json:"vsize,omitempty"`
+	// In sat/vB, 0 if unknown
+	FeeRate       float64 `protobuf:"fixed64,17,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoinNews) Reset() {
+	*x = CoinNews{}
+	mi := &file_misc_v1_misc_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoinNews) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoinNews) ProtoMessage() {}
+
+func (x *CoinNews) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoinNews.ProtoReflect.Descriptor instead.
+func (*CoinNews) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CoinNews) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CoinNews) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *CoinNews) GetHeadline() string {
+	if x != nil {
+		return x.Headline
+	}
+	return ""
+}
+
+func (x *CoinNews) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *CoinNews) GetFeeSats() int64 {
+	if x != nil {
+		return x.FeeSats
+	}
+	return 0
+}
+
+func (x *CoinNews) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *CoinNews) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *CoinNews) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *CoinNews) GetModerated() bool {
+	if x != nil {
+		return x.Moderated
+	}
+	return false
+}
+
+func (x *CoinNews) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *CoinNews) GetHeight() uint32 {
+	if x != nil && x.Height != nil {
+		return *x.Height
+	}
+	return 0
+}
+
+func (x *CoinNews) GetReplyTo() string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return ""
+}
+
+func (x *CoinNews) GetReaction() string {
+	if x != nil {
+		return x.Reaction
+	}
+	return ""
+}
+
+func (x *CoinNews) GetReplyCount() uint32 {
+	if x != nil {
+		return x.ReplyCount
+	}
+	return 0
+}
+
+func (x *CoinNews) GetReactions() map[string]uint32 {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *CoinNews) GetVsize() int64 {
+	if x != nil {
+		return x.Vsize
+	}
+	return 0
+}
+
+func (x *CoinNews) GetFeeRate() float64 {
+	if x != nil {
+		return x.FeeRate
+	}
+	return 0
+}
+
+type ListCoinNewsThreadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Of a post, or of a reply to get the thread below it
+	Txid string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	// Also return replies hidden by the topic owner, marked as moderated
+	IgnoreModeration bool `protobuf:"varint,2,opt,name=ignore_moderation,json=ignoreModeration,proto3" json:"ignore_moderation,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListCoinNewsThreadRequest) Reset() {
+	*x = ListCoinNewsThreadRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCoinNewsThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCoinNewsThreadRequest) ProtoMessage() {}
+
+func (x *ListCoinNewsThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCoinNewsThreadRequest.ProtoReflect.Descriptor instead.
+func (*ListCoinNewsThreadRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListCoinNewsThreadRequest) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *ListCoinNewsThreadRequest) GetIgnoreModeration() bool {
+	if x != nil {
+		return x.IgnoreModeration
+	}
+	return false
+}
+
+type ListCoinNewsThreadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Thread        *CoinNewsThread        `protobuf:"bytes,1,opt,name=thread,proto3" json:"thread,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCoinNewsThreadResponse) Reset() {
+	*x = ListCoinNewsThreadResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCoinNewsThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCoinNewsThreadResponse) ProtoMessage() {}
+
+func (x *ListCoinNewsThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCoinNewsThreadResponse.ProtoReflect.Descriptor instead.
+func (*ListCoinNewsThreadResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListCoinNewsThreadResponse) GetThread() *CoinNewsThread {
+	if x != nil {
+		return x.Thread
+	}
+	return nil
+}
+
+type CoinNewsThread struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Post  *CoinNews              `protobuf:"bytes,1,opt,name=post,proto3" json:"post,omitempty"`
+	// Oldest first
+	Replies       []*CoinNewsThread `protobuf:"bytes,2,rep,name=replies,proto3" json:"replies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoinNewsThread) Reset() {
+	*x = CoinNewsThread{}
+	mi := &file_misc_v1_misc_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoinNewsThread) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoinNewsThread) ProtoMessage() {}
+
+func (x *CoinNewsThread) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoinNewsThread.ProtoReflect.Descriptor instead.
+func (*CoinNewsThread) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CoinNewsThread) GetPost() *CoinNews {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *CoinNewsThread) GetReplies() []*CoinNewsThread {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
+type ReactRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Of the post or reply to react to
+	Txid string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	// At most 16 bytes, like an emoji
+	Reaction string `protobuf:"bytes,2,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	// Sign the reaction with this wallet's news key
+	Sign          bool `protobuf:"varint,3,opt,name=sign,proto3" json:"sign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactRequest) Reset() {
+	*x = ReactRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactRequest) ProtoMessage() {}
+
+func (x *ReactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactRequest.ProtoReflect.Descriptor instead.
+func (*ReactRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReactRequest) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *ReactRequest) GetReaction() string {
+	if x != nil {
+		return x.Reaction
+	}
+	return ""
+}
+
+func (x *ReactRequest) GetSign() bool {
+	if x != nil {
+		return x.Sign
+	}
+	return false
+}
+
+type ReactResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Txid  string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	// Set if the reaction was signed
+	Author        string `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactResponse) Reset() {
+	*x = ReactResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactResponse) ProtoMessage() {}
+
+func (x *ReactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactResponse.ProtoReflect.Descriptor instead.
+func (*ReactResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ReactResponse) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *ReactResponse) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+type ListCoinNewsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first
+	CoinNews []*CoinNews `protobuf:"bytes,1,rep,name=coin_news,json=coinNews,proto3" json:"coin_news,omitempty"`
+	// Empty if there are no more pages
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCoinNewsResponse) Reset() {
+	*x = ListCoinNewsResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCoinNewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCoinNewsResponse) ProtoMessage() {}
+
+func (x *ListCoinNewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCoinNewsResponse.ProtoReflect.Descriptor instead.
+func (*ListCoinNewsResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListCoinNewsResponse) GetCoinNews() []*CoinNews {
+	if x != nil {
+		return x.CoinNews
+	}
+	return nil
+}
+
+func (x *ListCoinNewsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// File timestamp messages
+type TimestampFileRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Filename string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	FileData []byte                 `protobuf:"bytes,2,opt,name=file_data,json=fileData,proto3" json:"file_data,omitempty"`
+	// Wallet to send the timestamp from. If empty, the active wallet is used.
+	// Ignored when timestamps are batched, batches are sent from the active
+	// wallet.
+	WalletId string `protobuf:"bytes,3,opt,name=wallet_id,json=walletId,proto3" json:"wallet_id,omitempty"`
+	// Fee rate, measured in sat/vb. If set to zero, a reasonable
+	// rate is used by asking Core for an estimate.
+	FeeSatPerVbyte uint64 `protobuf:"varint,4,opt,name=fee_sat_per_vbyte,json=feeSatPerVbyte,proto3" json:"fee_sat_per_vbyte,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TimestampFileRequest) Reset() {
+	*x = TimestampFileRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimestampFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimestampFileRequest) ProtoMessage() {}
+
+func (x *TimestampFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimestampFileRequest.ProtoReflect.Descriptor instead.
+func (*TimestampFileRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TimestampFileRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *TimestampFileRequest) GetFileData() []byte {
+	if x != nil {
+		return x.FileData
+	}
+	return nil
+}
+
+func (x *TimestampFileRequest) GetWalletId() string {
+	if x != nil {
+		return x.WalletId
+	}
+	return ""
+}
+
+func (x *TimestampFileRequest) GetFeeSatPerVbyte() uint64 {
+	if x != nil {
+		return x.FeeSatPerVbyte
+	}
+	return 0
+}
+
+type TimestampFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FileHash      string                 `protobuf:"bytes,2,opt,name=file_hash,json=fileHash,proto3" json:"file_hash,omitempty"`
+	Txid          string                 `protobuf:"bytes,3,opt,name=txid,proto3" json:"txid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimestampFileResponse) Reset() {
+	*x = TimestampFileResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimestampFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimestampFileResponse) ProtoMessage() {}
+
+func (x *TimestampFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimestampFileResponse.ProtoReflect.Descriptor instead.
+func (*TimestampFileResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *TimestampFileResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TimestampFileResponse) GetFileHash() string {
+	if x != nil {
+		return x.FileHash
+	}
+	return ""
+}
+
+func (x *TimestampFileResponse) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+type TimestampFileStreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only read from the first message
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Chunk    []byte `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	// Only read from the first message, see TimestampFileRequest
+	WalletId       string `protobuf:"bytes,3,opt,name=wallet_id,json=walletId,proto3" json:"wallet_id,omitempty"`
+	FeeSatPerVbyte uint64 `protobuf:"varint,4,opt,name=fee_sat_per_vbyte,json=feeSatPerVbyte,proto3" json:"fee_sat_per_vbyte,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TimestampFileStreamRequest) Reset() {
+	*x = TimestampFileStreamRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimestampFileStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimestampFileStreamRequest) ProtoMessage() {}
+
+func (x *TimestampFileStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimestampFileStreamRequest.ProtoReflect.Descriptor instead.
+func (*TimestampFileStreamRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TimestampFileStreamRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *TimestampFileStreamRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *TimestampFileStreamRequest) GetWalletId() string {
+	if x != nil {
+		return x.WalletId
+	}
+	return ""
+}
+
+func (x *TimestampFileStreamRequest) GetFeeSatPerVbyte() uint64 {
+	if x != nil {
+		return x.FeeSatPerVbyte
+	}
+	return 0
+}
+
+type TimestampPathRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Path  string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// See TimestampFileRequest
+	WalletId       string `protobuf:"bytes,2,opt,name=wallet_id,json=walletId,proto3" json:"wallet_id,omitempty"`
+	FeeSatPerVbyte uint64 `protobuf:"varint,3,opt,name=fee_sat_per_vbyte,json=feeSatPerVbyte,proto3" json:"fee_sat_per_vbyte,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TimestampPathRequest) Reset() {
+	*x = TimestampPathRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimestampPathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimestampPathRequest) ProtoMessage() {}
+
+func (x *TimestampPathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimestampPathRequest.ProtoReflect.Descriptor instead.
+func (*TimestampPathRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TimestampPathRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *TimestampPathRequest) GetWalletId() string {
+	if x != nil {
+		return x.WalletId
+	}
+	return ""
+}
+
+func (x *TimestampPathRequest) GetFeeSatPerVbyte() uint64 {
+	if x != nil {
+		return x.FeeSatPerVbyte
+	}
+	return 0
+}
+
+type TimestampPathResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp *FileTimestamp         `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// For directories, what the timestamp commits to: the hashes of all files
+	// in it, in the format of sha256sum. It only depends on the files, so it
+	// can be rebuilt from the directory to verify it later.
+	Manifest      *string `protobuf:"bytes,2,opt,name=manifest,proto3,oneof" json:"manifest,omitempty"`
+	FileCount     int64   `protobuf:"varint,3,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimestampPathResponse) Reset() {
+	*x = TimestampPathResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimestampPathResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimestampPathResponse) ProtoMessage() {}
+
+func (x *TimestampPathResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimestampPathResponse.ProtoReflect.Descriptor instead.
+func (*TimestampPathResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TimestampPathResponse) GetTimestamp() *FileTimestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *TimestampPathResponse) GetManifest() string {
+	if x != nil && x.Manifest != nil {
+		return *x.Manifest
+	}
+	return ""
+}
+
+func (x *TimestampPathResponse) GetFileCount() int64 {
+	if x != nil {
+		return x.FileCount
+	}
+	return 0
+}
+
+type FileTimestamp struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename    string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	FileHash    string                 `protobuf:"bytes,3,opt,name=file_hash,json=fileHash,proto3" json:"file_hash,omitempty"`
+	Txid        *string                `protobuf:"bytes,4,opt,name=txid,proto3,oneof" json:"txid,omitempty"`
+	BlockHeight *int64                 `protobuf:"varint,5,opt,name=block_height,json=blockHeight,proto3,oneof" json:"block_height,omitempty"`
+	Status      string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// The time of the block it was confirmed in
+	ConfirmedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=confirmed_at,json=confirmedAt,proto3,oneof" json:"confirmed_at,omitempty"`
+	BlockHash   *string                `protobuf:"bytes,9,opt,name=block_hash,json=blockHash,proto3,oneof" json:"block_hash,omitempty"`
+	// Set if the status is failed, e.g. because the transaction was dropped
+	// from the mempool or replaced
+	FailureReason *string `protobuf:"bytes,10,opt,name=failure_reason,json=failureReason,proto3,oneof" json:"failure_reason,omitempty"`
+	// Set if the file was timestamped as part of a batch, where the transaction
+	// commits to the merkle root over the hashes of all files in the batch
+	MerkleRoot *string `protobuf:"bytes,11,opt,name=merkle_root,json=merkleRoot,proto3,oneof" json:"merkle_root,omitempty"`
+	// Leads from the file hash to the merkle root
+	MerklePath    []*MerkleStep `protobuf:"bytes,12,rep,name=merkle_path,json=merklePath,proto3" json:"merkle_path,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BroadcastNewsRequest) Reset() {
-	*x = BroadcastNewsRequest{}
-	mi := &file_misc_v1_misc_proto_msgTypes[2]
+func (x *FileTimestamp) Reset() {
+	*x = FileTimestamp{}
+	mi := &file_misc_v1_misc_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BroadcastNewsRequest) String() string {
+func (x *FileTimestamp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BroadcastNewsRequest) ProtoMessage() {}
+func (*FileTimestamp) ProtoMessage() {}
 
-func (x *BroadcastNewsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[2]
+func (x *FileTimestamp) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -185,99 +2824,120 @@ func (x *BroadcastNewsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BroadcastNewsRequest.ProtoReflect.Descriptor instead.
-func (*BroadcastNewsRequest) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use FileTimestamp.ProtoReflect.Descriptor instead.
+func (*FileTimestamp) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *BroadcastNewsRequest) GetTopic() string {
+func (x *FileTimestamp) GetId() int64 {
 	if x != nil {
-		return x.Topic
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-func (x *BroadcastNewsRequest) GetHeadline() string {
+func (x *FileTimestamp) GetFilename() string {
 	if x != nil {
-		return x.Headline
+		return x.Filename
 	}
 	return ""
 }
 
-func (x *BroadcastNewsRequest) GetContent() string {
+func (x *FileTimestamp) GetFileHash() string {
 	if x != nil {
-		return x.Content
+		return x.FileHash
 	}
 	return ""
 }
 
-type BroadcastNewsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *FileTimestamp) GetTxid() string {
+	if x != nil && x.Txid != nil {
+		return *x.Txid
+	}
+	return ""
 }
 
-func (x *BroadcastNewsResponse) Reset() {
-	*x = BroadcastNewsResponse{}
-	mi := &file_misc_v1_misc_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *FileTimestamp) GetBlockHeight() int64 {
+	if x != nil && x.BlockHeight != nil {
+		return *x.BlockHeight
+	}
+	return 0
 }
 
-func (x *BroadcastNewsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *FileTimestamp) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
 }
 
-func (*BroadcastNewsResponse) ProtoMessage() {}
+func (x *FileTimestamp) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
 
-func (x *BroadcastNewsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[3]
+func (x *FileTimestamp) GetConfirmedAt() *timestamppb.Timestamp {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ConfirmedAt
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use BroadcastNewsResponse.ProtoReflect.Descriptor instead.
-func (*BroadcastNewsResponse) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{3}
+func (x *FileTimestamp) GetBlockHash() string {
+	if x != nil && x.BlockHash != nil {
+		return *x.BlockHash
+	}
+	return ""
 }
 
-func (x *BroadcastNewsResponse) GetTxid() string {
-	if x != nil {
-		return x.Txid
+func (x *FileTimestamp) GetFailureReason() string {
+	if x != nil && x.FailureReason != nil {
+		return *x.FailureReason
 	}
 	return ""
 }
 
-type CreateTopicRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Topic         string                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+func (x *FileTimestamp) GetMerkleRoot() string {
+	if x != nil && x.MerkleRoot != nil {
+		return *x.MerkleRoot
+	}
+	return ""
+}
+
+func (x *FileTimestamp) GetMerklePath() []*MerkleStep {
+	if x != nil {
+		return x.MerklePath
+	}
+	return nil
+}
+
+type MerkleStep struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Hex encoded hash of the sibling node
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Whether the sibling is on the left
+	Left          bool `protobuf:"varint,2,opt,name=left,proto3" json:"left,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTopicRequest) Reset() {
-	*x = CreateTopicRequest{}
-	mi := &file_misc_v1_misc_proto_msgTypes[4]
+func (x *MerkleStep) Reset() {
+	*x = MerkleStep{}
+	mi := &file_misc_v1_misc_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTopicRequest) String() string {
+func (x *MerkleStep) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTopicRequest) ProtoMessage() {}
+func (*MerkleStep) ProtoMessage() {}
 
-func (x *CreateTopicRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[4]
+func (x *MerkleStep) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -288,47 +2948,47 @@ func (x *CreateTopicRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTopicRequest.ProtoReflect.Descriptor instead.
-func (*CreateTopicRequest) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use MerkleStep.ProtoReflect.Descriptor instead.
+func (*MerkleStep) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *CreateTopicRequest) GetTopic() string {
+func (x *MerkleStep) GetHash() string {
 	if x != nil {
-		return x.Topic
+		return x.Hash
 	}
 	return ""
 }
 
-func (x *CreateTopicRequest) GetName() string {
+func (x *MerkleStep) GetLeft() bool {
 	if x != nil {
-		return x.Name
+		return x.Left
 	}
-	return ""
+	return false
 }
 
-type CreateTopicResponse struct {
+type ListTimestampsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Timestamps    []*FileTimestamp       `protobuf:"bytes,1,rep,name=timestamps,proto3" json:"timestamps,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTopicResponse) Reset() {
-	*x = CreateTopicResponse{}
-	mi := &file_misc_v1_misc_proto_msgTypes[5]
+func (x *ListTimestampsResponse) Reset() {
+	*x = ListTimestampsResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTopicResponse) String() string {
+func (x *ListTimestampsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTopicResponse) ProtoMessage() {}
+func (*ListTimestampsResponse) ProtoMessage() {}
 
-func (x *CreateTopicResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[5]
+func (x *ListTimestampsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -339,43 +2999,40 @@ func (x *CreateTopicResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTopicResponse.ProtoReflect.Descriptor instead.
-func (*CreateTopicResponse) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListTimestampsResponse.ProtoReflect.Descriptor instead.
+func (*ListTimestampsResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *CreateTopicResponse) GetTxid() string {
+func (x *ListTimestampsResponse) GetTimestamps() []*FileTimestamp {
 	if x != nil {
-		return x.Txid
+		return x.Timestamps
 	}
-	return ""
+	return nil
 }
 
-type Topic struct {
+type RebroadcastTimestampRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Topic         string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Topic) Reset() {
-	*x = Topic{}
-	mi := &file_misc_v1_misc_proto_msgTypes[6]
+func (x *RebroadcastTimestampRequest) Reset() {
+	*x = RebroadcastTimestampRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Topic) String() string {
+func (x *RebroadcastTimestampRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Topic) ProtoMessage() {}
+func (*RebroadcastTimestampRequest) ProtoMessage() {}
 
-func (x *Topic) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[6]
+func (x *RebroadcastTimestampRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -386,61 +3043,40 @@ func (x *Topic) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Topic.ProtoReflect.Descriptor instead.
-func (*Topic) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use RebroadcastTimestampRequest.ProtoReflect.Descriptor instead.
+func (*RebroadcastTimestampRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *Topic) GetId() int64 {
+func (x *RebroadcastTimestampRequest) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *Topic) GetTopic() string {
-	if x != nil {
-		return x.Topic
-	}
-	return ""
-}
-
-func (x *Topic) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Topic) GetCreateTime() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreateTime
-	}
-	return nil
-}
-
-type ListTopicsResponse struct {
+type RebroadcastTimestampResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Topics        []*Topic               `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+	Timestamp     *FileTimestamp         `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTopicsResponse) Reset() {
-	*x = ListTopicsResponse{}
-	mi := &file_misc_v1_misc_proto_msgTypes[7]
+func (x *RebroadcastTimestampResponse) Reset() {
+	*x = RebroadcastTimestampResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTopicsResponse) String() string {
+func (x *RebroadcastTimestampResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTopicsResponse) ProtoMessage() {}
+func (*RebroadcastTimestampResponse) ProtoMessage() {}
 
-func (x *ListTopicsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[7]
+func (x *RebroadcastTimestampResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -451,41 +3087,40 @@ func (x *ListTopicsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTopicsResponse.ProtoReflect.Descriptor instead.
-func (*ListTopicsResponse) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use RebroadcastTimestampResponse.ProtoReflect.Descriptor instead.
+func (*RebroadcastTimestampResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *ListTopicsResponse) GetTopics() []*Topic {
+func (x *RebroadcastTimestampResponse) GetTimestamp() *FileTimestamp {
 	if x != nil {
-		return x.Topics
+		return x.Timestamp
 	}
 	return nil
 }
 
-type ListCoinNewsRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// if set, only return news for this topic
-	Topic         *string `protobuf:"bytes,1,opt,name=topic,proto3,oneof" json:"topic,omitempty"`
+type ExportTimestampProofRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListCoinNewsRequest) Reset() {
-	*x = ListCoinNewsRequest{}
-	mi := &file_misc_v1_misc_proto_msgTypes[8]
+func (x *ExportTimestampProofRequest) Reset() {
+	*x = ExportTimestampProofRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListCoinNewsRequest) String() string {
+func (x *ExportTimestampProofRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCoinNewsRequest) ProtoMessage() {}
+func (*ExportTimestampProofRequest) ProtoMessage() {}
 
-func (x *ListCoinNewsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[8]
+func (x *ExportTimestampProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -496,45 +3131,43 @@ func (x *ListCoinNewsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCoinNewsRequest.ProtoReflect.Descriptor instead.
-func (*ListCoinNewsRequest) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ExportTimestampProofRequest.ProtoReflect.Descriptor instead.
+func (*ExportTimestampProofRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *ListCoinNewsRequest) GetTopic() string {
-	if x != nil && x.Topic != nil {
-		return *x.Topic
+func (x *ExportTimestampProofRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-type CoinNews struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Topic         string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
-	Headline      string                 `protobuf:"bytes,3,opt,name=headline,proto3" json:"headline,omitempty"`
-	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
-	FeeSats       int64                  `protobuf:"varint,5,opt,name=fee_sats,json=feeSats,proto3" json:"fee_sats,omitempty"`
-	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+type ExportTimestampProofResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Serialized OpenTimestamps proof
+	Proof []byte `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+	// Suggested name to save the proof as, i.e. the file name with .ots added
+	Filename      string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CoinNews) Reset() {
-	*x = CoinNews{}
-	mi := &file_misc_v1_misc_proto_msgTypes[9]
+func (x *ExportTimestampProofResponse) Reset() {
+	*x = ExportTimestampProofResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CoinNews) String() string {
+func (x *ExportTimestampProofResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CoinNews) ProtoMessage() {}
+func (*ExportTimestampProofResponse) ProtoMessage() {}
 
-func (x *CoinNews) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[9]
+func (x *ExportTimestampProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -545,75 +3178,49 @@ func (x *CoinNews) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CoinNews.ProtoReflect.Descriptor instead.
-func (*CoinNews) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *CoinNews) GetId() int64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *CoinNews) GetTopic() string {
-	if x != nil {
-		return x.Topic
-	}
-	return ""
-}
-
-func (x *CoinNews) GetHeadline() string {
-	if x != nil {
-		return x.Headline
-	}
-	return ""
-}
-
-func (x *CoinNews) GetContent() string {
-	if x != nil {
-		return x.Content
-	}
-	return ""
+// Deprecated: Use ExportTimestampProofResponse.ProtoReflect.Descriptor instead.
+func (*ExportTimestampProofResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *CoinNews) GetFeeSats() int64 {
+func (x *ExportTimestampProofResponse) GetProof() []byte {
 	if x != nil {
-		return x.FeeSats
+		return x.Proof
 	}
-	return 0
+	return nil
 }
 
-func (x *CoinNews) GetCreateTime() *timestamppb.Timestamp {
+func (x *ExportTimestampProofResponse) GetFilename() string {
 	if x != nil {
-		return x.CreateTime
+		return x.Filename
 	}
-	return nil
+	return ""
 }
 
-type ListCoinNewsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CoinNews      []*CoinNews            `protobuf:"bytes,1,rep,name=coin_news,json=coinNews,proto3" json:"coin_news,omitempty"`
+type VerifyTimestampProofRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	FileData []byte                 `protobuf:"bytes,1,opt,name=file_data,json=fileData,proto3" json:"file_data,omitempty"`
+	// Serialized OpenTimestamps proof
+	Proof         []byte `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListCoinNewsResponse) Reset() {
-	*x = ListCoinNewsResponse{}
-	mi := &file_misc_v1_misc_proto_msgTypes[10]
+func (x *VerifyTimestampProofRequest) Reset() {
+	*x = VerifyTimestampProofRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListCoinNewsResponse) String() string {
+func (x *VerifyTimestampProofRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCoinNewsResponse) ProtoMessage() {}
+func (*VerifyTimestampProofRequest) ProtoMessage() {}
 
-func (x *ListCoinNewsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[10]
+func (x *VerifyTimestampProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -624,42 +3231,51 @@ func (x *ListCoinNewsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCoinNewsResponse.ProtoReflect.Descriptor instead.
-func (*ListCoinNewsResponse) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use VerifyTimestampProofRequest.ProtoReflect.Descriptor instead.
+func (*VerifyTimestampProofRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *ListCoinNewsResponse) GetCoinNews() []*CoinNews {
+func (x *VerifyTimestampProofRequest) GetFileData() []byte {
 	if x != nil {
-		return x.CoinNews
+		return x.FileData
 	}
 	return nil
 }
 
-// File timestamp messages
-type TimestampFileRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
-	FileData      []byte                 `protobuf:"bytes,2,opt,name=file_data,json=fileData,proto3" json:"file_data,omitempty"`
+func (x *VerifyTimestampProofRequest) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type VerifyTimestampProofResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	FileHash string                 `protobuf:"bytes,1,opt,name=file_hash,json=fileHash,proto3" json:"file_hash,omitempty"`
+	// The block the proof leads to
+	BlockHeight   uint32                 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	BlockHash     string                 `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TimestampFileRequest) Reset() {
-	*x = TimestampFileRequest{}
-	mi := &file_misc_v1_misc_proto_msgTypes[11]
+func (x *VerifyTimestampProofResponse) Reset() {
+	*x = VerifyTimestampProofResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TimestampFileRequest) String() string {
+func (x *VerifyTimestampProofResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TimestampFileRequest) ProtoMessage() {}
+func (*VerifyTimestampProofResponse) ProtoMessage() {}
 
-func (x *TimestampFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[11]
+func (x *VerifyTimestampProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -670,49 +3286,68 @@ func (x *TimestampFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TimestampFileRequest.ProtoReflect.Descriptor instead.
-func (*TimestampFileRequest) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use VerifyTimestampProofResponse.ProtoReflect.Descriptor instead.
+func (*VerifyTimestampProofResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *TimestampFileRequest) GetFilename() string {
+func (x *VerifyTimestampProofResponse) GetFileHash() string {
 	if x != nil {
-		return x.Filename
+		return x.FileHash
 	}
 	return ""
 }
 
-func (x *TimestampFileRequest) GetFileData() []byte {
+func (x *VerifyTimestampProofResponse) GetBlockHeight() uint32 {
 	if x != nil {
-		return x.FileData
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *VerifyTimestampProofResponse) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *VerifyTimestampProofResponse) GetBlockTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BlockTime
 	}
 	return nil
 }
 
-type TimestampFileResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	FileHash      string                 `protobuf:"bytes,2,opt,name=file_hash,json=fileHash,proto3" json:"file_hash,omitempty"`
-	Txid          string                 `protobuf:"bytes,3,opt,name=txid,proto3" json:"txid,omitempty"`
+type VerifyTimestampRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	FileData []byte                 `protobuf:"bytes,1,opt,name=file_data,json=fileData,proto3" json:"file_data,omitempty"`
+	// Look for a transaction committing to the file on chain, instead of among
+	// our own timestamps. Works for files timestamped by anyone.
+	ChainOnly bool `protobuf:"varint,2,opt,name=chain_only,json=chainOnly,proto3" json:"chain_only,omitempty"`
+	// Narrow down the blocks to search on chain, inclusive. Blocks that aren't
+	// indexed yet are scanned through Core, which only works for small ranges.
+	StartHeight   *uint32 `protobuf:"varint,3,opt,name=start_height,json=startHeight,proto3,oneof" json:"start_height,omitempty"`
+	EndHeight     *uint32 `protobuf:"varint,4,opt,name=end_height,json=endHeight,proto3,oneof" json:"end_height,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TimestampFileResponse) Reset() {
-	*x = TimestampFileResponse{}
-	mi := &file_misc_v1_misc_proto_msgTypes[12]
+func (x *VerifyTimestampRequest) Reset() {
+	*x = VerifyTimestampRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TimestampFileResponse) String() string {
+func (x *VerifyTimestampRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TimestampFileResponse) ProtoMessage() {}
+func (*VerifyTimestampRequest) ProtoMessage() {}
 
-func (x *TimestampFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[12]
+func (x *VerifyTimestampRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -723,61 +3358,62 @@ func (x *TimestampFileResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TimestampFileResponse.ProtoReflect.Descriptor instead.
-func (*TimestampFileResponse) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use VerifyTimestampRequest.ProtoReflect.Descriptor instead.
+func (*VerifyTimestampRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *TimestampFileResponse) GetId() int64 {
+func (x *VerifyTimestampRequest) GetFileData() []byte {
 	if x != nil {
-		return x.Id
+		return x.FileData
 	}
-	return 0
+	return nil
 }
 
-func (x *TimestampFileResponse) GetFileHash() string {
+func (x *VerifyTimestampRequest) GetChainOnly() bool {
 	if x != nil {
-		return x.FileHash
+		return x.ChainOnly
 	}
-	return ""
+	return false
 }
 
-func (x *TimestampFileResponse) GetTxid() string {
-	if x != nil {
-		return x.Txid
+func (x *VerifyTimestampRequest) GetStartHeight() uint32 {
+	if x != nil && x.StartHeight != nil {
+		return *x.StartHeight
 	}
-	return ""
+	return 0
 }
 
-type FileTimestamp struct {
+func (x *VerifyTimestampRequest) GetEndHeight() uint32 {
+	if x != nil && x.EndHeight != nil {
+		return *x.EndHeight
+	}
+	return 0
+}
+
+type VerifyTimestampResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
-	FileHash      string                 `protobuf:"bytes,3,opt,name=file_hash,json=fileHash,proto3" json:"file_hash,omitempty"`
-	Txid          *string                `protobuf:"bytes,4,opt,name=txid,proto3,oneof" json:"txid,omitempty"`
-	BlockHeight   *int64                 `protobuf:"varint,5,opt,name=block_height,json=blockHeight,proto3,oneof" json:"block_height,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	ConfirmedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=confirmed_at,json=confirmedAt,proto3,oneof" json:"confirmed_at,omitempty"`
+	Timestamp     *FileTimestamp         `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileTimestamp) Reset() {
-	*x = FileTimestamp{}
-	mi := &file_misc_v1_misc_proto_msgTypes[13]
+func (x *VerifyTimestampResponse) Reset() {
+	*x = VerifyTimestampResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileTimestamp) String() string {
+func (x *VerifyTimestampResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileTimestamp) ProtoMessage() {}
+func (*VerifyTimestampResponse) ProtoMessage() {}
 
-func (x *FileTimestamp) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[13]
+func (x *VerifyTimestampResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -788,89 +3424,93 @@ func (x *FileTimestamp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileTimestamp.ProtoReflect.Descriptor instead.
-func (*FileTimestamp) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use VerifyTimestampResponse.ProtoReflect.Descriptor instead.
+func (*VerifyTimestampResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *FileTimestamp) GetId() int64 {
+func (x *VerifyTimestampResponse) GetTimestamp() *FileTimestamp {
 	if x != nil {
-		return x.Id
+		return x.Timestamp
 	}
-	return 0
+	return nil
 }
 
-func (x *FileTimestamp) GetFilename() string {
+func (x *VerifyTimestampResponse) GetMessage() string {
 	if x != nil {
-		return x.Filename
+		return x.Message
 	}
 	return ""
 }
 
-func (x *FileTimestamp) GetFileHash() string {
-	if x != nil {
-		return x.FileHash
-	}
-	return ""
+type WatchOPReturnsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// If set, only push OP_RETURNs of these protocols
+	Protocols     []Protocol `protobuf:"varint,1,rep,packed,name=protocols,proto3,enum=misc.v1.Protocol" json:"protocols,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileTimestamp) GetTxid() string {
-	if x != nil && x.Txid != nil {
-		return *x.Txid
-	}
-	return ""
+func (x *WatchOPReturnsRequest) Reset() {
+	*x = WatchOPReturnsRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileTimestamp) GetBlockHeight() int64 {
-	if x != nil && x.BlockHeight != nil {
-		return *x.BlockHeight
-	}
-	return 0
+func (x *WatchOPReturnsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *FileTimestamp) GetStatus() string {
+func (*WatchOPReturnsRequest) ProtoMessage() {}
+
+func (x *WatchOPReturnsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[47]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *FileTimestamp) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
+// Deprecated: Use WatchOPReturnsRequest.ProtoReflect.Descriptor instead.
+func (*WatchOPReturnsRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *FileTimestamp) GetConfirmedAt() *timestamppb.Timestamp {
+func (x *WatchOPReturnsRequest) GetProtocols() []Protocol {
 	if x != nil {
-		return x.ConfirmedAt
+		return x.Protocols
 	}
 	return nil
 }
 
-type ListTimestampsResponse struct {
+type WatchOPReturnsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Timestamps    []*FileTimestamp       `protobuf:"bytes,1,rep,name=timestamps,proto3" json:"timestamps,omitempty"`
+	OpReturn      *OPReturn              `protobuf:"bytes,1,opt,name=op_return,json=opReturn,proto3" json:"op_return,omitempty"`
+	Event         WatchEvent             `protobuf:"varint,2,opt,name=event,proto3,enum=misc.v1.WatchEvent" json:"event,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTimestampsResponse) Reset() {
-	*x = ListTimestampsResponse{}
-	mi := &file_misc_v1_misc_proto_msgTypes[14]
+func (x *WatchOPReturnsResponse) Reset() {
+	*x = WatchOPReturnsResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTimestampsResponse) String() string {
+func (x *WatchOPReturnsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTimestampsResponse) ProtoMessage() {}
+func (*WatchOPReturnsResponse) ProtoMessage() {}
 
-func (x *ListTimestampsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[14]
+func (x *WatchOPReturnsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -881,40 +3521,54 @@ func (x *ListTimestampsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTimestampsResponse.ProtoReflect.Descriptor instead.
-func (*ListTimestampsResponse) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use WatchOPReturnsResponse.ProtoReflect.Descriptor instead.
+func (*WatchOPReturnsResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *ListTimestampsResponse) GetTimestamps() []*FileTimestamp {
+func (x *WatchOPReturnsResponse) GetOpReturn() *OPReturn {
 	if x != nil {
-		return x.Timestamps
+		return x.OpReturn
 	}
 	return nil
 }
 
-type VerifyTimestampRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileData      []byte                 `protobuf:"bytes,1,opt,name=file_data,json=fileData,proto3" json:"file_data,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *WatchOPReturnsResponse) GetEvent() WatchEvent {
+	if x != nil {
+		return x.Event
+	}
+	return WatchEvent_WATCH_EVENT_UNSPECIFIED
 }
 
-func (x *VerifyTimestampRequest) Reset() {
-	*x = VerifyTimestampRequest{}
-	mi := &file_misc_v1_misc_proto_msgTypes[15]
+type WatchCoinNewsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// if set, only push news for this topic
+	Topic *string `protobuf:"bytes,1,opt,name=topic,proto3,oneof" json:"topic,omitempty"`
+	// if set, only push news with a valid signature by this author
+	Author *string `protobuf:"bytes,2,opt,name=author,proto3,oneof" json:"author,omitempty"`
+	// Also push posts hidden by the topic owner, marked as moderated
+	IgnoreModeration bool `protobuf:"varint,3,opt,name=ignore_moderation,json=ignoreModeration,proto3" json:"ignore_moderation,omitempty"`
+	// Also push posts left out by topic subscriptions
+	IgnoreSubscriptions bool `protobuf:"varint,4,opt,name=ignore_subscriptions,json=ignoreSubscriptions,proto3" json:"ignore_subscriptions,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *WatchCoinNewsRequest) Reset() {
+	*x = WatchCoinNewsRequest{}
+	mi := &file_misc_v1_misc_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyTimestampRequest) String() string {
+func (x *WatchCoinNewsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyTimestampRequest) ProtoMessage() {}
+func (*WatchCoinNewsRequest) ProtoMessage() {}
 
-func (x *VerifyTimestampRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[15]
+func (x *WatchCoinNewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -925,41 +3579,62 @@ func (x *VerifyTimestampRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyTimestampRequest.ProtoReflect.Descriptor instead.
-func (*VerifyTimestampRequest) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use WatchCoinNewsRequest.ProtoReflect.Descriptor instead.
+func (*WatchCoinNewsRequest) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *VerifyTimestampRequest) GetFileData() []byte {
+func (x *WatchCoinNewsRequest) GetTopic() string {
+	if x != nil && x.Topic != nil {
+		return *x.Topic
+	}
+	return ""
+}
+
+func (x *WatchCoinNewsRequest) GetAuthor() string {
+	if x != nil && x.Author != nil {
+		return *x.Author
+	}
+	return ""
+}
+
+func (x *WatchCoinNewsRequest) GetIgnoreModeration() bool {
 	if x != nil {
-		return x.FileData
+		return x.IgnoreModeration
 	}
-	return nil
+	return false
 }
 
-type VerifyTimestampResponse struct {
+func (x *WatchCoinNewsRequest) GetIgnoreSubscriptions() bool {
+	if x != nil {
+		return x.IgnoreSubscriptions
+	}
+	return false
+}
+
+type WatchCoinNewsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Timestamp     *FileTimestamp         `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	CoinNews      *CoinNews              `protobuf:"bytes,1,opt,name=coin_news,json=coinNews,proto3" json:"coin_news,omitempty"`
+	Event         WatchEvent             `protobuf:"varint,2,opt,name=event,proto3,enum=misc.v1.WatchEvent" json:"event,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VerifyTimestampResponse) Reset() {
-	*x = VerifyTimestampResponse{}
-	mi := &file_misc_v1_misc_proto_msgTypes[16]
+func (x *WatchCoinNewsResponse) Reset() {
+	*x = WatchCoinNewsResponse{}
+	mi := &file_misc_v1_misc_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyTimestampResponse) String() string {
+func (x *WatchCoinNewsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyTimestampResponse) ProtoMessage() {}
+func (*WatchCoinNewsResponse) ProtoMessage() {}
 
-func (x *VerifyTimestampResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_misc_v1_misc_proto_msgTypes[16]
+func (x *WatchCoinNewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_misc_v1_misc_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -970,33 +3645,59 @@ func (x *VerifyTimestampResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyTimestampResponse.ProtoReflect.Descriptor instead.
-func (*VerifyTimestampResponse) Descriptor() ([]byte, []int) {
-	return file_misc_v1_misc_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use WatchCoinNewsResponse.ProtoReflect.Descriptor instead.
+func (*WatchCoinNewsResponse) Descriptor() ([]byte, []int) {
+	return file_misc_v1_misc_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *VerifyTimestampResponse) GetTimestamp() *FileTimestamp {
+func (x *WatchCoinNewsResponse) GetCoinNews() *CoinNews {
 	if x != nil {
-		return x.Timestamp
+		return x.CoinNews
 	}
 	return nil
 }
 
-func (x *VerifyTimestampResponse) GetMessage() string {
+func (x *WatchCoinNewsResponse) GetEvent() WatchEvent {
 	if x != nil {
-		return x.Message
+		return x.Event
 	}
-	return ""
+	return WatchEvent_WATCH_EVENT_UNSPECIFIED
 }
 
 var File_misc_v1_misc_proto protoreflect.FileDescriptor
 
 const file_misc_v1_misc_proto_rawDesc = "" +
 	"\n" +
-	"\x12misc/v1/misc.proto\x12\amisc.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"H\n" +
+	"\x12misc/v1/misc.proto\x12\amisc.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x86\x03\n" +
+	"\fSearchFilter\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\"\n" +
+	"\n" +
+	"min_height\x18\x02 \x01(\rH\x00R\tminHeight\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_height\x18\x03 \x01(\rH\x01R\tmaxHeight\x88\x01\x01\x129\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1f\n" +
+	"\vtxid_prefix\x18\x06 \x01(\tR\n" +
+	"txidPrefix\x12\x19\n" +
+	"\x05topic\x18\a \x01(\tH\x02R\x05topic\x88\x01\x01\x12 \n" +
+	"\fmin_fee_sats\x18\b \x01(\x03R\n" +
+	"minFeeSats\x12 \n" +
+	"\fmin_fee_rate\x18\t \x01(\x01R\n" +
+	"minFeeRateB\r\n" +
+	"\v_min_heightB\r\n" +
+	"\v_max_heightB\b\n" +
+	"\x06_topic\"\xb1\x01\n" +
+	"\x13ListOPReturnRequest\x12/\n" +
+	"\tprotocols\x18\x01 \x03(\x0e2\x11.misc.v1.ProtocolR\tprotocols\x12-\n" +
+	"\x06filter\x18\x02 \x01(\v2\x15.misc.v1.SearchFilterR\x06filter\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\rR\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"p\n" +
 	"\x14ListOPReturnResponse\x120\n" +
 	"\n" +
-	"op_returns\x18\x01 \x03(\v2\x11.misc.v1.OPReturnR\topReturns\"\xc1\x01\n" +
+	"op_returns\x18\x01 \x03(\v2\x11.misc.v1.OPReturnR\topReturns\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xb6\x04\n" +
 	"\bOPReturn\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x12\n" +
@@ -1004,30 +3705,123 @@ const file_misc_v1_misc_proto_rawDesc = "" +
 	"\x04vout\x18\x04 \x01(\x05R\x04vout\x12\x1b\n" +
 	"\x06height\x18\x05 \x01(\x05H\x00R\x06height\x88\x01\x01\x12;\n" +
 	"\vcreate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"createTimeB\t\n" +
-	"\a_height\"b\n" +
+	"createTime\x120\n" +
+	"\x06status\x18\b \x01(\x0e2\x18.misc.v1.OPReturn.StatusR\x06status\x12-\n" +
+	"\x10replaced_by_txid\x18\t \x01(\tH\x01R\x0ereplacedByTxid\x88\x01\x01\x12-\n" +
+	"\bprotocol\x18\n" +
+	" \x01(\x0e2\x11.misc.v1.ProtocolR\bprotocol\x125\n" +
+	"\x06fields\x18\v \x03(\v2\x1d.misc.v1.OPReturn.FieldsEntryR\x06fields\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\\\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rSTATUS_ACTIVE\x10\x01\x12\x12\n" +
+	"\x0eSTATUS_DROPPED\x10\x02\x12\x13\n" +
+	"\x0fSTATUS_REPLACED\x10\x03B\t\n" +
+	"\a_heightB\x13\n" +
+	"\x11_replaced_by_txid\"\xbd\x01\n" +
 	"\x14BroadcastNewsRequest\x12\x14\n" +
 	"\x05topic\x18\x01 \x01(\tR\x05topic\x12\x1a\n" +
 	"\bheadline\x18\x02 \x01(\tR\bheadline\x12\x18\n" +
-	"\acontent\x18\x03 \x01(\tR\acontent\"+\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x12\n" +
+	"\x04sign\x18\x04 \x01(\bR\x04sign\x12\x18\n" +
+	"\achunked\x18\x05 \x01(\bR\achunked\x12\x1e\n" +
+	"\breply_to\x18\x06 \x01(\tH\x00R\areplyTo\x88\x01\x01B\v\n" +
+	"\t_reply_to\"d\n" +
 	"\x15BroadcastNewsResponse\x12\x12\n" +
-	"\x04txid\x18\x01 \x01(\tR\x04txid\">\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\x12\x1f\n" +
+	"\vchunk_txids\x18\x03 \x03(\tR\n" +
+	"chunkTxids\"T\n" +
 	"\x12CreateTopicRequest\x12\x14\n" +
 	"\x05topic\x18\x01 \x01(\tR\x05topic\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\")\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05owned\x18\x03 \x01(\bR\x05owned\"?\n" +
 	"\x13CreateTopicResponse\x12\x12\n" +
-	"\x04txid\x18\x01 \x01(\tR\x04txid\"~\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\"\x9e\x02\n" +
 	"\x05Topic\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
 	"\x05topic\x18\x02 \x01(\tR\x05topic\x12\x12\n" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12;\n" +
 	"\vcreate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"createTime\"<\n" +
+	"createTime\x12\x14\n" +
+	"\x05owner\x18\x05 \x01(\tR\x05owner\x12\x18\n" +
+	"\aposters\x18\x06 \x03(\tR\aposters\x12>\n" +
+	"\fsubscription\x18\a \x01(\v2\x1a.misc.v1.TopicSubscriptionR\fsubscription\x12\x12\n" +
+	"\x04txid\x18\b \x01(\tR\x04txid\x12\x1a\n" +
+	"\bimported\x18\t \x01(\bR\bimported\"\x98\x01\n" +
+	"\x11TopicSubscription\x12\x1e\n" +
+	"\n" +
+	"subscribed\x18\x01 \x01(\bR\n" +
+	"subscribed\x12\x14\n" +
+	"\x05muted\x18\x02 \x01(\bR\x05muted\x12 \n" +
+	"\fmin_fee_sats\x18\x03 \x01(\x03R\n" +
+	"minFeeSats\x12+\n" +
+	"\x11ignore_moderation\x18\x04 \x01(\bR\x10ignoreModeration\"s\n" +
+	"\x1bSetTopicSubscriptionRequest\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x12>\n" +
+	"\fsubscription\x18\x02 \x01(\v2\x1a.misc.v1.TopicSubscriptionR\fsubscription\"D\n" +
+	"\x1cSetTopicSubscriptionResponse\x12$\n" +
+	"\x05topic\x18\x01 \x01(\v2\x0e.misc.v1.TopicR\x05topic\"-\n" +
+	"\x13ExportTopicsRequest\x12\x16\n" +
+	"\x06topics\x18\x01 \x03(\tR\x06topics\"3\n" +
+	"\x14ExportTopicsResponse\x12\x1b\n" +
+	"\ttopic_set\x18\x01 \x01(\tR\btopicSet\"2\n" +
+	"\x13ImportTopicsRequest\x12\x1b\n" +
+	"\ttopic_set\x18\x01 \x01(\tR\btopicSet\"W\n" +
+	"\x14ImportTopicsResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\rR\bimported\x12#\n" +
+	"\ralready_known\x18\x02 \x01(\rR\falreadyKnown\"\xb7\x01\n" +
+	"\x14ModerateTopicRequest\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x12\x18\n" +
+	"\x06rename\x18\x02 \x01(\tH\x00R\x06rename\x12\x1f\n" +
+	"\n" +
+	"add_poster\x18\x03 \x01(\tH\x00R\taddPoster\x12%\n" +
+	"\rremove_poster\x18\x04 \x01(\tH\x00R\fremovePoster\x12\x1d\n" +
+	"\thide_txid\x18\x05 \x01(\tH\x00R\bhideTxidB\b\n" +
+	"\x06action\"+\n" +
+	"\x15ModerateTopicResponse\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\"`\n" +
+	"\x17BroadcastChunkedRequest\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\"\n" +
+	"\n" +
+	"chunk_size\x18\x02 \x01(\rH\x00R\tchunkSize\x88\x01\x01B\r\n" +
+	"\v_chunk_size\"O\n" +
+	"\x18BroadcastChunkedResponse\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x14\n" +
+	"\x05txids\x18\x02 \x03(\tR\x05txids\"\xc1\x02\n" +
+	"\x0eChunkedMessage\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12!\n" +
+	"\ftotal_chunks\x18\x02 \x01(\rR\vtotalChunks\x12'\n" +
+	"\x0freceived_chunks\x18\x03 \x01(\rR\x0ereceivedChunks\x12\x14\n" +
+	"\x05txids\x18\x04 \x03(\tR\x05txids\x12\x1a\n" +
+	"\bcomplete\x18\x05 \x01(\bR\bcomplete\x12\x12\n" +
+	"\x04data\x18\x06 \x01(\fR\x04data\x12\x19\n" +
+	"\bfee_sats\x18\a \x01(\x03R\afeeSats\x12\x1b\n" +
+	"\x06height\x18\b \x01(\rH\x00R\x06height\x88\x01\x01\x12;\n" +
+	"\vcreate_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTimeB\t\n" +
+	"\a_height\"R\n" +
+	"\x1bListChunkedMessagesResponse\x123\n" +
+	"\bmessages\x18\x01 \x03(\v2\x17.misc.v1.ChunkedMessageR\bmessages\"<\n" +
 	"\x12ListTopicsResponse\x12&\n" +
-	"\x06topics\x18\x01 \x03(\v2\x0e.misc.v1.TopicR\x06topics\":\n" +
+	"\x06topics\x18\x01 \x03(\v2\x0e.misc.v1.TopicR\x06topics\"\xd8\x02\n" +
 	"\x13ListCoinNewsRequest\x12\x19\n" +
-	"\x05topic\x18\x01 \x01(\tH\x00R\x05topic\x88\x01\x01B\b\n" +
-	"\x06_topic\"\xbe\x01\n" +
+	"\x05topic\x18\x01 \x01(\tH\x00R\x05topic\x88\x01\x01\x12\x1b\n" +
+	"\x06author\x18\x02 \x01(\tH\x01R\x06author\x88\x01\x01\x12+\n" +
+	"\x11ignore_moderation\x18\x03 \x01(\bR\x10ignoreModeration\x12-\n" +
+	"\x06filter\x18\x04 \x01(\v2\x15.misc.v1.SearchFilterR\x06filter\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\rR\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x121\n" +
+	"\x14ignore_subscriptions\x18\a \x01(\bR\x13ignoreSubscriptions\x12)\n" +
+	"\x04sort\x18\b \x01(\x0e2\x15.misc.v1.CoinNewsSortR\x04sortB\b\n" +
+	"\x06_topicB\t\n" +
+	"\a_author\"\xd3\x04\n" +
 	"\bCoinNews\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
 	"\x05topic\x18\x02 \x01(\tR\x05topic\x12\x1a\n" +
@@ -1035,16 +3829,66 @@ const file_misc_v1_misc_proto_rawDesc = "" +
 	"\acontent\x18\x04 \x01(\tR\acontent\x12\x19\n" +
 	"\bfee_sats\x18\x05 \x01(\x03R\afeeSats\x12;\n" +
 	"\vcreate_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"createTime\"F\n" +
+	"createTime\x12\x16\n" +
+	"\x06author\x18\a \x01(\tR\x06author\x12\x1a\n" +
+	"\bverified\x18\b \x01(\bR\bverified\x12\x1c\n" +
+	"\tmoderated\x18\t \x01(\bR\tmoderated\x12\x12\n" +
+	"\x04txid\x18\n" +
+	" \x01(\tR\x04txid\x12\x1b\n" +
+	"\x06height\x18\v \x01(\rH\x00R\x06height\x88\x01\x01\x12\x19\n" +
+	"\breply_to\x18\f \x01(\tR\areplyTo\x12\x1a\n" +
+	"\breaction\x18\r \x01(\tR\breaction\x12\x1f\n" +
+	"\vreply_count\x18\x0e \x01(\rR\n" +
+	"replyCount\x12>\n" +
+	"\treactions\x18\x0f \x03(\v2 .misc.v1.CoinNews.ReactionsEntryR\treactions\x12\x14\n" +
+	"\x05vsize\x18\x10 \x01(\x03R\x05vsize\x12\x19\n" +
+	"\bfee_rate\x18\x11 \x01(\x01R\afeeRate\x1a<\n" +
+	"\x0eReactionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\rR\x05value:\x028\x01B\t\n" +
+	"\a_height\"\\\n" +
+	"\x19ListCoinNewsThreadRequest\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x12+\n" +
+	"\x11ignore_moderation\x18\x02 \x01(\bR\x10ignoreModeration\"M\n" +
+	"\x1aListCoinNewsThreadResponse\x12/\n" +
+	"\x06thread\x18\x01 \x01(\v2\x17.misc.v1.CoinNewsThreadR\x06thread\"j\n" +
+	"\x0eCoinNewsThread\x12%\n" +
+	"\x04post\x18\x01 \x01(\v2\x11.misc.v1.CoinNewsR\x04post\x121\n" +
+	"\areplies\x18\x02 \x03(\v2\x17.misc.v1.CoinNewsThreadR\areplies\"R\n" +
+	"\fReactRequest\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x12\x1a\n" +
+	"\breaction\x18\x02 \x01(\tR\breaction\x12\x12\n" +
+	"\x04sign\x18\x03 \x01(\bR\x04sign\";\n" +
+	"\rReactResponse\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\"n\n" +
 	"\x14ListCoinNewsResponse\x12.\n" +
-	"\tcoin_news\x18\x01 \x03(\v2\x11.misc.v1.CoinNewsR\bcoinNews\"O\n" +
+	"\tcoin_news\x18\x01 \x03(\v2\x11.misc.v1.CoinNewsR\bcoinNews\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x97\x01\n" +
 	"\x14TimestampFileRequest\x12\x1a\n" +
 	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x1b\n" +
-	"\tfile_data\x18\x02 \x01(\fR\bfileData\"X\n" +
+	"\tfile_data\x18\x02 \x01(\fR\bfileData\x12\x1b\n" +
+	"\twallet_id\x18\x03 \x01(\tR\bwalletId\x12)\n" +
+	"\x11fee_sat_per_vbyte\x18\x04 \x01(\x04R\x0efeeSatPerVbyte\"X\n" +
 	"\x15TimestampFileResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
 	"\tfile_hash\x18\x02 \x01(\tR\bfileHash\x12\x12\n" +
-	"\x04txid\x18\x03 \x01(\tR\x04txid\"\xdb\x02\n" +
+	"\x04txid\x18\x03 \x01(\tR\x04txid\"\x96\x01\n" +
+	"\x1aTimestampFileStreamRequest\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x14\n" +
+	"\x05chunk\x18\x02 \x01(\fR\x05chunk\x12\x1b\n" +
+	"\twallet_id\x18\x03 \x01(\tR\bwalletId\x12)\n" +
+	"\x11fee_sat_per_vbyte\x18\x04 \x01(\x04R\x0efeeSatPerVbyte\"r\n" +
+	"\x14TimestampPathRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1b\n" +
+	"\twallet_id\x18\x02 \x01(\tR\bwalletId\x12)\n" +
+	"\x11fee_sat_per_vbyte\x18\x03 \x01(\x04R\x0efeeSatPerVbyte\"\x9a\x01\n" +
+	"\x15TimestampPathResponse\x124\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x16.misc.v1.FileTimestampR\ttimestamp\x12\x1f\n" +
+	"\bmanifest\x18\x02 \x01(\tH\x00R\bmanifest\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"file_count\x18\x03 \x01(\x03R\tfileCountB\v\n" +
+	"\t_manifest\"\xb9\x04\n" +
 	"\rFileTimestamp\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
 	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1b\n" +
@@ -1054,29 +3898,123 @@ const file_misc_v1_misc_proto_rawDesc = "" +
 	"\x06status\x18\x06 \x01(\tR\x06status\x129\n" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12B\n" +
-	"\fconfirmed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x02R\vconfirmedAt\x88\x01\x01B\a\n" +
+	"\fconfirmed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x02R\vconfirmedAt\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"block_hash\x18\t \x01(\tH\x03R\tblockHash\x88\x01\x01\x12*\n" +
+	"\x0efailure_reason\x18\n" +
+	" \x01(\tH\x04R\rfailureReason\x88\x01\x01\x12$\n" +
+	"\vmerkle_root\x18\v \x01(\tH\x05R\n" +
+	"merkleRoot\x88\x01\x01\x124\n" +
+	"\vmerkle_path\x18\f \x03(\v2\x13.misc.v1.MerkleStepR\n" +
+	"merklePathB\a\n" +
 	"\x05_txidB\x0f\n" +
 	"\r_block_heightB\x0f\n" +
-	"\r_confirmed_at\"P\n" +
+	"\r_confirmed_atB\r\n" +
+	"\v_block_hashB\x11\n" +
+	"\x0f_failure_reasonB\x0e\n" +
+	"\f_merkle_root\"4\n" +
+	"\n" +
+	"MerkleStep\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\x12\x12\n" +
+	"\x04left\x18\x02 \x01(\bR\x04left\"P\n" +
 	"\x16ListTimestampsResponse\x126\n" +
 	"\n" +
 	"timestamps\x18\x01 \x03(\v2\x16.misc.v1.FileTimestampR\n" +
-	"timestamps\"5\n" +
+	"timestamps\"-\n" +
+	"\x1bRebroadcastTimestampRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"T\n" +
+	"\x1cRebroadcastTimestampResponse\x124\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x16.misc.v1.FileTimestampR\ttimestamp\"-\n" +
+	"\x1bExportTimestampProofRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"P\n" +
+	"\x1cExportTimestampProofResponse\x12\x14\n" +
+	"\x05proof\x18\x01 \x01(\fR\x05proof\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\"P\n" +
+	"\x1bVerifyTimestampProofRequest\x12\x1b\n" +
+	"\tfile_data\x18\x01 \x01(\fR\bfileData\x12\x14\n" +
+	"\x05proof\x18\x02 \x01(\fR\x05proof\"\xb8\x01\n" +
+	"\x1cVerifyTimestampProofResponse\x12\x1b\n" +
+	"\tfile_hash\x18\x01 \x01(\tR\bfileHash\x12!\n" +
+	"\fblock_height\x18\x02 \x01(\rR\vblockHeight\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x03 \x01(\tR\tblockHash\x129\n" +
+	"\n" +
+	"block_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tblockTime\"\xc0\x01\n" +
 	"\x16VerifyTimestampRequest\x12\x1b\n" +
-	"\tfile_data\x18\x01 \x01(\fR\bfileData\"i\n" +
+	"\tfile_data\x18\x01 \x01(\fR\bfileData\x12\x1d\n" +
+	"\n" +
+	"chain_only\x18\x02 \x01(\bR\tchainOnly\x12&\n" +
+	"\fstart_height\x18\x03 \x01(\rH\x00R\vstartHeight\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"end_height\x18\x04 \x01(\rH\x01R\tendHeight\x88\x01\x01B\x0f\n" +
+	"\r_start_heightB\r\n" +
+	"\v_end_height\"i\n" +
 	"\x17VerifyTimestampResponse\x124\n" +
 	"\ttimestamp\x18\x01 \x01(\v2\x16.misc.v1.FileTimestampR\ttimestamp\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xef\x04\n" +
-	"\vMiscService\x12E\n" +
-	"\fListOPReturn\x12\x16.google.protobuf.Empty\x1a\x1d.misc.v1.ListOPReturnResponse\x12N\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"H\n" +
+	"\x15WatchOPReturnsRequest\x12/\n" +
+	"\tprotocols\x18\x01 \x03(\x0e2\x11.misc.v1.ProtocolR\tprotocols\"s\n" +
+	"\x16WatchOPReturnsResponse\x12.\n" +
+	"\top_return\x18\x01 \x01(\v2\x11.misc.v1.OPReturnR\bopReturn\x12)\n" +
+	"\x05event\x18\x02 \x01(\x0e2\x13.misc.v1.WatchEventR\x05event\"\xc3\x01\n" +
+	"\x14WatchCoinNewsRequest\x12\x19\n" +
+	"\x05topic\x18\x01 \x01(\tH\x00R\x05topic\x88\x01\x01\x12\x1b\n" +
+	"\x06author\x18\x02 \x01(\tH\x01R\x06author\x88\x01\x01\x12+\n" +
+	"\x11ignore_moderation\x18\x03 \x01(\bR\x10ignoreModeration\x121\n" +
+	"\x14ignore_subscriptions\x18\x04 \x01(\bR\x13ignoreSubscriptionsB\b\n" +
+	"\x06_topicB\t\n" +
+	"\a_author\"r\n" +
+	"\x15WatchCoinNewsResponse\x12.\n" +
+	"\tcoin_news\x18\x01 \x01(\v2\x11.misc.v1.CoinNewsR\bcoinNews\x12)\n" +
+	"\x05event\x18\x02 \x01(\x0e2\x13.misc.v1.WatchEventR\x05event*\x8e\x02\n" +
+	"\bProtocol\x12\x18\n" +
+	"\x14PROTOCOL_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10PROTOCOL_UNKNOWN\x10\x01\x12\x16\n" +
+	"\x12PROTOCOL_COIN_NEWS\x10\x02\x12\x1c\n" +
+	"\x18PROTOCOL_COIN_NEWS_TOPIC\x10\x03\x12!\n" +
+	"\x1dPROTOCOL_COIN_NEWS_MODERATION\x10\x04\x12\x12\n" +
+	"\x0ePROTOCOL_CHUNK\x10\x05\x12\x13\n" +
+	"\x0fPROTOCOL_BIP300\x10\x06\x12\x12\n" +
+	"\x0ePROTOCOL_RUNES\x10\a\x12\x11\n" +
+	"\rPROTOCOL_OMNI\x10\b\x12\x16\n" +
+	"\x12PROTOCOL_TIMESTAMP\x10\t\x12\x11\n" +
+	"\rPROTOCOL_TEXT\x10\n" +
+	"*\x82\x01\n" +
+	"\fCoinNewsSort\x12\x1e\n" +
+	"\x1aCOIN_NEWS_SORT_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15COIN_NEWS_SORT_NEWEST\x10\x01\x12\x1a\n" +
+	"\x16COIN_NEWS_SORT_TOP_DAY\x10\x02\x12\x1b\n" +
+	"\x17COIN_NEWS_SORT_TOP_WEEK\x10\x03*a\n" +
+	"\n" +
+	"WatchEvent\x12\x1b\n" +
+	"\x17WATCH_EVENT_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17WATCH_EVENT_UNCONFIRMED\x10\x01\x12\x19\n" +
+	"\x15WATCH_EVENT_CONFIRMED\x10\x022\x8d\x0f\n" +
+	"\vMiscService\x12K\n" +
+	"\fListOPReturn\x12\x1c.misc.v1.ListOPReturnRequest\x1a\x1d.misc.v1.ListOPReturnResponse\x12N\n" +
 	"\rBroadcastNews\x12\x1d.misc.v1.BroadcastNewsRequest\x1a\x1e.misc.v1.BroadcastNewsResponse\x12H\n" +
 	"\vCreateTopic\x12\x1b.misc.v1.CreateTopicRequest\x1a\x1c.misc.v1.CreateTopicResponse\x12A\n" +
 	"\n" +
 	"ListTopics\x12\x16.google.protobuf.Empty\x1a\x1b.misc.v1.ListTopicsResponse\x12K\n" +
-	"\fListCoinNews\x12\x1c.misc.v1.ListCoinNewsRequest\x1a\x1d.misc.v1.ListCoinNewsResponse\x12N\n" +
-	"\rTimestampFile\x12\x1d.misc.v1.TimestampFileRequest\x1a\x1e.misc.v1.TimestampFileResponse\x12I\n" +
+	"\fListCoinNews\x12\x1c.misc.v1.ListCoinNewsRequest\x1a\x1d.misc.v1.ListCoinNewsResponse\x12]\n" +
+	"\x12ListCoinNewsThread\x12\".misc.v1.ListCoinNewsThreadRequest\x1a#.misc.v1.ListCoinNewsThreadResponse\x126\n" +
+	"\x05React\x12\x15.misc.v1.ReactRequest\x1a\x16.misc.v1.ReactResponse\x12N\n" +
+	"\rModerateTopic\x12\x1d.misc.v1.ModerateTopicRequest\x1a\x1e.misc.v1.ModerateTopicResponse\x12c\n" +
+	"\x14SetTopicSubscription\x12$.misc.v1.SetTopicSubscriptionRequest\x1a%.misc.v1.SetTopicSubscriptionResponse\x12K\n" +
+	"\fExportTopics\x12\x1c.misc.v1.ExportTopicsRequest\x1a\x1d.misc.v1.ExportTopicsResponse\x12K\n" +
+	"\fImportTopics\x12\x1c.misc.v1.ImportTopicsRequest\x1a\x1d.misc.v1.ImportTopicsResponse\x12S\n" +
+	"\x0eWatchOPReturns\x12\x1e.misc.v1.WatchOPReturnsRequest\x1a\x1f.misc.v1.WatchOPReturnsResponse0\x01\x12P\n" +
+	"\rWatchCoinNews\x12\x1d.misc.v1.WatchCoinNewsRequest\x1a\x1e.misc.v1.WatchCoinNewsResponse0\x01\x12W\n" +
+	"\x10BroadcastChunked\x12 .misc.v1.BroadcastChunkedRequest\x1a!.misc.v1.BroadcastChunkedResponse\x12S\n" +
+	"\x13ListChunkedMessages\x12\x16.google.protobuf.Empty\x1a$.misc.v1.ListChunkedMessagesResponse\x12N\n" +
+	"\rTimestampFile\x12\x1d.misc.v1.TimestampFileRequest\x1a\x1e.misc.v1.TimestampFileResponse\x12\\\n" +
+	"\x13TimestampFileStream\x12#.misc.v1.TimestampFileStreamRequest\x1a\x1e.misc.v1.TimestampFileResponse(\x01\x12N\n" +
+	"\rTimestampPath\x12\x1d.misc.v1.TimestampPathRequest\x1a\x1e.misc.v1.TimestampPathResponse\x12I\n" +
 	"\x0eListTimestamps\x12\x16.google.protobuf.Empty\x1a\x1f.misc.v1.ListTimestampsResponse\x12T\n" +
-	"\x0fVerifyTimestamp\x12\x1f.misc.v1.VerifyTimestampRequest\x1a .misc.v1.VerifyTimestampResponseB\x9c\x01\n" +
+	"\x0fVerifyTimestamp\x12\x1f.misc.v1.VerifyTimestampRequest\x1a .misc.v1.VerifyTimestampResponse\x12c\n" +
+	"\x14RebroadcastTimestamp\x12$.misc.v1.RebroadcastTimestampRequest\x1a%.misc.v1.RebroadcastTimestampResponse\x12c\n" +
+	"\x14ExportTimestampProof\x12$.misc.v1.ExportTimestampProofRequest\x1a%.misc.v1.ExportTimestampProofResponse\x12c\n" +
+	"\x14VerifyTimestampProof\x12$.misc.v1.VerifyTimestampProofRequest\x1a%.misc.v1.VerifyTimestampProofResponseB\x9c\x01\n" +
 	"\vcom.misc.v1B\tMiscProtoP\x01ZEgithub.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/misc/v1;miscv1\xa2\x02\x03MXX\xaa\x02\aMisc.V1\xca\x02\aMisc\\V1\xe2\x02\x13Misc\\V1\\GPBMetadata\xea\x02\bMisc::V1b\x06proto3"
 
 var (
@@ -1091,60 +4029,158 @@ func file_misc_v1_misc_proto_rawDescGZIP() []byte {
 	return file_misc_v1_misc_proto_rawDescData
 }
 
-var file_misc_v1_misc_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_misc_v1_misc_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_misc_v1_misc_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
 var file_misc_v1_misc_proto_goTypes = []any{
-	(*ListOPReturnResponse)(nil),    // 0: misc.v1.ListOPReturnResponse
-	(*OPReturn)(nil),                // 1: misc.v1.OPReturn
-	(*BroadcastNewsRequest)(nil),    // 2: misc.v1.BroadcastNewsRequest
-	(*BroadcastNewsResponse)(nil),   // 3: misc.v1.BroadcastNewsResponse
-	(*CreateTopicRequest)(nil),      // 4: misc.v1.CreateTopicRequest
-	(*CreateTopicResponse)(nil),     // 5: misc.v1.CreateTopicResponse
-	(*Topic)(nil),                   // 6: misc.v1.Topic
-	(*ListTopicsResponse)(nil),      // 7: misc.v1.ListTopicsResponse
-	(*ListCoinNewsRequest)(nil),     // 8: misc.v1.ListCoinNewsRequest
-	(*CoinNews)(nil),                // 9: misc.v1.CoinNews
-	(*ListCoinNewsResponse)(nil),    // 10: misc.v1.ListCoinNewsResponse
-	(*TimestampFileRequest)(nil),    // 11: misc.v1.TimestampFileRequest
-	(*TimestampFileResponse)(nil),   // 12: misc.v1.TimestampFileResponse
-	(*FileTimestamp)(nil),           // 13: misc.v1.FileTimestamp
-	(*ListTimestampsResponse)(nil),  // 14: misc.v1.ListTimestampsResponse
-	(*VerifyTimestampRequest)(nil),  // 15: misc.v1.VerifyTimestampRequest
-	(*VerifyTimestampResponse)(nil), // 16: misc.v1.VerifyTimestampResponse
-	(*timestamppb.Timestamp)(nil),   // 17: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),           // 18: google.protobuf.Empty
+	(Protocol)(0),                        // 0: misc.v1.Protocol
+	(CoinNewsSort)(0),                    // 1: misc.v1.CoinNewsSort
+	(WatchEvent)(0),                      // 2: misc.v1.WatchEvent
+	(OPReturn_Status)(0),                 // 3: misc.v1.OPReturn.Status
+	(*SearchFilter)(nil),                 // 4: misc.v1.SearchFilter
+	(*ListOPReturnRequest)(nil),          // 5: misc.v1.ListOPReturnRequest
+	(*ListOPReturnResponse)(nil),         // 6: misc.v1.ListOPReturnResponse
+	(*OPReturn)(nil),                     // 7: misc.v1.OPReturn
+	(*BroadcastNewsRequest)(nil),         // 8: misc.v1.BroadcastNewsRequest
+	(*BroadcastNewsResponse)(nil),        // 9: misc.v1.BroadcastNewsResponse
+	(*CreateTopicRequest)(nil),           // 10: misc.v1.CreateTopicRequest
+	(*CreateTopicResponse)(nil),          // 11: misc.v1.CreateTopicResponse
+	(*Topic)(nil),                        // 12: misc.v1.Topic
+	(*TopicSubscription)(nil),            // 13: misc.v1.TopicSubscription
+	(*SetTopicSubscriptionRequest)(nil),  // 14: misc.v1.SetTopicSubscriptionRequest
+	(*SetTopicSubscriptionResponse)(nil), // 15: misc.v1.SetTopicSubscriptionResponse
+	(*ExportTopicsRequest)(nil),          // 16: misc.v1.ExportTopicsRequest
+	(*ExportTopicsResponse)(nil),         // 17: misc.v1.ExportTopicsResponse
+	(*ImportTopicsRequest)(nil),          // 18: misc.v1.ImportTopicsRequest
+	(*ImportTopicsResponse)(nil),         // 19: misc.v1.ImportTopicsResponse
+	(*ModerateTopicRequest)(nil),         // 20: misc.v1.ModerateTopicRequest
+	(*ModerateTopicResponse)(nil),        // 21: misc.v1.ModerateTopicResponse
+	(*BroadcastChunkedRequest)(nil),      // 22: misc.v1.BroadcastChunkedRequest
+	(*BroadcastChunkedResponse)(nil),     // 23: misc.v1.BroadcastChunkedResponse
+	(*ChunkedMessage)(nil),               // 24: misc.v1.ChunkedMessage
+	(*ListChunkedMessagesResponse)(nil),  // 25: misc.v1.ListChunkedMessagesResponse
+	(*ListTopicsResponse)(nil),           // 26: misc.v1.ListTopicsResponse
+	(*ListCoinNewsRequest)(nil),          // 27: misc.v1.ListCoinNewsRequest
+	(*CoinNews)(nil),                     // 28: misc.v1.CoinNews
+	(*ListCoinNewsThreadRequest)(nil),    // 29: misc.v1.ListCoinNewsThreadRequest
+	(*ListCoinNewsThreadResponse)(nil),   // 30: misc.v1.ListCoinNewsThreadResponse
+	(*CoinNewsThread)(nil),               // 31: misc.v1.CoinNewsThread
+	(*ReactRequest)(nil),                 // 32: misc.v1.ReactRequest
+	(*ReactResponse)(nil),                // 33: misc.v1.ReactResponse
+	(*ListCoinNewsResponse)(nil),         // 34: misc.v1.ListCoinNewsResponse
+	(*TimestampFileRequest)(nil),         // 35: misc.v1.TimestampFileRequest
+	(*TimestampFileResponse)(nil),        // 36: misc.v1.TimestampFileResponse
+	(*TimestampFileStreamRequest)(nil),   // 37: misc.v1.TimestampFileStreamRequest
+	(*TimestampPathRequest)(nil),         // 38: misc.v1.TimestampPathRequest
+	(*TimestampPathResponse)(nil),        // 39: misc.v1.TimestampPathResponse
+	(*FileTimestamp)(nil),                // 40: misc.v1.FileTimestamp
+	(*MerkleStep)(nil),                   // 41: misc.v1.MerkleStep
+	(*ListTimestampsResponse)(nil),       // 42: misc.v1.ListTimestampsResponse
+	(*RebroadcastTimestampRequest)(nil),  // 43: misc.v1.RebroadcastTimestampRequest
+	(*RebroadcastTimestampResponse)(nil), // 44: misc.v1.RebroadcastTimestampResponse
+	(*ExportTimestampProofRequest)(nil),  // 45: misc.v1.ExportTimestampProofRequest
+	(*ExportTimestampProofResponse)(nil), // 46: misc.v1.ExportTimestampProofResponse
+	(*VerifyTimestampProofRequest)(nil),  // 47: misc.v1.VerifyTimestampProofRequest
+	(*VerifyTimestampProofResponse)(nil), // 48: misc.v1.VerifyTimestampProofResponse
+	(*VerifyTimestampRequest)(nil),       // 49: misc.v1.VerifyTimestampRequest
+	(*VerifyTimestampResponse)(nil),      // 50: misc.v1.VerifyTimestampResponse
+	(*WatchOPReturnsRequest)(nil),        // 51: misc.v1.WatchOPReturnsRequest
+	(*WatchOPReturnsResponse)(nil),       // 52: misc.v1.WatchOPReturnsResponse
+	(*WatchCoinNewsRequest)(nil),         // 53: misc.v1.WatchCoinNewsRequest
+	(*WatchCoinNewsResponse)(nil),        // 54: misc.v1.WatchCoinNewsResponse
+	nil,                                  // 55: misc.v1.OPReturn.FieldsEntry
+	nil,                                  // 56: misc.v1.CoinNews.ReactionsEntry
+	(*timestamppb.Timestamp)(nil),        // 57: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                // 58: google.protobuf.Empty
 }
 var file_misc_v1_misc_proto_depIdxs = []int32{
-	1,  // 0: misc.v1.ListOPReturnResponse.op_returns:type_name -> misc.v1.OPReturn
-	17, // 1: misc.v1.OPReturn.create_time:type_name -> google.protobuf.Timestamp
-	17, // 2: misc.v1.Topic.create_time:type_name -> google.protobuf.Timestamp
-	6,  // 3: misc.v1.ListTopicsResponse.topics:type_name -> misc.v1.Topic
-	17, // 4: misc.v1.CoinNews.create_time:type_name -> google.protobuf.Timestamp
-	9,  // 5: misc.v1.ListCoinNewsResponse.coin_news:type_name -> misc.v1.CoinNews
-	17, // 6: misc.v1.FileTimestamp.created_at:type_name -> google.protobuf.Timestamp
-	17, // 7: misc.v1.FileTimestamp.confirmed_at:type_name -> google.protobuf.Timestamp
-	13, // 8: misc.v1.ListTimestampsResponse.timestamps:type_name -> misc.v1.FileTimestamp
-	13, // 9: misc.v1.VerifyTimestampResponse.timestamp:type_name -> misc.v1.FileTimestamp
-	18, // 10: misc.v1.MiscService.ListOPReturn:input_type -> google.protobuf.Empty
-	2,  // 11: misc.v1.MiscService.BroadcastNews:input_type -> misc.v1.BroadcastNewsRequest
-	4,  // 12: misc.v1.MiscService.CreateTopic:input_type -> misc.v1.CreateTopicRequest
-	18, // 13: misc.v1.MiscService.ListTopics:input_type -> google.protobuf.Empty
-	8,  // 14: misc.v1.MiscService.ListCoinNews:input_type -> misc.v1.ListCoinNewsRequest
-	11, // 15: misc.v1.MiscService.TimestampFile:input_type -> misc.v1.TimestampFileRequest
-	18, // 16: misc.v1.MiscService.ListTimestamps:input_type -> google.protobuf.Empty
-	15, // 17: misc.v1.MiscService.VerifyTimestamp:input_type -> misc.v1.VerifyTimestampRequest
-	0,  // 18: misc.v1.MiscService.ListOPReturn:output_type -> misc.v1.ListOPReturnResponse
-	3,  // 19: misc.v1.MiscService.BroadcastNews:output_type -> misc.v1.BroadcastNewsResponse
-	5,  // 20: misc.v1.MiscService.CreateTopic:output_type -> misc.v1.CreateTopicResponse
-	7,  // 21: misc.v1.MiscService.ListTopics:output_type -> misc.v1.ListTopicsResponse
-	10, // 22: misc.v1.MiscService.ListCoinNews:output_type -> misc.v1.ListCoinNewsResponse
-	12, // 23: misc.v1.MiscService.TimestampFile:output_type -> misc.v1.TimestampFileResponse
-	14, // 24: misc.v1.MiscService.ListTimestamps:output_type -> misc.v1.ListTimestampsResponse
-	16, // 25: misc.v1.MiscService.VerifyTimestamp:output_type -> misc.v1.VerifyTimestampResponse
-	18, // [18:26] is the sub-list for method output_type
-	10, // [10:18] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	57, // 0: misc.v1.SearchFilter.start_time:type_name -> google.protobuf.Timestamp
+	57, // 1: misc.v1.SearchFilter.end_time:type_name -> google.protobuf.Timestamp
+	0,  // 2: misc.v1.ListOPReturnRequest.protocols:type_name -> misc.v1.Protocol
+	4,  // 3: misc.v1.ListOPReturnRequest.filter:type_name -> misc.v1.SearchFilter
+	7,  // 4: misc.v1.ListOPReturnResponse.op_returns:type_name -> misc.v1.OPReturn
+	57, // 5: misc.v1.OPReturn.create_time:type_name -> google.protobuf.Timestamp
+	3,  // 6: misc.v1.OPReturn.status:type_name -> misc.v1.OPReturn.Status
+	0,  // 7: misc.v1.OPReturn.protocol:type_name -> misc.v1.Protocol
+	55, // 8: misc.v1.OPReturn.fields:type_name -> misc.v1.OPReturn.FieldsEntry
+	57, // 9: misc.v1.Topic.create_time:type_name -> google.protobuf.Timestamp
+	13, // 10: misc.v1.Topic.subscription:type_name -> misc.v1.TopicSubscription
+	13, // 11: misc.v1.SetTopicSubscriptionRequest.subscription:type_name -> misc.v1.TopicSubscription
+	12, // 12: misc.v1.SetTopicSubscriptionResponse.topic:type_name -> misc.v1.Topic
+	57, // 13: misc.v1.ChunkedMessage.create_time:type_name -> google.protobuf.Timestamp
+	24, // 14: misc.v1.ListChunkedMessagesResponse.messages:type_name -> misc.v1.ChunkedMessage
+	12, // 15: misc.v1.ListTopicsResponse.topics:type_name -> misc.v1.Topic
+	4,  // 16: misc.v1.ListCoinNewsRequest.filter:type_name -> misc.v1.SearchFilter
+	1,  // 17: misc.v1.ListCoinNewsRequest.sort:type_name -> misc.v1.CoinNewsSort
+	57, // 18: misc.v1.CoinNews.create_time:type_name -> google.protobuf.Timestamp
+	56, // 19: misc.v1.CoinNews.reactions:type_name -> misc.v1.CoinNews.ReactionsEntry
+	31, // 20: misc.v1.ListCoinNewsThreadResponse.thread:type_name -> misc.v1.CoinNewsThread
+	28, // 21: misc.v1.CoinNewsThread.post:type_name -> misc.v1.CoinNews
+	31, // 22: misc.v1.CoinNewsThread.replies:type_name -> misc.v1.CoinNewsThread
+	28, // 23: misc.v1.ListCoinNewsResponse.coin_news:type_name -> misc.v1.CoinNews
+	40, // 24: misc.v1.TimestampPathResponse.timestamp:type_name -> misc.v1.FileTimestamp
+	57, // 25: misc.v1.FileTimestamp.created_at:type_name -> google.protobuf.Timestamp
+	57, // 26: misc.v1.FileTimestamp.confirmed_at:type_name -> google.protobuf.Timestamp
+	41, // 27: misc.v1.FileTimestamp.merkle_path:type_name -> misc.v1.MerkleStep
+	40, // 28: misc.v1.ListTimestampsResponse.timestamps:type_name -> misc.v1.FileTimestamp
+	40, // 29: misc.v1.RebroadcastTimestampResponse.timestamp:type_name -> misc.v1.FileTimestamp
+	57, // 30: misc.v1.VerifyTimestampProofResponse.block_time:type_name -> google.protobuf.Timestamp
+	40, // 31: misc.v1.VerifyTimestampResponse.timestamp:type_name -> misc.v1.FileTimestamp
+	0,  // 32: misc.v1.WatchOPReturnsRequest.protocols:type_name -> misc.v1.Protocol
+	7,  // 33: misc.v1.WatchOPReturnsResponse.op_return:type_name -> misc.v1.OPReturn
+	2,  // 34: misc.v1.WatchOPReturnsResponse.event:type_name -> misc.v1.WatchEvent
+	28, // 35: misc.v1.WatchCoinNewsResponse.coin_news:type_name -> misc.v1.CoinNews
+	2,  // 36: misc.v1.WatchCoinNewsResponse.event:type_name -> misc.v1.WatchEvent
+	5,  // 37: misc.v1.MiscService.ListOPReturn:input_type -> misc.v1.ListOPReturnRequest
+	8,  // 38: misc.v1.MiscService.BroadcastNews:input_type -> misc.v1.BroadcastNewsRequest
+	10, // 39: misc.v1.MiscService.CreateTopic:input_type -> misc.v1.CreateTopicRequest
+	58, // 40: misc.v1.MiscService.ListTopics:input_type -> google.protobuf.Empty
+	27, // 41: misc.v1.MiscService.ListCoinNews:input_type -> misc.v1.ListCoinNewsRequest
+	29, // 42: misc.v1.MiscService.ListCoinNewsThread:input_type -> misc.v1.ListCoinNewsThreadRequest
+	32, // 43: misc.v1.MiscService.React:input_type -> misc.v1.ReactRequest
+	20, // 44: misc.v1.MiscService.ModerateTopic:input_type -> misc.v1.ModerateTopicRequest
+	14, // 45: misc.v1.MiscService.SetTopicSubscription:input_type -> misc.v1.SetTopicSubscriptionRequest
+	16, // 46: misc.v1.MiscService.ExportTopics:input_type -> misc.v1.ExportTopicsRequest
+	18, // 47: misc.v1.MiscService.ImportTopics:input_type -> misc.v1.ImportTopicsRequest
+	51, // 48: misc.v1.MiscService.WatchOPReturns:input_type -> misc.v1.WatchOPReturnsRequest
+	53, // 49: misc.v1.MiscService.WatchCoinNews:input_type -> misc.v1.WatchCoinNewsRequest
+	22, // 50: misc.v1.MiscService.BroadcastChunked:input_type -> misc.v1.BroadcastChunkedRequest
+	58, // 51: misc.v1.MiscService.ListChunkedMessages:input_type -> google.protobuf.Empty
+	35, // 52: misc.v1.MiscService.TimestampFile:input_type -> misc.v1.TimestampFileRequest
+	37, // 53: misc.v1.MiscService.TimestampFileStream:input_type -> misc.v1.TimestampFileStreamRequest
+	38, // 54: misc.v1.MiscService.TimestampPath:input_type -> misc.v1.TimestampPathRequest
+	58, // 55: misc.v1.MiscService.ListTimestamps:input_type -> google.protobuf.Empty
+	49, // 56: misc.v1.MiscService.VerifyTimestamp:input_type -> misc.v1.VerifyTimestampRequest
+	43, // 57: misc.v1.MiscService.RebroadcastTimestamp:input_type -> misc.v1.RebroadcastTimestampRequest
+	45, // 58: misc.v1.MiscService.ExportTimestampProof:input_type -> misc.v1.ExportTimestampProofRequest
+	47, // 59: misc.v1.MiscService.VerifyTimestampProof:input_type -> misc.v1.VerifyTimestampProofRequest
+	6,  // 60: misc.v1.MiscService.ListOPReturn:output_type -> misc.v1.ListOPReturnResponse
+	9,  // 61: misc.v1.MiscService.BroadcastNews:output_type -> misc.v1.BroadcastNewsResponse
+	11, // 62: misc.v1.MiscService.CreateTopic:output_type -> misc.v1.CreateTopicResponse
+	26, // 63: misc.v1.MiscService.ListTopics:output_type -> misc.v1.ListTopicsResponse
+	34, // 64: misc.v1.MiscService.ListCoinNews:output_type -> misc.v1.ListCoinNewsResponse
+	30, // 65: misc.v1.MiscService.ListCoinNewsThread:output_type -> misc.v1.ListCoinNewsThreadResponse
+	33, // 66: misc.v1.MiscService.React:output_type -> misc.v1.ReactResponse
+	21, // 67: misc.v1.MiscService.ModerateTopic:output_type -> misc.v1.ModerateTopicResponse
+	15, // 68: misc.v1.MiscService.SetTopicSubscription:output_type -> misc.v1.SetTopicSubscriptionResponse
+	17, // 69: misc.v1.MiscService.ExportTopics:output_type -> misc.v1.ExportTopicsResponse
+	19, // 70: misc.v1.MiscService.ImportTopics:output_type -> misc.v1.ImportTopicsResponse
+	52, // 71: misc.v1.MiscService.WatchOPReturns:output_type -> misc.v1.WatchOPReturnsResponse
+	54, // 72: misc.v1.MiscService.WatchCoinNews:output_type -> misc.v1.WatchCoinNewsResponse
+	23, // 73: misc.v1.MiscService.BroadcastChunked:output_type -> misc.v1.BroadcastChunkedResponse
+	25, // 74: misc.v1.MiscService.ListChunkedMessages:output_type -> misc.v1.ListChunkedMessagesResponse
+	36, // 75: misc.v1.MiscService.TimestampFile:output_type -> misc.v1.TimestampFileResponse
+	36, // 76: misc.v1.MiscService.TimestampFileStream:output_type -> misc.v1.TimestampFileResponse
+	39, // 77: misc.v1.MiscService.TimestampPath:output_type -> misc.v1.TimestampPathResponse
+	42, // 78: misc.v1.MiscService.ListTimestamps:output_type -> misc.v1.ListTimestampsResponse
+	50, // 79: misc.v1.MiscService.VerifyTimestamp:output_type -> misc.v1.VerifyTimestampResponse
+	44, // 80: misc.v1.MiscService.RebroadcastTimestamp:output_type -> misc.v1.RebroadcastTimestampResponse
+	46, // 81: misc.v1.MiscService.ExportTimestampProof:output_type -> misc.v1.ExportTimestampProofResponse
+	48, // 82: misc.v1.MiscService.VerifyTimestampProof:output_type -> misc.v1.VerifyTimestampProofResponse
+	60, // [60:83] is the sub-list for method output_type
+	37, // [37:60] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_misc_v1_misc_proto_init() }
@@ -1152,21 +4188,36 @@ func file_misc_v1_misc_proto_init() {
 	if File_misc_v1_misc_proto != nil {
 		return
 	}
-	file_misc_v1_misc_proto_msgTypes[1].OneofWrappers = []any{}
-	file_misc_v1_misc_proto_msgTypes[8].OneofWrappers = []any{}
-	file_misc_v1_misc_proto_msgTypes[13].OneofWrappers = []any{}
+	file_misc_v1_misc_proto_msgTypes[0].OneofWrappers = []any{}
+	file_misc_v1_misc_proto_msgTypes[3].OneofWrappers = []any{}
+	file_misc_v1_misc_proto_msgTypes[4].OneofWrappers = []any{}
+	file_misc_v1_misc_proto_msgTypes[16].OneofWrappers = []any{
+		(*ModerateTopicRequest_Rename)(nil),
+		(*ModerateTopicRequest_AddPoster)(nil),
+		(*ModerateTopicRequest_RemovePoster)(nil),
+		(*ModerateTopicRequest_HideTxid)(nil),
+	}
+	file_misc_v1_misc_proto_msgTypes[18].OneofWrappers = []any{}
+	file_misc_v1_misc_proto_msgTypes[20].OneofWrappers = []any{}
+	file_misc_v1_misc_proto_msgTypes[23].OneofWrappers = []any{}
+	file_misc_v1_misc_proto_msgTypes[24].OneofWrappers = []any{}
+	file_misc_v1_misc_proto_msgTypes[35].OneofWrappers = []any{}
+	file_misc_v1_misc_proto_msgTypes[36].OneofWrappers = []any{}
+	file_misc_v1_misc_proto_msgTypes[45].OneofWrappers = []any{}
+	file_misc_v1_misc_proto_msgTypes[49].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_misc_v1_misc_proto_rawDesc), len(file_misc_v1_misc_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   17,
+			NumEnums:      4,
+			NumMessages:   53,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_misc_v1_misc_proto_goTypes,
 		DependencyIndexes: file_misc_v1_misc_proto_depIdxs,
+		EnumInfos:         file_misc_v1_misc_proto_enumTypes,
 		MessageInfos:      file_misc_v1_misc_proto_msgTypes,
 	}.Build()
 	File_misc_v1_misc_proto = out.File
@@ -47,28 +47,105 @@ const (
 	// MiscServiceListCoinNewsProcedure is the fully-qualified name of the MiscService's ListCoinNews
 	// RPC.
 	MiscServiceListCoinNewsProcedure = "/misc.v1.MiscService/ListCoinNews"
+	// MiscServiceListCoinNewsThreadProcedure is the fully-qualified name of the MiscService's
+	// ListCoinNewsThread RPC.
+	MiscServiceListCoinNewsThreadProcedure = "/misc.v1.MiscService/ListCoinNewsThread"
+	// MiscServiceReactProcedure is the fully-qualified name of the MiscService's React RPC.
+	MiscServiceReactProcedure = "/misc.v1.MiscService/React"
+	// MiscServiceModerateTopicProcedure is the fully-qualified name of the MiscService's ModerateTopic
+	// RPC.
+	MiscServiceModerateTopicProcedure = "/misc.v1.MiscService/ModerateTopic"
+	// MiscServiceSetTopicSubscriptionProcedure is the fully-qualified name of the MiscService's
+	// SetTopicSubscription RPC.
+	MiscServiceSetTopicSubscriptionProcedure = "/misc.v1.MiscService/SetTopicSubscription"
+	// MiscServiceExportTopicsProcedure is the fully-qualified name of the MiscService's ExportTopics
+	// RPC.
+	MiscServiceExportTopicsProcedure = "/misc.v1.MiscService/ExportTopics"
+	// MiscServiceImportTopicsProcedure is the fully-qualified name of the MiscService's ImportTopics
+	// RPC.
+	MiscServiceImportTopicsProcedure = "/misc.v1.MiscService/ImportTopics"
+	// MiscServiceWatchOPReturnsProcedure is the fully-qualified name of the MiscService's
+	// WatchOPReturns RPC.
+	MiscServiceWatchOPReturnsProcedure = "/misc.v1.MiscService/WatchOPReturns"
+	// MiscServiceWatchCoinNewsProcedure is the fully-qualified name of the MiscService's WatchCoinNews
+	// RPC.
+	MiscServiceWatchCoinNewsProcedure = "/misc.v1.MiscService/WatchCoinNews"
+	// MiscServiceBroadcastChunkedProcedure is the fully-qualified name of the MiscService's
+	// BroadcastChunked RPC.
+	MiscServiceBroadcastChunkedProcedure = "/misc.v1.MiscService/BroadcastChunked"
+	// MiscServiceListChunkedMessagesProcedure is the fully-qualified name of the MiscService's
+	// ListChunkedMessages RPC.
+	MiscServiceListChunkedMessagesProcedure = "/misc.v1.MiscService/ListChunkedMessages"
 	// MiscServiceTimestampFileProcedure is the fully-qualified name of the MiscService's TimestampFile
 	// RPC.
 	MiscServiceTimestampFileProcedure = "/misc.v1.MiscService/TimestampFile"
+	// MiscServiceTimestampFileStreamProcedure is the fully-qualified name of the MiscService's
+	// TimestampFileStream RPC.
+	MiscServiceTimestampFileStreamProcedure = "/misc.v1.MiscService/TimestampFileStream"
+	// MiscServiceTimestampPathProcedure is the fully-qualified name of the MiscService's TimestampPath
+	// RPC.
+	MiscServiceTimestampPathProcedure = "/misc.v1.MiscService/TimestampPath"
 	// MiscServiceListTimestampsProcedure is the fully-qualified name of the MiscService's
 	// ListTimestamps RPC.
 	MiscServiceListTimestampsProcedure = "/misc.v1.MiscService/ListTimestamps"
 	// MiscServiceVerifyTimestampProcedure is the fully-qualified name of the MiscService's
 	// VerifyTimestamp RPC.
 	MiscServiceVerifyTimestampProcedure = "/misc.v1.MiscService/VerifyTimestamp"
+	// MiscServiceRebroadcastTimestampProcedure is the fully-qualified name of the MiscService's
+	// RebroadcastTimestamp RPC.
+	MiscServiceRebroadcastTimestampProcedure = "/misc.v1.MiscService/RebroadcastTimestamp"
+	// MiscServiceExportTimestampProofProcedure is the fully-qualified name of the MiscService's
+	// ExportTimestampProof RPC.
+	MiscServiceExportTimestampProofProcedure = "/misc.v1.MiscService/ExportTimestampProof"
+	// MiscServiceVerifyTimestampProofProcedure is the fully-qualified name of the MiscService's
+	// VerifyTimestampProof RPC.
+	MiscServiceVerifyTimestampProofProcedure = "/misc.v1.MiscService/VerifyTimestampProof"
 )
 
 // MiscServiceClient is a client for the misc.v1.MiscService service.
 type MiscServiceClient interface {
-	ListOPReturn(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListOPReturnResponse], error)
+	ListOPReturn(context.Context, *connect.Request[v1.ListOPReturnRequest]) (*connect.Response[v1.ListOPReturnResponse], error)
 	BroadcastNews(context.Context, *connect.Request[v1.BroadcastNewsRequest]) (*connect.Response[v1.BroadcastNewsResponse], error)
 	CreateTopic(context.Context, *connect.Request[v1.CreateTopicRequest]) (*connect.Response[v1.CreateTopicResponse], error)
 	ListTopics(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListTopicsResponse], error)
+	// Only lists posts, replies are listed with ListCoinNewsThread
 	ListCoinNews(context.Context, *connect.Request[v1.ListCoinNewsRequest]) (*connect.Response[v1.ListCoinNewsResponse], error)
+	ListCoinNewsThread(context.Context, *connect.Request[v1.ListCoinNewsThreadRequest]) (*connect.Response[v1.ListCoinNewsThreadResponse], error)
+	React(context.Context, *connect.Request[v1.ReactRequest]) (*connect.Response[v1.ReactResponse], error)
+	// Only works for topics owned by this wallet's news key
+	ModerateTopic(context.Context, *connect.Request[v1.ModerateTopicRequest]) (*connect.Response[v1.ModerateTopicResponse], error)
+	SetTopicSubscription(context.Context, *connect.Request[v1.SetTopicSubscriptionRequest]) (*connect.Response[v1.SetTopicSubscriptionResponse], error)
+	// Topic sets are JSON, for sharing a curated feed:
+	// {"version": 1, "topics": [{"topic": "<hex>", "name": "...", "txid": "<hex>"}]}
+	ExportTopics(context.Context, *connect.Request[v1.ExportTopicsRequest]) (*connect.Response[v1.ExportTopicsResponse], error)
+	// Adds the topics that aren't known yet, and subscribes to all of them
+	ImportTopics(context.Context, *connect.Request[v1.ImportTopicsRequest]) (*connect.Response[v1.ImportTopicsResponse], error)
+	// Pushes OP_RETURNs and coin news as they're seen, first from the
+	// mempool and again once confirmed. Only new ones, list the existing
+	// ones first.
+	WatchOPReturns(context.Context, *connect.Request[v1.WatchOPReturnsRequest]) (*connect.ServerStreamForClient[v1.WatchOPReturnsResponse], error)
+	WatchCoinNews(context.Context, *connect.Request[v1.WatchCoinNewsRequest]) (*connect.ServerStreamForClient[v1.WatchCoinNewsResponse], error)
+	// Payloads too large for one OP_RETURN, split across several transactions
+	BroadcastChunked(context.Context, *connect.Request[v1.BroadcastChunkedRequest]) (*connect.Response[v1.BroadcastChunkedResponse], error)
+	ListChunkedMessages(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListChunkedMessagesResponse], error)
 	// File timestamping
 	TimestampFile(context.Context, *connect.Request[v1.TimestampFileRequest]) (*connect.Response[v1.TimestampFileResponse], error)
+	// Like TimestampFile, but the file is sent in chunks and hashed as they
+	// arrive, for files too large to send in one message
+	TimestampFileStream(context.Context) *connect.ClientStreamForClient[v1.TimestampFileStreamRequest, v1.TimestampFileResponse]
+	// Timestamp a file or directory on the machine bitwindowd runs on. Only
+	// allowed for callers on the same machine
+	TimestampPath(context.Context, *connect.Request[v1.TimestampPathRequest]) (*connect.Response[v1.TimestampPathResponse], error)
 	ListTimestamps(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListTimestampsResponse], error)
 	VerifyTimestamp(context.Context, *connect.Request[v1.VerifyTimestampRequest]) (*connect.Response[v1.VerifyTimestampResponse], error)
+	// Sends a new transaction for a failed timestamp
+	RebroadcastTimestamp(context.Context, *connect.Request[v1.RebroadcastTimestampRequest]) (*connect.Response[v1.RebroadcastTimestampResponse], error)
+	// Export an OpenTimestamps proof of a confirmed timestamp, verifiable by
+	// anyone with the block headers
+	ExportTimestampProof(context.Context, *connect.Request[v1.ExportTimestampProofRequest]) (*connect.Response[v1.ExportTimestampProofResponse], error)
+	// Verify an OpenTimestamps proof of a file against the chain, whether or
+	// not it was timestamped by us
+	VerifyTimestampProof(context.Context, *connect.Request[v1.VerifyTimestampProofRequest]) (*connect.Response[v1.VerifyTimestampProofResponse], error)
 }
 
 // NewMiscServiceClient constructs a client for the misc.v1.MiscService service. By default, it uses
@@ -82,7 +159,7 @@ func NewMiscServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 	baseURL = strings.TrimRight(baseURL, "/")
 	miscServiceMethods := v1.File_misc_v1_misc_proto.Services().ByName("MiscService").Methods()
 	return &miscServiceClient{
-		listOPReturn: connect.NewClient[emptypb.Empty, v1.ListOPReturnResponse](
+		listOPReturn: connect.NewClient[v1.ListOPReturnRequest, v1.ListOPReturnResponse](
 			httpClient,
 			baseURL+MiscServiceListOPReturnProcedure,
 			connect.WithSchema(miscServiceMethods.ByName("ListOPReturn")),
@@ -112,12 +189,84 @@ func NewMiscServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(miscServiceMethods.ByName("ListCoinNews")),
 			connect.WithClientOptions(opts...),
 		),
+		listCoinNewsThread: connect.NewClient[v1.ListCoinNewsThreadRequest, v1.ListCoinNewsThreadResponse](
+			httpClient,
+			baseURL+MiscServiceListCoinNewsThreadProcedure,
+			connect.WithSchema(miscServiceMethods.ByName("ListCoinNewsThread")),
+			connect.WithClientOptions(opts...),
+		),
+		react: connect.NewClient[v1.ReactRequest, v1.ReactResponse](
+			httpClient,
+			baseURL+MiscServiceReactProcedure,
+			connect.WithSchema(miscServiceMethods.ByName("React")),
+			connect.WithClientOptions(opts...),
+		),
+		moderateTopic: connect.NewClient[v1.ModerateTopicRequest, v1.ModerateTopicResponse](
+			httpClient,
+			baseURL+MiscServiceModerateTopicProcedure,
+			connect.WithSchema(miscServiceMethods.ByName("ModerateTopic")),
+			connect.WithClientOptions(opts...),
+		),
+		setTopicSubscription: connect.NewClient[v1.SetTopicSubscriptionRequest, v1.SetTopicSubscriptionResponse](
+			httpClient,
+			baseURL+MiscServiceSetTopicSubscriptionProcedure,
+			connect.WithSchema(miscServiceMethods.ByName("SetTopicSubscription")),
+			connect.WithClientOptions(opts...),
+		),
+		exportTopics: connect.NewClient[v1.ExportTopicsRequest, v1.ExportTopicsResponse](
+			httpClient,
+			baseURL+MiscServiceExportTopicsProcedure,
+			connect.WithSchema(miscServiceMethods.ByName("ExportTopics")),
+			connect.WithClientOptions(opts...),
+		),
+		importTopics: connect.NewClient[v1.ImportTopicsRequest, v1.ImportTopicsResponse](
+			httpClient,
+			baseURL+MiscServiceImportTopicsProcedure,
+			connect.WithSchema(miscServiceMethods.ByName("ImportTopics")),
+			connect.WithClientOptions(opts...),
+		),
+		watchOPReturns: connect.NewClient[v1.WatchOPReturnsRequest, v1.WatchOPReturnsResponse](
+			httpClient,
+			baseURL+MiscServiceWatchOPReturnsProcedure,
+			connect.WithSchema(miscServiceMethods.ByName("WatchOPReturns")),
+			connect.WithClientOptions(opts...),
+		),
+		watchCoinNews: connect.NewClient[v1.WatchCoinNewsRequest, v1.WatchCoinNewsResponse](
+			httpClient,
+			baseURL+MiscServiceWatchCoinNewsProcedure,
+			connect.WithSchema(miscServiceMethods.ByName("WatchCoinNews")),
+			connect.WithClientOptions(opts...),
+		),
+		broadcastChunked: connect.NewClient[v1.BroadcastChunkedRequest, v1.BroadcastChunkedResponse](
+			httpClient,
+			baseURL+MiscServiceBroadcastChunkedProcedure,
+			connect.WithSchema(miscServiceMethods.ByName("BroadcastChunked")),
+			connect.WithClientOptions(opts...),
+		),
+		listChunkedMessages: connect.NewClient[emptypb.Empty, v1.ListChunkedMessagesResponse](
+			httpClient,
+			baseURL+MiscServiceListChunkedMessagesProcedure,
+			connect.WithSchema(miscServiceMethods.ByName("ListChunkedMessages")),
+			connect.WithClientOptions(opts...),
+		),
 		timestampFile: connect.NewClient[v1.TimestampFileRequest, v1.TimestampFileResponse](
 			httpClient,
 			baseURL+MiscServiceTimestampFileProcedure,
 			connect.WithSchema(miscServiceMethods.ByName("TimestampFile")),
 			connect.WithClientOptions(opts...),
 		),
+		timestampFileStream: connect.NewClient[v1.TimestampFileStreamRequest, v1.TimestampFileResponse](
+			httpClient,
+			baseURL+MiscServiceTimestampFileStreamProcedure,
+			connect.WithSchema(miscServiceMethods.ByName("TimestampFileStream")),
+			connect.WithClientOptions(opts...),
+		),
+		timestampPath: connect.NewClient[v1.TimestampPathRequest, v1.TimestampPathResponse](
+			httpClient,
+			baseURL+MiscServiceTimestampPathProcedure,
+			connect.WithSchema(miscServiceMethods.ByName("TimestampPath")),
+			connect.WithClientOptions(opts...),
+		),
 		listTimestamps: connect.NewClient[emptypb.Empty, v1.ListTimestampsResponse](
 			httpClient,
 			baseURL+MiscServiceListTimestampsProcedure,
@@ -130,23 +279,56 @@ func NewMiscServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(miscServiceMethods.ByName("VerifyTimestamp")),
 			connect.WithClientOptions(opts...),
 		),
+		rebroadcastTimestamp: connect.NewClient[v1.RebroadcastTimestampRequest, v1.RebroadcastTimestampResponse](
+			httpClient,
+			baseURL+MiscServiceRebroadcastTimestampProcedure,
+			connect.WithSchema(miscServiceMethods.ByName("RebroadcastTimestamp")),
+			connect.WithClientOptions(opts...),
+		),
+		exportTimestampProof: connect.NewClient[v1.ExportTimestampProofRequest, v1.ExportTimestampProofResponse](
+			httpClient,
+			baseURL+MiscServiceExportTimestampProofProcedure,
+			connect.WithSchema(miscServiceMethods.ByName("ExportTimestampProof")),
+			connect.WithClientOptions(opts...),
+		),
+		verifyTimestampProof: connect.NewClient[v1.VerifyTimestampProofRequest, v1.VerifyTimestampProofResponse](
+			httpClient,
+			baseURL+MiscServiceVerifyTimestampProofProcedure,
+			connect.WithSchema(miscServiceMethods.ByName("VerifyTimestampProof")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // miscServiceClient implements MiscServiceClient.
 type miscServiceClient struct {
-	listOPReturn    *connect.Client[emptypb.Empty, v1.ListOPReturnResponse]
-	broadcastNews   *connect.Client[v1.BroadcastNewsRequest, v1.BroadcastNewsResponse]
-	createTopic     *connect.Client[v1.CreateTopicRequest, v1.CreateTopicResponse]
-	listTopics      *connect.Client[emptypb.Empty, v1.ListTopicsResponse]
-	listCoinNews    *connect.Client[v1.ListCoinNewsRequest, v1.ListCoinNewsResponse]
-	timestampFile   *connect.Client[v1.TimestampFileRequest, v1.TimestampFileResponse]
-	listTimestamps  *connect.Client[emptypb.Empty, v1.ListTimestampsResponse]
-	verifyTimestamp *connect.Client[v1.VerifyTimestampRequest, v1.VerifyTimestampResponse]
+	listOPReturn         *connect.Client[v1.ListOPReturnRequest, v1.ListOPReturnResponse]
+	broadcastNews        *connect.Client[v1.BroadcastNewsRequest, v1.BroadcastNewsResponse]
+	createTopic          *connect.Client[v1.CreateTopicRequest, v1.CreateTopicResponse]
+	listTopics           *connect.Client[emptypb.Empty, v1.ListTopicsResponse]
+	listCoinNews         *connect.Client[v1.ListCoinNewsRequest, v1.ListCoinNewsResponse]
+	listCoinNewsThread   *connect.Client[v1.ListCoinNewsThreadRequest, v1.ListCoinNewsThreadResponse]
+	react                *connect.Client[v1.ReactRequest, v1.ReactResponse]
+	moderateTopic        *connect.Client[v1.ModerateTopicRequest, v1.ModerateTopicResponse]
+	setTopicSubscription *connect.Client[v1.SetTopicSubscriptionRequest, v1.SetTopicSubscriptionResponse]
+	exportTopics         *connect.Client[v1.ExportTopicsRequest, v1.ExportTopicsResponse]
+	importTopics         *connect.Client[v1.ImportTopicsRequest, v1.ImportTopicsResponse]
+	watchOPReturns       *connect.Client[v1.WatchOPReturnsRequest, v1.WatchOPReturnsResponse]
+	watchCoinNews        *connect.Client[v1.WatchCoinNewsRequest, v1.WatchCoinNewsResponse]
+	broadcastChunked     *connect.Client[v1.BroadcastChunkedRequest, v1.BroadcastChunkedResponse]
+	listChunkedMessages  *connect.Client[emptypb.Empty, v1.ListChunkedMessagesResponse]
+	timestampFile        *connect.Client[v1.TimestampFileRequest, v1.TimestampFileResponse]
+	timestampFileStream  *connect.Client[v1.TimestampFileStreamRequest, v1.TimestampFileResponse]
+	timestampPath        *connect.Client[v1.TimestampPathRequest, v1.TimestampPathResponse]
+	listTimestamps       *connect.Client[emptypb.Empty, v1.ListTimestampsResponse]
+	verifyTimestamp      *connect.Client[v1.VerifyTimestampRequest, v1.VerifyTimestampResponse]
+	rebroadcastTimestamp *connect.Client[v1.RebroadcastTimestampRequest, v1.RebroadcastTimestampResponse]
+	exportTimestampProof *connect.Client[v1.ExportTimestampProofRequest, v1.ExportTimestampProofResponse]
+	verifyTimestampProof *connect.Client[v1.VerifyTimestampProofRequest, v1.VerifyTimestampProofResponse]
 }
 
 // ListOPReturn calls misc.v1.MiscService.ListOPReturn.
-func (c *miscServiceClient) ListOPReturn(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListOPReturnResponse], error) {
+func (c *miscServiceClient) ListOPReturn(ctx context.Context, req *connect.Request[v1.ListOPReturnRequest]) (*connect.Response[v1.ListOPReturnResponse], error) {
 	return c.listOPReturn.CallUnary(ctx, req)
 }
 
@@ -170,11 +352,71 @@ func (c *miscServiceClient) ListCoinNews(ctx context.Context, req *connect.Reque
 	return c.listCoinNews.CallUnary(ctx, req)
 }
 
+// ListCoinNewsThread calls misc.v1.MiscService.ListCoinNewsThread.
+func (c *miscServiceClient) ListCoinNewsThread(ctx context.Context, req *connect.Request[v1.ListCoinNewsThreadRequest]) (*connect.Response[v1.ListCoinNewsThreadResponse], error) {
+	return c.listCoinNewsThread.CallUnary(ctx, req)
+}
+
+// React calls misc.v1.MiscService.React.
+func (c *miscServiceClient) React(ctx context.Context, req *connect.Request[v1.ReactRequest]) (*connect.Response[v1.ReactResponse], error) {
+	return c.react.CallUnary(ctx, req)
+}
+
+// ModerateTopic calls misc.v1.MiscService.ModerateTopic.
+func (c *miscServiceClient) ModerateTopic(ctx context.Context, req *connect.Request[v1.ModerateTopicRequest]) (*connect.Response[v1.ModerateTopicResponse], error) {
+	return c.moderateTopic.CallUnary(ctx, req)
+}
+
+// SetTopicSubscription calls misc.v1.MiscService.SetTopicSubscription.
+func (c *miscServiceClient) SetTopicSubscription(ctx context.Context, req *connect.Request[v1.SetTopicSubscriptionRequest]) (*connect.Response[v1.SetTopicSubscriptionResponse], error) {
+	return c.setTopicSubscription.CallUnary(ctx, req)
+}
+
+// ExportTopics calls misc.v1.MiscService.ExportTopics.
+func (c *miscServiceClient) ExportTopics(ctx context.Context, req *connect.Request[v1.ExportTopicsRequest]) (*connect.Response[v1.ExportTopicsResponse], error) {
+	return c.exportTopics.CallUnary(ctx, req)
+}
+
+// ImportTopics calls misc.v1.MiscService.ImportTopics.
+func (c *miscServiceClient) ImportTopics(ctx context.Context, req *connect.Request[v1.ImportTopicsRequest]) (*connect.Response[v1.ImportTopicsResponse], error) {
+	return c.importTopics.CallUnary(ctx, req)
+}
+
+// WatchOPReturns calls misc.v1.MiscService.WatchOPReturns.
+func (c *miscServiceClient) WatchOPReturns(ctx context.Context, req *connect.Request[v1.WatchOPReturnsRequest]) (*connect.ServerStreamForClient[v1.WatchOPReturnsResponse], error) {
+	return c.watchOPReturns.CallServerStream(ctx, req)
+}
+
+// WatchCoinNews calls misc.v1.MiscService.WatchCoinNews.
+func (c *miscServiceClient) WatchCoinNews(ctx context.Context, req *connect.Request[v1.WatchCoinNewsRequest]) (*connect.ServerStreamForClient[v1.WatchCoinNewsResponse], error) {
+	return c.watchCoinNews.CallServerStream(ctx, req)
+}
+
+// BroadcastChunked calls misc.v1.MiscService.BroadcastChunked.
+func (c *miscServiceClient) BroadcastChunked(ctx context.Context, req *connect.Request[v1.BroadcastChunkedRequest]) (*connect.Response[v1.BroadcastChunkedResponse], error) {
+	return c.broadcastChunked.CallUnary(ctx, req)
+}
+
+// ListChunkedMessages calls misc.v1.MiscService.ListChunkedMessages.
+func (c *miscServiceClient) ListChunkedMessages(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListChunkedMessagesResponse], error) {
+	return c.listChunkedMessages.CallUnary(ctx, req)
+}
+
 // TimestampFile calls misc.v1.MiscService.TimestampFile.
 func (c *miscServiceClient) TimestampFile(ctx context.Context, req *connect.Request[v1.TimestampFileRequest]) (*connect.Response[v1.TimestampFileResponse], error) {
 	return c.timestampFile.CallUnary(ctx, req)
 }
 
+// TimestampFileStream calls misc.v1.MiscService.TimestampFileStream.
+func (c *miscServiceClient) TimestampFileStream(ctx context.Context) *connect.ClientStreamForClient[v1.TimestampFileStreamRequest, v1.TimestampFileResponse] {
+	return c.timestampFileStream.CallClientStream(ctx)
+}
+
+// TimestampPath calls misc.v1.MiscService.TimestampPath.
+func (c *miscServiceClient) TimestampPath(ctx context.Context, req *connect.Request[v1.TimestampPathRequest]) (*connect.Response[v1.TimestampPathResponse], error) {
+	return c.timestampPath.CallUnary(ctx, req)
+}
+
 // ListTimestamps calls misc.v1.MiscService.ListTimestamps.
 func (c *miscServiceClient) ListTimestamps(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListTimestampsResponse], error) {
 	return c.listTimestamps.CallUnary(ctx, req)
@@ -185,17 +427,65 @@ func (c *miscServiceClient) VerifyTimestamp(ctx context.Context, req *connect.Re
 	return c.verifyTimestamp.CallUnary(ctx, req)
 }
 
+// RebroadcastTimestamp calls misc.v1.MiscService.RebroadcastTimestamp.
+func (c *miscServiceClient) RebroadcastTimestamp(ctx context.Context, req *connect.Request[v1.RebroadcastTimestampRequest]) (*connect.Response[v1.RebroadcastTimestampResponse], error) {
+	return c.rebroadcastTimestamp.CallUnary(ctx, req)
+}
+
+// ExportTimestampProof calls misc.v1.MiscService.ExportTimestampProof.
+func (c *miscServiceClient) ExportTimestampProof(ctx context.Context, req *connect.Request[v1.ExportTimestampProofRequest]) (*connect.Response[v1.ExportTimestampProofResponse], error) {
+	return c.exportTimestampProof.CallUnary(ctx, req)
+}
+
+// VerifyTimestampProof calls misc.v1.MiscService.VerifyTimestampProof.
+func (c *miscServiceClient) VerifyTimestampProof(ctx context.Context, req *connect.Request[v1.VerifyTimestampProofRequest]) (*connect.Response[v1.VerifyTimestampProofResponse], error) {
+	return c.verifyTimestampProof.CallUnary(ctx, req)
+}
+
 // MiscServiceHandler is an implementation of the misc.v1.MiscService service.
 type MiscServiceHandler interface {
-	ListOPReturn(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListOPReturnResponse], error)
+	ListOPReturn(context.Context, *connect.Request[v1.ListOPReturnRequest]) (*connect.Response[v1.ListOPReturnResponse], error)
 	BroadcastNews(context.Context, *connect.Request[v1.BroadcastNewsRequest]) (*connect.Response[v1.BroadcastNewsResponse], error)
 	CreateTopic(context.Context, *connect.Request[v1.CreateTopicRequest]) (*connect.Response[v1.CreateTopicResponse], error)
 	ListTopics(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListTopicsResponse], error)
+	// Only lists posts, replies are listed with ListCoinNewsThread
 	ListCoinNews(context.Context, *connect.Request[v1.ListCoinNewsRequest]) (*connect.Response[v1.ListCoinNewsResponse], error)
+	ListCoinNewsThread(context.Context, *connect.Request[v1.ListCoinNewsThreadRequest]) (*connect.Response[v1.ListCoinNewsThreadResponse], error)
+	React(context.Context, *connect.Request[v1.ReactRequest]) (*connect.Response[v1.ReactResponse], error)
+	// Only works for topics owned by this wallet's news key
+	ModerateTopic(context.Context, *connect.Request[v1.ModerateTopicRequest]) (*connect.Response[v1.ModerateTopicResponse], error)
+	SetTopicSubscription(context.Context, *connect.Request[v1.SetTopicSubscriptionRequest]) (*connect.Response[v1.SetTopicSubscriptionResponse], error)
+	// Topic sets are JSON, for sharing a curated feed:
+	// {"version": 1, "topics": [{"topic": "<hex>", "name": "...", "txid": "<hex>"}]}
+	ExportTopics(context.Context, *connect.Request[v1.ExportTopicsRequest]) (*connect.Response[v1.ExportTopicsResponse], error)
+	// Adds the topics that aren't known yet, and subscribes to all of them
+	ImportTopics(context.Context, *connect.Request[v1.ImportTopicsRequest]) (*connect.Response[v1.ImportTopicsResponse], error)
+	// Pushes OP_RETURNs and coin news as they're seen, first from the
+	// mempool and again once confirmed. Only new ones, list the existing
+	// ones first.
+	WatchOPReturns(context.Context, *connect.Request[v1.WatchOPReturnsRequest], *connect.ServerStream[v1.WatchOPReturnsResponse]) error
+	WatchCoinNews(context.Context, *connect.Request[v1.WatchCoinNewsRequest], *connect.ServerStream[v1.WatchCoinNewsResponse]) error
+	// Payloads too large for one OP_RETURN, split across several transactions
+	BroadcastChunked(context.Context, *connect.Request[v1.BroadcastChunkedRequest]) (*connect.Response[v1.BroadcastChunkedResponse], error)
+	ListChunkedMessages(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListChunkedMessagesResponse], error)
 	// File timestamping
 	TimestampFile(context.Context, *connect.Request[v1.TimestampFileRequest]) (*connect.Response[v1.TimestampFileResponse], error)
+	// Like TimestampFile, but the file is sent in chunks and hashed as they
+	// arrive, for files too large to send in one message
+	TimestampFileStream(context.Context, *connect.ClientStream[v1.TimestampFileStreamRequest]) (*connect.Response[v1.TimestampFileResponse], error)
+	// Timestamp a file or directory on the machine bitwindowd runs on. Only
+	// allowed for callers on the same machine
+	TimestampPath(context.Context, *connect.Request[v1.TimestampPathRequest]) (*connect.Response[v1.TimestampPathResponse], error)
 	ListTimestamps(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListTimestampsResponse], error)
 	VerifyTimestamp(context.Context, *connect.Request[v1.VerifyTimestampRequest]) (*connect.Response[v1.VerifyTimestampResponse], error)
+	// Sends a new transaction for a failed timestamp
+	RebroadcastTimestamp(context.Context, *connect.Request[v1.RebroadcastTimestampRequest]) (*connect.Response[v1.RebroadcastTimestampResponse], error)
+	// Export an OpenTimestamps proof of a confirmed timestamp, verifiable by
+	// anyone with the block headers
+	ExportTimestampProof(context.Context, *connect.Request[v1.ExportTimestampProofRequest]) (*connect.Response[v1.ExportTimestampProofResponse], error)
+	// Verify an OpenTimestamps proof of a file against the chain, whether or
+	// not it was timestamped by us
+	VerifyTimestampProof(context.Context, *connect.Request[v1.VerifyTimestampProofRequest]) (*connect.Response[v1.VerifyTimestampProofResponse], error)
 }
 
 // NewMiscServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -235,12 +525,84 @@ func NewMiscServiceHandler(svc MiscServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(miscServiceMethods.ByName("ListCoinNews")),
 		connect.WithHandlerOptions(opts...),
 	)
+	miscServiceListCoinNewsThreadHandler := connect.NewUnaryHandler(
+		MiscServiceListCoinNewsThreadProcedure,
+		svc.ListCoinNewsThread,
+		connect.WithSchema(miscServiceMethods.ByName("ListCoinNewsThread")),
+		connect.WithHandlerOptions(opts...),
+	)
+	miscServiceReactHandler := connect.NewUnaryHandler(
+		MiscServiceReactProcedure,
+		svc.React,
+		connect.WithSchema(miscServiceMethods.ByName("React")),
+		connect.WithHandlerOptions(opts...),
+	)
+	miscServiceModerateTopicHandler := connect.NewUnaryHandler(
+		MiscServiceModerateTopicProcedure,
+		svc.ModerateTopic,
+		connect.WithSchema(miscServiceMethods.ByName("ModerateTopic")),
+		connect.WithHandlerOptions(opts...),
+	)
+	miscServiceSetTopicSubscriptionHandler := connect.NewUnaryHandler(
+		MiscServiceSetTopicSubscriptionProcedure,
+		svc.SetTopicSubscription,
+		connect.WithSchema(miscServiceMethods.ByName("SetTopicSubscription")),
+		connect.WithHandlerOptions(opts...),
+	)
+	miscServiceExportTopicsHandler := connect.NewUnaryHandler(
+		MiscServiceExportTopicsProcedure,
+		svc.ExportTopics,
+		connect.WithSchema(miscServiceMethods.ByName("ExportTopics")),
+		connect.WithHandlerOptions(opts...),
+	)
+	miscServiceImportTopicsHandler := connect.NewUnaryHandler(
+		MiscServiceImportTopicsProcedure,
+		svc.ImportTopics,
+		connect.WithSchema(miscServiceMethods.ByName("ImportTopics")),
+		connect.WithHandlerOptions(opts...),
+	)
+	miscServiceWatchOPReturnsHandler := connect.NewServerStreamHandler(
+		MiscServiceWatchOPReturnsProcedure,
+		svc.WatchOPReturns,
+		connect.WithSchema(miscServiceMethods.ByName("WatchOPReturns")),
+		connect.WithHandlerOptions(opts...),
+	)
+	miscServiceWatchCoinNewsHandler := connect.NewServerStreamHandler(
+		MiscServiceWatchCoinNewsProcedure,
+		svc.WatchCoinNews,
+		connect.WithSchema(miscServiceMethods.ByName("WatchCoinNews")),
+		connect.WithHandlerOptions(opts...),
+	)
+	miscServiceBroadcastChunkedHandler := connect.NewUnaryHandler(
+		MiscServiceBroadcastChunkedProcedure,
+		svc.BroadcastChunked,
+		connect.WithSchema(miscServiceMethods.ByName("BroadcastChunked")),
+		connect.WithHandlerOptions(opts...),
+	)
+	miscServiceListChunkedMessagesHandler := connect.NewUnaryHandler(
+		MiscServiceListChunkedMessagesProcedure,
+		svc.ListChunkedMessages,
+		connect.WithSchema(miscServiceMethods.ByName("ListChunkedMessages")),
+		connect.WithHandlerOptions(opts...),
+	)
 	miscServiceTimestampFileHandler := connect.NewUnaryHandler(
 		MiscServiceTimestampFileProcedure,
 		svc.TimestampFile,
 		connect.WithSchema(miscServiceMethods.ByName("TimestampFile")),
 		connect.WithHandlerOptions(opts...),
 	)
+	miscServiceTimestampFileStreamHandler := connect.NewClientStreamHandler(
+		MiscServiceTimestampFileStreamProcedure,
+		svc.TimestampFileStream,
+		connect.WithSchema(miscServiceMethods.ByName("TimestampFileStream")),
+		connect.WithHandlerOptions(opts...),
+	)
+	miscServiceTimestampPathHandler := connect.NewUnaryHandler(
+		MiscServiceTimestampPathProcedure,
+		svc.TimestampPath,
+		connect.WithSchema(miscServiceMethods.ByName("TimestampPath")),
+		connect.WithHandlerOptions(opts...),
+	)
 	miscServiceListTimestampsHandler := connect.NewUnaryHandler(
 		MiscServiceListTimestampsProcedure,
 		svc.ListTimestamps,
@@ -253,6 +615,24 @@ func NewMiscServiceHandler(svc MiscServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(miscServiceMethods.ByName("VerifyTimestamp")),
 		connect.WithHandlerOptions(opts...),
 	)
+	miscServiceRebroadcastTimestampHandler := connect.NewUnaryHandler(
+		MiscServiceRebroadcastTimestampProcedure,
+		svc.RebroadcastTimestamp,
+		connect.WithSchema(miscServiceMethods.ByName("RebroadcastTimestamp")),
+		connect.WithHandlerOptions(opts...),
+	)
+	miscServiceExportTimestampProofHandler := connect.NewUnaryHandler(
+		MiscServiceExportTimestampProofProcedure,
+		svc.ExportTimestampProof,
+		connect.WithSchema(miscServiceMethods.ByName("ExportTimestampProof")),
+		connect.WithHandlerOptions(opts...),
+	)
+	miscServiceVerifyTimestampProofHandler := connect.NewUnaryHandler(
+		MiscServiceVerifyTimestampProofProcedure,
+		svc.VerifyTimestampProof,
+		connect.WithSchema(miscServiceMethods.ByName("VerifyTimestampProof")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/misc.v1.MiscService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case MiscServiceListOPReturnProcedure:
@@ -265,12 +645,42 @@ func NewMiscServiceHandler(svc MiscServiceHandler, opts ...connect.HandlerOption
 			miscServiceListTopicsHandler.ServeHTTP(w, r)
 		case MiscServiceListCoinNewsProcedure:
 			miscServiceListCoinNewsHandler.ServeHTTP(w, r)
+		case MiscServiceListCoinNewsThreadProcedure:
+			miscServiceListCoinNewsThreadHandler.ServeHTTP(w, r)
+		case MiscServiceReactProcedure:
+			miscServiceReactHandler.ServeHTTP(w, r)
+		case MiscServiceModerateTopicProcedure:
+			miscServiceModerateTopicHandler.ServeHTTP(w, r)
+		case MiscServiceSetTopicSubscriptionProcedure:
+			miscServiceSetTopicSubscriptionHandler.ServeHTTP(w, r)
+		case MiscServiceExportTopicsProcedure:
+			miscServiceExportTopicsHandler.ServeHTTP(w, r)
+		case MiscServiceImportTopicsProcedure:
+			miscServiceImportTopicsHandler.ServeHTTP(w, r)
+		case MiscServiceWatchOPReturnsProcedure:
+			miscServiceWatchOPReturnsHandler.ServeHTTP(w, r)
+		case MiscServiceWatchCoinNewsProcedure:
+			miscServiceWatchCoinNewsHandler.ServeHTTP(w, r)
+		case MiscServiceBroadcastChunkedProcedure:
+			miscServiceBroadcastChunkedHandler.ServeHTTP(w, r)
+		case MiscServiceListChunkedMessagesProcedure:
+			miscServiceListChunkedMessagesHandler.ServeHTTP(w, r)
 		case MiscServiceTimestampFileProcedure:
 			miscServiceTimestampFileHandler.ServeHTTP(w, r)
+		case MiscServiceTimestampFileStreamProcedure:
+			miscServiceTimestampFileStreamHandler.ServeHTTP(w, r)
+		case MiscServiceTimestampPathProcedure:
+			miscServiceTimestampPathHandler.ServeHTTP(w, r)
 		case MiscServiceListTimestampsProcedure:
 			miscServiceListTimestampsHandler.ServeHTTP(w, r)
 		case MiscServiceVerifyTimestampProcedure:
 			miscServiceVerifyTimestampHandler.ServeHTTP(w, r)
+		case MiscServiceRebroadcastTimestampProcedure:
+			miscServiceRebroadcastTimestampHandler.ServeHTTP(w, r)
+		case MiscServiceExportTimestampProofProcedure:
+			miscServiceExportTimestampProofHandler.ServeHTTP(w, r)
+		case MiscServiceVerifyTimestampProofProcedure:
+			miscServiceVerifyTimestampProofHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -280,7 +690,7 @@ func NewMiscServiceHandler(svc MiscServiceHandler, opts ...connect.HandlerOption
 // UnimplementedMiscServiceHandler returns CodeUnimplemented from all methods.
 type UnimplementedMiscServiceHandler struct{}
 
-func (UnimplementedMiscServiceHandler) ListOPReturn(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListOPReturnResponse], error) {
+func (UnimplementedMiscServiceHandler) ListOPReturn(context.Context, *connect.Request[v1.ListOPReturnRequest]) (*connect.Response[v1.ListOPReturnResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.ListOPReturn is not implemented"))
 }
 
@@ -300,10 +710,58 @@ func (UnimplementedMiscServiceHandler) ListCoinNews(context.Context, *connect.Re
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.ListCoinNews is not implemented"))
 }
 
+func (UnimplementedMiscServiceHandler) ListCoinNewsThread(context.Context, *connect.Request[v1.ListCoinNewsThreadRequest]) (*connect.Response[v1.ListCoinNewsThreadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.ListCoinNewsThread is not implemented"))
+}
+
+func (UnimplementedMiscServiceHandler) React(context.Context, *connect.Request[v1.ReactRequest]) (*connect.Response[v1.ReactResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.React is not implemented"))
+}
+
+func (UnimplementedMiscServiceHandler) ModerateTopic(context.Context, *connect.Request[v1.ModerateTopicRequest]) (*connect.Response[v1.ModerateTopicResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.ModerateTopic is not implemented"))
+}
+
+func (UnimplementedMiscServiceHandler) SetTopicSubscription(context.Context, *connect.Request[v1.SetTopicSubscriptionRequest]) (*connect.Response[v1.SetTopicSubscriptionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.SetTopicSubscription is not implemented"))
+}
+
+func (UnimplementedMiscServiceHandler) ExportTopics(context.Context, *connect.Request[v1.ExportTopicsRequest]) (*connect.Response[v1.ExportTopicsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.ExportTopics is not implemented"))
+}
+
+func (UnimplementedMiscServiceHandler) ImportTopics(context.Context, *connect.Request[v1.ImportTopicsRequest]) (*connect.Response[v1.ImportTopicsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.ImportTopics is not implemented"))
+}
+
+func (UnimplementedMiscServiceHandler) WatchOPReturns(context.Context, *connect.Request[v1.WatchOPReturnsRequest], *connect.ServerStream[v1.WatchOPReturnsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.WatchOPReturns is not implemented"))
+}
+
+func (UnimplementedMiscServiceHandler) WatchCoinNews(context.Context, *connect.Request[v1.WatchCoinNewsRequest], *connect.ServerStream[v1.WatchCoinNewsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.WatchCoinNews is not implemented"))
+}
+
+func (UnimplementedMiscServiceHandler) BroadcastChunked(context.Context, *connect.Request[v1.BroadcastChunkedRequest]) (*connect.Response[v1.BroadcastChunkedResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.BroadcastChunked is not implemented"))
+}
+
+func (UnimplementedMiscServiceHandler) ListChunkedMessages(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListChunkedMessagesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.ListChunkedMessages is not implemented"))
+}
+
 func (UnimplementedMiscServiceHandler) TimestampFile(context.Context, *connect.Request[v1.TimestampFileRequest]) (*connect.Response[v1.TimestampFileResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.TimestampFile is not implemented"))
 }
 
+func (UnimplementedMiscServiceHandler) TimestampFileStream(context.Context, *connect.ClientStream[v1.TimestampFileStreamRequest]) (*connect.Response[v1.TimestampFileResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.TimestampFileStream is not implemented"))
+}
+
+func (UnimplementedMiscServiceHandler) TimestampPath(context.Context, *connect.Request[v1.TimestampPathRequest]) (*connect.Response[v1.TimestampPathResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.TimestampPath is not implemented"))
+}
+
 func (UnimplementedMiscServiceHandler) ListTimestamps(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListTimestampsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.ListTimestamps is not implemented"))
 }
@@ -311,3 +769,15 @@ func (UnimplementedMiscServiceHandler) ListTimestamps(context.Context, *connect.
 func (UnimplementedMiscServiceHandler) VerifyTimestamp(context.Context, *connect.Request[v1.VerifyTimestampRequest]) (*connect.Response[v1.VerifyTimestampResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.VerifyTimestamp is not implemented"))
 }
+
+func (UnimplementedMiscServiceHandler) RebroadcastTimestamp(context.Context, *connect.Request[v1.RebroadcastTimestampRequest]) (*connect.Response[v1.RebroadcastTimestampResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.RebroadcastTimestamp is not implemented"))
+}
+
+func (UnimplementedMiscServiceHandler) ExportTimestampProof(context.Context, *connect.Request[v1.ExportTimestampProofRequest]) (*connect.Response[v1.ExportTimestampProofResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.ExportTimestampProof is not implemented"))
+}
+
+func (UnimplementedMiscServiceHandler) VerifyTimestampProof(context.Context, *connect.Request[v1.VerifyTimestampProofRequest]) (*connect.Response[v1.VerifyTimestampProofResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("misc.v1.MiscService.VerifyTimestampProof is not implemented"))
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -15,12 +16,14 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/api"
+	api_esplora "github.com/LayerTwo-Labs/sidesail/bitwindow/server/api/esplora"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/config"
 	database "github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
 	dial "github.com/LayerTwo-Labs/sidesail/bitwindow/server/dial"
 	engines "github.com/LayerTwo-Labs/sidesail/bitwindow/server/engines"
 	cryptorpc "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/cusf/crypto/v1/cryptov1connect"
 	rpc "github.com/LayerTwo-Labs/sidesail/bitwindow/server/gen/cusf/mainchain/v1/mainchainv1connect"
+	service "github.com/LayerTwo-Labs/sidesail/bitwindow/server/service"
 	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/version"
 	corepb "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha"
 	corerpc "github.com/barebitcoin/btc-buf/gen/bitcoin/bitcoind/v1alpha/bitcoindv1alphaconnect"
@@ -103,6 +106,11 @@ func realMain(ctx context.Context, cancelCtx context.CancelFunc) error {
 		return bitcoind, err
 	}
 
+	if conf.Command == "reindex" {
+		bitcoinEngine := api.NewBitcoinEngine(service.New("bitcoind", bitcoindConnector), db, getChainParams(conf.BitcoinCoreNetwork), conf)
+		return bitcoinEngine.Reindex(ctx, conf.Reindex.From, conf.Reindex.To, conf.Reindex.Only)
+	}
+
 	coreProxy, err := startCoreProxy(ctx, conf)
 	if err != nil {
 		return fmt.Errorf("init core proxy: %w", err)
@@ -202,7 +210,7 @@ func realMain(ctx context.Context, cancelCtx context.CancelFunc) error {
 		}
 	}()
 
-	bitcoinEngine := engines.NewBitcoind(srv.Bitcoind, db, conf)
+	bitcoinEngine := srv.BitcoinEngine
 	deniabilityEngine := engines.NewDeniability(srv.Wallet, srv.Bitcoind, db)
 
 	log.Info().Msgf("server: listening on %s", conf.APIHost)
@@ -221,11 +229,22 @@ func realMain(ctx context.Context, cancelCtx context.CancelFunc) error {
 	go func() {
 		errs <- deniabilityEngine.Run(ctx)
 	}()
+	go func() {
+		errs <- srv.TimestampEngine.Run(ctx)
+	}()
+	if conf.EsploraHost != "" {
+		esplora := api_esplora.New(db, srv.Bitcoind, chainParams, conf)
+		go func() {
+			errs <- esplora.Serve(ctx, conf.EsploraHost)
+		}()
+	}
 
 	// If Bitcoin Core publishes raw transactions, we can use this to handle
-	// pending mempool entries. ZMQ notifications might not be available
-	// right away. We want a retry mechanism that doesn't stall startup for
-	// the rest of the system.
+	// pending mempool entries. If it publishes block hashes or sequence
+	// events, we can pick up new blocks right away instead of waiting for
+	// the next poll. ZMQ notifications might not be available right away.
+	// We want a retry mechanism that doesn't stall startup for the rest of
+	// the system.
 
 	go func() {
 		var zmqEngine *engines.ZMQ
@@ -268,6 +287,28 @@ func realMain(ctx context.Context, cancelCtx context.CancelFunc) error {
 			}
 		}()
 
+		if zmqEngine.HasBlockNotifications() {
+			go func() {
+				for msg := range zmqEngine.SubscribeBlocks() {
+					log.Debug().
+						Msgf("ZMQ: %s: %x", msg.Event, msg.Hash)
+					bitcoinEngine.NotifyBlock()
+				}
+			}()
+
+			go func() {
+				for msg := range zmqEngine.SubscribeMempool() {
+					if msg.Event != engines.TransactionRemoved {
+						continue
+					}
+					txid := hex.EncodeToString(msg.Hash[:])
+					if err := bitcoinEngine.HandleRemovedTransaction(ctx, txid); err != nil {
+						log.Error().Err(err).Msgf("handle removed transaction: %s", txid)
+					}
+				}
+			}()
+		}
+
 		log.Info().Msg("starting ZMQ engine")
 		errs <- zmqEngine.Run(ctx)
 	}()
@@ -385,16 +426,30 @@ func getZmqEngine(ctx context.Context, conf config.Config) (*engines.ZMQ, error)
 		return nil, fmt.Errorf("get zmq notifications: %w", err)
 	}
 
-	pubRawTxAddress, foundPubRawTx := lo.Find(notifs.Msg.Notifications,
-		func(n *corepb.GetZmqNotificationsResponse_Notification) bool {
-			return n.Type == "pubrawtx"
-		})
+	address := func(typ string) string {
+		notif, _ := lo.Find(notifs.Msg.Notifications,
+			func(n *corepb.GetZmqNotificationsResponse_Notification) bool {
+				return n.Type == typ
+			})
+		return notif.GetAddress()
+	}
 
-	if !foundPubRawTx {
+	endpoints := engines.ZMQEndpoints{
+		RawTx:     address("pubrawtx"),
+		HashBlock: address("pubhashblock"),
+		Sequence:  address("pubsequence"),
+	}
+	if endpoints == (engines.ZMQEndpoints{}) {
 		return nil, nil
 	}
 
-	return engines.NewZMQ(pubRawTxAddress.Address)
+	zerolog.Ctx(ctx).Info().
+		Str("rawtx", endpoints.RawTx).
+		Str("hashblock", endpoints.HashBlock).
+		Str("sequence", endpoints.Sequence).
+		Msg("found ZMQ notifications")
+
+	return engines.NewZMQ(endpoints)
 }
 
 func getChainParams(network config.Network) *chaincfg.Params {
@@ -0,0 +1,338 @@
+package addresshistory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/rs/zerolog"
+)
+
+type EntryType string
+
+const (
+	EntryTypeFunding  EntryType = "funding"
+	EntryTypeSpending EntryType = "spending"
+)
+
+// Entry is a single funding or spending of an output belonging to a script.
+type Entry struct {
+	Type EntryType
+	// The transaction funding or spending the output
+	TxID   string
+	Height uint32
+	Value  btcutil.Amount
+
+	// The output that was funded or spent
+	OutputTxID string
+	OutputVout uint32
+	// The input spending the output. Only set for spends.
+	InputIndex *uint32
+}
+
+// Balance sums up all outputs ever sent to a script.
+type Balance struct {
+	FundedCount int64
+	FundedSum   btcutil.Amount
+	SpentCount  int64
+	SpentSum    btcutil.Amount
+}
+
+// Confirmed is the sum of all unspent outputs.
+func (b Balance) Confirmed() btcutil.Amount {
+	return b.FundedSum - b.SpentSum
+}
+
+// IndexBlock records all spendable outputs created in the block, and marks
+// the outputs spent by the block as such.
+func IndexBlock(ctx context.Context, db *sql.DB, height uint32, block *wire.MsgBlock) error {
+	start := time.Now()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer database.SafeDefer(ctx, tx.Rollback)
+
+	insert, err := tx.PrepareContext(ctx, `
+		INSERT INTO address_outputs (txid, vout, script_pubkey, value, block_height)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (txid, vout) DO UPDATE SET
+			script_pubkey = excluded.script_pubkey,
+			value = excluded.value,
+			block_height = excluded.block_height,
+			spent_txid = NULL,
+			spent_vin = NULL,
+			spent_height = NULL
+	`)
+	if err != nil {
+		return fmt.Errorf("prepare insert: %w", err)
+	}
+	defer insert.Close()
+
+	spend, err := tx.PrepareContext(ctx, `
+		UPDATE address_outputs
+		SET spent_txid = ?, spent_vin = ?, spent_height = ?
+		WHERE txid = ? AND vout = ?
+	`)
+	if err != nil {
+		return fmt.Errorf("prepare spend: %w", err)
+	}
+	defer spend.Close()
+
+	var outputs, spends int
+	// Transactions can spend outputs created earlier in the same block, so
+	// these have to be handled in order.
+	for _, msgTx := range block.Transactions {
+		txid := msgTx.TxID()
+
+		if !blockchain.IsCoinBaseTx(msgTx) {
+			for vin, in := range msgTx.TxIn {
+				if _, err := spend.ExecContext(ctx,
+					txid, vin, height,
+					in.PreviousOutPoint.Hash.String(), in.PreviousOutPoint.Index,
+				); err != nil {
+					return fmt.Errorf("mark %s spent: %w", in.PreviousOutPoint, err)
+				}
+				spends++
+			}
+		}
+
+		for vout, out := range msgTx.TxOut {
+			if txscript.IsUnspendable(out.PkScript) {
+				continue
+			}
+
+			if _, err := insert.ExecContext(ctx, txid, vout, out.PkScript, out.Value, height); err != nil {
+				return fmt.Errorf("insert %s:%d: %w", txid, vout, err)
+			}
+			outputs++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	zerolog.Ctx(ctx).Trace().
+		Msgf("addresshistory: indexed %d outputs and %d spends in block %d in %s", outputs, spends, height, time.Since(start))
+
+	return nil
+}
+
+// DeleteAboveHeight removes all outputs created in blocks strictly above the
+// given height, and unspends outputs spent in those blocks.
+func DeleteAboveHeight(ctx context.Context, db *sql.DB, height uint32) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer database.SafeDefer(ctx, tx.Rollback)
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM address_outputs WHERE block_height > ?`, height); err != nil {
+		return fmt.Errorf("delete outputs above %d: %w", height, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE address_outputs
+		SET spent_txid = NULL, spent_vin = NULL, spent_height = NULL
+		WHERE spent_height > ?
+	`, height); err != nil {
+		return fmt.Errorf("unspend outputs above %d: %w", height, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// ListHistory returns all fundings and spendings of outputs paying to the
+// given script, newest first.
+func ListHistory(ctx context.Context, db *sql.DB, script []byte, limit, offset int) ([]Entry, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT type, txid, height, value, output_txid, output_vout, input_index FROM (
+			SELECT 'funding' AS type, txid, block_height AS height, value,
+				txid AS output_txid, vout AS output_vout, NULL AS input_index
+			FROM address_outputs
+			WHERE script_pubkey = ?
+
+			UNION ALL
+
+			SELECT 'spending' AS type, spent_txid, spent_height, value,
+				txid, vout, spent_vin
+			FROM address_outputs
+			WHERE script_pubkey = ? AND spent_txid IS NOT NULL
+		)
+		ORDER BY height DESC, type DESC, txid
+		LIMIT ? OFFSET ?
+	`, script, script, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("query address history: %w", err)
+	}
+	defer database.SafeDefer(ctx, rows.Close)
+
+	var entries []Entry
+	for rows.Next() {
+		var entry Entry
+		if err := rows.Scan(
+			&entry.Type, &entry.TxID, &entry.Height, &entry.Value,
+			&entry.OutputTxID, &entry.OutputVout, &entry.InputIndex,
+		); err != nil {
+			return nil, fmt.Errorf("scan address history: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// GetBalance sums up all outputs paying to the given script.
+func GetBalance(ctx context.Context, db *sql.DB, script []byte) (Balance, error) {
+	var balance Balance
+	err := db.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(value), 0),
+			COUNT(spent_txid),
+			COALESCE(SUM(CASE WHEN spent_txid IS NOT NULL THEN value END), 0)
+		FROM address_outputs
+		WHERE script_pubkey = ?
+	`, script).Scan(&balance.FundedCount, &balance.FundedSum, &balance.SpentCount, &balance.SpentSum)
+	if err != nil {
+		return Balance{}, fmt.Errorf("query address balance: %w", err)
+	}
+
+	return balance, nil
+}
+
+// GetOutputs returns the given outputs, as far as they're in the index.
+// Outputs that aren't are left out of the result. All outputs are looked up
+// in a single query.
+func GetOutputs(ctx context.Context, db *sql.DB, outpoints []wire.OutPoint) (map[wire.OutPoint]*wire.TxOut, error) {
+	outputs := make(map[wire.OutPoint]*wire.TxOut, len(outpoints))
+	if len(outpoints) == 0 {
+		return outputs, nil
+	}
+
+	// Passed as a single JSON array, to stay clear of the limit on the
+	// number of query parameters
+	wanted := make([][2]any, len(outpoints))
+	for i, outpoint := range outpoints {
+		wanted[i] = [2]any{outpoint.Hash.String(), outpoint.Index}
+	}
+	encoded, err := json.Marshal(wanted)
+	if err != nil {
+		return nil, fmt.Errorf("encode outpoints: %w", err)
+	}
+
+	// CROSS JOIN makes SQLite loop over the outpoints, and look each of
+	// them up through the primary key
+	rows, err := db.QueryContext(ctx, `
+		SELECT o.txid, o.vout, o.script_pubkey, o.value
+		FROM json_each(?) AS wanted
+		CROSS JOIN address_outputs o
+			ON o.txid = json_extract(wanted.value, '$[0]')
+			AND o.vout = json_extract(wanted.value, '$[1]')
+	`, string(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("query outputs: %w", err)
+	}
+	defer database.SafeDefer(ctx, rows.Close)
+
+	for rows.Next() {
+		var (
+			txid     string
+			outpoint wire.OutPoint
+			out      wire.TxOut
+		)
+		if err := rows.Scan(&txid, &outpoint.Index, &out.PkScript, &out.Value); err != nil {
+			return nil, fmt.Errorf("scan output: %w", err)
+		}
+
+		hash, err := chainhash.NewHashFromStr(txid)
+		if err != nil {
+			return nil, fmt.Errorf("parse txid %q: %w", txid, err)
+		}
+		outpoint.Hash = *hash
+		outputs[outpoint] = &out
+	}
+
+	return outputs, rows.Err()
+}
+
+// TxRef is a transaction in the index.
+type TxRef struct {
+	TxID   string
+	Height uint32
+}
+
+// ListTxs returns the transactions funding or spending outputs paying to the
+// given script, newest first. If afterTxID is set, the list starts right
+// after that transaction.
+func ListTxs(ctx context.Context, db *sql.DB, script []byte, afterTxID string, limit int) ([]TxRef, error) {
+	rows, err := db.QueryContext(ctx, `
+		WITH txs AS (
+			SELECT txid, block_height AS height
+			FROM address_outputs
+			WHERE script_pubkey = ?
+
+			UNION
+
+			SELECT spent_txid, spent_height
+			FROM address_outputs
+			WHERE script_pubkey = ? AND spent_txid IS NOT NULL
+		),
+		after AS (
+			SELECT txid, height FROM txs WHERE txid = ?
+		)
+		SELECT txid, height FROM txs
+		WHERE ? = ''
+			OR height < (SELECT height FROM after)
+			OR (height = (SELECT height FROM after) AND txid > (SELECT txid FROM after))
+		ORDER BY height DESC, txid
+		LIMIT ?
+	`, script, script, afterTxID, afterTxID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query address txs: %w", err)
+	}
+	defer database.SafeDefer(ctx, rows.Close)
+
+	var txs []TxRef
+	for rows.Next() {
+		var tx TxRef
+		if err := rows.Scan(&tx.TxID, &tx.Height); err != nil {
+			return nil, fmt.Errorf("scan address tx: %w", err)
+		}
+		txs = append(txs, tx)
+	}
+
+	return txs, rows.Err()
+}
+
+// GetTxHeight returns the height of the block the given transaction was
+// confirmed in. Only transactions with at least one spendable output are in
+// the index, returns nil for anything else.
+func GetTxHeight(ctx context.Context, db *sql.DB, txid string) (*uint32, error) {
+	var height uint32
+	err := db.QueryRowContext(ctx, `
+		SELECT block_height FROM address_outputs WHERE txid = ? LIMIT 1
+	`, txid).Scan(&height)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("get tx height %s: %w", txid, err)
+	}
+
+	return &height, nil
+}
@@ -0,0 +1,151 @@
+package addresshistory
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressHistory(t *testing.T) {
+	ctx := context.Background()
+	db := database.Test(t)
+
+	alice := append([]byte{txscript.OP_0, txscript.OP_DATA_20}, bytes.Repeat([]byte{0x01}, 20)...)
+	bob := append([]byte{txscript.OP_0, txscript.OP_DATA_20}, bytes.Repeat([]byte{0x02}, 20)...)
+
+	coinbase := wire.NewMsgTx(2)
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), nil, nil))
+	coinbase.AddTxOut(wire.NewTxOut(5_000, alice))
+	coinbase.AddTxOut(wire.NewTxOut(0, []byte{0x6a, 0x01, 0x02}))
+
+	// Spends the coinbase within the same block
+	payment := wire.NewMsgTx(2)
+	coinbaseHash := coinbase.TxHash()
+	payment.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&coinbaseHash, 0), nil, nil))
+	payment.AddTxOut(wire.NewTxOut(3_000, bob))
+	payment.AddTxOut(wire.NewTxOut(1_500, alice))
+
+	block := &wire.MsgBlock{Transactions: []*wire.MsgTx{coinbase, payment}}
+	require.NoError(t, IndexBlock(ctx, db, 1, block))
+
+	refund := wire.NewMsgTx(2)
+	paymentHash := payment.TxHash()
+	refund.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&paymentHash, 0), nil, nil))
+	refund.AddTxOut(wire.NewTxOut(2_500, alice))
+
+	coinbase2 := wire.NewMsgTx(2)
+	coinbase2.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), []byte{0x02}, nil))
+	coinbase2.AddTxOut(wire.NewTxOut(5_000, bob))
+
+	require.NoError(t, IndexBlock(ctx, db, 2, &wire.MsgBlock{
+		Transactions: []*wire.MsgTx{coinbase2, refund},
+	}))
+
+	t.Run("balance", func(t *testing.T) {
+		balance, err := GetBalance(ctx, db, alice)
+		require.NoError(t, err)
+		assert.Equal(t, Balance{
+			FundedCount: 3,
+			FundedSum:   5_000 + 1_500 + 2_500,
+			SpentCount:  1,
+			SpentSum:    5_000,
+		}, balance)
+		assert.Equal(t, btcutil.Amount(4_000), balance.Confirmed())
+
+		balance, err = GetBalance(ctx, db, bob)
+		require.NoError(t, err)
+		assert.Equal(t, btcutil.Amount(5_000), balance.Confirmed())
+	})
+
+	t.Run("history", func(t *testing.T) {
+		entries, err := ListHistory(ctx, db, bob, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+
+		// Newest first, spends before fundings within a block
+		assert.Equal(t, EntryTypeSpending, entries[0].Type)
+		assert.Equal(t, refund.TxID(), entries[0].TxID)
+		assert.Equal(t, payment.TxID(), entries[0].OutputTxID)
+		require.NotNil(t, entries[0].InputIndex)
+		assert.Equal(t, uint32(0), *entries[0].InputIndex)
+
+		assert.Equal(t, EntryTypeFunding, entries[1].Type)
+		assert.Equal(t, coinbase2.TxID(), entries[1].TxID)
+		assert.Nil(t, entries[1].InputIndex)
+
+		assert.Equal(t, EntryTypeFunding, entries[2].Type)
+		assert.Equal(t, payment.TxID(), entries[2].TxID)
+		assert.Equal(t, uint32(1), entries[2].Height)
+
+		paged, err := ListHistory(ctx, db, bob, 2, 1)
+		require.NoError(t, err)
+		assert.Equal(t, entries[1:], paged)
+	})
+
+	t.Run("txs", func(t *testing.T) {
+		// The refund both spends from and pays to alice, but is only listed once
+		txs, err := ListTxs(ctx, db, alice, "", 10)
+		require.NoError(t, err)
+		require.Len(t, txs, 3)
+		assert.Equal(t, TxRef{TxID: refund.TxID(), Height: 2}, txs[0])
+		assert.ElementsMatch(t, []string{coinbase.TxID(), payment.TxID()}, []string{txs[1].TxID, txs[2].TxID})
+
+		paged, err := ListTxs(ctx, db, alice, txs[0].TxID, 10)
+		require.NoError(t, err)
+		assert.Equal(t, txs[1:], paged)
+
+		paged, err = ListTxs(ctx, db, alice, txs[1].TxID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, txs[2:], paged)
+
+		height, err := GetTxHeight(ctx, db, refund.TxID())
+		require.NoError(t, err)
+		require.NotNil(t, height)
+		assert.Equal(t, uint32(2), *height)
+
+		height, err = GetTxHeight(ctx, db, chainhash.Hash{1}.String())
+		require.NoError(t, err)
+		assert.Nil(t, height)
+	})
+
+	t.Run("outputs", func(t *testing.T) {
+		refundHash := refund.TxHash()
+		outputs, err := GetOutputs(ctx, db, []wire.OutPoint{
+			{Hash: paymentHash, Index: 1},
+			{Hash: refundHash, Index: 0},
+			// Unspendable, so never indexed
+			{Hash: coinbaseHash, Index: 1},
+			{Hash: chainhash.Hash{1}, Index: 0},
+			{Hash: paymentHash, Index: 1},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[wire.OutPoint]*wire.TxOut{
+			{Hash: paymentHash, Index: 1}: wire.NewTxOut(1_500, alice),
+			{Hash: refundHash, Index: 0}:  wire.NewTxOut(2_500, alice),
+		}, outputs)
+
+		outputs, err = GetOutputs(ctx, db, nil)
+		require.NoError(t, err)
+		assert.Empty(t, outputs)
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		require.NoError(t, DeleteAboveHeight(ctx, db, 1))
+
+		balance, err := GetBalance(ctx, db, bob)
+		require.NoError(t, err)
+		assert.Equal(t, Balance{FundedCount: 1, FundedSum: 3_000}, balance)
+
+		balance, err = GetBalance(ctx, db, alice)
+		require.NoError(t, err)
+		assert.Equal(t, btcutil.Amount(1_500), balance.Confirmed())
+	})
+}
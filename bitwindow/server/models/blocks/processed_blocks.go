@@ -96,16 +96,18 @@ func MarkBlocksProcessed(ctx context.Context, db *sql.DB, blocks []ProcessedBloc
 	return nil
 }
 
-func WipeProcessedBlocks(ctx context.Context, db *sql.DB) error {
+// DeleteProcessedBlocksAbove removes all processed blocks with a height
+// strictly greater than the given height. Passing 0 removes everything.
+func DeleteProcessedBlocksAbove(ctx context.Context, db *sql.DB, height uint32) error {
 	start := time.Now()
-	tag, err := db.ExecContext(ctx, `DELETE FROM processed_blocks`)
+	tag, err := db.ExecContext(ctx, `DELETE FROM processed_blocks WHERE height > ?`, height)
 	if err != nil {
-		return fmt.Errorf("wipe processed blocks: %w", err)
+		return fmt.Errorf("delete processed blocks above %d: %w", height, err)
 	}
 
 	if rows, _ := tag.RowsAffected(); rows > 0 {
 		zerolog.Ctx(ctx).Debug().
-			Msgf("blocks: wiped %d processed blocks in %s", rows, time.Since(start))
+			Msgf("blocks: deleted %d processed blocks above height %d in %s", rows, height, time.Since(start))
 	}
 
 	return nil
@@ -0,0 +1,80 @@
+package blocks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// ProcessorCursor is the last block a block processor has processed.
+type ProcessorCursor struct {
+	Name      string
+	Height    uint32
+	Hash      chainhash.Hash
+	UpdatedAt time.Time
+}
+
+// GetProcessorCursor returns the cursor for the given processor, or nil if
+// it hasn't processed any blocks yet.
+func GetProcessorCursor(ctx context.Context, db *sql.DB, name string) (*ProcessorCursor, error) {
+	var (
+		cursor  = ProcessorCursor{Name: name}
+		rawHash string
+	)
+	err := db.QueryRowContext(ctx, `
+		SELECT height, block_hash, updated_at
+		FROM block_processor_cursors
+		WHERE name = ?
+	`, name).Scan(&cursor.Height, &rawHash, &cursor.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("get processor cursor %q: %w", name, err)
+	}
+
+	hash, err := chainhash.NewHashFromStr(rawHash)
+	if err != nil {
+		return nil, fmt.Errorf("parse block hash: %w", err)
+	}
+	cursor.Hash = *hash
+
+	return &cursor, nil
+}
+
+// Execer is satisfied by both *sql.DB and *sql.Tx.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// SetProcessorCursor moves the cursor for the given processor to the given
+// block. Takes a transaction when the cursor has to move together with the
+// state the processor derived from the block.
+func SetProcessorCursor(ctx context.Context, db Execer, name string, height uint32, hash chainhash.Hash) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO block_processor_cursors (name, height, block_hash, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET
+			height = excluded.height,
+			block_hash = excluded.block_hash,
+			updated_at = excluded.updated_at
+	`, name, height, hash.String(), time.Now())
+	if err != nil {
+		return fmt.Errorf("set processor cursor %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// DeleteProcessorCursor resets the given processor, such that it starts
+// over from the genesis block.
+func DeleteProcessorCursor(ctx context.Context, db *sql.DB, name string) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM block_processor_cursors WHERE name = ?`, name); err != nil {
+		return fmt.Errorf("delete processor cursor %q: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,424 @@
+package blockstats
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// Percentiles are the feerate percentiles we keep track of.
+var Percentiles = [5]float64{0.10, 0.25, 0.50, 0.75, 0.90}
+
+// Stats summarizes the transactions in a single block.
+type Stats struct {
+	Height    uint32
+	Hash      chainhash.Hash
+	BlockTime time.Time
+	Bits      uint32
+
+	// Including the coinbase
+	TxCount  int64
+	Weight   int64
+	VSize    int64
+	TotalFee btcutil.Amount
+
+	SegwitTxCount  int64
+	TaprootTxCount int64
+
+	OPReturnCount int64
+	OPReturnBytes int64
+
+	// Feerates in sat/vB at each of the Percentiles, weighted by vsize.
+	// Nil if the fees of the individual transactions weren't known.
+	FeeratePercentiles *[5]float64
+}
+
+// Difficulty is the difficulty of the block, relative to the lowest
+// mainnet difficulty. Same as what Bitcoin Core reports.
+func (s Stats) Difficulty() float64 {
+	target := blockchain.CompactToBig(s.Bits)
+	if target.Sign() <= 0 {
+		return 0
+	}
+
+	difficulty, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(blockchain.CompactToBig(chaincfg.MainNetParams.PowLimitBits)),
+		new(big.Float).SetInt(target),
+	).Float64()
+	return difficulty
+}
+
+// Prevouts returns the outputs spent by the block that were created in
+// earlier blocks.
+func Prevouts(block *wire.MsgBlock) []wire.OutPoint {
+	created := make(map[chainhash.Hash]struct{}, len(block.Transactions))
+	var prevouts []wire.OutPoint
+	for _, tx := range block.Transactions {
+		if !blockchain.IsCoinBaseTx(tx) {
+			for _, in := range tx.TxIn {
+				if _, ok := created[in.PreviousOutPoint.Hash]; !ok {
+					prevouts = append(prevouts, in.PreviousOutPoint)
+				}
+			}
+		}
+		created[tx.TxHash()] = struct{}{}
+	}
+
+	return prevouts
+}
+
+// Compute summarizes the given block. The prevouts are the outputs returned
+// by Prevouts. If nil, the total fee is taken from the coinbase, and the
+// feerate percentiles are left out.
+func Compute(
+	height uint32, block *wire.MsgBlock, params *chaincfg.Params,
+	prevouts map[wire.OutPoint]*wire.TxOut,
+) (Stats, error) {
+	stats := Stats{
+		Height:    height,
+		Hash:      block.BlockHash(),
+		BlockTime: block.Header.Timestamp,
+		Bits:      block.Header.Bits,
+		TxCount:   int64(len(block.Transactions)),
+		Weight:    blockchain.GetBlockWeight(btcutil.NewBlock(block)),
+	}
+	stats.VSize = (stats.Weight + blockchain.WitnessScaleFactor - 1) / blockchain.WitnessScaleFactor
+
+	// Outputs created in this block, which can be spent by later
+	// transactions in the same block.
+	created := make(map[wire.OutPoint]*wire.TxOut)
+	lookup := func(outpoint wire.OutPoint) *wire.TxOut {
+		if out, ok := created[outpoint]; ok {
+			return out
+		}
+		return prevouts[outpoint]
+	}
+
+	var (
+		coinbaseValue int64
+		totalFee      int64
+		feerates      []feerate
+	)
+	for i, tx := range block.Transactions {
+		txHash := tx.TxHash()
+		var outputValue int64
+		for vout, out := range tx.TxOut {
+			outputValue += out.Value
+			if prevouts != nil {
+				created[wire.OutPoint{Hash: txHash, Index: uint32(vout)}] = out
+			}
+
+			if len(out.PkScript) > 0 && out.PkScript[0] == txscript.OP_RETURN {
+				stats.OPReturnCount++
+				stats.OPReturnBytes += int64(len(out.PkScript))
+			}
+		}
+
+		if i == 0 && blockchain.IsCoinBaseTx(tx) {
+			coinbaseValue = outputValue
+			continue
+		}
+
+		if tx.HasWitness() {
+			stats.SegwitTxCount++
+		}
+
+		var (
+			inputValue int64
+			taproot    bool
+		)
+		for _, in := range tx.TxIn {
+			if prevouts == nil {
+				taproot = taproot || isTaprootWitness(in.Witness)
+				continue
+			}
+
+			prevout := lookup(in.PreviousOutPoint)
+			if prevout == nil {
+				return Stats{}, fmt.Errorf("missing prevout %s of %s", in.PreviousOutPoint, txHash)
+			}
+			inputValue += prevout.Value
+			taproot = taproot || txscript.IsPayToTaproot(prevout.PkScript)
+		}
+		if taproot {
+			stats.TaprootTxCount++
+		}
+
+		if prevouts != nil {
+			fee := inputValue - outputValue
+			weight := blockchain.GetTransactionWeight(btcutil.NewTx(tx))
+			vsize := (weight + blockchain.WitnessScaleFactor - 1) / blockchain.WitnessScaleFactor
+
+			totalFee += fee
+			feerates = append(feerates, feerate{
+				satPerVByte: float64(fee) / float64(vsize),
+				weight:      weight,
+			})
+		}
+	}
+
+	if prevouts != nil {
+		stats.TotalFee = btcutil.Amount(totalFee)
+		percentiles := feeratePercentiles(feerates)
+		stats.FeeratePercentiles = &percentiles
+	} else {
+		// Miners can claim less than they're owed, but hardly ever do
+		subsidy := blockchain.CalcBlockSubsidy(int32(height), params)
+		stats.TotalFee = btcutil.Amount(max(coinbaseValue-subsidy, 0))
+	}
+
+	return stats, nil
+}
+
+type feerate struct {
+	satPerVByte float64
+	weight      int64
+}
+
+// feeratePercentiles weights each feerate by the weight of its transaction,
+// the same way Bitcoin Core's getblockstats does.
+func feeratePercentiles(feerates []feerate) [5]float64 {
+	var percentiles [5]float64
+	if len(feerates) == 0 {
+		return percentiles
+	}
+
+	slices.SortFunc(feerates, func(a, b feerate) int {
+		switch {
+		case a.satPerVByte < b.satPerVByte:
+			return -1
+		case a.satPerVByte > b.satPerVByte:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	var totalWeight int64
+	for _, f := range feerates {
+		totalWeight += f.weight
+	}
+
+	var (
+		next       int
+		cumulative int64
+	)
+	for _, f := range feerates {
+		cumulative += f.weight
+		for next < len(Percentiles) && float64(cumulative) >= float64(totalWeight)*Percentiles[next] {
+			percentiles[next] = f.satPerVByte
+			next++
+		}
+	}
+	for ; next < len(Percentiles); next++ {
+		percentiles[next] = feerates[len(feerates)-1].satPerVByte
+	}
+
+	return percentiles
+}
+
+// isTaprootWitness guesses whether an input spends a taproot output from
+// the shape of its witness, for when the output itself isn't known.
+func isTaprootWitness(witness wire.TxWitness) bool {
+	if len(witness) == 0 {
+		return false
+	}
+
+	if len(witness) >= 2 {
+		if last := witness[len(witness)-1]; len(last) > 0 && last[0] == txscript.TaprootAnnexTag {
+			witness = witness[:len(witness)-1]
+		}
+	}
+
+	// Key path spend: a single schnorr signature
+	if len(witness) == 1 {
+		return len(witness[0]) == 64 || len(witness[0]) == 65
+	}
+
+	// Script path spend: ends with a control block
+	controlBlock := witness[len(witness)-1]
+	return len(controlBlock) >= txscript.ControlBlockBaseSize &&
+		(len(controlBlock)-txscript.ControlBlockBaseSize)%txscript.ControlBlockNodeSize == 0 &&
+		txscript.TapscriptLeafVersion(controlBlock[0]&0xfe) == txscript.BaseLeafVersion
+}
+
+// Save stores the stats of a block, replacing any stats already stored at
+// the same height.
+func Save(ctx context.Context, db *sql.DB, stats Stats) error {
+	var percentiles [5]sql.NullFloat64
+	if stats.FeeratePercentiles != nil {
+		for i, value := range stats.FeeratePercentiles {
+			percentiles[i] = sql.NullFloat64{Float64: value, Valid: true}
+		}
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO block_stats (
+			height, block_hash, block_time, bits,
+			tx_count, weight, vsize, total_fee,
+			segwit_tx_count, taproot_tx_count,
+			op_return_count, op_return_bytes,
+			feerate_p10, feerate_p25, feerate_p50, feerate_p75, feerate_p90
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		stats.Height, stats.Hash.String(), stats.BlockTime, stats.Bits,
+		stats.TxCount, stats.Weight, stats.VSize, int64(stats.TotalFee),
+		stats.SegwitTxCount, stats.TaprootTxCount,
+		stats.OPReturnCount, stats.OPReturnBytes,
+		percentiles[0], percentiles[1], percentiles[2], percentiles[3], percentiles[4],
+	)
+	if err != nil {
+		return fmt.Errorf("save block stats %d: %w", stats.Height, err)
+	}
+
+	return nil
+}
+
+// DeleteAboveHeight removes the stats of all blocks strictly above the
+// given height.
+func DeleteAboveHeight(ctx context.Context, db *sql.DB, height uint32) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM block_stats WHERE height > ?`, height); err != nil {
+		return fmt.Errorf("delete block stats above %d: %w", height, err)
+	}
+
+	return nil
+}
+
+const selectStats = `
+	SELECT
+		height, block_hash, block_time, bits,
+		tx_count, weight, vsize, total_fee,
+		segwit_tx_count, taproot_tx_count,
+		op_return_count, op_return_bytes,
+		feerate_p10, feerate_p25, feerate_p50, feerate_p75, feerate_p90
+	FROM block_stats
+`
+
+// List returns the stats of all blocks between from and to, inclusive,
+// ordered by height.
+func List(ctx context.Context, db *sql.DB, from, to uint32) ([]Stats, error) {
+	rows, err := db.QueryContext(ctx, selectStats+`
+		WHERE height BETWEEN ? AND ?
+		ORDER BY height
+	`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query block stats: %w", err)
+	}
+	defer database.SafeDefer(ctx, rows.Close)
+
+	var result []Stats
+	for rows.Next() {
+		stats, err := scanStats(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, stats)
+	}
+
+	return result, rows.Err()
+}
+
+// GetTip returns the stats of the highest block, or nil if there are none.
+func GetTip(ctx context.Context, db *sql.DB) (*Stats, error) {
+	stats, err := scanStats(db.QueryRowContext(ctx, selectStats+`
+		ORDER BY height DESC
+		LIMIT 1
+	`))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// Summary adds up the stats of several blocks.
+type Summary struct {
+	Blocks int64
+	// Excluding the coinbases
+	TxCount  int64
+	VSize    int64
+	TotalFee btcutil.Amount
+
+	SegwitTxCount  int64
+	TaprootTxCount int64
+
+	OPReturnBytes int64
+}
+
+// Summarize adds up the stats of all blocks with a block time of at least
+// since.
+func Summarize(ctx context.Context, db *sql.DB, since time.Time) (Summary, error) {
+	var (
+		summary  Summary
+		totalFee int64
+	)
+	err := db.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(tx_count - 1), 0),
+			COALESCE(SUM(vsize), 0),
+			COALESCE(SUM(total_fee), 0),
+			COALESCE(SUM(segwit_tx_count), 0),
+			COALESCE(SUM(taproot_tx_count), 0),
+			COALESCE(SUM(op_return_bytes), 0)
+		FROM block_stats
+		WHERE block_time >= ?
+	`, since).Scan(
+		&summary.Blocks, &summary.TxCount, &summary.VSize, &totalFee,
+		&summary.SegwitTxCount, &summary.TaprootTxCount, &summary.OPReturnBytes,
+	)
+	if err != nil {
+		return Summary{}, fmt.Errorf("summarize block stats: %w", err)
+	}
+	summary.TotalFee = btcutil.Amount(totalFee)
+
+	return summary, nil
+}
+
+func scanStats(row interface{ Scan(dest ...any) error }) (Stats, error) {
+	var (
+		stats       Stats
+		rawHash     string
+		totalFee    int64
+		percentiles [5]sql.NullFloat64
+	)
+	if err := row.Scan(
+		&stats.Height, &rawHash, &stats.BlockTime, &stats.Bits,
+		&stats.TxCount, &stats.Weight, &stats.VSize, &totalFee,
+		&stats.SegwitTxCount, &stats.TaprootTxCount,
+		&stats.OPReturnCount, &stats.OPReturnBytes,
+		&percentiles[0], &percentiles[1], &percentiles[2], &percentiles[3], &percentiles[4],
+	); err != nil {
+		return Stats{}, fmt.Errorf("scan block stats: %w", err)
+	}
+
+	hash, err := chainhash.NewHashFromStr(rawHash)
+	if err != nil {
+		return Stats{}, fmt.Errorf("parse block hash: %w", err)
+	}
+	stats.Hash = *hash
+	stats.TotalFee = btcutil.Amount(totalFee)
+
+	if percentiles[0].Valid {
+		stats.FeeratePercentiles = &[5]float64{}
+		for i, value := range percentiles {
+			stats.FeeratePercentiles[i] = value.Float64
+		}
+	}
+
+	return stats, nil
+}
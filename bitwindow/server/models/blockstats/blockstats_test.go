@@ -0,0 +1,220 @@
+package blockstats
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompute(t *testing.T) {
+	t.Parallel()
+
+	p2wpkh := append([]byte{txscript.OP_0, txscript.OP_DATA_20}, bytes.Repeat([]byte{0x01}, 20)...)
+	p2tr := append([]byte{txscript.OP_1, txscript.OP_DATA_32}, bytes.Repeat([]byte{0x02}, 32)...)
+
+	external := map[wire.OutPoint]*wire.TxOut{
+		{Hash: chainhash.Hash{1}}: wire.NewTxOut(100_000, p2wpkh),
+		{Hash: chainhash.Hash{2}}: wire.NewTxOut(50_000, p2tr),
+	}
+
+	// Segwit v0 spend, fee 1000
+	segwit := wire.NewMsgTx(2)
+	segwit.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{1}}, nil, wire.TxWitness{
+		make([]byte, 71), make([]byte, 33),
+	}))
+	segwit.AddTxOut(wire.NewTxOut(99_000, p2wpkh))
+
+	// Taproot key path spend with an OP_RETURN, fee 2000
+	taproot := wire.NewMsgTx(2)
+	taproot.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{2}}, nil, wire.TxWitness{
+		make([]byte, 64),
+	}))
+	taproot.AddTxOut(wire.NewTxOut(48_000, p2tr))
+	taproot.AddTxOut(wire.NewTxOut(0, []byte{txscript.OP_RETURN, txscript.OP_DATA_4, 'h', 'e', 'y', '!'}))
+
+	// Legacy spend of an output created in the same block, fee 500
+	segwitHash := segwit.TxHash()
+	legacy := wire.NewMsgTx(2)
+	legacy.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: segwitHash}, make([]byte, 107), nil))
+	legacy.AddTxOut(wire.NewTxOut(98_500, p2wpkh))
+
+	const height = 1
+	params := &chaincfg.RegressionNetParams
+
+	coinbase := wire.NewMsgTx(2)
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), []byte{0x51, 0x01}, nil))
+	coinbase.AddTxOut(wire.NewTxOut(50*btcutil.SatoshiPerBitcoin+3_500, p2wpkh))
+
+	block := &wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Timestamp: time.Unix(1_700_000_000, 0),
+			Bits:      params.PowLimitBits,
+		},
+		Transactions: []*wire.MsgTx{coinbase, segwit, taproot, legacy},
+	}
+
+	assert.ElementsMatch(t, []wire.OutPoint{{Hash: chainhash.Hash{1}}, {Hash: chainhash.Hash{2}}}, Prevouts(block))
+
+	t.Run("without prevouts", func(t *testing.T) {
+		t.Parallel()
+
+		stats, err := Compute(height, block, params, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, block.BlockHash(), stats.Hash)
+		assert.Equal(t, int64(4), stats.TxCount)
+		assert.Equal(t, btcutil.Amount(3_500), stats.TotalFee)
+		assert.Equal(t, int64(2), stats.SegwitTxCount)
+		assert.Equal(t, int64(1), stats.TaprootTxCount)
+		assert.Equal(t, int64(1), stats.OPReturnCount)
+		assert.Equal(t, int64(6), stats.OPReturnBytes)
+		assert.Equal(t, (stats.Weight+3)/4, stats.VSize)
+		assert.Nil(t, stats.FeeratePercentiles)
+	})
+
+	t.Run("with prevouts", func(t *testing.T) {
+		t.Parallel()
+
+		stats, err := Compute(height, block, params, external)
+		require.NoError(t, err)
+
+		assert.Equal(t, btcutil.Amount(3_500), stats.TotalFee)
+		assert.Equal(t, int64(1), stats.TaprootTxCount)
+		require.NotNil(t, stats.FeeratePercentiles)
+
+		// The taproot spend is the smallest and pays the most
+		taprootVSize := (blockchain.GetTransactionWeight(btcutil.NewTx(taproot)) + 3) / 4
+		assert.InDelta(t, 2_000/float64(taprootVSize), stats.FeeratePercentiles[4], 1e-9)
+		for i := 1; i < len(stats.FeeratePercentiles); i++ {
+			assert.LessOrEqual(t, stats.FeeratePercentiles[i-1], stats.FeeratePercentiles[i])
+		}
+	})
+
+	t.Run("missing prevout", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Compute(height, block, params, map[wire.OutPoint]*wire.TxOut{
+			{Hash: chainhash.Hash{1}}: wire.NewTxOut(100_000, p2wpkh),
+		})
+		require.ErrorContains(t, err, "missing prevout")
+	})
+
+	t.Run("difficulty", func(t *testing.T) {
+		t.Parallel()
+
+		assert.InDelta(t, 1.0, Stats{Bits: chaincfg.MainNetParams.PowLimitBits}.Difficulty(), 1e-9)
+		assert.InDelta(t, 4.0, Stats{Bits: 0x1c3fffc0}.Difficulty(), 1e-6)
+	})
+}
+
+func TestFeeratePercentiles(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, [5]float64{}, feeratePercentiles(nil))
+
+	assert.Equal(t, [5]float64{1, 1, 5, 5, 10}, feeratePercentiles([]feerate{
+		{satPerVByte: 10, weight: 200},
+		{satPerVByte: 1, weight: 400},
+		{satPerVByte: 5, weight: 400},
+	}))
+}
+
+func TestIsTaprootWitness(t *testing.T) {
+	t.Parallel()
+
+	controlBlock := append([]byte{0xc1}, make([]byte, 32+32)...)
+
+	for name, test := range map[string]struct {
+		witness wire.TxWitness
+		want    bool
+	}{
+		"empty":            {nil, false},
+		"key path":         {wire.TxWitness{make([]byte, 64)}, true},
+		"key path sighash": {wire.TxWitness{make([]byte, 65)}, true},
+		"key path annex":   {wire.TxWitness{make([]byte, 64), {txscript.TaprootAnnexTag}}, true},
+		"script path":      {wire.TxWitness{make([]byte, 64), {txscript.OP_TRUE}, controlBlock}, true},
+		"p2wpkh":           {wire.TxWitness{make([]byte, 71), make([]byte, 33)}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, isTaprootWitness(test.witness))
+		})
+	}
+}
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := database.Test(t)
+
+	tip, err := GetTip(ctx, db)
+	require.NoError(t, err)
+	assert.Nil(t, tip)
+
+	blockTime := time.Unix(1_700_000_000, 0).UTC()
+	for height := uint32(1); height <= 3; height++ {
+		stats := Stats{
+			Height:    height,
+			Hash:      chainhash.Hash{byte(height)},
+			BlockTime: blockTime.Add(time.Duration(height) * 10 * time.Minute),
+			Bits:      chaincfg.MainNetParams.PowLimitBits,
+			TxCount:   int64(height),
+			VSize:     int64(height) * 100,
+			TotalFee:  btcutil.Amount(height * 1_000),
+
+			SegwitTxCount: int64(height) - 1,
+			OPReturnBytes: 10,
+		}
+		if height == 2 {
+			stats.FeeratePercentiles = &[5]float64{1, 2, 3, 4, 5.5}
+		}
+		require.NoError(t, Save(ctx, db, stats))
+	}
+
+	stats, err := List(ctx, db, 2, 10)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, uint32(2), stats[0].Height)
+	assert.Equal(t, chainhash.Hash{2}, stats[0].Hash)
+	assert.Equal(t, btcutil.Amount(2_000), stats[0].TotalFee)
+	assert.True(t, blockTime.Add(20*time.Minute).Equal(stats[0].BlockTime))
+	assert.Equal(t, &[5]float64{1, 2, 3, 4, 5.5}, stats[0].FeeratePercentiles)
+	assert.Nil(t, stats[1].FeeratePercentiles)
+
+	tip, err = GetTip(ctx, db)
+	require.NoError(t, err)
+	require.NotNil(t, tip)
+	assert.Equal(t, uint32(3), tip.Height)
+
+	summary, err := Summarize(ctx, db, blockTime.Add(20*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, Summary{
+		Blocks:        2,
+		TxCount:       1 + 2,
+		VSize:         200 + 300,
+		TotalFee:      2_000 + 3_000,
+		SegwitTxCount: 1 + 2,
+		OPReturnBytes: 20,
+	}, summary)
+
+	summary, err = Summarize(ctx, db, blockTime.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, Summary{}, summary)
+
+	require.NoError(t, DeleteAboveHeight(ctx, db, 1))
+	tip, err = GetTip(ctx, db)
+	require.NoError(t, err)
+	require.NotNil(t, tip)
+	assert.Equal(t, uint32(1), tip.Height)
+}
@@ -0,0 +1,379 @@
+package opreturns
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/samber/lo"
+)
+
+// MaxOPReturnSize is the largest OP_RETURN payload that's relayed by Bitcoin
+// Core nodes running with -datacarriersize set to its old default.
+const MaxOPReturnSize = 80
+
+var chunkTag = []byte("bwchunk")
+
+const (
+	ChunkIDLength   = 8
+	chunkHeaderSize = 7 + ChunkIDLength + 2 + 2
+	// MaxChunks is the largest number of chunks a payload can be split into
+	MaxChunks = 1<<16 - 1
+)
+
+// ChunkID identifies a chunked payload. It's the first 8 bytes of the SHA256
+// hash of the full payload, which lets us check that it was put back together
+// correctly.
+type ChunkID [ChunkIDLength]byte
+
+func (c ChunkID) String() string {
+	return hex.EncodeToString(c[:])
+}
+
+func NewChunkID(payload []byte) ChunkID {
+	hash := sha256.Sum256(payload)
+	return ChunkID(hash[:ChunkIDLength])
+}
+
+// EncodeChunks splits a payload into OP_RETURN messages of at most chunkSize
+// bytes, header included.
+//
+// Format for each OP_RETURN message: bwchunk<id (8 bytes)><index (2 bytes,
+// big endian)><total (2 bytes, big endian)><data>
+func EncodeChunks(payload []byte, chunkSize int) ([][]byte, error) {
+	if len(payload) == 0 {
+		return nil, fmt.Errorf("payload is empty")
+	}
+	if chunkSize <= chunkHeaderSize {
+		return nil, fmt.Errorf("chunk size must be larger than the %d byte header", chunkHeaderSize)
+	}
+
+	dataSize := chunkSize - chunkHeaderSize
+	total := (len(payload) + dataSize - 1) / dataSize
+	if total > MaxChunks {
+		return nil, fmt.Errorf("payload needs %d chunks, can be at most %d", total, MaxChunks)
+	}
+
+	id := NewChunkID(payload)
+	chunks := make([][]byte, 0, total)
+	for index, data := range lo.Chunk(payload, dataSize) {
+		chunks = append(chunks, slices.Concat(
+			chunkTag,
+			id[:],
+			binary.BigEndian.AppendUint16(nil, uint16(index)),
+			binary.BigEndian.AppendUint16(nil, uint16(total)),
+			data,
+		))
+	}
+
+	return chunks, nil
+}
+
+type Chunk struct {
+	ID    ChunkID
+	Index uint16
+	Total uint16
+	Data  []byte
+}
+
+func ParseChunk(data []byte) (Chunk, bool) {
+	if len(data) <= chunkHeaderSize {
+		return Chunk{}, false
+	}
+
+	rest, ok := bytes.CutPrefix(data, chunkTag)
+	if !ok {
+		return Chunk{}, false
+	}
+
+	chunk := Chunk{
+		ID:    ChunkID(rest[:ChunkIDLength]),
+		Index: binary.BigEndian.Uint16(rest[ChunkIDLength:]),
+		Total: binary.BigEndian.Uint16(rest[ChunkIDLength+2:]),
+		Data:  rest[ChunkIDLength+4:],
+	}
+	if chunk.Total == 0 || chunk.Index >= chunk.Total {
+		return Chunk{}, false
+	}
+
+	return chunk, true
+}
+
+// ChunkedMessage is a payload that was split across several transactions.
+type ChunkedMessage struct {
+	ID    ChunkID
+	Total uint16
+	// Txids of the chunks we've seen, indexed by chunk. Empty for missing
+	// chunks.
+	TxIDs []string
+	// Only set once all chunks are seen, and they hash to the ID
+	Data     []byte
+	Complete bool
+	// The sum of the fees paid for, and sizes of, all chunks seen
+	Fee   btcutil.Amount
+	VSize int64
+	// Nil until all chunks seen are confirmed
+	Height *uint32
+	// When the first chunk was seen
+	CreatedAt *time.Time
+	seenAt    float64
+
+	// ID of the OP_RETURN for the first chunk, if seen
+	firstOPReturn int64
+}
+
+// Received returns the number of chunks seen.
+func (m ChunkedMessage) Received() int {
+	return len(lo.Compact(m.TxIDs))
+}
+
+// opReturn returns a complete message as if it was a single OP_RETURN, seen
+// in the transaction of the first chunk.
+func (m ChunkedMessage) opReturn() OPReturn {
+	return OPReturn{
+		ID:        m.firstOPReturn,
+		TxID:      m.TxIDs[0],
+		Data:      m.Data,
+		Fee:       m.Fee,
+		VSize:     m.VSize,
+		Height:    m.Height,
+		CreatedAt: m.CreatedAt,
+		Status:    StatusActive,
+		seenAt:    m.seenAt,
+	}
+}
+
+// The most combinations of candidate chunks we try when putting a payload
+// back together. Anyone can send chunks with the ID of someone else's
+// payload, so there can be several candidates for each chunk. Bounds the
+// work an attacker can cause by spamming garbage chunks.
+const maxChunkCombinations = 1 << 12
+
+// ListChunkedMessages puts chunked payloads back together. Payloads with
+// missing chunks are returned as incomplete.
+func ListChunkedMessages(ctx context.Context, db *sql.DB) ([]ChunkedMessage, error) {
+	return listChunkedMessages(ctx, db, [][]byte{chunkTag})
+}
+
+// listChunkedMessagesByID puts the payloads with the given IDs back
+// together.
+func listChunkedMessagesByID(ctx context.Context, db *sql.DB, ids []ChunkID) ([]ChunkedMessage, error) {
+	return listChunkedMessages(ctx, db, lo.Map(ids, func(id ChunkID, _ int) []byte {
+		return slices.Concat(chunkTag, id[:])
+	}))
+}
+
+// listChunkedMessages puts the payloads of all chunks starting with one of
+// the given prefixes back together.
+func listChunkedMessages(ctx context.Context, db *sql.DB, prefixes [][]byte) ([]ChunkedMessage, error) {
+	// GLOB on a prefix goes through the index on op_return_data
+	var matches sq.Or
+	for _, prefix := range prefixes {
+		matches = append(matches, sq.Expr("op_return_data GLOB ?", hex.EncodeToString(prefix)+"*"))
+	}
+	query, args := sq.
+		Select("id", "txid", "unhex(op_return_data)", "fee_sats", "vsize", "height", "created_at", seenAtColumn).
+		From("op_returns").
+		Where(sq.Eq{"status": StatusActive}).
+		Where(matches).
+		OrderBy("id").
+		MustSql()
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query chunks: %w", err)
+	}
+	defer rows.Close()
+
+	var opReturns []OPReturn
+	for rows.Next() {
+		var opReturn OPReturn
+		err := rows.Scan(
+			&opReturn.ID, &opReturn.TxID, &opReturn.Data,
+			&opReturn.Fee, &opReturn.VSize, &opReturn.Height, &opReturn.CreatedAt, &opReturn.seenAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan chunk: %w", err)
+		}
+		opReturns = append(opReturns, opReturn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate chunks: %w", err)
+	}
+
+	result := assembleChunks(opReturns)
+
+	// Newest first, like List
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(*result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+// chunkSet is all chunks seen for a payload that agree on the total number
+// of chunks.
+type chunkSet struct {
+	total uint16
+	// Every OP_RETURN claiming to be a chunk, indexed by chunk, in the order
+	// they were seen
+	candidates [][]OPReturn
+}
+
+// assembleChunks puts the chunked payloads in the given OP_RETURNs back
+// together, in order of the first chunk seen.
+func assembleChunks(opReturns []OPReturn) []ChunkedMessage {
+	var (
+		sets  = make(map[ChunkID][]*chunkSet)
+		order []ChunkID
+	)
+	for _, opReturn := range opReturns {
+		chunk, ok := ParseChunk(opReturn.Data)
+		if !ok {
+			continue
+		}
+
+		if _, ok := sets[chunk.ID]; !ok {
+			order = append(order, chunk.ID)
+		}
+
+		set, ok := lo.Find(sets[chunk.ID], func(set *chunkSet) bool {
+			return set.total == chunk.Total
+		})
+		if !ok {
+			set = &chunkSet{
+				total:      chunk.Total,
+				candidates: make([][]OPReturn, chunk.Total),
+			}
+			sets[chunk.ID] = append(sets[chunk.ID], set)
+		}
+
+		set.candidates[chunk.Index] = append(set.candidates[chunk.Index], opReturn)
+	}
+
+	result := make([]ChunkedMessage, 0, len(order))
+	for _, id := range order {
+		result = append(result, assembleChunkedMessage(id, sets[id]))
+	}
+
+	return result
+}
+
+// assembleChunkedMessage picks the chunks that hash to the ID, out of all
+// candidates. If there are none, the message is incomplete, and made up of
+// the first candidate seen for each chunk.
+func assembleChunkedMessage(id ChunkID, sets []*chunkSet) ChunkedMessage {
+	for _, set := range sets {
+		if chosen, ok := set.assemble(id); ok {
+			message := newChunkedMessage(id, set.total, chosen)
+			message.Data = slices.Concat(lo.Map(chosen, func(opReturn OPReturn, _ int) []byte {
+				chunk, _ := ParseChunk(opReturn.Data)
+				return chunk.Data
+			})...)
+			message.Complete = true
+			return message
+		}
+	}
+
+	// Going by the set we saw first
+	set := sets[0]
+	chosen := lo.Map(set.candidates, func(candidates []OPReturn, _ int) OPReturn {
+		first, _ := lo.First(candidates)
+		return first
+	})
+
+	return newChunkedMessage(id, set.total, chosen)
+}
+
+// assemble searches for a combination of candidates that hashes to the
+// given ID. Gives up after maxChunkCombinations attempts.
+func (s *chunkSet) assemble(id ChunkID) ([]OPReturn, bool) {
+	if slices.ContainsFunc(s.candidates, func(candidates []OPReturn) bool {
+		return len(candidates) == 0
+	}) {
+		return nil, false
+	}
+
+	var (
+		chosen   = make([]OPReturn, s.total)
+		attempts int
+		search   func(index int) bool
+	)
+	search = func(index int) bool {
+		if index == len(s.candidates) {
+			attempts++
+			data := slices.Concat(lo.Map(chosen, func(opReturn OPReturn, _ int) []byte {
+				chunk, _ := ParseChunk(opReturn.Data)
+				return chunk.Data
+			})...)
+			return NewChunkID(data) == id
+		}
+
+		for _, candidate := range s.candidates[index] {
+			if attempts >= maxChunkCombinations {
+				return false
+			}
+
+			chosen[index] = candidate
+			if search(index + 1) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return chosen, search(0)
+}
+
+// newChunkedMessage creates a message out of the given chunks, indexed by
+// chunk. Missing chunks have an empty txid.
+func newChunkedMessage(id ChunkID, total uint16, chunks []OPReturn) ChunkedMessage {
+	message := ChunkedMessage{
+		ID:    id,
+		Total: total,
+		TxIDs: make([]string, total),
+	}
+
+	seen := lo.Filter(chunks, func(opReturn OPReturn, _ int) bool {
+		return opReturn.TxID != ""
+	})
+	first := lo.MinBy(seen, func(a, b OPReturn) bool { return a.ID < b.ID })
+	message.Height = first.Height
+	message.CreatedAt = first.CreatedAt
+	message.seenAt = first.seenAt
+
+	for index, opReturn := range chunks {
+		if opReturn.TxID == "" {
+			continue
+		}
+
+		message.TxIDs[index] = opReturn.TxID
+		message.Fee += opReturn.Fee
+		message.VSize += opReturn.VSize
+		if index == 0 {
+			message.firstOPReturn = opReturn.ID
+		}
+
+		switch {
+		case opReturn.Height == nil:
+			message.Height = nil
+		case message.Height != nil && *opReturn.Height > *message.Height:
+			message.Height = opReturn.Height
+		}
+		if opReturn.CreatedAt != nil && opReturn.CreatedAt.Before(*message.CreatedAt) {
+			message.CreatedAt = opReturn.CreatedAt
+		}
+		message.seenAt = min(message.seenAt, opReturn.seenAt)
+	}
+
+	return message
+}
@@ -0,0 +1,154 @@
+package opreturns
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssembleChunks(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte("the real payload "), 10)
+	chunks, err := EncodeChunks(payload, MaxOPReturnSize)
+	require.NoError(t, err)
+	require.Len(t, chunks, 3)
+
+	id := NewChunkID(payload)
+
+	// A chunk with the same ID and index as a real one, but different data
+	forged := func(index, total uint16) []byte {
+		return slices.Concat(
+			chunkTag, id[:],
+			binary.BigEndian.AppendUint16(nil, index),
+			binary.BigEndian.AppendUint16(nil, total),
+			[]byte("garbage"),
+		)
+	}
+
+	var nextID int64
+	opReturn := func(data []byte) OPReturn {
+		nextID++
+		return OPReturn{
+			ID:        nextID,
+			TxID:      fmt.Sprintf("%064x", nextID),
+			Data:      data,
+			Fee:       100,
+			VSize:     10,
+			Height:    lo.ToPtr(uint32(nextID)),
+			CreatedAt: lo.ToPtr(time.Unix(nextID, 0)),
+		}
+	}
+
+	t.Run("forged chunks seen first", func(t *testing.T) {
+		nextID = 0
+		opReturns := []OPReturn{
+			opReturn(forged(1, 3)),
+			opReturn(forged(0, 2)),
+			opReturn(chunks[0]),
+			opReturn(forged(0, 3)),
+			opReturn(chunks[2]),
+			opReturn(chunks[1]),
+		}
+
+		messages := assembleChunks(opReturns)
+		require.Len(t, messages, 1)
+
+		message := messages[0]
+		assert.True(t, message.Complete)
+		assert.Equal(t, payload, message.Data)
+		assert.Equal(t, uint16(3), message.Total)
+		assert.Equal(t, []string{opReturns[2].TxID, opReturns[5].TxID, opReturns[4].TxID}, message.TxIDs)
+		assert.Equal(t, int64(3), message.firstOPReturn)
+		assert.EqualValues(t, 300, message.Fee)
+		assert.Equal(t, int64(30), message.VSize)
+		assert.Equal(t, uint32(6), *message.Height)
+		assert.Equal(t, time.Unix(3, 0), *message.CreatedAt)
+	})
+
+	t.Run("incomplete", func(t *testing.T) {
+		nextID = 0
+		opReturns := []OPReturn{
+			opReturn(forged(1, 3)),
+			opReturn(chunks[0]),
+			opReturn(chunks[1]),
+		}
+
+		messages := assembleChunks(opReturns)
+		require.Len(t, messages, 1)
+
+		message := messages[0]
+		assert.False(t, message.Complete)
+		assert.Nil(t, message.Data)
+		assert.Equal(t, 2, message.Received())
+		assert.Equal(t, []string{opReturns[1].TxID, opReturns[0].TxID, ""}, message.TxIDs)
+	})
+
+	t.Run("gives up on too many candidates", func(t *testing.T) {
+		nextID = 0
+		var opReturns []OPReturn
+		for range 100 {
+			for index := range uint16(3) {
+				opReturns = append(opReturns, opReturn(forged(index, 3)))
+			}
+		}
+		opReturns = append(opReturns, lo.Map(chunks, func(chunk []byte, _ int) OPReturn {
+			return opReturn(chunk)
+		})...)
+
+		messages := assembleChunks(opReturns)
+		require.Len(t, messages, 1)
+		assert.False(t, messages[0].Complete)
+	})
+}
+
+func TestParseChunk(t *testing.T) {
+	t.Parallel()
+
+	id := NewChunkID([]byte("payload"))
+	chunk := func(index, total uint16, data string) []byte {
+		return slices.Concat(
+			chunkTag, id[:],
+			binary.BigEndian.AppendUint16(nil, index),
+			binary.BigEndian.AppendUint16(nil, total),
+			[]byte(data),
+		)
+	}
+
+	for _, test := range []struct {
+		name  string
+		data  []byte
+		chunk Chunk
+	}{
+		{"first", chunk(0, 2, "pay"), Chunk{ID: id, Index: 0, Total: 2, Data: []byte("pay")}},
+		{"last", chunk(1, 2, "load"), Chunk{ID: id, Index: 1, Total: 2, Data: []byte("load")}},
+		{"single", chunk(0, 1, "x"), Chunk{ID: id, Index: 0, Total: 1, Data: []byte("x")}},
+		{"most chunks", chunk(MaxChunks-1, MaxChunks, "x"), Chunk{ID: id, Index: MaxChunks - 1, Total: MaxChunks, Data: []byte("x")}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			parsed, ok := ParseChunk(test.data)
+			require.True(t, ok)
+			assert.Equal(t, test.chunk, parsed)
+		})
+	}
+
+	for name, data := range map[string][]byte{
+		"empty":              nil,
+		"tag only":           chunkTag,
+		"truncated header":   chunk(0, 1, "")[:chunkHeaderSize-1],
+		"no data":            chunk(0, 1, ""),
+		"wrong tag":          slices.Concat([]byte("bwchunx"), chunk(0, 1, "x")[len(chunkTag):]),
+		"zero total":         chunk(0, 0, "x"),
+		"index out of range": chunk(2, 2, "x"),
+	} {
+		_, ok := ParseChunk(data)
+		assert.False(t, ok, name)
+	}
+}
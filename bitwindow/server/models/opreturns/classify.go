@@ -0,0 +1,502 @@
+package opreturns
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/rs/zerolog"
+	"github.com/samber/lo"
+)
+
+// Protocol is what an OP_RETURN was made by.
+type Protocol string
+
+const (
+	ProtocolUnknown            Protocol = "unknown"
+	ProtocolCoinNews           Protocol = "coin_news"
+	ProtocolCoinNewsTopic      Protocol = "coin_news_topic"
+	ProtocolCoinNewsModeration Protocol = "coin_news_moderation"
+	ProtocolChunk              Protocol = "chunk"
+	ProtocolBIP300             Protocol = "bip300"
+	ProtocolRunes              Protocol = "runes"
+	ProtocolOmni               Protocol = "omni"
+	ProtocolTimestamp          Protocol = "timestamp"
+	ProtocolText               Protocol = "text"
+)
+
+// Classification is the protocol of an OP_RETURN, together with the fields
+// we could decode from it.
+type Classification struct {
+	Protocol Protocol
+	Fields   map[string]string
+}
+
+// Classifier recognises the protocol of an OP_RETURN output. script is the
+// full output script, data is what it pushes.
+type Classifier func(script, data []byte) (Classification, bool)
+
+// Classifiers are tried in order, the first one to recognise an output
+// wins.
+type Classifiers []Classifier
+
+// NewClassifiers returns all known classifiers. Coin news posts can only be
+// recognised for the given topics.
+func NewClassifiers(topics []TopicID) Classifiers {
+	return Classifiers{
+		classifyCoinNewsTopic,
+		classifyCoinNewsModeration,
+		classifyChunk,
+		coinNewsClassifier(topics),
+		classifyBIP300,
+		classifyRunestone,
+		classifyOmni,
+		classifyText,
+		classifyTimestamp,
+	}
+}
+
+func (c Classifiers) Classify(script, data []byte) Classification {
+	for _, classify := range c {
+		if classification, ok := classify(script, data); ok {
+			return classification
+		}
+	}
+	return Classification{Protocol: ProtocolUnknown}
+}
+
+func classifyCoinNewsTopic(_, data []byte) (Classification, bool) {
+	info, ok := IsCreateTopic(data)
+	if !ok {
+		return Classification{}, false
+	}
+
+	fields := map[string]string{"topic": info.ID.String(), "name": info.Name}
+	if info.Owner != "" {
+		fields["owner"] = info.Owner
+	}
+	return Classification{Protocol: ProtocolCoinNewsTopic, Fields: fields}, true
+}
+
+var moderationKindNames = map[ModerationKind]string{
+	ModerationRename:       "rename",
+	ModerationAddPoster:    "add_poster",
+	ModerationRemovePoster: "remove_poster",
+	ModerationHidePost:     "hide_post",
+}
+
+func classifyCoinNewsModeration(_, data []byte) (Classification, bool) {
+	op, ok := ParseModerationMessage(data)
+	if !ok {
+		return Classification{}, false
+	}
+
+	return Classification{Protocol: ProtocolCoinNewsModeration, Fields: map[string]string{
+		"topic":    op.Topic.String(),
+		"sequence": strconv.FormatUint(uint64(op.Sequence), 10),
+		"action":   moderationKindNames[op.Kind],
+		"signer":   op.Signer,
+	}}, true
+}
+
+func classifyChunk(_, data []byte) (Classification, bool) {
+	chunk, ok := ParseChunk(data)
+	if !ok {
+		return Classification{}, false
+	}
+
+	return Classification{Protocol: ProtocolChunk, Fields: map[string]string{
+		"message_id": chunk.ID.String(),
+		"index":      strconv.Itoa(int(chunk.Index)),
+		"total":      strconv.Itoa(int(chunk.Total)),
+	}}, true
+}
+
+func coinNewsClassifier(topics []TopicID) Classifier {
+	return func(_, data []byte) (Classification, bool) {
+		if len(data) < TopicIdLength || !slices.Contains(topics, TopicID(data[:TopicIdLength])) {
+			return Classification{}, false
+		}
+
+		post := decodeNewsPost(TopicID(data[:TopicIdLength]), data[TopicIdLength:])
+		fields := map[string]string{
+			"topic":    post.topic.String(),
+			"headline": post.headline,
+		}
+		if post.author != "" {
+			fields["author"] = post.author
+			fields["verified"] = strconv.FormatBool(post.verified)
+		}
+		if post.replyTo != "" {
+			delete(fields, "headline")
+			fields["reply_to"] = post.replyTo
+		}
+		if post.reaction != "" {
+			fields["reaction"] = post.reaction
+		}
+		return Classification{Protocol: ProtocolCoinNews, Fields: fields}, true
+	}
+}
+
+// BIP300 and BIP301 messages, M5 and M6 are not included as they're
+// recognised by their OP_DRIVECHAIN output, not an OP_RETURN.
+var bip300Tags = []struct {
+	message string
+	tag     []byte
+}{
+	{"M1", []byte{0xd5, 0xe0, 0xc4, 0xaf}}, // Propose sidechain
+	{"M2", []byte{0xd6, 0xe1, 0xc5, 0xdf}}, // Ack sidechain
+	{"M3", []byte{0xd4, 0x5a, 0xa9, 0x43}}, // Propose withdrawal bundle
+	{"M4", []byte{0xd7, 0x7d, 0x17, 0x76}}, // Ack withdrawal bundles
+	{"M7", []byte{0xd1, 0x61, 0x73, 0x68}}, // BMM accept
+	{"M8", []byte{0x00, 0xbf, 0x00}},       // BMM request
+}
+
+func classifyBIP300(_, data []byte) (Classification, bool) {
+	for _, candidate := range bip300Tags {
+		rest, ok := bytes.CutPrefix(data, candidate.tag)
+		if !ok {
+			continue
+		}
+
+		fields := map[string]string{"message": candidate.message}
+		switch {
+		case candidate.message == "M1" && len(rest) >= 3:
+			fields["sidechain"] = strconv.Itoa(int(rest[0]))
+			fields["version"] = strconv.Itoa(int(rest[1]))
+			if titleLen := int(rest[2]); len(rest) >= 3+titleLen && isText(rest[3:3+titleLen]) {
+				fields["title"] = string(rest[3 : 3+titleLen])
+			}
+
+		case candidate.message == "M2" && len(rest) == 33:
+			fields["sidechain"] = strconv.Itoa(int(rest[0]))
+			fields["proposal_hash"] = hex.EncodeToString(rest[1:])
+
+		case candidate.message == "M3" && len(rest) == 33:
+			fields["sidechain"] = strconv.Itoa(int(rest[0]))
+			fields["bundle_hash"] = hex.EncodeToString(rest[1:])
+
+		case candidate.message == "M4" && len(rest) >= 1:
+			fields["version"] = strconv.Itoa(int(rest[0]))
+
+		case candidate.message == "M7" && len(rest) == 33:
+			fields["sidechain"] = strconv.Itoa(int(rest[0]))
+			fields["sidechain_block_hash"] = hex.EncodeToString(rest[1:])
+
+		case candidate.message == "M8" && len(rest) == 65:
+			fields["sidechain"] = strconv.Itoa(int(rest[0]))
+			fields["sidechain_block_hash"] = hex.EncodeToString(rest[1:33])
+			fields["prev_block_hash"] = hex.EncodeToString(rest[33:])
+		}
+
+		return Classification{Protocol: ProtocolBIP300, Fields: fields}, true
+	}
+
+	return Classification{}, false
+}
+
+// RunestonePayload returns the concatenated data pushes of a runestone,
+// which is an OP_RETURN output starting with OP_RETURN OP_13. The payload is
+// nil if the runestone isn't made up of data pushes only.
+func RunestonePayload(script []byte) ([]byte, bool) {
+	if len(script) < 2 || script[0] != txscript.OP_RETURN || script[1] != txscript.OP_13 {
+		return nil, false
+	}
+
+	payload := []byte{}
+	tokenizer := txscript.MakeScriptTokenizer(0, script[2:])
+	for tokenizer.Next() {
+		// Only data pushes are allowed, anything else makes the
+		// runestone a cenotaph
+		if tokenizer.Opcode() > txscript.OP_PUSHDATA4 {
+			return nil, true
+		}
+		payload = append(payload, tokenizer.Data()...)
+	}
+	if tokenizer.Err() != nil {
+		return nil, true
+	}
+
+	return payload, true
+}
+
+// Runestone tags, see https://docs.ordinals.com/runes/specification.html
+const (
+	runeTagBody         = 0
+	runeTagDivisibility = 1
+	runeTagFlags        = 2
+	runeTagRune         = 4
+	runeTagSymbol       = 5
+	runeTagMint         = 20
+)
+
+func classifyRunestone(script, _ []byte) (Classification, bool) {
+	payload, ok := RunestonePayload(script)
+	if !ok {
+		return Classification{}, false
+	}
+
+	cenotaph := Classification{Protocol: ProtocolRunes, Fields: map[string]string{"cenotaph": "true"}}
+	if payload == nil {
+		return cenotaph, true
+	}
+
+	var integers []*big.Int
+	for len(payload) > 0 {
+		value, n := decodeLEB128(payload)
+		if n == 0 {
+			return cenotaph, true
+		}
+		integers = append(integers, value)
+		payload = payload[n:]
+	}
+
+	fields := map[string]string{}
+	var edicts int
+	for i := 0; i < len(integers); i += 2 {
+		tag := integers[i].Uint64()
+		if tag == runeTagBody {
+			edicts = len(integers[i+1:]) / 4
+			if len(integers[i+1:])%4 != 0 {
+				return cenotaph, true
+			}
+			break
+		}
+		if i+1 >= len(integers) {
+			return cenotaph, true
+		}
+		value := integers[i+1]
+
+		switch tag {
+		case runeTagFlags:
+			if value.Bit(0) == 1 {
+				fields["etching"] = "true"
+			}
+		case runeTagRune:
+			fields["rune"] = runeName(value)
+		case runeTagDivisibility:
+			fields["divisibility"] = value.String()
+		case runeTagSymbol:
+			if value.IsInt64() && utf8.ValidRune(rune(value.Int64())) {
+				fields["symbol"] = string(rune(value.Int64()))
+			}
+		case runeTagMint:
+			if i+3 < len(integers) && integers[i+2].Uint64() == runeTagMint {
+				fields["mint"] = value.String() + ":" + integers[i+3].String()
+				i += 2
+			}
+		}
+	}
+	fields["edicts"] = strconv.Itoa(edicts)
+
+	return Classification{Protocol: ProtocolRunes, Fields: fields}, true
+}
+
+// decodeLEB128 decodes an unsigned LEB128 integer of at most 128 bits,
+// returning how many bytes were read. Zero means the integer is invalid.
+func decodeLEB128(data []byte) (*big.Int, int) {
+	value := new(big.Int)
+	for i, b := range data {
+		if i == 19 {
+			return nil, 0
+		}
+		value.Or(value, new(big.Int).Lsh(big.NewInt(int64(b&0x7f)), uint(7*i)))
+		if b&0x80 == 0 {
+			if value.BitLen() > 128 {
+				return nil, 0
+			}
+			return value, i + 1
+		}
+	}
+	return nil, 0
+}
+
+// runeName converts a rune to its name, with 0 being A, 25 being Z and 26
+// being AA.
+func runeName(value *big.Int) string {
+	var (
+		n       = new(big.Int).Add(value, big.NewInt(1))
+		one     = big.NewInt(1)
+		letters = big.NewInt(26)
+		letter  = new(big.Int)
+		name    []byte
+	)
+	for n.Sign() > 0 {
+		n.Sub(n, one)
+		n.DivMod(n, letters, letter)
+		name = append(name, 'A'+byte(letter.Int64()))
+	}
+	slices.Reverse(name)
+	return string(name)
+}
+
+var omniTag = []byte("omni")
+
+var omniTypeNames = map[uint16]string{
+	0:  "simple_send",
+	3:  "send_to_owners",
+	4:  "send_all",
+	50: "create_property_fixed",
+	51: "create_property_variable",
+	54: "create_property_managed",
+	55: "grant_property_tokens",
+	56: "revoke_property_tokens",
+}
+
+func classifyOmni(_, data []byte) (Classification, bool) {
+	rest, ok := bytes.CutPrefix(data, omniTag)
+	if !ok || len(rest) < 4 {
+		return Classification{}, false
+	}
+
+	version := binary.BigEndian.Uint16(rest[0:2])
+	txType := binary.BigEndian.Uint16(rest[2:4])
+	fields := map[string]string{
+		"version": strconv.Itoa(int(version)),
+		"type":    strconv.Itoa(int(txType)),
+	}
+	if name, ok := omniTypeNames[txType]; ok {
+		fields["type_name"] = name
+	}
+	if txType == 0 && len(rest) >= 16 {
+		fields["property"] = strconv.FormatUint(uint64(binary.BigEndian.Uint32(rest[4:8])), 10)
+		fields["amount"] = strconv.FormatUint(binary.BigEndian.Uint64(rest[8:16]), 10)
+	}
+
+	return Classification{Protocol: ProtocolOmni, Fields: fields}, true
+}
+
+func isText(data []byte) bool {
+	if len(data) == 0 || !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func classifyText(_, data []byte) (Classification, bool) {
+	if !isText(data) {
+		return Classification{}, false
+	}
+	return Classification{Protocol: ProtocolText, Fields: map[string]string{
+		"text": strings.TrimSpace(string(data)),
+	}}, true
+}
+
+// classifyTimestamp recognises bare SHA256 hashes, which is what both our
+// own timestamps and OpenTimestamps calendars commit to.
+func classifyTimestamp(_, data []byte) (Classification, bool) {
+	if len(data) != 32 {
+		return Classification{}, false
+	}
+	return Classification{Protocol: ProtocolTimestamp, Fields: map[string]string{
+		"hash": hex.EncodeToString(data),
+	}}, true
+}
+
+// ClassifyUnclassified classifies all stored OP_RETURNs that don't have a
+// protocol yet. We don't keep the output script around, so this assumes
+// they're plain OP_RETURN <data> outputs.
+func ClassifyUnclassified(ctx context.Context, db *sql.DB, classifiers Classifiers) error {
+	start := time.Now()
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, txid, vout, unhex(op_return_data)
+		FROM op_returns
+		WHERE protocol IS NULL
+	`)
+	if err != nil {
+		return fmt.Errorf("query unclassified OP_RETURNs: %w", err)
+	}
+
+	var unclassified []OPReturn
+	for rows.Next() {
+		var opReturn OPReturn
+		if err := rows.Scan(&opReturn.ID, &opReturn.TxID, &opReturn.Vout, &opReturn.Data); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan unclassified OP_RETURN: %w", err)
+		}
+
+		script, err := txscript.NullDataScript(opReturn.Data)
+		if err != nil {
+			// Too large to be standard, still pushed by a single opcode
+			script = slices.Concat(
+				[]byte{txscript.OP_RETURN, txscript.OP_PUSHDATA4},
+				binary.LittleEndian.AppendUint32(nil, uint32(len(opReturn.Data))),
+				opReturn.Data,
+			)
+		}
+		classification := classifiers.Classify(script, opReturn.Data)
+		opReturn.Protocol, opReturn.Fields = classification.Protocol, classification.Fields
+		unclassified = append(unclassified, opReturn)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate unclassified OP_RETURNs: %w", err)
+	}
+
+	if len(unclassified) == 0 {
+		return nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, opReturn := range unclassified {
+		fields, err := encodeFields(opReturn.Fields)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE op_returns SET protocol = ?, protocol_fields = ? WHERE id = ?
+		`, opReturn.Protocol, fields, opReturn.ID); err != nil {
+			return fmt.Errorf("classify OP_RETURN %d: %w", opReturn.ID, err)
+		}
+	}
+
+	// Fields are indexed as well
+	for chunk := range slices.Chunk(unclassified, 500) {
+		if err := indexSearchText(ctx, tx, chunk); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	zerolog.Ctx(ctx).Debug().
+		Msgf("opreturns: classified %d OP_RETURN(s) in %s", len(unclassified), time.Since(start))
+
+	return nil
+}
+
+func encodeFields(fields map[string]string) (*string, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("encode protocol fields: %w", err)
+	}
+	return lo.ToPtr(string(encoded)), nil
+}
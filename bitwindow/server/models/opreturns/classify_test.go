@@ -0,0 +1,305 @@
+package opreturns
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeLEB128(t *testing.T) {
+	t.Parallel()
+
+	maxUint128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	for _, test := range []struct {
+		name  string
+		data  []byte
+		value *big.Int
+		n     int
+	}{
+		{"zero", []byte{0x00}, big.NewInt(0), 1},
+		{"one byte", []byte{0x7f}, big.NewInt(127), 1},
+		{"two bytes", []byte{0x80, 0x01}, big.NewInt(128), 2},
+		{"stops at the last byte", []byte{0xe5, 0x8e, 0x26, 0xff}, big.NewInt(624485), 3},
+		{"max", append(bytes.Repeat([]byte{0xff}, 18), 0x03), maxUint128, 19},
+		{"empty", nil, nil, 0},
+		{"truncated", []byte{0x80}, nil, 0},
+		{"truncated after several bytes", []byte{0xff, 0xff, 0xff}, nil, 0},
+		{"more than 128 bits", append(bytes.Repeat([]byte{0xff}, 18), 0x07), nil, 0},
+		{"more than 19 bytes", append(bytes.Repeat([]byte{0x80}, 19), 0x00), nil, 0},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			value, n := decodeLEB128(test.data)
+			assert.Equal(t, test.n, n)
+			if test.value == nil {
+				assert.Nil(t, value)
+			} else {
+				assert.Equal(t, test.value.String(), value.String())
+			}
+		})
+	}
+}
+
+func TestRuneName(t *testing.T) {
+	t.Parallel()
+
+	for value, name := range map[int64]string{
+		0:   "A",
+		1:   "B",
+		25:  "Z",
+		26:  "AA",
+		27:  "AB",
+		701: "ZZ",
+		702: "AAA",
+	} {
+		assert.Equal(t, name, runeName(big.NewInt(value)), value)
+	}
+}
+
+func TestClassifyRunestone(t *testing.T) {
+	t.Parallel()
+
+	runestone := func(pushes ...[]byte) []byte {
+		builder := txscript.NewScriptBuilder().AddOp(txscript.OP_RETURN).AddOp(txscript.OP_13)
+		for _, push := range pushes {
+			builder.AddData(push)
+		}
+		script, err := builder.Script()
+		require.NoError(t, err)
+		return script
+	}
+	cenotaph := map[string]string{"cenotaph": "true"}
+
+	for _, test := range []struct {
+		name   string
+		script []byte
+		fields map[string]string
+	}{
+		{
+			name:   "etching",
+			script: runestone([]byte{2, 1, 4, 26, 1, 2, 5, 0x41, 20, 1, 20, 5, 0, 1, 2, 3, 4}),
+			fields: map[string]string{
+				"etching": "true", "rune": "AA", "divisibility": "2", "symbol": "A", "mint": "1:5", "edicts": "1",
+			},
+		},
+		{
+			name:   "split across pushes",
+			script: runestone([]byte{4, 0x80}, []byte{0x01, 0, 1, 2}, []byte{3, 4, 5, 6, 7, 8}),
+			fields: map[string]string{"rune": "DY", "edicts": "2"},
+		},
+		{
+			name:   "empty",
+			script: runestone(),
+			fields: map[string]string{"edicts": "0"},
+		},
+		{
+			name:   "unknown tags are skipped",
+			script: runestone([]byte{99, 1, 4, 0}),
+			fields: map[string]string{"rune": "A", "edicts": "0"},
+		},
+		{
+			name:   "invalid symbol",
+			script: runestone([]byte{5, 0x80, 0x80, 0xc4, 0x00}),
+			fields: map[string]string{"edicts": "0"},
+		},
+		{
+			name:   "non-push opcode",
+			script: append(runestone([]byte{4, 0}), txscript.OP_VERIFY),
+			fields: cenotaph,
+		},
+		{
+			name:   "truncated push",
+			script: []byte{txscript.OP_RETURN, txscript.OP_13, txscript.OP_DATA_5, 4, 0},
+			fields: cenotaph,
+		},
+		{
+			name:   "truncated integer",
+			script: runestone([]byte{4, 0x80}),
+			fields: cenotaph,
+		},
+		{
+			name:   "tag without value",
+			script: runestone([]byte{2, 1, 4}),
+			fields: cenotaph,
+		},
+		{
+			name:   "incomplete edict",
+			script: runestone([]byte{0, 1, 2, 3}),
+			fields: cenotaph,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			classification, ok := classifyRunestone(test.script, nil)
+			require.True(t, ok)
+			assert.Equal(t, ProtocolRunes, classification.Protocol)
+			assert.Equal(t, test.fields, classification.Fields)
+		})
+	}
+
+	t.Run("not a runestone", func(t *testing.T) {
+		for _, script := range [][]byte{
+			nil,
+			{txscript.OP_RETURN},
+			{txscript.OP_RETURN, txscript.OP_DATA_1, 13},
+			{txscript.OP_RETURN, txscript.OP_12, txscript.OP_DATA_1, 0},
+		} {
+			_, ok := classifyRunestone(script, nil)
+			assert.False(t, ok, hex.EncodeToString(script))
+		}
+	})
+}
+
+func TestClassifyBIP300(t *testing.T) {
+	t.Parallel()
+
+	hash := bytes.Repeat([]byte{0xab}, 32)
+	other := bytes.Repeat([]byte{0xcd}, 32)
+	for _, test := range []struct {
+		name   string
+		data   []byte
+		fields map[string]string
+	}{
+		{
+			name:   "M1",
+			data:   slices.Concat([]byte{0xd5, 0xe0, 0xc4, 0xaf, 9, 1, 5}, []byte("Thunder")),
+			fields: map[string]string{"message": "M1", "sidechain": "9", "version": "1", "title": "Thund"},
+		},
+		{
+			name:   "M1 with a title that's too long",
+			data:   slices.Concat([]byte{0xd5, 0xe0, 0xc4, 0xaf, 9, 1, 50}, []byte("Thunder")),
+			fields: map[string]string{"message": "M1", "sidechain": "9", "version": "1"},
+		},
+		{
+			name:   "M1 with a binary title",
+			data:   []byte{0xd5, 0xe0, 0xc4, 0xaf, 9, 1, 2, 0xff, 0x00},
+			fields: map[string]string{"message": "M1", "sidechain": "9", "version": "1"},
+		},
+		{
+			name:   "truncated M1",
+			data:   []byte{0xd5, 0xe0, 0xc4, 0xaf, 9, 1},
+			fields: map[string]string{"message": "M1"},
+		},
+		{
+			name:   "M2",
+			data:   slices.Concat([]byte{0xd6, 0xe1, 0xc5, 0xdf, 2}, hash),
+			fields: map[string]string{"message": "M2", "sidechain": "2", "proposal_hash": hex.EncodeToString(hash)},
+		},
+		{
+			name:   "truncated M2",
+			data:   slices.Concat([]byte{0xd6, 0xe1, 0xc5, 0xdf, 2}, hash[1:]),
+			fields: map[string]string{"message": "M2"},
+		},
+		{
+			name:   "M3",
+			data:   slices.Concat([]byte{0xd4, 0x5a, 0xa9, 0x43, 3}, hash),
+			fields: map[string]string{"message": "M3", "sidechain": "3", "bundle_hash": hex.EncodeToString(hash)},
+		},
+		{
+			name:   "M4",
+			data:   []byte{0xd7, 0x7d, 0x17, 0x76, 1, 0xff},
+			fields: map[string]string{"message": "M4", "version": "1"},
+		},
+		{
+			name:   "M4 without a version",
+			data:   []byte{0xd7, 0x7d, 0x17, 0x76},
+			fields: map[string]string{"message": "M4"},
+		},
+		{
+			name:   "M7",
+			data:   slices.Concat([]byte{0xd1, 0x61, 0x73, 0x68, 4}, hash),
+			fields: map[string]string{"message": "M7", "sidechain": "4", "sidechain_block_hash": hex.EncodeToString(hash)},
+		},
+		{
+			name: "M8",
+			data: slices.Concat([]byte{0x00, 0xbf, 0x00, 5}, hash, other),
+			fields: map[string]string{
+				"message": "M8", "sidechain": "5",
+				"sidechain_block_hash": hex.EncodeToString(hash), "prev_block_hash": hex.EncodeToString(other),
+			},
+		},
+		{
+			name:   "truncated M8",
+			data:   slices.Concat([]byte{0x00, 0xbf, 0x00, 5}, hash),
+			fields: map[string]string{"message": "M8"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			classification, ok := classifyBIP300(nil, test.data)
+			require.True(t, ok)
+			assert.Equal(t, ProtocolBIP300, classification.Protocol)
+			assert.Equal(t, test.fields, classification.Fields)
+		})
+	}
+
+	t.Run("not BIP300", func(t *testing.T) {
+		for _, data := range [][]byte{
+			nil,
+			{0xd5, 0xe0, 0xc4},
+			{0x00, 0xbf},
+			[]byte("hello world"),
+		} {
+			_, ok := classifyBIP300(nil, data)
+			assert.False(t, ok, hex.EncodeToString(data))
+		}
+	})
+}
+
+func TestClassifyOmni(t *testing.T) {
+	t.Parallel()
+
+	omni := func(rest ...byte) []byte {
+		return slices.Concat([]byte("omni"), rest)
+	}
+	for _, test := range []struct {
+		name   string
+		data   []byte
+		fields map[string]string
+	}{
+		{
+			name: "simple send",
+			data: omni(0, 0, 0, 0, 0, 0, 0, 31, 0, 0, 0, 0, 0, 0, 0x27, 0x10),
+			fields: map[string]string{
+				"version": "0", "type": "0", "type_name": "simple_send", "property": "31", "amount": "10000",
+			},
+		},
+		{
+			name:   "truncated simple send",
+			data:   omni(0, 0, 0, 0, 0, 0, 0, 31, 0, 0),
+			fields: map[string]string{"version": "0", "type": "0", "type_name": "simple_send"},
+		},
+		{
+			name:   "create property",
+			data:   omni(0, 1, 0, 50, 1, 2, 3),
+			fields: map[string]string{"version": "1", "type": "50", "type_name": "create_property_fixed"},
+		},
+		{
+			name:   "unknown type",
+			data:   omni(0, 0, 0x03, 0xe7),
+			fields: map[string]string{"version": "0", "type": "999"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			classification, ok := classifyOmni(nil, test.data)
+			require.True(t, ok)
+			assert.Equal(t, ProtocolOmni, classification.Protocol)
+			assert.Equal(t, test.fields, classification.Fields)
+		})
+	}
+
+	t.Run("not omni", func(t *testing.T) {
+		for _, data := range [][]byte{
+			nil,
+			[]byte("omni"),
+			omni(0, 0, 0),
+			[]byte("omnx\x00\x00\x00\x00"),
+		} {
+			_, ok := classifyOmni(nil, data)
+			assert.False(t, ok, hex.EncodeToString(data))
+		}
+	})
+}
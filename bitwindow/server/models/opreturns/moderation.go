@@ -0,0 +1,268 @@
+package opreturns
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+var (
+	moderationTag     = []byte("mod")
+	topicSignatureTag = []byte("bitwindow/coinnews/topic")
+)
+
+type ModerationKind byte
+
+const (
+	// Payload: the new name
+	ModerationRename ModerationKind = 0x01
+	// Payload: hash160 of the poster's news key
+	ModerationAddPoster ModerationKind = 0x02
+	// Payload: hash160 of the poster's news key
+	ModerationRemovePoster ModerationKind = 0x03
+	// Payload: txid of the post, in internal byte order
+	ModerationHidePost ModerationKind = 0x04
+)
+
+// ModerationOp is an operation on a topic, signed by its owner.
+type ModerationOp struct {
+	Topic TopicID
+	// Operations are applied in order of their sequence number, not the
+	// order they're confirmed in. Operations with a sequence number that's
+	// already been used are ignored, so they can't be replayed. Starts at 1.
+	Sequence uint32
+	Kind     ModerationKind
+	Payload  []byte
+
+	// Who signed the operation. Only set when parsing.
+	Signer string
+	TxID   string
+}
+
+// topicSigHash commits to the topic, the kind of message and its contents.
+func topicSigHash(topic TopicID, tag []byte, parts ...[]byte) *chainhash.Hash {
+	return chainhash.TaggedHash(topicSignatureTag, slices.Concat(append([][]byte{topic[:], tag}, parts...)...))
+}
+
+// recoverTopicSigner returns the author the signature of a topic message
+// was made by.
+func recoverTopicSigner(topic TopicID, tag []byte, signature []byte, parts ...[]byte) (string, bool) {
+	pubKey, compressed, err := ecdsa.RecoverCompact(signature, topicSigHash(topic, tag, parts...)[:])
+	if err != nil || !compressed {
+		return "", false
+	}
+
+	return NewsAuthor(pubKey), true
+}
+
+// Format for OP_RETURN message: <topic>new<0x01><compact signature (65
+// bytes)><title>. The key that signed the message owns the topic.
+func EncodeOwnedTopicCreationMessage(topic TopicID, name string, key *btcec.PrivateKey) []byte {
+	signature := ecdsa.SignCompact(key, topicSigHash(topic, newTopicTag, []byte(name))[:], true)
+	return slices.Concat(
+		topic[:], newTopicTag, []byte{signedNewsFlag}, signature, []byte(name),
+	)
+}
+
+// Format for OP_RETURN message: <topic>mod<sequence (4 bytes, big
+// endian)><kind (1 byte)><compact signature (65 bytes)><payload>
+func EncodeModerationMessage(op ModerationOp, key *btcec.PrivateKey) []byte {
+	sequence := binary.BigEndian.AppendUint32(nil, op.Sequence)
+	kind := []byte{byte(op.Kind)}
+	signature := ecdsa.SignCompact(key, topicSigHash(op.Topic, moderationTag, sequence, kind, op.Payload)[:], true)
+
+	return slices.Concat(
+		op.Topic[:], moderationTag, sequence, kind, signature, op.Payload,
+	)
+}
+
+// ParseModerationMessage parses a moderation operation, and recovers who
+// signed it. Whether the signer owns the topic is up to the caller.
+func ParseModerationMessage(data []byte) (ModerationOp, bool) {
+	const headerSize = TopicIdLength + 3 + 4 + 1 + newsSignatureLength
+	if len(data) < headerSize {
+		return ModerationOp{}, false
+	}
+
+	topic := TopicID(data[:TopicIdLength])
+	rest, ok := bytes.CutPrefix(data[TopicIdLength:], moderationTag)
+	if !ok {
+		return ModerationOp{}, false
+	}
+
+	sequence, kind := rest[:4], rest[4:5]
+	signature, payload := rest[5:5+newsSignatureLength], rest[5+newsSignatureLength:]
+
+	op := ModerationOp{
+		Topic:    topic,
+		Sequence: binary.BigEndian.Uint32(sequence),
+		Kind:     ModerationKind(kind[0]),
+		Payload:  payload,
+	}
+	if err := op.Validate(); err != nil {
+		return ModerationOp{}, false
+	}
+
+	op.Signer, ok = recoverTopicSigner(topic, moderationTag, signature, sequence, kind, payload)
+	if !ok {
+		return ModerationOp{}, false
+	}
+
+	return op, true
+}
+
+// Validate checks that the payload makes sense for the kind of operation.
+func (op ModerationOp) Validate() error {
+	switch op.Kind {
+	case ModerationRename:
+		if len(op.Payload) == 0 || len(op.Payload) > 64 || !utf8.Valid(op.Payload) {
+			return fmt.Errorf("name must be between 1 and 64 bytes of UTF-8")
+		}
+
+	case ModerationAddPoster, ModerationRemovePoster:
+		if len(op.Payload) != authorLength {
+			return fmt.Errorf("poster must be %d bytes, got %d", authorLength, len(op.Payload))
+		}
+
+	case ModerationHidePost:
+		if len(op.Payload) != chainhash.HashSize {
+			return fmt.Errorf("txid must be %d bytes, got %d", chainhash.HashSize, len(op.Payload))
+		}
+
+	default:
+		return fmt.Errorf("unknown moderation kind %d", op.Kind)
+	}
+
+	return nil
+}
+
+// Moderation is the state of an owned topic, after applying all operations
+// signed by its owner.
+type Moderation struct {
+	Owner string
+	// Empty if the topic was never renamed
+	Name string
+	// If not empty, only verified posts by the owner and these authors are
+	// allowed
+	Posters map[string]bool
+	// Txids of hidden posts
+	Hidden map[string]bool
+	// Sequence number of the last applied operation. The next one has to
+	// be higher.
+	Sequence uint32
+}
+
+// Allows returns whether the post should be shown.
+func (m *Moderation) Allows(txid, author string, verified bool) bool {
+	if m.Hidden[txid] {
+		return false
+	}
+	if len(m.Posters) == 0 {
+		return true
+	}
+
+	return verified && (author == m.Owner || m.Posters[author])
+}
+
+func (m *Moderation) apply(op ModerationOp) {
+	switch op.Kind {
+	case ModerationRename:
+		m.Name = string(op.Payload)
+	case ModerationAddPoster:
+		m.Posters[hex.EncodeToString(op.Payload)] = true
+	case ModerationRemovePoster:
+		delete(m.Posters, hex.EncodeToString(op.Payload))
+	case ModerationHidePost:
+		m.Hidden[chainhash.Hash(op.Payload).String()] = true
+	}
+	m.Sequence = op.Sequence
+}
+
+// GetModeration returns the moderation state of all owned topics among
+// the given ones. Operations still in the mempool are included.
+func GetModeration(ctx context.Context, db *sql.DB, topics []Topic) (map[TopicID]*Moderation, error) {
+	moderation := make(map[TopicID]*Moderation)
+	for _, topic := range topics {
+		if topic.Owner == "" {
+			continue
+		}
+		moderation[topic.Topic] = &Moderation{
+			Owner:   topic.Owner,
+			Posters: make(map[string]bool),
+			Hidden:  make(map[string]bool),
+		}
+	}
+	if len(moderation) == 0 {
+		return moderation, nil
+	}
+
+	// The topic is hex encoded in the database, the tag starts right after
+	rows, err := db.QueryContext(ctx, `
+		SELECT txid, unhex(op_return_data)
+		FROM op_returns
+		WHERE status = ? AND substr(op_return_data, ?, ?) = ?
+		ORDER BY id
+	`, StatusActive, TopicIdLength*2+1, len(moderationTag)*2, hex.EncodeToString(moderationTag))
+	if err != nil {
+		return nil, fmt.Errorf("query moderation ops: %w", err)
+	}
+	defer rows.Close()
+
+	var ops []ModerationOp
+	for rows.Next() {
+		var (
+			txid string
+			data []byte
+		)
+		if err := rows.Scan(&txid, &data); err != nil {
+			return nil, fmt.Errorf("scan moderation op: %w", err)
+		}
+
+		op, ok := ParseModerationMessage(data)
+		if !ok {
+			continue
+		}
+		if mod, ok := moderation[op.Topic]; !ok || op.Signer != mod.Owner {
+			continue
+		}
+		op.TxID = txid
+		ops = append(ops, op)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate moderation ops: %w", err)
+	}
+
+	// Stable, so the first operation seen wins if a sequence number is
+	// reused
+	sort.SliceStable(ops, func(i, j int) bool {
+		return ops[i].Sequence < ops[j].Sequence
+	})
+	for _, op := range ops {
+		mod := moderation[op.Topic]
+		if op.Sequence <= mod.Sequence {
+			continue
+		}
+		mod.apply(op)
+	}
+
+	return moderation, nil
+}
+
+// PosterHash decodes a hex encoded author, as returned by NewsAuthor.
+func PosterHash(author string) ([]byte, error) {
+	hash, err := hex.DecodeString(author)
+	if err != nil || len(hash) != authorLength {
+		return nil, fmt.Errorf("author %q is not a %d byte hex pubkey hash", author, authorLength)
+	}
+	return hash, nil
+}
@@ -0,0 +1,80 @@
+package opreturns
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseModerationMessage(t *testing.T) {
+	t.Parallel()
+
+	key, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	owner := NewsAuthor(key.PubKey())
+	poster, err := PosterHash(NewsAuthor(key.PubKey()))
+	require.NoError(t, err)
+
+	topic := TopicID([]byte("news0001"))
+	txid := bytes.Repeat([]byte{0x42}, 32)
+
+	for _, op := range []ModerationOp{
+		{Topic: topic, Sequence: 1, Kind: ModerationRename, Payload: []byte("Renamed")},
+		{Topic: topic, Sequence: 2, Kind: ModerationAddPoster, Payload: poster},
+		{Topic: topic, Sequence: 3, Kind: ModerationRemovePoster, Payload: poster},
+		{Topic: topic, Sequence: 1<<32 - 1, Kind: ModerationHidePost, Payload: txid},
+	} {
+		parsed, ok := ParseModerationMessage(EncodeModerationMessage(op, key))
+		require.True(t, ok, op.Kind)
+
+		op.Signer = owner
+		assert.Equal(t, op, parsed)
+	}
+
+	valid := EncodeModerationMessage(ModerationOp{
+		Topic: topic, Sequence: 1, Kind: ModerationHidePost, Payload: txid,
+	}, key)
+	signed := func(kind ModerationKind, payload []byte) []byte {
+		return EncodeModerationMessage(ModerationOp{Topic: topic, Sequence: 1, Kind: kind, Payload: payload}, key)
+	}
+	for name, data := range map[string][]byte{
+		"empty":                  nil,
+		"topic only":             topic[:],
+		"truncated header":       valid[:TopicIdLength+3+4+1+newsSignatureLength-1],
+		"truncated payload":      valid[:len(valid)-1],
+		"not a moderation tag":   slices.Concat(topic[:], []byte("new"), valid[TopicIdLength+3:]),
+		"unknown kind":           signed(0x05, txid),
+		"empty name":             signed(ModerationRename, nil),
+		"name too long":          signed(ModerationRename, bytes.Repeat([]byte("a"), 65)),
+		"name not UTF-8":         signed(ModerationRename, []byte{0xff, 0xfe}),
+		"poster hash too short":  signed(ModerationAddPoster, poster[1:]),
+		"poster hash too long":   signed(ModerationRemovePoster, append(poster, 0)),
+		"invalid signature":      slices.Concat(valid[:TopicIdLength+3+4+1], make([]byte, newsSignatureLength), txid),
+		"uncompressed signature": slices.Concat(valid[:TopicIdLength+3+4+1], []byte{valid[TopicIdLength+3+4+1] - 4}, valid[TopicIdLength+3+4+2:]),
+	} {
+		_, ok := ParseModerationMessage(data)
+		assert.False(t, ok, name)
+	}
+
+	t.Run("tampered", func(t *testing.T) {
+		// Still a valid signature, but by someone else
+		tampered := slices.Clone(valid)
+		tampered[len(tampered)-1] ^= 0xff
+
+		op, ok := ParseModerationMessage(tampered)
+		if ok {
+			assert.NotEqual(t, owner, op.Signer)
+		}
+
+		otherTopic := slices.Clone(valid)
+		copy(otherTopic, "news0002")
+		op, ok = ParseModerationMessage(otherTopic)
+		if ok {
+			assert.NotEqual(t, owner, op.Signer)
+		}
+	})
+}
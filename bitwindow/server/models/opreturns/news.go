@@ -0,0 +1,580 @@
+package opreturns
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/samber/lo"
+)
+
+// NewsReader decodes coin news, against the topics and their moderation as
+// they were when the reader was created.
+type NewsReader struct {
+	db         *sql.DB
+	topics     []Topic
+	moderation map[TopicID]*Moderation
+
+	// Complete chunked posts, loaded when first needed
+	chunked       []CoinNews
+	chunkedLoaded bool
+}
+
+func NewNewsReader(ctx context.Context, db *sql.DB) (*NewsReader, error) {
+	topics, err := ListTopics(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	moderation, err := GetModeration(ctx, db, topics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NewsReader{db: db, topics: topics, moderation: moderation}, nil
+}
+
+// Decode decodes the coin news posts among the given OP_RETURNs.
+func (r *NewsReader) Decode(opReturns []OPReturn) []CoinNews {
+	var coinNews []CoinNews
+	for _, opReturn := range opReturns {
+		if post, ok := r.decode(opReturn); ok {
+			coinNews = append(coinNews, post)
+		}
+	}
+	return coinNews
+}
+
+func (r *NewsReader) decode(opReturn OPReturn) (CoinNews, bool) {
+	// Headlines that will never confirm
+	if opReturn.Status != StatusActive {
+		return CoinNews{}, false
+	}
+
+	if len(opReturn.Data) < TopicIdLength {
+		return CoinNews{}, false
+	}
+
+	// Skip over all topic creation and moderation OP_RETURNs
+	if _, ok := IsCreateTopic(opReturn.Data); ok {
+		return CoinNews{}, false
+	}
+	if _, ok := ParseModerationMessage(opReturn.Data); ok {
+		return CoinNews{}, false
+	}
+
+	topic, ok := extractTopic(r.topics, TopicID(opReturn.Data[:TopicIdLength]))
+	if !ok {
+		return CoinNews{}, false
+	}
+
+	post := decodeNewsPost(topic.Topic, opReturn.Data[TopicIdLength:])
+	if post.isReaction && !ValidReaction(post.reaction) {
+		return CoinNews{}, false
+	}
+
+	topicName := topic.Name
+	var moderated bool
+	if mod, ok := r.moderation[topic.Topic]; ok {
+		topicName = lo.CoalesceOrEmpty(mod.Name, topicName)
+		moderated = !topic.Subscription.IgnoreModeration && !mod.Allows(opReturn.TxID, post.author, post.verified)
+	}
+
+	return CoinNews{
+		ID:        opReturn.ID,
+		TxID:      opReturn.TxID,
+		Height:    opReturn.Height,
+		Topic:     topic.Topic,
+		TopicName: topicName,
+		Headline:  post.headline,
+		Content:   post.content,
+		Fee:       opReturn.Fee,
+		VSize:     opReturn.VSize,
+		Author:    post.author,
+		Verified:  post.verified,
+		Moderated: moderated,
+		ReplyTo:   post.replyTo,
+		Reaction:  post.reaction,
+		CreatedAt: opReturn.CreatedAt,
+		seenAt:    opReturn.seenAt,
+	}, true
+}
+
+// CoinNewsFor returns the coin news posted by the given OP_RETURNs. Chunks
+// are returned as the post they're part of, if it's complete.
+func (r *NewsReader) CoinNewsFor(ctx context.Context, values []OPReturn) ([]CoinNews, error) {
+	var (
+		chunkIDs   []ChunkID
+		chunkTxIDs = make(map[string]bool)
+	)
+	opReturns := lo.Filter(values, func(value OPReturn, _ int) bool {
+		if chunk, ok := ParseChunk(value.Data); ok {
+			chunkIDs = append(chunkIDs, chunk.ID)
+			chunkTxIDs[value.TxID] = true
+			return false
+		}
+		// Saves decoding everything else. Unclassified ones could still be
+		// news.
+		return value.Protocol == "" || value.Protocol == ProtocolCoinNews
+	})
+
+	if len(chunkIDs) > 0 {
+		chunked, err := listChunkedMessagesByID(ctx, r.db, lo.Uniq(chunkIDs))
+		if err != nil {
+			return nil, err
+		}
+		for _, message := range chunked {
+			if message.Complete && lo.SomeBy(message.TxIDs, func(txid string) bool { return chunkTxIDs[txid] }) {
+				opReturns = append(opReturns, message.opReturn())
+			}
+		}
+	}
+
+	return r.Decode(opReturns), nil
+}
+
+// FindPost returns the post or reply with the given txid. Posts split across
+// several transactions have the txid of their first chunk. Reactions aren't
+// posts, and aren't returned.
+func (r *NewsReader) FindPost(ctx context.Context, txid string) (CoinNews, bool, error) {
+	opReturns, err := ListByTxID(ctx, r.db, txid)
+	if err != nil {
+		return CoinNews{}, false, err
+	}
+
+	news, err := r.CoinNewsFor(ctx, opReturns)
+	if err != nil {
+		return CoinNews{}, false, err
+	}
+
+	post, ok := lo.Find(news, func(post CoinNews) bool {
+		return post.TxID == txid && post.Reaction == ""
+	})
+	return post, ok, nil
+}
+
+// ChangesTopics returns whether any of the OP_RETURNs creates or moderates a
+// topic. A NewsReader created before them can be out of date.
+func ChangesTopics(values []OPReturn) bool {
+	return lo.SomeBy(values, func(value OPReturn) bool {
+		if _, ok := IsCreateTopic(value.Data); ok {
+			return true
+		}
+		_, ok := ParseModerationMessage(value.Data)
+		return ok
+	})
+}
+
+// chunkedNews returns the posts that were split across several
+// transactions, and are complete.
+func (r *NewsReader) chunkedNews(ctx context.Context) ([]CoinNews, error) {
+	if r.chunkedLoaded {
+		return r.chunked, nil
+	}
+
+	messages, err := ListChunkedMessages(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	r.chunked = r.Decode(lo.FilterMap(messages, func(message ChunkedMessage, _ int) (OPReturn, bool) {
+		return message.opReturn(), message.Complete
+	}))
+	r.chunkedLoaded = true
+
+	return r.chunked, nil
+}
+
+// NewsQuery selects coin news posts. Replies and reactions are left out,
+// they're listed with their thread.
+type NewsQuery struct {
+	Filter Filter
+	// Only posts in this topic
+	Topic *TopicID
+	// Only posts verified to be by this author, lowercase hex
+	Author string
+	// Includes posts hidden by topic moderation
+	IgnoreModeration bool
+	// Leaves out the posts topic subscriptions don't show
+	Subscriptions bool
+	// Sorts by fee rate, before recency
+	Ranked bool
+	// Where the previous page ended, nil for the first page
+	After *NewsCursor
+	Limit int
+}
+
+// Search returns a page of posts matching the query, and where the next page
+// starts. The cursor is nil if there are no more pages.
+func (r *NewsReader) Search(ctx context.Context, query NewsQuery) ([]CoinNews, *NewsCursor, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	limit = min(limit, MaxPageSize)
+
+	topics := r.listedTopics(query)
+	if len(topics) == 0 {
+		return nil, nil, nil
+	}
+
+	// Prefixes of the data can use the index on it
+	var inTopics sq.Or
+	for _, topic := range topics {
+		inTopic := sq.And{sq.Expr("op_return_data GLOB ?", topic.Topic.String()+"*")}
+		if query.Subscriptions && topic.Subscription.MinFee > 0 {
+			inTopic = append(inTopic, sq.GtOrEq{"fee_sats": int64(topic.Subscription.MinFee)})
+		}
+		inTopics = append(inTopics, inTopic)
+	}
+
+	selected := query.Filter.where(selectOPReturns()).
+		Where(sq.Eq{"status": StatusActive}).
+		Where(inTopics).
+		// One extra, to know if there's another page
+		Limit(uint64(limit + 1))
+	if query.Author != "" {
+		// Signed posts have the flag and the author right after the topic
+		selected = selected.Where("substr(op_return_data, ?, ?) = ?",
+			TopicIdLength*2+1, (1+authorLength)*2, hex.EncodeToString([]byte{signedNewsFlag})+query.Author)
+	}
+	if query.Ranked {
+		selected = selected.OrderBy(feeRateColumn+" DESC", seenAtColumn+" DESC", "id DESC")
+	} else {
+		selected = selected.OrderBy(seenAtColumn+" DESC", "id DESC")
+	}
+
+	// Moderation and replies can't be told apart in SQL. Pages are fetched
+	// until enough of what they return is listed.
+	var (
+		news  []CoinNews
+		after = query.After
+	)
+	for {
+		page := selected
+		if after != nil {
+			page = after.where(page)
+		}
+
+		opReturns, err := queryOPReturns(ctx, r.db, page)
+		if err != nil {
+			return nil, nil, fmt.Errorf("search news: %w", queryError(query.Filter.Query, err))
+		}
+		for _, opReturn := range opReturns {
+			if post, ok := r.decode(opReturn); ok && r.lists(query, post) {
+				news = append(news, post)
+			}
+		}
+
+		if len(opReturns) <= limit || len(news) > limit {
+			break
+		}
+		last := opReturns[len(opReturns)-1]
+		after = lo.ToPtr(newNewsCursor(query.Ranked, FeeRate(last.Fee, last.VSize), last.seenAt, last.ID))
+	}
+
+	chunked, err := r.searchChunkedNews(ctx, query, topics)
+	if err != nil {
+		return nil, nil, err
+	}
+	news = append(news, chunked...)
+
+	sort.Slice(news, func(i, j int) bool {
+		return newsCursor(news[i], query.Ranked).after(newsCursor(news[j], query.Ranked))
+	})
+	if len(news) <= limit {
+		return news, nil, nil
+	}
+	news = news[:limit]
+	return news, lo.ToPtr(newsCursor(news[limit-1], query.Ranked)), nil
+}
+
+// searchChunkedNews returns the complete chunked posts matching the query.
+// There are few enough of them to filter here.
+func (r *NewsReader) searchChunkedNews(ctx context.Context, query NewsQuery, topics []Topic) ([]CoinNews, error) {
+	chunked, err := r.chunkedNews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byTopic := lo.KeyBy(topics, func(topic Topic) TopicID { return topic.Topic })
+	chunked = lo.Filter(chunked, func(post CoinNews, _ int) bool {
+		topic, ok := byTopic[post.Topic]
+		switch {
+		case !ok:
+			return false
+		case query.Subscriptions && post.Fee < topic.Subscription.MinFee:
+			return false
+		case query.Author != "" && post.Author != query.Author:
+			return false
+		case query.After != nil && !query.After.after(newsCursor(post, query.Ranked)):
+			return false
+		}
+		return r.lists(query, post)
+	})
+
+	var matched map[int64]bool
+	if query.Filter.Query != "" {
+		matched, err = matchSearch(ctx, r.db, query.Filter.Query, lo.Map(chunked, func(post CoinNews, _ int) int64 {
+			return post.ID
+		}))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return lo.Filter(chunked, func(post CoinNews, _ int) bool {
+		return query.Filter.MatchesNews(post, matched)
+	}), nil
+}
+
+// listedTopics returns the topics posts can be listed from.
+func (r *NewsReader) listedTopics(query NewsQuery) []Topic {
+	return lo.Filter(r.topics, func(topic Topic, _ int) bool {
+		switch {
+		case query.Topic != nil && topic.Topic != *query.Topic:
+			return false
+		case !query.Subscriptions:
+			return true
+		// The minimum fee is left to the caller, see Subscription.Shows
+		case topic.Subscription.Muted:
+			return false
+		case !topic.Subscription.Subscribed && query.Topic == nil:
+			return false
+		}
+		return true
+	})
+}
+
+// lists returns whether a post in one of the listed topics matches the
+// parts of the query that can't be checked in SQL.
+func (r *NewsReader) lists(query NewsQuery, post CoinNews) bool {
+	switch {
+	case post.ReplyTo != "":
+		return false
+	case !query.IgnoreModeration && post.Moderated:
+		return false
+	// Unverified posts could be from anyone
+	case query.Author != "" && !post.Verified:
+		return false
+	}
+	return true
+}
+
+// Summarize returns the thread summary of each of the given posts, by txid.
+// Moderated replies and reactions aren't counted, unless moderation is
+// ignored.
+func (r *NewsReader) Summarize(ctx context.Context, posts []CoinNews, ignoreModeration bool) (map[string]ThreadSummary, error) {
+	thread, err := r.listThreads(ctx, posts, ignoreModeration)
+	if err != nil {
+		return nil, err
+	}
+	return SummarizeThreads(thread), nil
+}
+
+// Thread returns the thread under the post or reply with the given txid.
+// Moderated posts and replies are left out, unless moderation is ignored.
+// Returns false if there's no such post.
+func (r *NewsReader) Thread(ctx context.Context, txid string, ignoreModeration bool) (*Thread, bool, error) {
+	post, ok, err := r.FindPost(ctx, txid)
+	if err != nil || !ok || (!ignoreModeration && post.Moderated) {
+		return nil, false, err
+	}
+
+	thread, err := r.listThreads(ctx, []CoinNews{post}, ignoreModeration)
+	if err != nil {
+		return nil, false, err
+	}
+
+	built, ok := BuildThread(thread, post.TxID)
+	return built, ok, nil
+}
+
+// listThreads returns the given posts, together with all replies and
+// reactions below them. They're listed a level at a time.
+func (r *NewsReader) listThreads(ctx context.Context, posts []CoinNews, ignoreModeration bool) ([]CoinNews, error) {
+	chunked, err := r.chunkedNews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		thread  = slices.Clone(posts)
+		seen    = make(map[int64]bool)
+		parents = posts
+	)
+	for _, post := range posts {
+		seen[post.ID] = true
+	}
+	for len(parents) > 0 {
+		replies, err := r.listReplies(ctx, parents)
+		if err != nil {
+			return nil, err
+		}
+		parentTxIDs := lo.SliceToMap(parents, func(parent CoinNews) (string, bool) {
+			return parent.TxID, true
+		})
+		replies = append(replies, lo.Filter(chunked, func(post CoinNews, _ int) bool {
+			return parentTxIDs[post.ReplyTo]
+		})...)
+
+		parents = nil
+		for _, reply := range replies {
+			if seen[reply.ID] || (!ignoreModeration && reply.Moderated) {
+				continue
+			}
+			seen[reply.ID] = true
+
+			thread = append(thread, reply)
+			if reply.Reaction == "" {
+				parents = append(parents, reply)
+			}
+		}
+	}
+
+	return thread, nil
+}
+
+// listReplies returns the replies and reactions to the given posts. They're
+// in the topic of the post they're for.
+func (r *NewsReader) listReplies(ctx context.Context, parents []CoinNews) ([]CoinNews, error) {
+	// Signed replies have the author and signature before the flag
+	var (
+		signedFlag = hex.EncodeToString([]byte{signedNewsFlag})
+		signature  = strings.Repeat("?", (authorLength+newsSignatureLength)*2)
+	)
+
+	var replies []CoinNews
+	for batch := range slices.Chunk(parents, DefaultPageSize) {
+		var patterns sq.Or
+		for _, parent := range batch {
+			// Replies commit to the txid, which can't be anything else
+			hash, err := chainhash.NewHashFromStr(parent.TxID)
+			if err != nil {
+				continue
+			}
+			for _, flag := range []byte{replyFlag, reactionFlag} {
+				body := hex.EncodeToString(append([]byte{flag}, hash[:]...)) + "*"
+				patterns = append(patterns,
+					sq.Expr("op_return_data GLOB ?", parent.Topic.String()+body),
+					sq.Expr("op_return_data GLOB ?", parent.Topic.String()+signedFlag+signature+body),
+				)
+			}
+		}
+		if len(patterns) == 0 {
+			continue
+		}
+
+		opReturns, err := queryOPReturns(ctx, r.db, selectOPReturns().
+			Where(sq.Eq{"status": StatusActive}).
+			Where(patterns).
+			OrderBy("id"))
+		if err != nil {
+			return nil, fmt.Errorf("list replies: %w", err)
+		}
+		replies = append(replies, r.Decode(opReturns)...)
+	}
+
+	return replies, nil
+}
+
+// feeRateColumn is the fee rate of an OP_RETURN's transaction in sat/vB, as
+// FeeRate computes it.
+const feeRateColumn = "CASE WHEN vsize > 0 THEN CAST(fee_sats AS REAL) / vsize ELSE 0 END"
+
+// NewsCursor is where a page of coin news ends. Posts are sorted by when
+// they were first seen, newest first. Ranked posts are sorted by fee rate
+// first. The ID breaks ties, so pages are stable.
+type NewsCursor struct {
+	Ranked bool
+	// In sat/vB, only set if ranked
+	FeeRate float64
+	// In seconds since the epoch, to the millisecond
+	SeenAt float64
+	ID     int64
+}
+
+func newNewsCursor(ranked bool, feeRate, seenAt float64, id int64) NewsCursor {
+	cursor := NewsCursor{Ranked: ranked, SeenAt: seenAt, ID: id}
+	if ranked {
+		cursor.FeeRate = feeRate
+	}
+	return cursor
+}
+
+func newsCursor(news CoinNews, ranked bool) NewsCursor {
+	return newNewsCursor(ranked, news.FeeRate(), news.seenAt, news.ID)
+}
+
+// after returns whether other comes after c, when sorted.
+func (c NewsCursor) after(other NewsCursor) bool {
+	if c.FeeRate != other.FeeRate {
+		return c.FeeRate > other.FeeRate
+	}
+	if c.SeenAt != other.SeenAt {
+		return c.SeenAt > other.SeenAt
+	}
+	return c.ID > other.ID
+}
+
+// where narrows down a query over op_returns to what comes after c.
+func (c NewsCursor) where(query sq.SelectBuilder) sq.SelectBuilder {
+	if c.Ranked {
+		return query.Where("("+feeRateColumn+", "+seenAtColumn+", id) < (?, ?, ?)", c.FeeRate, c.SeenAt, c.ID)
+	}
+	return query.Where("("+seenAtColumn+", id) < (?, ?)", c.SeenAt, c.ID)
+}
+
+func (c NewsCursor) String() string {
+	seenAt := strconv.FormatFloat(c.SeenAt, 'f', -1, 64)
+	if c.Ranked {
+		return fmt.Sprintf("%s:%s:%d", strconv.FormatFloat(c.FeeRate, 'g', -1, 64), seenAt, c.ID)
+	}
+	return fmt.Sprintf("%s:%d", seenAt, c.ID)
+}
+
+// ParseNewsCursor parses a page token. Tokens for ranked and unranked pages
+// can't be mixed up.
+func ParseNewsCursor(token string, ranked bool) (NewsCursor, error) {
+	invalid := fmt.Errorf("invalid page token %q", token)
+	parseFloat := func(value string) (float64, bool) {
+		parsed, err := strconv.ParseFloat(value, 64)
+		return parsed, err == nil && parsed >= 0 && !math.IsInf(parsed, 0)
+	}
+
+	parts := strings.Split(token, ":")
+	cursor := NewsCursor{Ranked: ranked}
+	if ranked {
+		if len(parts) != 3 {
+			return NewsCursor{}, invalid
+		}
+		feeRate, ok := parseFloat(parts[0])
+		if !ok {
+			return NewsCursor{}, invalid
+		}
+		cursor.FeeRate = feeRate
+		parts = parts[1:]
+	}
+	if len(parts) != 2 {
+		return NewsCursor{}, invalid
+	}
+
+	var (
+		ok  bool
+		err error
+	)
+	if cursor.SeenAt, ok = parseFloat(parts[0]); !ok {
+		return NewsCursor{}, invalid
+	}
+	if cursor.ID, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+		return NewsCursor{}, invalid
+	}
+	return cursor, nil
+}
@@ -0,0 +1,184 @@
+package opreturns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewsReaderSearch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := database.Test(t)
+
+	topic := TopicID([]byte("news0001"))
+	muted := TopicID([]byte("news0002"))
+	require.NoError(t, CreateTopic(ctx, db, topic, "News", "topic_txid"))
+	require.NoError(t, CreateTopic(ctx, db, muted, "Muted", "muted_txid"))
+	require.NoError(t, SetSubscription(ctx, db, Subscription{Topic: muted, Subscribed: true, Muted: true}))
+
+	key, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	now := time.Now()
+	persist := func(name string, data []byte, fee btcutil.Amount, age time.Duration) chainhash.Hash {
+		txid := chainhash.HashH([]byte(name))
+		require.NoError(t, Persist(ctx, db, []OPReturn{{
+			TxID:      txid.String(),
+			Data:      data,
+			Fee:       fee,
+			VSize:     100,
+			CreatedAt: lo.ToPtr(now.Add(-age)),
+		}}))
+		return txid
+	}
+
+	first := persist("first", EncodeNewsMessage(topic, "First", ""), 1_000, 5*time.Hour)
+	signed := persist("signed", EncodeSignedNewsMessage(topic, "Signed", "", key), 3_000, 4*time.Hour)
+	reply := persist("reply", EncodeReplyMessage(topic, first, "unsigned reply"), 100, 3*time.Hour)
+	persist("nested", SignNewsMessage(EncodeReplyMessage(topic, reply, "signed reply"), key), 100, 3*time.Hour)
+	persist("reaction", EncodeReactionMessage(topic, first, "👍"), 100, 3*time.Hour)
+	persist("muted", EncodeNewsMessage(muted, "Muted", ""), 100_000, 2*time.Hour)
+	// Replies fill up whole pages, which have to be skipped over
+	for i := range 5 {
+		persist("filler"+string(rune('a'+i)), EncodeReactionMessage(topic, signed, "🔥"), 100, 2*time.Hour)
+	}
+	persist("last", EncodeNewsMessage(topic, "Last", ""), 2_000, time.Hour)
+
+	chunks, err := EncodeChunks(EncodeNewsMessage(topic, "Chunked", string(make([]byte, 100))), MaxOPReturnSize)
+	require.NoError(t, err)
+	for i, chunk := range chunks {
+		persist("chunk"+string(rune('a'+i)), chunk, 500, 90*time.Minute-time.Duration(i)*time.Minute)
+	}
+
+	reader, err := NewNewsReader(ctx, db)
+	require.NoError(t, err)
+
+	headlines := func(news []CoinNews) []string {
+		return lo.Map(news, func(post CoinNews, _ int) string { return post.Headline })
+	}
+	search := func(query NewsQuery) []string {
+		var all []string
+		for {
+			news, next, err := reader.Search(ctx, query)
+			require.NoError(t, err)
+			all = append(all, headlines(news)...)
+			if next == nil {
+				return all
+			}
+
+			// Through the page token, like clients do
+			cursor, err := ParseNewsCursor(next.String(), query.Ranked)
+			require.NoError(t, err)
+			query.After = &cursor
+		}
+	}
+
+	assert.Equal(t, []string{"Last", "Chunked", "Signed", "First"}, search(NewsQuery{Subscriptions: true, Limit: 1}))
+	assert.Equal(t, []string{"Last", "Chunked", "Signed", "First"}, search(NewsQuery{Subscriptions: true, Limit: 2}))
+	assert.Equal(t, []string{"Last", "Chunked", "Muted", "Signed", "First"}, search(NewsQuery{}))
+	assert.Equal(t, []string{"Signed", "Last", "First", "Chunked"}, search(NewsQuery{Subscriptions: true, Ranked: true, Limit: 1}))
+	assert.Equal(t, []string{"Signed"}, search(NewsQuery{Author: NewsAuthor(key.PubKey())}))
+	assert.Equal(t, []string{"Muted"}, search(NewsQuery{Topic: &muted}))
+	assert.Equal(t, []string{"Last", "Signed"}, search(NewsQuery{
+		Filter:        Filter{MinFee: 2_000},
+		Subscriptions: true,
+	}))
+	assert.Equal(t, []string{"Last", "Chunked"}, search(NewsQuery{
+		Filter: Filter{Start: lo.ToPtr(now.Add(-2 * time.Hour))},
+		Topic:  &topic,
+	}))
+
+	t.Run("thread summaries", func(t *testing.T) {
+		news, _, err := reader.Search(ctx, NewsQuery{Topic: &topic})
+		require.NoError(t, err)
+
+		summaries, err := reader.Summarize(ctx, news, false)
+		require.NoError(t, err)
+		assert.Equal(t, ThreadSummary{Replies: 2, Reactions: map[string]int{"👍": 1}}, summaries[first.String()])
+		assert.Equal(t, ThreadSummary{Reactions: map[string]int{"🔥": 5}}, summaries[signed.String()])
+	})
+
+	t.Run("thread", func(t *testing.T) {
+		thread, ok, err := reader.Thread(ctx, first.String(), false)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "First", thread.Post.Headline)
+		assert.Equal(t, map[string]int{"👍": 1}, thread.Reactions)
+		require.Len(t, thread.Replies, 1)
+		assert.Equal(t, "unsigned reply", thread.Replies[0].Post.Content)
+		require.Len(t, thread.Replies[0].Replies, 1)
+		assert.Equal(t, "signed reply", thread.Replies[0].Replies[0].Post.Content)
+
+		// Below a reply
+		thread, ok, err = reader.Thread(ctx, reply.String(), false)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Len(t, thread.Replies, 1)
+
+		_, ok, err = reader.Thread(ctx, chainhash.HashH([]byte("reaction")).String(), false)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("coin news for", func(t *testing.T) {
+		last, err := ListByTxID(ctx, db, chainhash.HashH([]byte("chunkc")).String())
+		require.NoError(t, err)
+
+		news, err := reader.CoinNewsFor(ctx, last)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Chunked"}, headlines(news))
+
+		assert.False(t, ChangesTopics(last))
+		assert.True(t, ChangesTopics(append(last, OPReturn{Data: EncodeTopicCreationMessage(muted, "Renamed")})))
+	})
+
+	t.Run("find post", func(t *testing.T) {
+		post, ok, err := reader.FindPost(ctx, first.String())
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "First", post.Headline)
+
+		post, ok, err = reader.FindPost(ctx, reply.String())
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, first.String(), post.ReplyTo)
+
+		post, ok, err = reader.FindPost(ctx, chainhash.HashH([]byte("chunka")).String())
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "Chunked", post.Headline)
+
+		for _, name := range []string{"reaction", "chunkb", "unknown"} {
+			_, ok, err := reader.FindPost(ctx, chainhash.HashH([]byte(name)).String())
+			require.NoError(t, err)
+			assert.False(t, ok, name)
+		}
+	})
+
+	t.Run("invalid page tokens", func(t *testing.T) {
+		ranked, err := ParseNewsCursor("0.5:1700000000.123:7", true)
+		require.NoError(t, err)
+		assert.Equal(t, NewsCursor{Ranked: true, FeeRate: 0.5, SeenAt: 1700000000.123, ID: 7}, ranked)
+
+		for token, ranked := range map[string]bool{
+			"1700000000.123:7":     true,
+			"0.5:1700000000.123:7": false,
+			"NaN:1:7":              true,
+			"-1:7":                 false,
+			"nope":                 false,
+		} {
+			_, err := ParseNewsCursor(token, ranked)
+			assert.Error(t, err, token)
+		}
+	})
+}
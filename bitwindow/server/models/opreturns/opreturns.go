@@ -5,16 +5,23 @@ import (
 	"context"
 	"database/sql"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"slices"
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/rs/zerolog"
+	"github.com/samber/lo"
 )
 
 func Persist(
@@ -27,27 +34,43 @@ func Persist(
 	start := time.Now()
 	builder := sq.
 		Insert("op_returns").
-		Columns("txid", "vout", "op_return_data", "fee_sats", "height", "created_at")
+		Columns(
+			"txid", "vout", "op_return_data", "fee_sats", "vsize", "height", "created_at",
+			"protocol", "protocol_fields",
+		)
 
 	for _, value := range values {
 		createdAt := time.Now()
 		if value.CreatedAt != nil {
 			createdAt = *value.CreatedAt
 		}
+		fields, err := encodeFields(value.Fields)
+		if err != nil {
+			return err
+		}
 		builder = builder.Values(
 			value.TxID, value.Vout,
 			// Much easier to work with hex strings in the database! We're
 			// storing this in a string column, should've been BLOB?
 			hex.EncodeToString(value.Data),
-			value.Fee, value.Height, createdAt,
+			value.Fee, value.VSize, value.Height, createdAt,
+			lo.EmptyableToPtr(value.Protocol), fields,
 		)
 	}
 
+	// Seeing a transaction again means it's either in the mempool or in a
+	// block, so it's no longer dropped or replaced.
 	builder = builder.Suffix(
 		`ON CONFLICT (txid, vout) DO UPDATE SET 
 			op_return_data = excluded.op_return_data, 
 			height = excluded.height, 
-			fee_sats = excluded.fee_sats`,
+			fee_sats = excluded.fee_sats,
+			vsize = excluded.vsize,
+			status = 'active',
+			replaced_by_txid = NULL,
+			status_updated_at = NULL,
+			protocol = COALESCE(excluded.protocol, protocol),
+			protocol_fields = COALESCE(excluded.protocol_fields, protocol_fields)`,
 	)
 
 	sql, args := builder.MustSql()
@@ -55,30 +78,155 @@ func Persist(
 		return fmt.Errorf("persist %d OP_RETURN(s): %w", len(values), err)
 	}
 
+	if err := indexSearchText(ctx, db, values); err != nil {
+		return err
+	}
+
 	zerolog.Ctx(ctx).Debug().
 		Msgf("opreturns: persisted %d OP_RETURN(s) in %s", len(values), time.Since(start))
 
 	return nil
 }
 
+// DeleteAboveHeight removes all confirmed OP_RETURNs with a height strictly
+// greater than the given height, together with any coin news topics that were
+// created by those transactions and the outpoints they spent. Unconfirmed
+// OP_RETURNs are left alone.
+func DeleteAboveHeight(ctx context.Context, db *sql.DB, height uint32) error {
+	start := time.Now()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	topics, err := tx.ExecContext(ctx, `
+		DELETE FROM coin_news_topics
+		WHERE txid IN (SELECT txid FROM op_returns WHERE height > ?)
+	`, height)
+	if err != nil {
+		return fmt.Errorf("delete topics above height %d: %w", height, err)
+	}
+
+	spends, err := tx.ExecContext(ctx, `
+		DELETE FROM op_return_spends
+		WHERE txid IN (SELECT txid FROM op_returns WHERE height > ?)
+	`, height)
+	if err != nil {
+		return fmt.Errorf("delete spends above height %d: %w", height, err)
+	}
+
+	opReturns, err := tx.ExecContext(ctx, `DELETE FROM op_returns WHERE height > ?`, height)
+	if err != nil {
+		return fmt.Errorf("delete OP_RETURNs above height %d: %w", height, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	deletedTopics, _ := topics.RowsAffected()
+	deletedSpends, _ := spends.RowsAffected()
+	deletedOPReturns, _ := opReturns.RowsAffected()
+	zerolog.Ctx(ctx).Debug().
+		Msgf("opreturns: deleted %d OP_RETURN(s), %d topic(s) and %d spend(s) above height %d in %s",
+			deletedOPReturns, deletedTopics, deletedSpends, height, time.Since(start))
+
+	return nil
+}
+
+// Status tracks what happened to an OP_RETURN while it was unconfirmed.
+type Status string
+
+const (
+	// StatusActive means the OP_RETURN is either confirmed, or in the mempool.
+	StatusActive Status = "active"
+	// StatusDropped means the transaction was removed from the mempool,
+	// for example by being evicted or expiring.
+	StatusDropped Status = "dropped"
+	// StatusReplaced means another transaction spent one of the same inputs,
+	// either through RBF or by being confirmed in a block.
+	StatusReplaced Status = "replaced"
+)
+
 type OPReturn struct {
-	ID        int64
-	TxID      string
-	Vout      int32
-	Data      []byte
-	Fee       btcutil.Amount // 0 can either mean zero fee or unknown fee
+	ID   int64
+	TxID string
+	Vout int32
+	Data []byte
+	Fee  btcutil.Amount // 0 can either mean zero fee or unknown fee
+	// Of the carrying transaction, 0 if unknown
+	VSize     int64
 	Height    *uint32
 	CreatedAt *time.Time
+
+	Status          Status
+	ReplacedByTxID  *string
+	StatusUpdatedAt *time.Time
+
+	// Empty if not classified yet
+	Protocol Protocol
+	Fields   map[string]string
+
+	// When it was first seen, as SQLite compares created_at: in seconds,
+	// to the millisecond
+	seenAt float64
 }
 
-func List(ctx context.Context, db *sql.DB) ([]OPReturn, error) {
-	rows, err := db.QueryContext(ctx, `
-		SELECT id, txid, vout, unhex(op_return_data), fee_sats, height, created_at
-		FROM op_returns
-		ORDER BY created_at DESC
-	`)
+// List returns all OP_RETURNs, newest first. If protocols are given, only
+// OP_RETURNs classified as one of them are returned.
+func List(ctx context.Context, db *sql.DB, protocols ...Protocol) ([]OPReturn, error) {
+	query := selectOPReturns().OrderBy("created_at DESC")
+	if len(protocols) > 0 {
+		query = query.Where(sq.Eq{"protocol": protocols})
+	}
+
+	opReturns, err := queryOPReturns(ctx, db, query)
+	if err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+	return opReturns, nil
+}
+
+// ListByTxID returns the OP_RETURNs of a transaction, as they're stored.
+func ListByTxID(ctx context.Context, db *sql.DB, txid string) ([]OPReturn, error) {
+	opReturns, err := queryOPReturns(ctx, db, selectOPReturns().
+		Where(sq.Eq{"txid": txid}).
+		OrderBy("vout"))
+	if err != nil {
+		return nil, fmt.Errorf("list by txid %s: %w", txid, err)
+	}
+	return opReturns, nil
+}
+
+// ListByData returns the OP_RETURNs with exactly the given data, oldest
+// confirmed first and unconfirmed last.
+func ListByData(ctx context.Context, db *sql.DB, data []byte) ([]OPReturn, error) {
+	opReturns, err := queryOPReturns(ctx, db, selectOPReturns().
+		Where(sq.Eq{"op_return_data": hex.EncodeToString(data)}).
+		OrderBy("height IS NULL", "height", "id"))
 	if err != nil {
-		return nil, fmt.Errorf("list: query op_returns: %w", err)
+		return nil, fmt.Errorf("list by data: %w", err)
+	}
+	return opReturns, nil
+}
+
+func selectOPReturns() sq.SelectBuilder {
+	return sq.
+		Select(
+			"id", "txid", "vout", "unhex(op_return_data)", "fee_sats", "vsize", "height", "created_at",
+			"status", "replaced_by_txid", "status_updated_at",
+			"COALESCE(protocol, '')", "protocol_fields", seenAtColumn,
+		).
+		From("op_returns")
+}
+
+func queryOPReturns(ctx context.Context, db *sql.DB, query sq.SelectBuilder) ([]OPReturn, error) {
+	sql, args := query.MustSql()
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query op_returns: %w", err)
 	}
 	defer rows.Close()
 
@@ -86,25 +234,155 @@ func List(ctx context.Context, db *sql.DB) ([]OPReturn, error) {
 	for rows.Next() {
 		var (
 			opReturn OPReturn
+			fields   *string
 		)
 		err := rows.Scan(
 			&opReturn.ID, &opReturn.TxID, &opReturn.Vout,
-			&opReturn.Data, &opReturn.Fee, &opReturn.Height, &opReturn.CreatedAt,
+			&opReturn.Data, &opReturn.Fee, &opReturn.VSize, &opReturn.Height, &opReturn.CreatedAt,
+			&opReturn.Status, &opReturn.ReplacedByTxID, &opReturn.StatusUpdatedAt,
+			&opReturn.Protocol, &fields, &opReturn.seenAt,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("list: scan op_return: %w", err)
+			return nil, fmt.Errorf("scan op_return: %w", err)
+		}
+		if fields != nil {
+			if err := json.Unmarshal([]byte(*fields), &opReturn.Fields); err != nil {
+				return nil, fmt.Errorf("decode protocol fields of %d: %w", opReturn.ID, err)
+			}
 		}
 
 		opReturns = append(opReturns, opReturn)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("list: iterate op_returns: %w", err)
+		return nil, fmt.Errorf("iterate op_returns: %w", err)
 	}
 
 	return opReturns, nil
 }
 
+// PersistSpends records the outpoints spent by an unconfirmed transaction,
+// if that transaction has any OP_RETURNs. This is what lets us find out which
+// transaction replaced it later on.
+func PersistSpends(ctx context.Context, db *sql.DB, tx *wire.MsgTx) error {
+	txid := tx.TxID()
+
+	for _, in := range tx.TxIn {
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO op_return_spends (txid, prev_txid, prev_vout)
+			SELECT ?, ?, ?
+			WHERE EXISTS (SELECT 1 FROM op_returns WHERE txid = ?)
+			ON CONFLICT (txid, prev_txid, prev_vout) DO NOTHING
+		`, txid, in.PreviousOutPoint.Hash.String(), in.PreviousOutPoint.Index, txid)
+		if err != nil {
+			return fmt.Errorf("persist spend of %s: %w", in.PreviousOutPoint, err)
+		}
+	}
+
+	return nil
+}
+
+// ListUnconfirmedSpends returns the outpoints spent by unconfirmed OP_RETURN
+// transactions that haven't been replaced yet, mapped to the spending txid.
+func ListUnconfirmedSpends(ctx context.Context, db *sql.DB) (map[wire.OutPoint]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT DISTINCT s.txid, s.prev_txid, s.prev_vout
+		FROM op_return_spends s
+		JOIN op_returns o ON o.txid = s.txid
+		WHERE o.height IS NULL AND o.status != 'replaced'
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list unconfirmed spends: query: %w", err)
+	}
+	defer rows.Close()
+
+	spends := make(map[wire.OutPoint]string)
+	for rows.Next() {
+		var (
+			txid, rawPrevTxid string
+			prevVout          uint32
+		)
+		if err := rows.Scan(&txid, &rawPrevTxid, &prevVout); err != nil {
+			return nil, fmt.Errorf("list unconfirmed spends: scan: %w", err)
+		}
+
+		prevTxid, err := chainhash.NewHashFromStr(rawPrevTxid)
+		if err != nil {
+			return nil, fmt.Errorf("list unconfirmed spends: invalid txid: %w", err)
+		}
+
+		spends[*wire.NewOutPoint(prevTxid, prevVout)] = txid
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list unconfirmed spends: iterate: %w", err)
+	}
+
+	return spends, nil
+}
+
+// ListUnconfirmedTxIDs returns the txids of all OP_RETURNs we believe are
+// still in the mempool.
+func ListUnconfirmedTxIDs(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT DISTINCT txid
+		FROM op_returns
+		WHERE height IS NULL AND status = 'active'
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list unconfirmed txids: query: %w", err)
+	}
+	defer rows.Close()
+
+	var txids []string
+	for rows.Next() {
+		var txid string
+		if err := rows.Scan(&txid); err != nil {
+			return nil, fmt.Errorf("list unconfirmed txids: scan: %w", err)
+		}
+		txids = append(txids, txid)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list unconfirmed txids: iterate: %w", err)
+	}
+
+	return txids, nil
+}
+
+// MarkDropped marks the unconfirmed OP_RETURNs of a transaction as dropped
+// from the mempool. Already replaced OP_RETURNs are left alone, as that's
+// more precise information.
+func MarkDropped(ctx context.Context, db *sql.DB, txid string) (bool, error) {
+	res, err := db.ExecContext(ctx, `
+		UPDATE op_returns
+		SET status = ?, status_updated_at = ?
+		WHERE txid = ? AND height IS NULL AND status = ?
+	`, StatusDropped, time.Now(), txid, StatusActive)
+	if err != nil {
+		return false, fmt.Errorf("mark %s as dropped: %w", txid, err)
+	}
+
+	rows, _ := res.RowsAffected()
+	return rows > 0, nil
+}
+
+// MarkReplaced marks the unconfirmed OP_RETURNs of a transaction as replaced
+// by another transaction.
+func MarkReplaced(ctx context.Context, db *sql.DB, txid string, replacedBy string) (bool, error) {
+	res, err := db.ExecContext(ctx, `
+		UPDATE op_returns
+		SET status = ?, replaced_by_txid = ?, status_updated_at = ?
+		WHERE txid = ? AND height IS NULL AND status != ?
+	`, StatusReplaced, replacedBy, time.Now(), txid, StatusReplaced)
+	if err != nil {
+		return false, fmt.Errorf("mark %s as replaced: %w", txid, err)
+	}
+
+	rows, _ := res.RowsAffected()
+	return rows > 0, nil
+}
+
 func OPReturnToReadable(data []byte) string {
 	// First try to decode as hex
 	decoded, err := hex.DecodeString(string(data))
@@ -159,6 +437,8 @@ func ValidNewsTopicID(topic string) (TopicID, error) {
 type TopicInfo struct {
 	ID   TopicID
 	Name string
+	// Set for topics created with an owner key
+	Owner string
 }
 
 var newTopicTag = []byte("new")
@@ -179,21 +459,61 @@ func IsCreateTopic(data []byte) (TopicInfo, bool) {
 
 	// Check if "new" follows the topic
 	name, ok := bytes.CutPrefix(data[TopicIdLength:], newTopicTag)
+	if !ok {
+		return TopicInfo{}, false
+	}
+
+	if len(name) == 0 || name[0] != signedNewsFlag {
+		return TopicInfo{
+			ID:   topicID,
+			Name: string(name),
+		}, true
+	}
+
+	// Owned topic, the owner is whoever signed the creation
+	if len(name) < 1+newsSignatureLength {
+		return TopicInfo{}, false
+	}
+	signature, name := name[1:1+newsSignatureLength], name[1+newsSignatureLength:]
+	owner, ok := recoverTopicSigner(topicID, newTopicTag, signature, name)
+	if !ok {
+		return TopicInfo{}, false
+	}
+
 	return TopicInfo{
-		ID:   topicID,
-		Name: string(name),
-	}, ok
+		ID:    topicID,
+		Name:  string(name),
+		Owner: owner,
+	}, true
 }
 
 func CreateTopic(ctx context.Context, db *sql.DB, topic TopicID, name string, txid string) error {
+	return CreateOwnedTopic(ctx, db, TopicInfo{ID: topic, Name: name}, txid)
+}
+
+// CreateOwnedTopic creates a topic that can be moderated by its owner. If
+// the owner is empty, the topic can't be moderated. Replaces the topic if it
+// was imported, as what's on chain is what counts.
+func CreateOwnedTopic(ctx context.Context, db *sql.DB, info TopicInfo, txid string) error {
+	var owner *string
+	if info.Owner != "" {
+		owner = &info.Owner
+	}
+
 	_, err := db.ExecContext(ctx, `
 		INSERT INTO coin_news_topics (
 			topic,
 			name,
-			txid
-		) VALUES (?, ?, ?)
-		 ON CONFLICT (topic) DO NOTHING
-	`, topic.String(), name, txid)
+			txid,
+			owner
+		) VALUES (?, ?, ?, ?)
+		 ON CONFLICT (topic) DO UPDATE SET
+			name = excluded.name,
+			txid = excluded.txid,
+			owner = excluded.owner,
+			imported = FALSE
+		 WHERE imported
+	`, info.ID.String(), info.Name, txid, owner)
 	if err != nil {
 		return fmt.Errorf("create topic: %w", err)
 	}
@@ -205,26 +525,70 @@ type Topic struct {
 	ID    int64
 	Topic TopicID
 	Name  string
+	// Empty for topics that can't be moderated
+	Owner string
+	// The transaction that created the topic. Empty for the default topics.
+	TxID string
+	// Imported from a topic set, and not seen on chain yet
+	Imported     bool
+	Subscription Subscription
 
 	CreatedAt time.Time
 }
 
 type CoinNews struct {
-	ID        int64
+	ID int64
+	// For posts split across several transactions, the first one
+	TxID      string
+	Height    *uint32
 	Topic     TopicID
 	TopicName string
 	Headline  string
 	Content   string
 	Fee       btcutil.Amount
+	// Of the carrying transactions, 0 if unknown
+	VSize int64
+
+	// Set for signed news. Verified is only true if the signature is valid
+	// for the author, anyone can claim to be anyone.
+	Author   string
+	Verified bool
+
+	// Hidden by the topic owner, or not posted by someone the owner allows
+	// to post. Never set in topics the user ignores moderation of.
+	Moderated bool
+
+	// The txid of the post this replies or reacts to. Replies have no
+	// headline, reactions have neither a headline nor content.
+	ReplyTo  string
+	Reaction string
 
 	CreatedAt *time.Time
+	seenAt    float64
+}
+
+// FeeRate is what the post paid to be published, in sat/vB. 0 if the fee or
+// size isn't known.
+func (n CoinNews) FeeRate() float64 {
+	return FeeRate(n.Fee, n.VSize)
+}
+
+// FeeRate returns the fee rate in sat/vB, or 0 if vsize is unknown.
+func FeeRate(fee btcutil.Amount, vsize int64) float64 {
+	if vsize <= 0 {
+		return 0
+	}
+	return float64(fee) / float64(vsize)
 }
 
 func ListTopics(ctx context.Context, db *sql.DB) ([]Topic, error) {
 	rows, err := db.QueryContext(ctx, `
-	SELECT id, topic, name, created_at
-	FROM coin_news_topics
-	ORDER BY created_at ASC
+	SELECT t.id, t.topic, t.name, COALESCE(t.owner, ''), COALESCE(t.txid, ''), t.imported,
+		COALESCE(s.subscribed, TRUE), COALESCE(s.muted, FALSE), COALESCE(s.min_fee_sats, 0),
+		COALESCE(s.ignore_moderation, FALSE), t.created_at
+	FROM coin_news_topics t
+	LEFT JOIN coin_news_subscriptions s ON s.topic = t.topic
+	ORDER BY t.created_at ASC
 `)
 	if err != nil {
 		return nil, fmt.Errorf("list topics: query: %w", err)
@@ -235,7 +599,11 @@ func ListTopics(ctx context.Context, db *sql.DB) ([]Topic, error) {
 	for rows.Next() {
 		var topic Topic
 		var rawTopicID string
-		err := rows.Scan(&topic.ID, &rawTopicID, &topic.Name, &topic.CreatedAt)
+		err := rows.Scan(
+			&topic.ID, &rawTopicID, &topic.Name, &topic.Owner, &topic.TxID, &topic.Imported,
+			&topic.Subscription.Subscribed, &topic.Subscription.Muted, &topic.Subscription.MinFee,
+			&topic.Subscription.IgnoreModeration, &topic.CreatedAt,
+		)
 		if err != nil {
 			return nil, fmt.Errorf("list topics: scan: %w", err)
 		}
@@ -245,6 +613,7 @@ func ListTopics(ctx context.Context, db *sql.DB) ([]Topic, error) {
 			return nil, fmt.Errorf("list topics: invalid topic ID: %w", err)
 		}
 		topic.Topic = topicID
+		topic.Subscription.Topic = topicID
 
 		topics = append(topics, topic)
 	}
@@ -275,6 +644,102 @@ func EncodeNewsMessage(topic TopicID, headline string, content string) []byte {
 	)
 }
 
+// Signed news messages have this byte right after the topic. Can't be
+// mistaken for the start of a headline, or the "new" tag of a topic creation.
+const signedNewsFlag byte = 0x01
+
+const (
+	authorLength         = 20 // hash160 of the compressed pubkey
+	newsSignatureLength  = 65 // compact, recoverable ECDSA signature
+	signedNewsHeaderSize = 1 + authorLength + newsSignatureLength
+)
+
+var newsSignatureTag = []byte("bitwindow/coinnews")
+
+// newsSigHash commits to the topic and the unsigned message body, i.e.
+// everything EncodeNewsMessage puts after the topic.
+func newsSigHash(topic TopicID, body []byte) *chainhash.Hash {
+	return chainhash.TaggedHash(newsSignatureTag, topic[:], body)
+}
+
+// Replies and reactions have these bytes right after the topic, or after
+// the signature for signed ones. Like the signed flag, they can't be
+// mistaken for the start of a headline.
+const (
+	replyFlag    byte = 0x02
+	reactionFlag byte = 0x03
+)
+
+// MaxReactionLength is the longest reaction, in bytes. Enough for an emoji
+// or a short word.
+const MaxReactionLength = 16
+
+// ValidReaction returns whether a reaction is UTF-8, and at most
+// MaxReactionLength bytes.
+func ValidReaction(reaction string) bool {
+	return reaction != "" && len(reaction) <= MaxReactionLength && utf8.ValidString(reaction)
+}
+
+// Format for OP_RETURN reply: <topic (8 bytes)><0x02><parent txid (32
+// bytes, internal byte order)><message (arbitrary length)>
+func EncodeReplyMessage(topic TopicID, parent chainhash.Hash, content string) []byte {
+	return slices.Concat(
+		topic[:], []byte{replyFlag}, parent[:], []byte(content),
+	)
+}
+
+// Format for OP_RETURN reaction: <topic (8 bytes)><0x03><parent txid (32
+// bytes, internal byte order)><reaction (at most 16 bytes)>
+func EncodeReactionMessage(topic TopicID, parent chainhash.Hash, reaction string) []byte {
+	return slices.Concat(
+		topic[:], []byte{reactionFlag}, parent[:], []byte(reaction),
+	)
+}
+
+// Format for signed OP_RETURN message: <topic (8 bytes)><0x01><author
+// pubkey hash (20 bytes)><compact signature (65 bytes)><headline (64
+// bytes)><message (arbitrary length)>
+func EncodeSignedNewsMessage(topic TopicID, headline string, content string, key *btcec.PrivateKey) []byte {
+	return SignNewsMessage(EncodeNewsMessage(topic, headline, content), key)
+}
+
+// SignNewsMessage signs an unsigned news message, reply or reaction. The
+// signature goes right after the topic, followed by the rest of the message.
+func SignNewsMessage(message []byte, key *btcec.PrivateKey) []byte {
+	topic, body := TopicID(message[:TopicIdLength]), message[TopicIdLength:]
+	author := btcutil.Hash160(key.PubKey().SerializeCompressed())
+	signature := ecdsa.SignCompact(key, newsSigHash(topic, body)[:], true)
+
+	return slices.Concat(
+		topic[:], []byte{signedNewsFlag}, author, signature, body,
+	)
+}
+
+// NewsAuthor identifies the key a news message was signed with, as the hex
+// encoded hash160 of the compressed pubkey.
+func NewsAuthor(key *btcec.PublicKey) string {
+	return hex.EncodeToString(btcutil.Hash160(key.SerializeCompressed()))
+}
+
+// decodeNewsSignature splits the signature off a signed news message body.
+// Returns the claimed author, and whether the signature is valid for it.
+// Unsigned bodies are returned as they are, with an empty author.
+func decodeNewsSignature(topic TopicID, body []byte) (rest []byte, author string, verified bool) {
+	if len(body) < signedNewsHeaderSize || body[0] != signedNewsFlag {
+		return body, "", false
+	}
+
+	authorHash := body[1 : 1+authorLength]
+	signature := body[1+authorLength : signedNewsHeaderSize]
+	rest = body[signedNewsHeaderSize:]
+
+	pubKey, compressed, err := ecdsa.RecoverCompact(signature, newsSigHash(topic, rest)[:])
+	verified = err == nil && compressed &&
+		bytes.Equal(btcutil.Hash160(pubKey.SerializeCompressed()), authorHash)
+
+	return rest, hex.EncodeToString(authorHash), verified
+}
+
 // Format for OP_RETURN message: <topic>new<title>
 func EncodeTopicCreationMessage(topic TopicID, name string) []byte {
 	return slices.Concat(
@@ -303,53 +768,70 @@ func ListCoinNews(ctx context.Context, db *sql.DB) ([]CoinNews, error) {
 		return nil, err
 	}
 
-	topics, err := ListTopics(ctx, db)
+	// Posts that didn't fit in one OP_RETURN are identified by their first
+	// chunk
+	chunked, err := ListChunkedMessages(ctx, db)
 	if err != nil {
 		return nil, err
 	}
-
-	var coinNews []CoinNews
-	for _, opReturn := range opReturns {
-		if len(opReturn.Data) < TopicIdLength {
-			continue
-		}
-
-		// Skip over all topic creation OP_RETURNs
-		if _, ok := IsCreateTopic(opReturn.Data); ok {
-			continue
-		}
-
-		topic, ok := extractTopic(topics, TopicID(opReturn.Data[:TopicIdLength]))
-		if !ok {
-			continue
+	for _, message := range chunked {
+		if message.Complete {
+			opReturns = append(opReturns, message.opReturn())
 		}
+	}
 
-		var (
-			headline string
-			content  string
-		)
-		switch {
-		case len(opReturn.Data) >= 72:
-			headline = strings.TrimRight(string(opReturn.Data[TopicIdLength:72]), " ")
-			content = string(opReturn.Data[72:])
+	return toCoinNews(ctx, db, opReturns)
+}
 
-		default:
-			headline = strings.TrimRight(string(opReturn.Data[TopicIdLength:]), " ")
-		}
+// toCoinNews decodes the coin news posts among the given OP_RETURNs.
+func toCoinNews(ctx context.Context, db *sql.DB, opReturns []OPReturn) ([]CoinNews, error) {
+	reader, err := NewNewsReader(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return reader.Decode(opReturns), nil
+}
 
-		// Remove all the whitespace padding
-		headline = strings.TrimRight(headline, string([]byte{0}))
+type newsPost struct {
+	topic    TopicID
+	headline string
+	content  string
+	author   string
+	verified bool
+
+	// Set for replies and reactions
+	replyTo    string
+	reaction   string
+	isReaction bool
+}
 
-		coinNews = append(coinNews, CoinNews{
-			ID:        opReturn.ID,
-			Topic:     topic.Topic,
-			TopicName: topic.Name,
-			Headline:  headline,
-			Content:   content,
-			Fee:       opReturn.Fee,
-			CreatedAt: opReturn.CreatedAt,
-		})
+// decodeNewsPost decodes everything after the topic of a coin news message.
+func decodeNewsPost(topic TopicID, data []byte) newsPost {
+	body, author, verified := decodeNewsSignature(topic, data)
+
+	post := newsPost{topic: topic, author: author, verified: verified}
+	switch {
+	case len(body) > chainhash.HashSize && body[0] == replyFlag:
+		post.replyTo = chainhash.Hash(body[1 : 1+chainhash.HashSize]).String()
+		post.content = string(body[1+chainhash.HashSize:])
+		return post
+
+	case len(body) > chainhash.HashSize && body[0] == reactionFlag:
+		post.replyTo = chainhash.Hash(body[1 : 1+chainhash.HashSize]).String()
+		post.reaction = string(body[1+chainhash.HashSize:])
+		post.isReaction = true
+		return post
+
+	case len(body) >= 64:
+		post.headline = strings.TrimRight(string(body[:64]), " ")
+		post.content = string(body[64:])
+
+	default:
+		post.headline = strings.TrimRight(string(body), " ")
 	}
 
-	return coinNews, nil
+	// Remove all the whitespace padding
+	post.headline = strings.TrimRight(post.headline, string([]byte{0}))
+
+	return post
 }
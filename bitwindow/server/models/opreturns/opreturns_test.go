@@ -0,0 +1,61 @@
+package opreturns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/database"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteAboveHeight(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := database.Test(t)
+
+	persist := func(height *uint32, prev byte) (*wire.MsgTx, wire.OutPoint) {
+		spent := wire.OutPoint{Hash: chainhash.Hash{prev}}
+		tx := wire.NewMsgTx(2)
+		tx.AddTxIn(wire.NewTxIn(&spent, nil, nil))
+		tx.AddTxOut(wire.NewTxOut(0, []byte{0x6a, 0x01, prev}))
+
+		require.NoError(t, Persist(ctx, db, []OPReturn{{
+			TxID: tx.TxID(), Data: []byte{prev}, Height: height,
+		}}))
+		// Seen in the mempool before it was mined
+		require.NoError(t, PersistSpends(ctx, db, tx))
+		return tx, spent
+	}
+	kept, _ := persist(lo.ToPtr(uint32(1)), 1)
+	persist(lo.ToPtr(uint32(2)), 2)
+	unconfirmed, unconfirmedSpent := persist(nil, 3)
+
+	require.NoError(t, DeleteAboveHeight(ctx, db, 1))
+
+	opReturns, err := List(ctx, db)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{kept.TxID(), unconfirmed.TxID()}, lo.Map(opReturns, func(opReturn OPReturn, _ int) string {
+		return opReturn.TxID
+	}))
+
+	var spendTxIDs []string
+	rows, err := db.QueryContext(ctx, `SELECT txid FROM op_return_spends`)
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var txid string
+		require.NoError(t, rows.Scan(&txid))
+		spendTxIDs = append(spendTxIDs, txid)
+	}
+	require.NoError(t, rows.Err())
+	assert.ElementsMatch(t, []string{kept.TxID(), unconfirmed.TxID()}, spendTxIDs)
+
+	spends, err := ListUnconfirmedSpends(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, map[wire.OutPoint]string{unconfirmedSpent: unconfirmed.TxID()}, spends)
+}
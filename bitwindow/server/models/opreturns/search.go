@@ -0,0 +1,244 @@
+package opreturns
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/samber/lo"
+)
+
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+// seenAtColumn is when an OP_RETURN was first seen, in seconds since the
+// epoch. SQLite only keeps milliseconds.
+const seenAtColumn = "unixepoch(created_at, 'subsec')"
+
+// Filter narrows down which OP_RETURNs are returned. Zero values match
+// everything.
+type Filter struct {
+	// Full-text query over the decoded message, in SQLite FTS4 syntax. For
+	// example `bitcoin AND news` or `sat*`.
+	Query     string
+	Protocols []Protocol
+	// Unconfirmed OP_RETURNs never match a height range
+	MinHeight *uint32
+	MaxHeight *uint32
+	// Compared to when the OP_RETURN was first seen, inclusive
+	Start *time.Time
+	End   *time.Time
+	// Lowercase hex
+	TxIDPrefix string
+	Topic      *TopicID
+	MinFee     btcutil.Amount
+	// In sat/vB. OP_RETURNs with an unknown fee rate never match.
+	MinFeeRate float64
+}
+
+// Search returns the OP_RETURNs matching the filter, newest first. after is
+// the ID of the last OP_RETURN on the previous page, or zero for the first
+// page. The returned cursor is the one to pass for the next page, zero if
+// there are no more pages.
+func Search(ctx context.Context, db *sql.DB, filter Filter, after int64, limit int) ([]OPReturn, int64, error) {
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	limit = min(limit, MaxPageSize)
+
+	query := selectOPReturns().
+		OrderBy("id DESC").
+		// One extra, to know if there's another page
+		Limit(uint64(limit + 1))
+	if after > 0 {
+		query = query.Where(sq.Lt{"id": after})
+	}
+
+	query = filter.where(query)
+
+	opReturns, err := queryOPReturns(ctx, db, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("search: %w", queryError(filter.Query, err))
+	}
+
+	if len(opReturns) <= limit {
+		return opReturns, 0, nil
+	}
+	opReturns = opReturns[:limit]
+	return opReturns, opReturns[limit-1].ID, nil
+}
+
+// where narrows down a query over op_returns to what matches the filter.
+func (f Filter) where(query sq.SelectBuilder) sq.SelectBuilder {
+	if f.Query != "" {
+		query = query.Where("id IN (SELECT docid FROM op_returns_fts WHERE op_returns_fts MATCH ?)", f.Query)
+	}
+	if len(f.Protocols) > 0 {
+		query = query.Where(sq.Eq{"protocol": f.Protocols})
+	}
+	if f.MinHeight != nil {
+		query = query.Where(sq.GtOrEq{"height": *f.MinHeight})
+	}
+	if f.MaxHeight != nil {
+		query = query.Where(sq.LtOrEq{"height": *f.MaxHeight})
+	}
+	if f.Start != nil {
+		query = query.Where(seenAtColumn+" >= ?", float64(f.Start.UnixMilli())/1000)
+	}
+	if f.End != nil {
+		query = query.Where(seenAtColumn+" <= ?", float64(f.End.UnixMilli())/1000)
+	}
+	if f.TxIDPrefix != "" {
+		// GLOB is case sensitive, so it can use the index on txid
+		query = query.Where("txid GLOB ?", f.TxIDPrefix+"*")
+	}
+	if f.Topic != nil {
+		query = query.Where("substr(op_return_data, 1, ?) = ?", TopicIdLength*2, f.Topic.String())
+	}
+	if f.MinFee > 0 {
+		query = query.Where(sq.GtOrEq{"fee_sats": int64(f.MinFee)})
+	}
+	if f.MinFeeRate > 0 {
+		query = query.Where("vsize > 0 AND fee_sats >= vsize * ?", f.MinFeeRate)
+	}
+
+	return query
+}
+
+// matchSearch returns which of the given OP_RETURNs match a full-text query.
+func matchSearch(ctx context.Context, db *sql.DB, query string, ids []int64) (map[int64]bool, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	sql, args := sq.
+		Select("docid").
+		From("op_returns_fts").
+		Where("op_returns_fts MATCH ?", query).
+		Where(sq.Eq{"docid": ids}).
+		MustSql()
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("match search: %w", queryError(query, err))
+	}
+	defer rows.Close()
+
+	matched := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("match search: scan: %w", err)
+		}
+		matched[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("match search: iterate: %w", err)
+	}
+
+	return matched, nil
+}
+
+// ErrInvalidQuery is returned for full-text queries SQLite can't parse.
+var ErrInvalidQuery = errors.New("invalid search query")
+
+func queryError(query string, err error) error {
+	if strings.Contains(err.Error(), "malformed MATCH expression") {
+		return fmt.Errorf("%w %q", ErrInvalidQuery, query)
+	}
+	return err
+}
+
+// ValidTxIDPrefix checks that a txid prefix is hex, and lowercases it.
+func ValidTxIDPrefix(prefix string) (string, error) {
+	prefix = strings.ToLower(prefix)
+	// Decoding needs an even number of characters
+	if _, err := hex.DecodeString(prefix + strings.Repeat("0", len(prefix)%2)); err != nil {
+		return "", fmt.Errorf("txid prefix %q is not hex", prefix)
+	}
+	return prefix, nil
+}
+
+// searchText is what's put in the full-text index for an OP_RETURN. Binary
+// data isn't, as hex can't be searched for in a meaningful way.
+func searchText(value OPReturn) string {
+	parts := lo.Map(slices.Sorted(maps.Keys(value.Fields)), func(key string, _ int) string {
+		return value.Fields[key]
+	})
+
+	switch {
+	case value.Protocol == ProtocolCoinNews && len(value.Data) >= TopicIdLength:
+		post := decodeNewsPost(TopicID(value.Data[:TopicIdLength]), value.Data[TopicIdLength:])
+		parts = append(parts, post.content)
+
+	case value.Protocol != ProtocolText && isText(value.Data):
+		parts = append(parts, string(value.Data))
+	}
+
+	return strings.Join(lo.Compact(parts), " ")
+}
+
+// indexSearchText (re)indexes OP_RETURNs, which have to be stored already.
+func indexSearchText(ctx context.Context, db sq.ExecerContext, values []OPReturn) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	// Goes by txid and vout, as that's what Persist gets
+	var (
+		rows []string
+		args []any
+	)
+	for _, value := range values {
+		rows = append(rows, "(?, ?, ?)")
+		args = append(args, value.TxID, value.Vout, searchText(value))
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO op_returns_fts (docid, body)
+		SELECT o.id, v.column3
+		FROM op_returns o
+		JOIN (VALUES `+strings.Join(rows, ", ")+`) v
+			ON o.txid = v.column1 AND o.vout = v.column2
+	`, args...)
+	if err != nil {
+		return fmt.Errorf("index %d OP_RETURN(s) for search: %w", len(values), err)
+	}
+
+	return nil
+}
+
+// MatchesNews returns whether a coin news post matches the filter. If the
+// filter has a query, matched has to be the result of matchSearch for it.
+func (f Filter) MatchesNews(news CoinNews, matched map[int64]bool) bool {
+	switch {
+	case f.Query != "" && !matched[news.ID]:
+		return false
+	case f.MinHeight != nil && (news.Height == nil || *news.Height < *f.MinHeight):
+		return false
+	case f.MaxHeight != nil && (news.Height == nil || *news.Height > *f.MaxHeight):
+		return false
+	case f.Start != nil && lo.FromPtr(news.CreatedAt).Before(*f.Start):
+		return false
+	case f.End != nil && lo.FromPtr(news.CreatedAt).After(*f.End):
+		return false
+	case !strings.HasPrefix(news.TxID, f.TxIDPrefix):
+		return false
+	case f.Topic != nil && news.Topic != *f.Topic:
+		return false
+	case news.Fee < f.MinFee:
+		return false
+	case f.MinFeeRate > 0 && (news.VSize == 0 || news.FeeRate() < f.MinFeeRate):
+		return false
+	}
+	return true
+}
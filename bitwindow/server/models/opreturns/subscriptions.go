@@ -0,0 +1,249 @@
+package opreturns
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/samber/lo"
+)
+
+// Subscription is what the user wants to see of a topic.
+type Subscription struct {
+	Topic TopicID
+	// Unsubscribed topics are left out of the feed, but can still be read
+	// by asking for the topic
+	Subscribed bool
+	// Muted topics stay subscribed, but none of their posts are listed
+	Muted bool
+	// Posts paying a lower fee are left out
+	MinFee btcutil.Amount
+	// Posts hidden by the topic owner are listed anyway
+	IgnoreModeration bool
+}
+
+// DefaultSubscription is the subscription of topics the user hasn't
+// changed anything about.
+func DefaultSubscription(topic TopicID) Subscription {
+	return Subscription{Topic: topic, Subscribed: true}
+}
+
+// Shows returns whether a post in the topic should be listed. requested is
+// whether the topic was asked for, rather than the whole feed.
+func (s Subscription) Shows(news CoinNews, requested bool) bool {
+	switch {
+	case s.Muted:
+		return false
+	case !s.Subscribed && !requested:
+		return false
+	case news.Fee < s.MinFee:
+		return false
+	}
+	return true
+}
+
+func SetSubscription(ctx context.Context, db *sql.DB, subscription Subscription) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO coin_news_subscriptions (topic, subscribed, muted, min_fee_sats, ignore_moderation)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (topic) DO UPDATE SET
+			subscribed = excluded.subscribed,
+			muted = excluded.muted,
+			min_fee_sats = excluded.min_fee_sats,
+			ignore_moderation = excluded.ignore_moderation,
+			updated_at = CURRENT_TIMESTAMP
+	`, subscription.Topic.String(), subscription.Subscribed, subscription.Muted, int64(subscription.MinFee),
+		subscription.IgnoreModeration)
+	if err != nil {
+		return fmt.Errorf("set subscription to %s: %w", subscription.Topic, err)
+	}
+	return nil
+}
+
+// ListSubscriptions returns the subscriptions the user has changed. Topics
+// that aren't included have the default subscription.
+func ListSubscriptions(ctx context.Context, db *sql.DB) (map[TopicID]Subscription, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT topic, subscribed, muted, min_fee_sats, ignore_moderation
+		FROM coin_news_subscriptions
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list subscriptions: query: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := make(map[TopicID]Subscription)
+	for rows.Next() {
+		var (
+			subscription Subscription
+			rawTopicID   string
+		)
+		err := rows.Scan(
+			&rawTopicID, &subscription.Subscribed, &subscription.Muted, &subscription.MinFee,
+			&subscription.IgnoreModeration,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("list subscriptions: scan: %w", err)
+		}
+
+		subscription.Topic, err = ValidNewsTopicID(rawTopicID)
+		if err != nil {
+			return nil, fmt.Errorf("list subscriptions: invalid topic ID: %w", err)
+		}
+		subscriptions[subscription.Topic] = subscription
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list subscriptions: iterate: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// TopicSetVersion is the version of the topic set format written by
+// ExportTopics.
+const TopicSetVersion = 1
+
+// MaxTopicNameLength is the longest topic name we create, in bytes.
+const MaxTopicNameLength = 64
+
+// TopicSet is a portable list of topics, for sharing a curated feed. Encoded
+// as JSON.
+type TopicSet struct {
+	Version int             `json:"version"`
+	Topics  []ExportedTopic `json:"topics"`
+}
+
+type ExportedTopic struct {
+	// Hex encoded
+	Topic string `json:"topic"`
+	Name  string `json:"name"`
+	// The transaction that created the topic, if known
+	TxID string `json:"txid,omitempty"`
+}
+
+// ExportTopics returns the given topics as a topic set. If no topics are
+// given, all subscribed ones are exported.
+func ExportTopics(ctx context.Context, db *sql.DB, topicIDs []TopicID) (TopicSet, error) {
+	topics, err := ListTopics(ctx, db)
+	if err != nil {
+		return TopicSet{}, err
+	}
+
+	if len(topicIDs) == 0 {
+		topics = lo.Filter(topics, func(topic Topic, _ int) bool {
+			return topic.Subscription.Subscribed
+		})
+	} else {
+		known := lo.KeyBy(topics, func(topic Topic) TopicID { return topic.Topic })
+		topics = make([]Topic, 0, len(topicIDs))
+		for _, id := range lo.Uniq(topicIDs) {
+			topic, ok := known[id]
+			if !ok {
+				return TopicSet{}, fmt.Errorf("%w: %s", ErrUnknownTopic, id)
+			}
+			topics = append(topics, topic)
+		}
+	}
+
+	return TopicSet{
+		Version: TopicSetVersion,
+		Topics: lo.Map(topics, func(topic Topic, _ int) ExportedTopic {
+			return ExportedTopic{
+				Topic: topic.Topic.String(),
+				Name:  topic.Name,
+				TxID:  topic.TxID,
+			}
+		}),
+	}, nil
+}
+
+var ErrUnknownTopic = errors.New("unknown topic")
+
+// ParseTopicSet decodes and validates a topic set.
+func ParseTopicSet(data []byte) (TopicSet, error) {
+	var set TopicSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return TopicSet{}, fmt.Errorf("decode topic set: %w", err)
+	}
+
+	if set.Version != TopicSetVersion {
+		return TopicSet{}, fmt.Errorf("unsupported topic set version %d", set.Version)
+	}
+
+	seen := make(map[TopicID]bool, len(set.Topics))
+	for i, topic := range set.Topics {
+		id, err := ValidNewsTopicID(topic.Topic)
+		if err != nil {
+			return TopicSet{}, fmt.Errorf("topic %d: %w", i, err)
+		}
+		if seen[id] {
+			return TopicSet{}, fmt.Errorf("topic %d: %s is listed twice", i, id)
+		}
+		seen[id] = true
+
+		if topic.Name == "" || len(topic.Name) > MaxTopicNameLength {
+			return TopicSet{}, fmt.Errorf("topic %d: name must be 1 to %d bytes", i, MaxTopicNameLength)
+		}
+		if topic.TxID != "" {
+			if _, err := hex.DecodeString(topic.TxID); err != nil || len(topic.TxID) != chainhash.MaxHashStringSize {
+				return TopicSet{}, fmt.Errorf("topic %d: invalid txid %q", i, topic.TxID)
+			}
+		}
+
+		set.Topics[i].Topic = id.String()
+		set.Topics[i].TxID = strings.ToLower(topic.TxID)
+	}
+
+	return set, nil
+}
+
+// ImportTopics adds the topics we don't know of yet, and subscribes to all
+// of them. Returns how many topics were new. Imported topics are replaced
+// once their creation is seen on chain.
+func ImportTopics(ctx context.Context, db *sql.DB, set TopicSet) (int, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var imported int
+	for _, topic := range set.Topics {
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO coin_news_topics (topic, name, txid, imported)
+			VALUES (?, ?, ?, TRUE)
+			ON CONFLICT (topic) DO NOTHING
+		`, topic.Topic, topic.Name, lo.EmptyableToPtr(topic.TxID))
+		if err != nil {
+			return 0, fmt.Errorf("import topic %s: %w", topic.Topic, err)
+		}
+		if added, _ := res.RowsAffected(); added > 0 {
+			imported++
+		}
+
+		// Muting and minimum fees are the user's own business
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO coin_news_subscriptions (topic, subscribed)
+			VALUES (?, TRUE)
+			ON CONFLICT (topic) DO UPDATE SET
+				subscribed = TRUE,
+				updated_at = CURRENT_TIMESTAMP
+		`, topic.Topic)
+		if err != nil {
+			return 0, fmt.Errorf("subscribe to imported topic %s: %w", topic.Topic, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return imported, nil
+}
@@ -0,0 +1,49 @@
+package opreturns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTopicSet(t *testing.T) {
+	t.Parallel()
+
+	txid := strings.Repeat("ab", 32)
+	set, err := ParseTopicSet([]byte(`{"version": 1, "topics": [
+		{"topic": "6E65777330303031", "name": "News", "txid": "` + strings.ToUpper(txid) + `"},
+		{"topic": "6e65777330303032", "name": "Other"}
+	]}`))
+	require.NoError(t, err)
+	assert.Equal(t, TopicSet{Version: TopicSetVersion, Topics: []ExportedTopic{
+		{Topic: "6e65777330303031", Name: "News", TxID: txid},
+		{Topic: "6e65777330303032", Name: "Other"},
+	}}, set)
+
+	set, err = ParseTopicSet([]byte(`{"version": 1}`))
+	require.NoError(t, err)
+	assert.Empty(t, set.Topics)
+
+	for name, data := range map[string]string{
+		"empty":           ``,
+		"not JSON":        `topics`,
+		"truncated":       `{"version": 1, "topics": [{"topic": "6e65777330303031"`,
+		"wrong types":     `{"version": "1", "topics": []}`,
+		"no version":      `{"topics": []}`,
+		"unknown version": `{"version": 2, "topics": []}`,
+		"empty topic":     `{"version": 1, "topics": [{"topic": "", "name": "News"}]}`,
+		"topic not hex":   `{"version": 1, "topics": [{"topic": "news0001", "name": "News"}]}`,
+		"topic too short": `{"version": 1, "topics": [{"topic": "6e657773303030", "name": "News"}]}`,
+		"topic too long":  `{"version": 1, "topics": [{"topic": "6e6577733030303100", "name": "News"}]}`,
+		"listed twice":    `{"version": 1, "topics": [{"topic": "6e65777330303031", "name": "A"}, {"topic": "6E65777330303031", "name": "B"}]}`,
+		"no name":         `{"version": 1, "topics": [{"topic": "6e65777330303031"}]}`,
+		"name too long":   `{"version": 1, "topics": [{"topic": "6e65777330303031", "name": "` + strings.Repeat("a", MaxTopicNameLength+1) + `"}]}`,
+		"txid not hex":    `{"version": 1, "topics": [{"topic": "6e65777330303031", "name": "News", "txid": "` + strings.Repeat("zz", 32) + `"}]}`,
+		"txid too short":  `{"version": 1, "topics": [{"topic": "6e65777330303031", "name": "News", "txid": "` + txid[2:] + `"}]}`,
+	} {
+		_, err := ParseTopicSet([]byte(data))
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,130 @@
+package opreturns
+
+import (
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+// Thread is a coin news post, with the replies to it and the replies to
+// those.
+type Thread struct {
+	Post CoinNews
+	// Oldest first
+	Replies []*Thread
+	// How many times each reaction was given to the post
+	Reactions map[string]int
+}
+
+// ThreadSummary is what a post's thread looks like, without the replies
+// themselves.
+type ThreadSummary struct {
+	// Replies anywhere in the thread, not just the direct ones
+	Replies   int
+	Reactions map[string]int
+}
+
+type threads struct {
+	posts     map[string]CoinNews
+	replies   map[string][]CoinNews
+	reactions map[string]map[string]int
+}
+
+func newThreads(news []CoinNews) threads {
+	t := threads{
+		posts:     make(map[string]CoinNews),
+		replies:   make(map[string][]CoinNews),
+		reactions: make(map[string]map[string]int),
+	}
+
+	for _, post := range news {
+		switch {
+		case post.Reaction != "":
+			if t.reactions[post.ReplyTo] == nil {
+				t.reactions[post.ReplyTo] = make(map[string]int)
+			}
+			t.reactions[post.ReplyTo][post.Reaction]++
+			continue
+
+		case post.ReplyTo != "":
+			t.replies[post.ReplyTo] = append(t.replies[post.ReplyTo], post)
+		}
+
+		// A transaction with several posts is identified by the first
+		if _, ok := t.posts[post.TxID]; !ok {
+			t.posts[post.TxID] = post
+		}
+	}
+
+	for _, replies := range t.replies {
+		sort.SliceStable(replies, func(i, j int) bool {
+			a, b := lo.FromPtr(replies[i].CreatedAt), lo.FromPtr(replies[j].CreatedAt)
+			if !a.Equal(b) {
+				return a.Before(b)
+			}
+			return replies[i].ID < replies[j].ID
+		})
+	}
+
+	return t
+}
+
+// BuildThread builds the thread under the post with the given txid, out of
+// the given news. For a reply, that's the part of the thread below it.
+// Returns false if the post isn't among the news.
+func BuildThread(news []CoinNews, txid string) (*Thread, bool) {
+	t := newThreads(news)
+
+	post, ok := t.posts[txid]
+	if !ok || post.Reaction != "" {
+		return nil, false
+	}
+
+	return t.build(post, make(map[string]bool)), true
+}
+
+func (t threads) build(post CoinNews, seen map[string]bool) *Thread {
+	seen[post.TxID] = true
+
+	thread := &Thread{
+		Post:      post,
+		Reactions: lo.Assign(t.reactions[post.TxID]),
+	}
+	for _, reply := range t.replies[post.TxID] {
+		// Can't happen for real transactions, as a txid commits to the
+		// parent's
+		if seen[reply.TxID] {
+			continue
+		}
+		thread.Replies = append(thread.Replies, t.build(reply, seen))
+	}
+	return thread
+}
+
+// SummarizeThreads returns the thread summary of every post among the news,
+// by txid.
+func SummarizeThreads(news []CoinNews) map[string]ThreadSummary {
+	t := newThreads(news)
+
+	summaries := make(map[string]ThreadSummary, len(t.posts))
+	for txid := range t.posts {
+		summaries[txid] = ThreadSummary{
+			Replies:   t.countReplies(txid, make(map[string]bool)),
+			Reactions: lo.Assign(t.reactions[txid]),
+		}
+	}
+	return summaries
+}
+
+func (t threads) countReplies(txid string, seen map[string]bool) int {
+	seen[txid] = true
+
+	var count int
+	for _, reply := range t.replies[txid] {
+		if seen[reply.TxID] {
+			continue
+		}
+		count += 1 + t.countReplies(reply.TxID, seen)
+	}
+	return count
+}
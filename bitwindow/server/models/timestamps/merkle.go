@@ -0,0 +1,108 @@
+package timestamps
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/samber/lo"
+)
+
+// MerkleStep is one step from a leaf up to the root of a merkle tree.
+type MerkleStep struct {
+	// The sibling hash to combine with, hex encoded
+	Hash string `json:"hash"`
+	// Whether the sibling goes on the left, i.e. before the hash so far
+	Left bool `json:"left"`
+}
+
+// MerklePath leads from a file hash to the merkle root committed on chain.
+// Empty for timestamps that commit to the file hash directly.
+type MerklePath []MerkleStep
+
+// BuildMerkleTree builds a SHA256 merkle tree over the given leaves, and
+// returns its root and the path from each leaf to it. Nodes are hashed as
+// SHA256(left || right). A node without a sibling moves up a level as is,
+// so a single leaf is its own root.
+func BuildMerkleTree(leaves [][32]byte) ([32]byte, []MerklePath) {
+	if len(leaves) == 0 {
+		return [32]byte{}, nil
+	}
+
+	paths := make([]MerklePath, len(leaves))
+	// Which leaves are below each node of the current level
+	below := make([][]int, len(leaves))
+	for i := range leaves {
+		below[i] = []int{i}
+	}
+
+	level := leaves
+	for len(level) > 1 {
+		var (
+			next      [][32]byte
+			nextBelow [][]int
+		)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				nextBelow = append(nextBelow, below[i])
+				continue
+			}
+
+			left, right := level[i], level[i+1]
+			for _, leaf := range below[i] {
+				paths[leaf] = append(paths[leaf], MerkleStep{Hash: hex.EncodeToString(right[:])})
+			}
+			for _, leaf := range below[i+1] {
+				paths[leaf] = append(paths[leaf], MerkleStep{Hash: hex.EncodeToString(left[:]), Left: true})
+			}
+
+			next = append(next, sha256.Sum256(append(left[:], right[:]...)))
+			nextBelow = append(nextBelow, append(below[i], below[i+1]...))
+		}
+		level, below = next, nextBelow
+	}
+
+	return level[0], paths
+}
+
+// Root returns the root the path leads to from the given leaf.
+func (p MerklePath) Root(leaf [32]byte) ([32]byte, error) {
+	current := leaf
+	for i, step := range p {
+		sibling, err := hex.DecodeString(step.Hash)
+		if err != nil || len(sibling) != sha256.Size {
+			return [32]byte{}, fmt.Errorf("merkle step %d: invalid hash %q", i, step.Hash)
+		}
+
+		if step.Left {
+			current = sha256.Sum256(append(sibling, current[:]...))
+		} else {
+			current = sha256.Sum256(append(current[:], sibling...))
+		}
+	}
+	return current, nil
+}
+
+func encodeMerklePath(path MerklePath) (*string, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(path)
+	if err != nil {
+		return nil, fmt.Errorf("encode merkle path: %w", err)
+	}
+	return lo.ToPtr(string(encoded)), nil
+}
+
+func decodeMerklePath(encoded *string) (MerklePath, error) {
+	if encoded == nil {
+		return nil, nil
+	}
+	var path MerklePath
+	if err := json.Unmarshal([]byte(*encoded), &path); err != nil {
+		return nil, fmt.Errorf("decode merkle path: %w", err)
+	}
+	return path, nil
+}
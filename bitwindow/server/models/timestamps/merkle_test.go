@@ -0,0 +1,61 @@
+package timestamps_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/timestamps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMerkleTree(t *testing.T) {
+	t.Parallel()
+
+	leaf := func(i int) [32]byte { return sha256.Sum256(fmt.Appendf(nil, "file %d", i)) }
+	pair := func(left, right [32]byte) [32]byte { return sha256.Sum256(append(left[:], right[:]...)) }
+
+	t.Run("single leaf is its own root", func(t *testing.T) {
+		root, paths := timestamps.BuildMerkleTree([][32]byte{leaf(0)})
+		assert.Equal(t, leaf(0), root)
+		require.Len(t, paths, 1)
+		assert.Empty(t, paths[0])
+	})
+
+	t.Run("odd leaf moves up as is", func(t *testing.T) {
+		root, paths := timestamps.BuildMerkleTree([][32]byte{leaf(0), leaf(1), leaf(2)})
+		assert.Equal(t, pair(pair(leaf(0), leaf(1)), leaf(2)), root)
+		require.Len(t, paths, 3)
+		assert.Len(t, paths[0], 2)
+		assert.Len(t, paths[2], 1)
+		assert.True(t, paths[2][0].Left)
+	})
+
+	for _, n := range []int{1, 2, 3, 4, 5, 8, 13, 500} {
+		t.Run(fmt.Sprintf("%d leaves", n), func(t *testing.T) {
+			leaves := make([][32]byte, n)
+			for i := range leaves {
+				leaves[i] = leaf(i)
+			}
+
+			root, paths := timestamps.BuildMerkleTree(leaves)
+			require.Len(t, paths, n)
+			for i, path := range paths {
+				got, err := path.Root(leaves[i])
+				require.NoError(t, err)
+				assert.Equal(t, root, got, "leaf %d", i)
+
+				// Paths don't work for other leaves
+				other, err := path.Root(leaf(n + i))
+				require.NoError(t, err)
+				assert.NotEqual(t, root, other)
+			}
+		})
+	}
+
+	t.Run("invalid step", func(t *testing.T) {
+		_, err := timestamps.MerklePath{{Hash: "abcd"}}.Root(leaf(0))
+		assert.Error(t, err)
+	})
+}
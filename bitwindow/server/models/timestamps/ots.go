@@ -0,0 +1,453 @@
+package timestamps
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/samber/lo"
+)
+
+// Proofs are serialized in the OpenTimestamps format, so they can be
+// verified with any OpenTimestamps client. See
+// https://github.com/opentimestamps/python-opentimestamps for the reference
+// implementation.
+
+var otsMagic = []byte("\x00OpenTimestamps\x00\x00Proof\x00\xbf\x89\xe2\xe8\x84\xe8\x92\x94")
+
+const (
+	otsVersion = 1
+
+	otsAttestation byte = 0x00
+	otsFork        byte = 0xff
+
+	// Limits from the reference implementation, to not be tricked into
+	// reading huge proofs
+	otsMaxArgLength = 4096
+	otsMaxDepth     = 256
+)
+
+var otsBitcoinAttestation = [8]byte{0x05, 0x88, 0x96, 0x0d, 0x73, 0xd7, 0x19, 0x01}
+
+var ErrInvalidProof = errors.New("invalid timestamp proof")
+
+// ErrProofTooLarge is returned for proofs that OpenTimestamps clients, and
+// ParseProof, would reject. Happens for large timestamp transactions.
+var ErrProofTooLarge = errors.New("timestamp proof too large")
+
+// OpKind is an operation on the message of a proof.
+type OpKind byte
+
+const (
+	OpSHA1      OpKind = 0x02
+	OpRIPEMD160 OpKind = 0x03
+	OpSHA256    OpKind = 0x08
+	OpKeccak256 OpKind = 0x67
+	OpAppend    OpKind = 0xf0
+	OpPrepend   OpKind = 0xf1
+	OpReverse   OpKind = 0xf2
+	OpHexlify   OpKind = 0xf3
+)
+
+// binary returns whether the op takes an argument.
+func (k OpKind) binary() bool {
+	return k == OpAppend || k == OpPrepend
+}
+
+// supported returns whether we can apply the op. Proofs from other tools
+// might use the others, but branches using them are skipped.
+func (k OpKind) supported() bool {
+	switch k {
+	case OpSHA256, OpAppend, OpPrepend, OpReverse:
+		return true
+	}
+	return false
+}
+
+func (k OpKind) valid() bool {
+	switch k {
+	case OpSHA1, OpRIPEMD160, OpSHA256, OpKeccak256, OpAppend, OpPrepend, OpReverse, OpHexlify:
+		return true
+	}
+	return false
+}
+
+// ProofOp is a single step from the file hash towards a block merkle root.
+type ProofOp struct {
+	Kind OpKind
+	// Only set for append and prepend
+	Arg []byte
+}
+
+func (op ProofOp) apply(msg []byte) ([]byte, error) {
+	switch op.Kind {
+	case OpSHA256:
+		hash := sha256.Sum256(msg)
+		return hash[:], nil
+	case OpAppend:
+		return append(bytes.Clone(msg), op.Arg...), nil
+	case OpPrepend:
+		return append(bytes.Clone(op.Arg), msg...), nil
+	case OpReverse:
+		reversed := bytes.Clone(msg)
+		for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+			reversed[i], reversed[j] = reversed[j], reversed[i]
+		}
+		return reversed, nil
+	}
+	return nil, fmt.Errorf("%w: unsupported op 0x%02x", ErrInvalidProof, byte(op.Kind))
+}
+
+// Proof leads from the hash of a file to the merkle root of a Bitcoin
+// block. It can be verified against the block header alone.
+type Proof struct {
+	FileHash [32]byte
+	Ops      []ProofOp
+	// The block the ops lead to the merkle root of
+	Height uint32
+}
+
+// BlockMerkleRoot applies the ops of the proof to the file hash. For a
+// valid proof, the result is the merkle root of the block at Height, in
+// internal byte order.
+func (p Proof) BlockMerkleRoot() ([]byte, error) {
+	msg := p.FileHash[:]
+	for _, op := range p.Ops {
+		var err error
+		if msg, err = op.apply(msg); err != nil {
+			return nil, err
+		}
+	}
+	if len(msg) != sha256.Size {
+		return nil, fmt.Errorf("%w: leads to %d bytes, not a merkle root", ErrInvalidProof, len(msg))
+	}
+	return msg, nil
+}
+
+// NewProof builds the proof for a timestamp confirmed in the given block. It
+// leads through the merkle path of batched timestamps, the transaction
+// committing to the timestamp, and the merkle tree of the block.
+func NewProof(timestamp FileTimestamp, block *wire.MsgBlock, height uint32) (*Proof, error) {
+	fileHash, err := hex.DecodeString(timestamp.FileHash)
+	if err != nil || len(fileHash) != sha256.Size {
+		return nil, fmt.Errorf("invalid file hash %q", timestamp.FileHash)
+	}
+	if timestamp.TxID == nil {
+		return nil, fmt.Errorf("timestamp %d has no transaction", timestamp.ID)
+	}
+
+	proof := &Proof{FileHash: [32]byte(fileHash), Height: height}
+	sha256Op := ProofOp{Kind: OpSHA256}
+	// Skips empty args, which some clients reject
+	appendOp := func(kind OpKind, arg []byte) {
+		if len(arg) > 0 {
+			proof.Ops = append(proof.Ops, ProofOp{Kind: kind, Arg: arg})
+		}
+	}
+
+	// Up to the merkle root of the batch
+	for _, step := range timestamp.MerklePath {
+		sibling, err := hex.DecodeString(step.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("decode merkle path: %w", err)
+		}
+		appendOp(lo.Ternary(step.Left, OpPrepend, OpAppend), sibling)
+		proof.Ops = append(proof.Ops, sha256Op)
+	}
+	commitment, err := proof.BlockMerkleRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	// Up to the txid
+	index := -1
+	for i, tx := range block.Transactions {
+		if tx.TxID() == *timestamp.TxID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("transaction %s is not in block %s", *timestamp.TxID, block.BlockHash())
+	}
+
+	var raw bytes.Buffer
+	if err := block.Transactions[index].SerializeNoWitness(&raw); err != nil {
+		return nil, fmt.Errorf("serialize transaction: %w", err)
+	}
+	offset := bytes.Index(raw.Bytes(), commitment)
+	if offset < 0 {
+		return nil, fmt.Errorf("transaction %s does not commit to the timestamp", *timestamp.TxID)
+	}
+	appendOp(OpPrepend, raw.Bytes()[:offset])
+	appendOp(OpAppend, raw.Bytes()[offset+len(commitment):])
+	proof.Ops = append(proof.Ops, sha256Op, sha256Op)
+
+	// Up to the merkle root of the block. Bitcoin hashes nodes twice, and
+	// pairs a node without a sibling with itself.
+	level := make([][32]byte, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		level[i] = tx.TxHash()
+	}
+	for len(level) > 1 {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		sibling := level[index^1]
+		appendOp(lo.Ternary(index%2 == 1, OpPrepend, OpAppend), sibling[:])
+		proof.Ops = append(proof.Ops, sha256Op, sha256Op)
+
+		next := make([][32]byte, len(level)/2)
+		for i := range next {
+			first := sha256.Sum256(append(level[2*i][:], level[2*i+1][:]...))
+			next[i] = sha256.Sum256(first[:])
+		}
+		level = next
+		index /= 2
+	}
+
+	if err := proof.checkOps(); err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// checkOps checks that the ops can be serialized, and be read back.
+func (p Proof) checkOps() error {
+	for i, op := range p.Ops {
+		if !op.Kind.valid() {
+			return fmt.Errorf("unknown op 0x%02x", byte(op.Kind))
+		}
+		if len(op.Arg) > otsMaxArgLength {
+			return fmt.Errorf("%w: argument of op %d is %d bytes, can be at most %d",
+				ErrProofTooLarge, i, len(op.Arg), otsMaxArgLength)
+		}
+	}
+	return nil
+}
+
+// MarshalBinary serializes the proof as an OpenTimestamps .ots file.
+func (p Proof) MarshalBinary() ([]byte, error) {
+	if err := p.checkOps(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(otsMagic)
+	writeVarUint(&buf, otsVersion)
+
+	buf.WriteByte(byte(OpSHA256))
+	buf.Write(p.FileHash[:])
+
+	for _, op := range p.Ops {
+		buf.WriteByte(byte(op.Kind))
+		if op.Kind.binary() {
+			writeVarBytes(&buf, op.Arg)
+		}
+	}
+
+	var height bytes.Buffer
+	writeVarUint(&height, uint64(p.Height))
+	buf.WriteByte(otsAttestation)
+	buf.Write(otsBitcoinAttestation[:])
+	writeVarBytes(&buf, height.Bytes())
+
+	return buf.Bytes(), nil
+}
+
+// ParseProof parses an OpenTimestamps .ots file. Proofs can branch out to
+// several attestations, e.g. pending ones from calendar servers: the first
+// Bitcoin attestation found is used.
+func ParseProof(data []byte) (*Proof, error) {
+	r := bytes.NewReader(data)
+
+	magic := make([]byte, len(otsMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || !bytes.Equal(magic, otsMagic) {
+		return nil, fmt.Errorf("%w: not an OpenTimestamps proof", ErrInvalidProof)
+	}
+	version, err := readVarUint(r)
+	if err != nil {
+		return nil, err
+	}
+	if version != otsVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidProof, version)
+	}
+
+	kind, err := r.ReadByte()
+	if err != nil {
+		return nil, errTruncated
+	}
+	if OpKind(kind) != OpSHA256 {
+		return nil, fmt.Errorf("%w: only SHA256 file hashes are supported", ErrInvalidProof)
+	}
+
+	var proof Proof
+	if _, err := io.ReadFull(r, proof.FileHash[:]); err != nil {
+		return nil, errTruncated
+	}
+
+	ops, height, err := readTimestamp(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if height == nil {
+		return nil, fmt.Errorf("%w: no Bitcoin attestation, the proof might still be pending", ErrInvalidProof)
+	}
+	if r.Len() > 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrInvalidProof, r.Len())
+	}
+
+	proof.Ops = ops
+	proof.Height = *height
+	return &proof, nil
+}
+
+var errTruncated = fmt.Errorf("%w: truncated", ErrInvalidProof)
+
+// readTimestamp reads a tree of ops and attestations, and returns the ops
+// leading to the first Bitcoin attestation in it, if any.
+func readTimestamp(r *bytes.Reader, depth int) ([]ProofOp, *uint32, error) {
+	if depth > otsMaxDepth {
+		return nil, nil, fmt.Errorf("%w: nested too deep", ErrInvalidProof)
+	}
+
+	var (
+		ops    []ProofOp
+		height *uint32
+	)
+	readItem := func(tag byte) error {
+		if tag == otsAttestation {
+			attested, err := readAttestation(r)
+			if err != nil {
+				return err
+			}
+			if height == nil && attested != nil {
+				ops, height = nil, attested
+			}
+			return nil
+		}
+
+		op, err := readOp(r, tag)
+		if err != nil {
+			return err
+		}
+		branch, attested, err := readTimestamp(r, depth+1)
+		if err != nil {
+			return err
+		}
+		if height == nil && attested != nil && op.Kind.supported() {
+			ops, height = append([]ProofOp{op}, branch...), attested
+		}
+		return nil
+	}
+
+	for {
+		tag, err := r.ReadByte()
+		if err != nil {
+			return nil, nil, errTruncated
+		}
+		if tag != otsFork {
+			if err := readItem(tag); err != nil {
+				return nil, nil, err
+			}
+			return ops, height, nil
+		}
+
+		if tag, err = r.ReadByte(); err != nil {
+			return nil, nil, errTruncated
+		}
+		if err := readItem(tag); err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+func readOp(r *bytes.Reader, tag byte) (ProofOp, error) {
+	op := ProofOp{Kind: OpKind(tag)}
+	if !op.Kind.valid() {
+		return ProofOp{}, fmt.Errorf("%w: unknown op 0x%02x", ErrInvalidProof, tag)
+	}
+	if op.Kind.binary() {
+		arg, err := readVarBytes(r)
+		if err != nil {
+			return ProofOp{}, err
+		}
+		op.Arg = arg
+	}
+	return op, nil
+}
+
+// readAttestation returns the attested height for Bitcoin attestations, and
+// nil for any other kind.
+func readAttestation(r *bytes.Reader) (*uint32, error) {
+	var tag [8]byte
+	if _, err := io.ReadFull(r, tag[:]); err != nil {
+		return nil, errTruncated
+	}
+	payload, err := readVarBytes(r)
+	if err != nil {
+		return nil, err
+	}
+	if tag != otsBitcoinAttestation {
+		return nil, nil
+	}
+
+	height, err := readVarUint(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	if height > uint64(^uint32(0)) {
+		return nil, fmt.Errorf("%w: invalid block height %d", ErrInvalidProof, height)
+	}
+	attested := uint32(height)
+	return &attested, nil
+}
+
+// Integers are LEB128 encoded, least significant group first.
+func writeVarUint(buf *bytes.Buffer, value uint64) {
+	for value >= 0x80 {
+		buf.WriteByte(byte(value) | 0x80)
+		value >>= 7
+	}
+	buf.WriteByte(byte(value))
+}
+
+func readVarUint(r *bytes.Reader) (uint64, error) {
+	var value uint64
+	for shift := 0; shift < 64; shift += 7 {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, errTruncated
+		}
+		value |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return value, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: integer overflow", ErrInvalidProof)
+}
+
+func writeVarBytes(buf *bytes.Buffer, data []byte) {
+	writeVarUint(buf, uint64(len(data)))
+	buf.Write(data)
+}
+
+func readVarBytes(r *bytes.Reader) ([]byte, error) {
+	length, err := readVarUint(r)
+	if err != nil {
+		return nil, err
+	}
+	if length > otsMaxArgLength {
+		return nil, fmt.Errorf("%w: %d bytes is too long", ErrInvalidProof, length)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, errTruncated
+	}
+	return data, nil
+}
@@ -0,0 +1,177 @@
+package timestamps_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/LayerTwo-Labs/sidesail/bitwindow/server/models/timestamps"
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// otsBlock returns a block with a transaction committing to data, at the
+// given position among filler transactions.
+func otsBlock(t *testing.T, data []byte, position, size int) (*wire.MsgBlock, string) {
+	t.Helper()
+
+	script, err := txscript.NullDataScript(data)
+	require.NoError(t, err)
+
+	block := &wire.MsgBlock{}
+	var txid string
+	for i := range size {
+		tx := &wire.MsgTx{
+			Version: 2,
+			TxIn:    []*wire.TxIn{{PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{byte(i)}}}},
+			TxOut:   []*wire.TxOut{{Value: int64(i), PkScript: []byte{txscript.OP_TRUE}}},
+		}
+		if i == position {
+			tx.TxOut = append(tx.TxOut, &wire.TxOut{PkScript: script})
+			txid = tx.TxID()
+		}
+		block.Transactions = append(block.Transactions, tx)
+	}
+
+	block.Header.MerkleRoot = blockchain.CalcMerkleRoot(lo.Map(block.Transactions, func(tx *wire.MsgTx, _ int) *btcutil.Tx {
+		return btcutil.NewTx(tx)
+	}), false)
+	return block, txid
+}
+
+func TestProof(t *testing.T) {
+	t.Parallel()
+
+	files := [][32]byte{sha256.Sum256([]byte("a")), sha256.Sum256([]byte("b")), sha256.Sum256([]byte("c"))}
+	root, paths := timestamps.BuildMerkleTree(files)
+
+	for _, size := range []int{1, 2, 5, 8} {
+		for position := range size {
+			t.Run(fmt.Sprintf("tx %d of %d", position, size), func(t *testing.T) {
+				block, txid := otsBlock(t, root[:], position, size)
+
+				for i, file := range files {
+					proof, err := timestamps.NewProof(timestamps.FileTimestamp{
+						FileHash:   hex.EncodeToString(file[:]),
+						TxID:       &txid,
+						MerkleRoot: lo.ToPtr(hex.EncodeToString(root[:])),
+						MerklePath: paths[i],
+					}, block, 100)
+					require.NoError(t, err)
+
+					merkleRoot, err := proof.BlockMerkleRoot()
+					require.NoError(t, err)
+					assert.Equal(t, block.Header.MerkleRoot[:], merkleRoot)
+
+					encoded, err := proof.MarshalBinary()
+					require.NoError(t, err)
+					parsed, err := timestamps.ParseProof(encoded)
+					require.NoError(t, err)
+					assert.Equal(t, proof, parsed)
+				}
+			})
+		}
+	}
+
+	t.Run("unbatched", func(t *testing.T) {
+		block, txid := otsBlock(t, files[0][:], 1, 3)
+		proof, err := timestamps.NewProof(timestamps.FileTimestamp{
+			FileHash: hex.EncodeToString(files[0][:]),
+			TxID:     &txid,
+		}, block, 100)
+		require.NoError(t, err)
+
+		merkleRoot, err := proof.BlockMerkleRoot()
+		require.NoError(t, err)
+		assert.Equal(t, block.Header.MerkleRoot[:], merkleRoot)
+	})
+
+	t.Run("transaction too large", func(t *testing.T) {
+		block, txid := otsBlock(t, files[0][:], 1, 3)
+		// The transaction before the commitment no longer fits in a
+		// single op
+		tx := block.Transactions[1]
+		for len(tx.TxIn) < 200 {
+			tx.TxIn = append(tx.TxIn, &wire.TxIn{SignatureScript: bytes.Repeat([]byte{0x01}, 20)})
+		}
+		txid = tx.TxID()
+
+		_, err := timestamps.NewProof(timestamps.FileTimestamp{
+			FileHash: hex.EncodeToString(files[0][:]),
+			TxID:     &txid,
+		}, block, 100)
+		assert.ErrorIs(t, err, timestamps.ErrProofTooLarge)
+
+		_, err = timestamps.Proof{Ops: []timestamps.ProofOp{
+			{Kind: timestamps.OpAppend, Arg: make([]byte, 4097)},
+		}}.MarshalBinary()
+		assert.ErrorIs(t, err, timestamps.ErrProofTooLarge)
+	})
+
+	t.Run("transaction not committing to the file", func(t *testing.T) {
+		block, txid := otsBlock(t, files[1][:], 1, 3)
+		_, err := timestamps.NewProof(timestamps.FileTimestamp{
+			FileHash: hex.EncodeToString(files[0][:]),
+			TxID:     &txid,
+		}, block, 100)
+		assert.Error(t, err)
+	})
+}
+
+func TestParseProof(t *testing.T) {
+	t.Parallel()
+
+	file := sha256.Sum256([]byte("file"))
+	proof := timestamps.Proof{
+		FileHash: file,
+		Ops: []timestamps.ProofOp{
+			{Kind: timestamps.OpAppend, Arg: []byte("suffix")},
+			{Kind: timestamps.OpSHA256},
+		},
+		Height: 358391,
+	}
+	encoded, err := proof.MarshalBinary()
+	require.NoError(t, err)
+
+	// Magic, version, file hash op
+	header := 31 + 1 + 1 + len(file)
+	assert.Equal(t, "004f70656e54696d657374616d7073000050726f6f6600bf89e2e884e89294", hex.EncodeToString(encoded[:31]))
+	// Bitcoin attestation of height 358391
+	assert.Equal(t, "000588960d73d7190103f7ef15", hex.EncodeToString(encoded[len(encoded)-13:]))
+
+	t.Run("skips pending and unsupported branches", func(t *testing.T) {
+		var forked bytes.Buffer
+		forked.Write(encoded[:header])
+		// A pending attestation from a calendar
+		forked.Write([]byte{0xff, 0x00, 0x83, 0xdf, 0xe3, 0x0d, 0x2e, 0xf9, 0x0c, 0x8e, 0x04, 0x03, 'u', 'r', 'l'})
+		// A SHA1 branch leading to another Bitcoin attestation
+		forked.Write([]byte{0xff, 0x02, 0x00, 0x05, 0x88, 0x96, 0x0d, 0x73, 0xd7, 0x19, 0x01, 0x01, 0x07})
+		forked.Write(encoded[header:])
+
+		parsed, err := timestamps.ParseProof(forked.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, &proof, parsed)
+	})
+
+	for name, data := range map[string][]byte{
+		"empty":          nil,
+		"not a proof":    []byte("hello world"),
+		"truncated":      encoded[:len(encoded)-1],
+		"trailing bytes": append(bytes.Clone(encoded), 0x00),
+		"only pending": append(bytes.Clone(encoded[:header]),
+			0x00, 0x83, 0xdf, 0xe3, 0x0d, 0x2e, 0xf9, 0x0c, 0x8e, 0x04, 0x03, 'u', 'r', 'l'),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := timestamps.ParseProof(data)
+			assert.ErrorIs(t, err, timestamps.ErrInvalidProof)
+		})
+	}
+}
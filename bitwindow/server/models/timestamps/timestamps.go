@@ -3,6 +3,7 @@ package timestamps
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -13,7 +14,10 @@ import (
 type Status string
 
 const (
-	StatusPending    Status = "pending"
+	StatusPending Status = "pending"
+	// The transaction of a batch is being sent. Batches are saved before
+	// they're sent, so they're never sent twice.
+	StatusSending    Status = "sending"
 	StatusConfirming Status = "confirming"
 	StatusConfirmed  Status = "confirmed"
 	StatusFailed     Status = "failed"
@@ -25,9 +29,18 @@ type FileTimestamp struct {
 	FileHash    string
 	TxID        *string
 	BlockHeight *int64
+	BlockHash   *string
 	Status      Status
 	CreatedAt   time.Time
+	// The time of the block it was confirmed in
 	ConfirmedAt *time.Time
+	// Set if the status is failed
+	FailureReason *string
+
+	// Set for batched timestamps, where the transaction commits to the root
+	// of a merkle tree over several files. Hex encoded.
+	MerkleRoot *string
+	MerklePath MerklePath
 }
 
 func Create(ctx context.Context, db *sql.DB, timestamp FileTimestamp) (int64, error) {
@@ -92,10 +105,43 @@ func Update(ctx context.Context, db *sql.DB, id int64, txid *string, blockHeight
 	return nil
 }
 
+const timestampColumns = `id, filename, file_hash, txid, block_height, block_hash,
+		       status, created_at, confirmed_at, failure_reason, merkle_root, merkle_path`
+
+func scanTimestamp(row interface{ Scan(dest ...any) error }) (FileTimestamp, error) {
+	var (
+		timestamp FileTimestamp
+		path      *string
+	)
+	err := row.Scan(
+		&timestamp.ID,
+		&timestamp.Filename,
+		&timestamp.FileHash,
+		&timestamp.TxID,
+		&timestamp.BlockHeight,
+		&timestamp.BlockHash,
+		&timestamp.Status,
+		&timestamp.CreatedAt,
+		&timestamp.ConfirmedAt,
+		&timestamp.FailureReason,
+		&timestamp.MerkleRoot,
+		&path,
+	)
+	if err != nil {
+		return FileTimestamp{}, fmt.Errorf("scan file timestamp: %w", err)
+	}
+
+	timestamp.MerklePath, err = decodeMerklePath(path)
+	if err != nil {
+		return FileTimestamp{}, fmt.Errorf("file timestamp %d: %w", timestamp.ID, err)
+	}
+
+	return timestamp, nil
+}
+
 func List(ctx context.Context, db *sql.DB) ([]FileTimestamp, error) {
 	rows, err := db.QueryContext(ctx, `
-		SELECT id, filename, file_hash, txid, block_height,
-		       status, created_at, confirmed_at
+		SELECT `+timestampColumns+`
 		FROM file_timestamps
 		ORDER BY created_at DESC
 	`)
@@ -106,19 +152,9 @@ func List(ctx context.Context, db *sql.DB) ([]FileTimestamp, error) {
 
 	var timestamps []FileTimestamp
 	for rows.Next() {
-		var timestamp FileTimestamp
-		err := rows.Scan(
-			&timestamp.ID,
-			&timestamp.Filename,
-			&timestamp.FileHash,
-			&timestamp.TxID,
-			&timestamp.BlockHeight,
-			&timestamp.Status,
-			&timestamp.CreatedAt,
-			&timestamp.ConfirmedAt,
-		)
+		timestamp, err := scanTimestamp(rows)
 		if err != nil {
-			return nil, fmt.Errorf("scan file timestamp: %w", err)
+			return nil, err
 		}
 
 		timestamps = append(timestamps, timestamp)
@@ -132,24 +168,12 @@ func List(ctx context.Context, db *sql.DB) ([]FileTimestamp, error) {
 }
 
 func Get(ctx context.Context, db *sql.DB, id int64) (*FileTimestamp, error) {
-	var timestamp FileTimestamp
-
-	err := db.QueryRowContext(ctx, `
-		SELECT id, filename, file_hash, txid, block_height,
-		       status, created_at, confirmed_at
+	timestamp, err := scanTimestamp(db.QueryRowContext(ctx, `
+		SELECT `+timestampColumns+`
 		FROM file_timestamps
 		WHERE id = ?
-	`, id).Scan(
-		&timestamp.ID,
-		&timestamp.Filename,
-		&timestamp.FileHash,
-		&timestamp.TxID,
-		&timestamp.BlockHeight,
-		&timestamp.Status,
-		&timestamp.CreatedAt,
-		&timestamp.ConfirmedAt,
-	)
-	if err == sql.ErrNoRows {
+	`, id))
+	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
 	if err != nil {
@@ -160,24 +184,12 @@ func Get(ctx context.Context, db *sql.DB, id int64) (*FileTimestamp, error) {
 }
 
 func GetByHash(ctx context.Context, db *sql.DB, fileHash string) (*FileTimestamp, error) {
-	var timestamp FileTimestamp
-
-	err := db.QueryRowContext(ctx, `
-		SELECT id, filename, file_hash, txid, block_height,
-		       status, created_at, confirmed_at
+	timestamp, err := scanTimestamp(db.QueryRowContext(ctx, `
+		SELECT `+timestampColumns+`
 		FROM file_timestamps
 		WHERE file_hash = ?
-	`, fileHash).Scan(
-		&timestamp.ID,
-		&timestamp.Filename,
-		&timestamp.FileHash,
-		&timestamp.TxID,
-		&timestamp.BlockHeight,
-		&timestamp.Status,
-		&timestamp.CreatedAt,
-		&timestamp.ConfirmedAt,
-	)
-	if err == sql.ErrNoRows {
+	`, fileHash))
+	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
 	if err != nil {
@@ -186,3 +198,284 @@ func GetByHash(ctx context.Context, db *sql.DB, fileHash string) (*FileTimestamp
 
 	return &timestamp, nil
 }
+
+// UnconfirmAboveHeight moves all timestamps confirmed in a block strictly
+// above the given height back to confirming, clearing their block and
+// confirmation time.
+func UnconfirmAboveHeight(ctx context.Context, db *sql.DB, height uint32) error {
+	builder := sq.
+		Update("file_timestamps").
+		Set("status", StatusConfirming).
+		Set("block_height", nil).
+		Set("block_hash", nil).
+		Set("confirmed_at", nil).
+		Where(sq.Gt{"block_height": height})
+
+	sql, args := builder.MustSql()
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("unconfirm file timestamps above height %d: %w", height, err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows > 0 {
+		zerolog.Ctx(ctx).Info().
+			Int64("count", rows).
+			Uint32("height", height).
+			Msg("unconfirmed file timestamps above height")
+	}
+
+	return nil
+}
+
+// ListUnconfirmedTxIDs returns the txids of all timestamps that aren't
+// confirmed yet, including failed ones. Those can still be confirmed if
+// someone else broadcasts the transaction.
+func ListUnconfirmedTxIDs(ctx context.Context, db *sql.DB) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT txid
+		FROM file_timestamps
+		WHERE txid IS NOT NULL AND status IN (?, ?)
+	`, StatusConfirming, StatusFailed)
+	if err != nil {
+		return nil, fmt.Errorf("list unconfirmed timestamp txids: query: %w", err)
+	}
+	defer rows.Close()
+
+	txids := make(map[string]bool)
+	for rows.Next() {
+		var txid string
+		if err := rows.Scan(&txid); err != nil {
+			return nil, fmt.Errorf("list unconfirmed timestamp txids: scan: %w", err)
+		}
+		txids[txid] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list unconfirmed timestamp txids: iterate: %w", err)
+	}
+
+	return txids, nil
+}
+
+// Confirm marks the timestamps of the given transactions as confirmed in a
+// block. Returns how many timestamps were confirmed.
+func Confirm(ctx context.Context, db *sql.DB, txids []string, height uint32, blockHash string, blockTime time.Time) (int64, error) {
+	if len(txids) == 0 {
+		return 0, nil
+	}
+
+	builder := sq.
+		Update("file_timestamps").
+		Set("status", StatusConfirmed).
+		Set("block_height", height).
+		Set("block_hash", blockHash).
+		Set("confirmed_at", blockTime).
+		Set("failure_reason", nil).
+		Where(sq.Eq{"txid": txids}).
+		Where(sq.Eq{"status": []Status{StatusConfirming, StatusFailed}})
+
+	sql, args := builder.MustSql()
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("confirm file timestamps in block %d: %w", height, err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows > 0 {
+		zerolog.Ctx(ctx).Info().
+			Int64("count", rows).
+			Uint32("height", height).
+			Str("block-hash", blockHash).
+			Msg("confirmed file timestamps")
+	}
+
+	return rows, nil
+}
+
+// MarkFailed marks the timestamp of a transaction that's never going to
+// confirm, e.g. because it was dropped from the mempool or replaced.
+func MarkFailed(ctx context.Context, db *sql.DB, txid string, reason string) (bool, error) {
+	result, err := db.ExecContext(ctx, `
+		UPDATE file_timestamps
+		SET status = ?, failure_reason = ?
+		WHERE txid = ? AND status = ?
+	`, StatusFailed, reason, txid, StatusConfirming)
+	if err != nil {
+		return false, fmt.Errorf("mark file timestamp of %s as failed: %w", txid, err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows > 0 {
+		zerolog.Ctx(ctx).Info().
+			Str("txid", txid).
+			Str("reason", reason).
+			Msg("file timestamp failed")
+	}
+
+	return rows > 0, nil
+}
+
+// ErrNotFailed is returned when re-broadcasting a timestamp that hasn't
+// failed.
+var ErrNotFailed = errors.New("timestamp has not failed")
+
+// Rebroadcast replaces the transaction of a failed timestamp, moving it back
+// to confirming. For batched timestamps, that's the whole batch.
+func Rebroadcast(ctx context.Context, db *sql.DB, id int64, txid string) error {
+	result, err := db.ExecContext(ctx, `
+		UPDATE file_timestamps
+		SET txid = ?, status = ?, failure_reason = NULL
+		WHERE status = ? AND (
+			id = ? OR
+			txid = (SELECT txid FROM file_timestamps WHERE id = ?) OR
+			merkle_root = (SELECT merkle_root FROM file_timestamps WHERE id = ?)
+		)
+	`, txid, StatusConfirming, StatusFailed, id, id, id)
+	if err != nil {
+		return fmt.Errorf("rebroadcast file timestamp %d: %w", id, err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("rebroadcast file timestamp %d: %w", id, ErrNotFailed)
+	}
+
+	return nil
+}
+
+// ListPending returns the timestamps queued for the next batch, oldest first.
+func ListPending(ctx context.Context, db *sql.DB) ([]FileTimestamp, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+timestampColumns+`
+		FROM file_timestamps
+		WHERE status = ? AND txid IS NULL
+		ORDER BY id
+	`, StatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("list pending file timestamps: query: %w", err)
+	}
+	defer rows.Close()
+
+	var timestamps []FileTimestamp
+	for rows.Next() {
+		timestamp, err := scanTimestamp(rows)
+		if err != nil {
+			return nil, err
+		}
+		timestamps = append(timestamps, timestamp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list pending file timestamps: iterate: %w", err)
+	}
+
+	return timestamps, nil
+}
+
+// StartBatch saves a batch of pending timestamps before its transaction is
+// sent, with the path from each file to the merkle root. paths is keyed by
+// timestamp ID.
+func StartBatch(ctx context.Context, db *sql.DB, merkleRoot string, paths map[int64]MerklePath) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for id, path := range paths {
+		encoded, err := encodeMerklePath(path)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE file_timestamps
+			SET status = ?, merkle_root = ?, merkle_path = ?
+			WHERE id = ? AND status = ?
+		`, StatusSending, merkleRoot, encoded, id, StatusPending)
+		if err != nil {
+			return fmt.Errorf("add file timestamp %d to batch: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// CommitBatch records the transaction committing to a batch that's being
+// sent.
+func CommitBatch(ctx context.Context, db *sql.DB, txid string, merkleRoot string) error {
+	result, err := db.ExecContext(ctx, `
+		UPDATE file_timestamps
+		SET txid = ?, status = ?
+		WHERE merkle_root = ? AND status = ?
+	`, txid, StatusConfirming, merkleRoot, StatusSending)
+	if err != nil {
+		return fmt.Errorf("commit file timestamp batch %s: %w", merkleRoot, err)
+	}
+
+	rows, _ := result.RowsAffected()
+	zerolog.Ctx(ctx).Info().
+		Int64("count", rows).
+		Str("txid", txid).
+		Str("merkle-root", merkleRoot).
+		Msg("committed file timestamp batch")
+
+	return nil
+}
+
+// AbortBatch queues the timestamps of a batch that couldn't be sent again.
+func AbortBatch(ctx context.Context, db *sql.DB, merkleRoot string) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE file_timestamps
+		SET status = ?, merkle_root = NULL, merkle_path = NULL
+		WHERE merkle_root = ? AND status = ?
+	`, StatusPending, merkleRoot, StatusSending)
+	if err != nil {
+		return fmt.Errorf("abort file timestamp batch %s: %w", merkleRoot, err)
+	}
+	return nil
+}
+
+// FailBatch marks the timestamps of a batch that's still being sent as
+// failed.
+func FailBatch(ctx context.Context, db *sql.DB, merkleRoot string, reason string) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE file_timestamps
+		SET status = ?, failure_reason = ?
+		WHERE merkle_root = ? AND status = ?
+	`, StatusFailed, reason, merkleRoot, StatusSending)
+	if err != nil {
+		return fmt.Errorf("fail file timestamp batch %s: %w", merkleRoot, err)
+	}
+	return nil
+}
+
+// ListSendingBatches returns the merkle roots of the batches that are being
+// sent.
+func ListSendingBatches(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT DISTINCT merkle_root
+		FROM file_timestamps
+		WHERE status = ? AND merkle_root IS NOT NULL
+		ORDER BY merkle_root
+	`, StatusSending)
+	if err != nil {
+		return nil, fmt.Errorf("list sending file timestamp batches: query: %w", err)
+	}
+	defer rows.Close()
+
+	var roots []string
+	for rows.Next() {
+		var root string
+		if err := rows.Scan(&root); err != nil {
+			return nil, fmt.Errorf("list sending file timestamp batches: scan: %w", err)
+		}
+		roots = append(roots, root)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list sending file timestamp batches: iterate: %w", err)
+	}
+
+	return roots, nil
+}
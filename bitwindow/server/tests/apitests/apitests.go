@@ -26,6 +26,7 @@ type configg struct {
 	enforcer mainchainv1connect.ValidatorServiceClient
 	crypto   cryptov1connect.CryptoServiceClient
 	bitcoind bitcoindv1alphaconnect.BitcoinServiceClient
+	config   config.Config
 }
 
 type ServerOpt func(opt *configg)
@@ -74,8 +75,19 @@ func WithBitcoind(bitcoind bitcoindv1alphaconnect.BitcoinServiceClient) ServerOp
 	return func(opt *configg) { opt.bitcoind = bitcoind }
 }
 
+func WithConfig(config config.Config) ServerOpt {
+	return func(opt *configg) { opt.config = config }
+}
+
 // API creates a new external API Connect server that we can send test requests to
 func API(t *testing.T, database *sql.DB, options ...ServerOpt) (connect.HTTPClient, string) {
+	_, client, url := Server(t, database, options...)
+	return client, url
+}
+
+// Server is like API, but also returns the server, for tests that have to
+// reach into its engines.
+func Server(t *testing.T, database *sql.DB, options ...ServerOpt) (*api.Server, connect.HTTPClient, string) {
 	ctrl := gomock.NewController(t)
 
 	logger := zerolog.New(zerolog.NewConsoleWriter()).
@@ -106,15 +118,13 @@ func API(t *testing.T, database *sql.DB, options ...ServerOpt) (connect.HTTPClie
 		WalletDir:   t.TempDir(), // Use temporary directory for wallet.json in tests
 	}
 
-	srv, err := api.New(context.Background(), services, config.Config{
-		GuiBootedMainchain: false,
-		GuiBootedEnforcer:  false,
-	}, nil, func(ctx context.Context) {
+	srv, err := api.New(context.Background(), services, conf.config, nil, func(ctx context.Context) {
 		zerolog.Ctx(context.Background()).Info().Msg("shutdown")
 	})
 	require.NoError(t, err)
 
-	return serve(t, srv)
+	client, url := serve(t, srv)
+	return srv, client, url
 }
 
 func serve(t *testing.T, server *api.Server) (connect.HTTPClient, string) {